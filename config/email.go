@@ -8,6 +8,8 @@ type EmailConfig struct {
 	SenderName   string `mapstructure:"SMTP_SENDER_NAME"`
 	AuthEmail    string `mapstructure:"SMTP_AUTH_EMAIL"`
 	AuthPassword string `mapstructure:"SMTP_AUTH_PASSWORD"`
+	Transport    string `mapstructure:"MAIL_TRANSPORT"`
+	FileDir      string `mapstructure:"MAIL_FILE_DIR"`
 }
 
 func NewEmailConfig() (*EmailConfig, error) {
@@ -18,6 +20,8 @@ func NewEmailConfig() (*EmailConfig, error) {
 	viper.BindEnv("SMTP_SENDER_NAME")
 	viper.BindEnv("SMTP_AUTH_EMAIL")
 	viper.BindEnv("SMTP_AUTH_PASSWORD")
+	viper.BindEnv("MAIL_TRANSPORT")
+	viper.BindEnv("MAIL_FILE_DIR")
 
 	config := EmailConfig{
 		Host:         viper.GetString("SMTP_HOST"),
@@ -25,6 +29,8 @@ func NewEmailConfig() (*EmailConfig, error) {
 		SenderName:   viper.GetString("SMTP_SENDER_NAME"),
 		AuthEmail:    viper.GetString("SMTP_AUTH_EMAIL"),
 		AuthPassword: viper.GetString("SMTP_AUTH_PASSWORD"),
+		Transport:    viper.GetString("MAIL_TRANSPORT"),
+		FileDir:      viper.GetString("MAIL_FILE_DIR"),
 	}
 
 	return &config, nil
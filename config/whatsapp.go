@@ -0,0 +1,42 @@
+package config
+
+import "github.com/spf13/viper"
+
+type WhatsAppConfig struct {
+	Provider         string `mapstructure:"WHATSAPP_PROVIDER"`
+	APIURL           string `mapstructure:"WHATSAPP_API_URL"`
+	PhoneNumberID    string `mapstructure:"WHATSAPP_PHONE_NUMBER_ID"`
+	AccessToken      string `mapstructure:"WHATSAPP_ACCESS_TOKEN"`
+	TemplateLanguage string `mapstructure:"WHATSAPP_TEMPLATE_LANGUAGE"`
+
+	// template yang disetujui Meta per jenis pesan, kosong berarti pesan teks biasa
+	TemplateETicket         string `mapstructure:"WHATSAPP_TEMPLATE_E_TICKET"`
+	TemplatePaymentReminder string `mapstructure:"WHATSAPP_TEMPLATE_PAYMENT_REMINDER"`
+	TemplateEventDay        string `mapstructure:"WHATSAPP_TEMPLATE_EVENT_DAY"`
+}
+
+func NewWhatsAppConfig() (*WhatsAppConfig, error) {
+	viper.AutomaticEnv()
+
+	viper.BindEnv("WHATSAPP_PROVIDER")
+	viper.BindEnv("WHATSAPP_API_URL")
+	viper.BindEnv("WHATSAPP_PHONE_NUMBER_ID")
+	viper.BindEnv("WHATSAPP_ACCESS_TOKEN")
+	viper.BindEnv("WHATSAPP_TEMPLATE_LANGUAGE")
+	viper.BindEnv("WHATSAPP_TEMPLATE_E_TICKET")
+	viper.BindEnv("WHATSAPP_TEMPLATE_PAYMENT_REMINDER")
+	viper.BindEnv("WHATSAPP_TEMPLATE_EVENT_DAY")
+
+	config := WhatsAppConfig{
+		Provider:                viper.GetString("WHATSAPP_PROVIDER"),
+		APIURL:                  viper.GetString("WHATSAPP_API_URL"),
+		PhoneNumberID:           viper.GetString("WHATSAPP_PHONE_NUMBER_ID"),
+		AccessToken:             viper.GetString("WHATSAPP_ACCESS_TOKEN"),
+		TemplateLanguage:        viper.GetString("WHATSAPP_TEMPLATE_LANGUAGE"),
+		TemplateETicket:         viper.GetString("WHATSAPP_TEMPLATE_E_TICKET"),
+		TemplatePaymentReminder: viper.GetString("WHATSAPP_TEMPLATE_PAYMENT_REMINDER"),
+		TemplateEventDay:        viper.GetString("WHATSAPP_TEMPLATE_EVENT_DAY"),
+	}
+
+	return &config, nil
+}
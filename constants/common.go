@@ -3,6 +3,7 @@ package constants
 const (
 	ENUM_ROLE_ADMIN = "admin"
 	ENUM_ROLE_GUEST = "guest"
+	ENUM_ROLE_CREW  = "crew"
 
 	ENUM_AUDIENCE_REGULAR = "regular"
 	ENUM_AUDIENCE_INVITED = "invited"
@@ -29,6 +30,127 @@ const (
 	ENUM_TICKET_PRE_EVENT_3 = "pre-event-3"
 	ENUM_TICKET_MAIN_EVENT  = "main-event"
 
+	ENUM_WAITLIST_STATUS_WAITING   = "waiting"
+	ENUM_WAITLIST_STATUS_OFFERED   = "offered"
+	ENUM_WAITLIST_STATUS_CLAIMED   = "claimed"
+	ENUM_WAITLIST_STATUS_EXPIRED   = "expired"
+	ENUM_WAITLIST_STATUS_CANCELLED = "cancelled"
+
+	ENUM_WAITLIST_OFFER_DURATION_MINUTES = 30
+
+	ENUM_TICKET_TRANSFER_CUTOFF_HOURS = 24
+
+	ENUM_TICKET_QR_LINK_EXPIRY_HOURS       = 72
+	ENUM_NOTIFICATION_QR_LINK_EXPIRY_HOURS = 24
+
+	ENUM_AVAILABILITY_SUBSCRIBER_BUFFER = 16
+	ENUM_AVAILABILITY_HEARTBEAT_SECONDS = 25
+
+	ENUM_CHECK_IN_FEED_SUBSCRIBER_BUFFER       = 64
+	ENUM_CHECK_IN_FEED_HEARTBEAT_SECONDS       = 25
+	ENUM_CHECK_IN_FEED_TOTALS_INTERVAL_SECONDS = 2
+
+	ENUM_FORM_FIELD_TEXT         = "text"
+	ENUM_FORM_FIELD_TEXTAREA     = "textarea"
+	ENUM_FORM_FIELD_NUMBER       = "number"
+	ENUM_FORM_FIELD_EMAIL        = "email"
+	ENUM_FORM_FIELD_SELECT       = "select"
+	ENUM_FORM_FIELD_MULTI_SELECT = "multi-select"
+	ENUM_FORM_FIELD_CHECKBOX     = "checkbox"
+
+	ENUM_CHECK_IN_SOURCE_ONLINE    = "online"
+	ENUM_CHECK_IN_SOURCE_OFFLINE   = "offline"
+	ENUM_CHECK_IN_SOURCE_HELP_DESK = "help-desk"
+
+	ENUM_CHECK_IN_SCAN_ACCEPTED  = "accepted"
+	ENUM_CHECK_IN_SCAN_DUPLICATE = "duplicate"
+	ENUM_CHECK_IN_SCAN_REJECTED  = "rejected"
+
+	ENUM_CHECK_IN_SYNC_MAX_SCANS     = 500
+	ENUM_CHECK_IN_OPEN_BEFORE_HOURS  = 6
+	ENUM_CHECK_IN_CLOCK_SKEW_SECONDS = 120
+	ENUM_CHECK_IN_SUPERSEDED_REASON  = "superseded by earlier offline scan"
+
+	ENUM_ATTENDEE_LOOKUP_MIN_SEARCH_LENGTH = 3
+	ENUM_ATTENDEE_LOOKUP_CANDIDATE_LIMIT   = 200
+	ENUM_ATTENDEE_LOOKUP_MAX_RESULTS       = 20
+	ENUM_ATTENDEE_LOOKUP_MIN_SCORE         = 30
+
+	ENUM_BADGE_FORMAT_SINGLE = "single"
+	ENUM_BADGE_FORMAT_SHEET  = "sheet"
+
+	ENUM_CREW_BULK_MAX_ACCOUNTS = 200
+	ENUM_CREW_PASSWORD_LENGTH   = 12
+
+	ENUM_CHECK_IN_DIRECTION_IN  = "in"
+	ENUM_CHECK_IN_DIRECTION_OUT = "out"
+
+	ENUM_REENTRY_POLICY_SINGLE_ENTRY = "single-entry"
+	ENUM_REENTRY_POLICY_REENTRY      = "re-entry"
+	ENUM_REENTRY_POLICY_PER_SESSION  = "per-session"
+
+	ENUM_EMAIL_OUTBOX_STATUS_PENDING = "pending"
+	ENUM_EMAIL_OUTBOX_STATUS_SENDING = "sending"
+	ENUM_EMAIL_OUTBOX_STATUS_SENT    = "sent"
+	ENUM_EMAIL_OUTBOX_STATUS_FAILED  = "failed"
+	ENUM_EMAIL_OUTBOX_STATUS_DEAD    = "dead"
+
+	ENUM_EMAIL_OUTBOX_MAX_ATTEMPTS          = 8
+	ENUM_EMAIL_OUTBOX_WORKERS               = 4
+	ENUM_EMAIL_OUTBOX_BATCH_SIZE            = 10
+	ENUM_EMAIL_OUTBOX_POLL_SECONDS          = 5
+	ENUM_EMAIL_OUTBOX_LEASE_SECONDS         = 120
+	ENUM_EMAIL_OUTBOX_BACKOFF_BASE_SECONDS  = 30
+	ENUM_EMAIL_OUTBOX_BACKOFF_LIMIT_SECONDS = 3600
+
+	ENUM_EMAIL_TEMPLATE_E_TICKET         = "e-ticket"
+	ENUM_EMAIL_TEMPLATE_INVITATION       = "invitation"
+	ENUM_EMAIL_TEMPLATE_WAITLIST_OFFER   = "waitlist-offer"
+	ENUM_EMAIL_TEMPLATE_PURCHASE_RECEIPT = "purchase-receipt"
+	ENUM_EMAIL_TEMPLATE_BROADCAST        = "broadcast"
+	ENUM_EMAIL_TEMPLATE_REMINDER         = "reminder"
+	ENUM_EMAIL_TEMPLATE_VERIFICATION     = "email-verification"
+	ENUM_EMAIL_TEMPLATE_PASSWORD_RESET   = "password-reset"
+
+	ENUM_E_TICKET_RESEND_LIMIT          = 5
+	ENUM_E_TICKET_RESEND_WINDOW_MINUTES = 60
+
+	ENUM_EMAIL_VERIFICATION_EXPIRY_HOURS   = 24
+	ENUM_EMAIL_VERIFICATION_RESEND_LIMIT   = 3
+	ENUM_EMAIL_VERIFICATION_WINDOW_MINUTES = 60
+
+	ENUM_PASSWORD_RESET_EXPIRY_MINUTES = 30
+	ENUM_PASSWORD_RESET_REQUEST_LIMIT  = 3
+	ENUM_PASSWORD_RESET_WINDOW_MINUTES = 60
+
+	ENUM_EVENT_REMINDER_OFFSETS      = "7d,1d"
+	ENUM_EVENT_REMINDER_POLL_MINUTES = 5
+	ENUM_EVENT_REMINDER_BATCH_SIZE   = 100
+
+	ENUM_NOTIFICATION_CHANNEL_WHATSAPP = "whatsapp"
+
+	ENUM_NOTIFICATION_KIND_E_TICKET         = "e-ticket"
+	ENUM_NOTIFICATION_KIND_PAYMENT_REMINDER = "payment-reminder"
+	ENUM_NOTIFICATION_KIND_EVENT_DAY        = "event-day"
+
+	ENUM_NOTIFICATION_OUTBOX_STATUS_PENDING = "pending"
+	ENUM_NOTIFICATION_OUTBOX_STATUS_SENDING = "sending"
+	ENUM_NOTIFICATION_OUTBOX_STATUS_SENT    = "sent"
+	ENUM_NOTIFICATION_OUTBOX_STATUS_FAILED  = "failed"
+	ENUM_NOTIFICATION_OUTBOX_STATUS_DEAD    = "dead"
+
+	ENUM_NOTIFICATION_OUTBOX_MAX_ATTEMPTS  = 5
+	ENUM_NOTIFICATION_OUTBOX_BATCH_SIZE    = 20
+	ENUM_NOTIFICATION_OUTBOX_POLL_SECONDS  = 10
+	ENUM_NOTIFICATION_OUTBOX_LEASE_SECONDS = 120
+
+	ENUM_NOTIFICATION_SCHEDULE_POLL_MINUTES = 5
+	ENUM_NOTIFICATION_SCHEDULE_BATCH_SIZE   = 100
+
+	ENUM_PAYMENT_REMINDER_AFTER_MINUTES = 30
+	ENUM_PAYMENT_REMINDER_EXPIRY_HOURS  = 24
+	ENUM_EVENT_DAY_NOTICE_HOUR          = 7
+
 	ENUM_RUN_PRODUCTION = "production"
 	ENUM_RUN_TESTING    = "testing"
 
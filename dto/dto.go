@@ -1,6 +1,7 @@
 package dto
 
 import (
+	"encoding/json"
 	"errors"
 	"mime/multipart"
 	"time"
@@ -24,6 +25,12 @@ const (
 	// Authentication
 	MESSAGE_FAILED_LOGIN_ADMIN = "failed login admin"
 	MESSAGE_FAILED_LOGIN_USER  = "failed login user"
+	// Email Verification
+	MESSAGE_FAILED_VERIFY_EMAIL              = "failed verify email"
+	MESSAGE_FAILED_RESEND_EMAIL_VERIFICATION = "failed resend email verification"
+	// Password Reset
+	MESSAGE_FAILED_FORGOT_PASSWORD = "failed forgot password"
+	MESSAGE_FAILED_RESET_PASSWORD  = "failed reset password"
 	// Query Params
 	MESSAGE_FAILED_INVALID_QUERY_PARAMS = "failed invalid query params"
 	// Middleware
@@ -32,6 +39,7 @@ const (
 	MESSAGE_FAILED_TOKEN_NOT_FOUND            = "failed token not found"
 	MESSAGE_FAILED_TOKEN_NOT_VALID            = "failed token not valid"
 	MESSAGE_FAILED_TOKEN_DENIED_ACCESS        = "failed token denied access"
+	MESSAGE_FAILED_TOKEN_REVOKED              = "failed token revoked, please login again"
 	MESSAGE_FAILED_GET_CUSTOM_CLAIMS          = "failed get custom claims"
 	MESSAGE_FAILED_GET_ROLE_USER              = "failed get role user"
 	MESSAGE_FAILED_INAVLID_ROUTE_FORMAT_TOKEN = "failed invalid route format in token"
@@ -41,6 +49,10 @@ const (
 	MESSAGE_FAILED_GET_DETAIL_USER = "failed get detail user"
 	MESSAGE_FAILED_UPDATE_USER     = "failed update user"
 	MESSAGE_FAILED_DELETE_USER     = "failed delete user"
+	// Crew
+	MESSAGE_FAILED_CREATE_CREW_BULK = "failed create crew bulk"
+	MESSAGE_FAILED_GET_LIST_CREW    = "failed get list crew"
+	MESSAGE_FAILED_EXPIRE_CREW_BULK = "failed expire crew bulk"
 	// Ticket
 	MESSAGE_FAILED_CREATE_TICKET     = "failed create ticket"
 	MESSAGE_FAILED_GET_LIST_TICKET   = "failed get list ticket"
@@ -89,19 +101,99 @@ const (
 	// Check-in
 	MESSAGE_FAILED_CHECK_IN                 = "failed create check-in"
 	MESSAGE_FAILED_GET_LIST_TICKET_CHECK_IN = "failed get list ticket check-in"
+	MESSAGE_FAILED_REVOKE_TICKET_QR         = "failed revoke ticket qr"
+	MESSAGE_FAILED_REISSUE_TICKET_QR        = "failed reissue ticket qr"
+	MESSAGE_FAILED_GET_CHECK_IN_MANIFEST    = "failed get check-in manifest"
+	MESSAGE_FAILED_SYNC_CHECK_IN            = "failed sync check-in"
+	MESSAGE_FAILED_GET_EVENT_OCCUPANCY      = "failed get event occupancy"
+	MESSAGE_FAILED_VOID_CHECK_IN            = "failed void check-in"
+	MESSAGE_FAILED_STREAM_CHECK_IN_FEED     = "failed stream check-in feed"
+	MESSAGE_FAILED_LOOKUP_ATTENDEE          = "failed lookup attendee"
+	MESSAGE_FAILED_HELP_DESK_CHECK_IN       = "failed help desk check-in"
 	// Dashboard Stats
 	MESSAGE_FAILED_GET_ALL_STATS = "failed get all stats"
+	// Waitlist
+	MESSAGE_FAILED_JOIN_WAITLIST     = "failed join waitlist"
+	MESSAGE_FAILED_LEAVE_WAITLIST    = "failed leave waitlist"
+	MESSAGE_FAILED_GET_LIST_WAITLIST = "failed get list waitlist"
+	MESSAGE_FAILED_CANCEL_WAITLIST   = "failed cancel waitlist"
+	MESSAGE_FAILED_OFFER_WAITLIST    = "failed offer waitlist"
+	// Ticket Transfer
+	MESSAGE_FAILED_TRANSFER_TICKET             = "failed transfer ticket"
+	MESSAGE_FAILED_GET_TICKET_TRANSFER_HISTORY = "failed get ticket transfer history"
+	// Ticket QR
+	MESSAGE_FAILED_GET_TICKET_QR_CODE = "failed get ticket qr code"
+	// Event
+	MESSAGE_FAILED_CREATE_EVENT     = "failed create event"
+	MESSAGE_FAILED_GET_LIST_EVENT   = "failed get list event"
+	MESSAGE_FAILED_GET_DETAIL_EVENT = "failed get detail event"
+	MESSAGE_FAILED_UPDATE_EVENT     = "failed update event"
+	MESSAGE_FAILED_DELETE_EVENT     = "failed delete event"
+	// Event Session
+	MESSAGE_FAILED_CREATE_EVENT_SESSION   = "failed create event session"
+	MESSAGE_FAILED_GET_LIST_EVENT_SESSION = "failed get list event session"
+	MESSAGE_FAILED_UPDATE_EVENT_SESSION   = "failed update event session"
+	MESSAGE_FAILED_DELETE_EVENT_SESSION   = "failed delete event session"
+	// Gate
+	MESSAGE_FAILED_CREATE_GATE   = "failed create gate"
+	MESSAGE_FAILED_GET_LIST_GATE = "failed get list gate"
+	MESSAGE_FAILED_UPDATE_GATE   = "failed update gate"
+	MESSAGE_FAILED_DELETE_GATE   = "failed delete gate"
+	// Ticket Form Field
+	MESSAGE_FAILED_CREATE_TICKET_FORM_FIELD   = "failed create ticket form field"
+	MESSAGE_FAILED_GET_LIST_TICKET_FORM_FIELD = "failed get list ticket form field"
+	MESSAGE_FAILED_UPDATE_TICKET_FORM_FIELD   = "failed update ticket form field"
+	MESSAGE_FAILED_DELETE_TICKET_FORM_FIELD   = "failed delete ticket form field"
+	MESSAGE_FAILED_EXPORT_ATTENDEE            = "failed export attendee"
+	MESSAGE_FAILED_PRINT_BADGE                = "failed print badge"
+	// Merch Pickup
+	MESSAGE_FAILED_GET_MERCH_PICKUP             = "failed get merch pickup"
+	MESSAGE_FAILED_HAND_OVER_MERCH              = "failed hand over merch"
+	MESSAGE_FAILED_GET_OUTSTANDING_MERCH_PICKUP = "failed get outstanding merch pickup"
+	// Email Outbox
+	MESSAGE_FAILED_GET_LIST_EMAIL_OUTBOX = "failed get list email outbox"
+	MESSAGE_FAILED_RETRY_EMAIL_OUTBOX    = "failed retry email outbox"
+	// Email Template
+	MESSAGE_FAILED_CREATE_EMAIL_TEMPLATE     = "failed create email template"
+	MESSAGE_FAILED_GET_LIST_EMAIL_TEMPLATE   = "failed get list email template"
+	MESSAGE_FAILED_GET_DETAIL_EMAIL_TEMPLATE = "failed get detail email template"
+	MESSAGE_FAILED_UPDATE_EMAIL_TEMPLATE     = "failed update email template"
+	MESSAGE_FAILED_DELETE_EMAIL_TEMPLATE     = "failed delete email template"
+	MESSAGE_FAILED_PREVIEW_EMAIL_TEMPLATE    = "failed preview email template"
+	// Resend E-Ticket
+	MESSAGE_FAILED_RESEND_E_TICKET = "failed resend e-ticket"
+	// Broadcast
+	MESSAGE_FAILED_COUNT_BROADCAST_RECIPIENT = "failed count broadcast recipient"
+	MESSAGE_FAILED_CREATE_BROADCAST          = "failed create broadcast"
+	MESSAGE_FAILED_GET_LIST_BROADCAST        = "failed get list broadcast"
+	MESSAGE_FAILED_GET_DETAIL_BROADCAST      = "failed get detail broadcast"
+	// Notification
+	MESSAGE_FAILED_GET_LIST_NOTIFICATION_OUTBOX = "failed get list notification outbox"
+	MESSAGE_FAILED_RETRY_NOTIFICATION_OUTBOX    = "failed retry notification outbox"
+	MESSAGE_FAILED_UPDATE_WHATSAPP_OPT_IN       = "failed update whatsapp opt-in"
+	// Availability
+	MESSAGE_FAILED_STREAM_AVAILABILITY = "failed stream availability"
 
 	// ====================================== Success ======================================
 	// Authentication
 	MESSAGE_SUCCESS_LOGIN_ADMIN = "success login admin"
 	MESSAGE_SUCCESS_LOGIN_USER  = "success login user"
+	// Email Verification
+	MESSAGE_SUCCESS_VERIFY_EMAIL              = "success verify email"
+	MESSAGE_SUCCESS_RESEND_EMAIL_VERIFICATION = "success resend email verification"
+	// Password Reset
+	MESSAGE_SUCCESS_FORGOT_PASSWORD = "success forgot password, check your email for the reset link"
+	MESSAGE_SUCCESS_RESET_PASSWORD  = "success reset password"
 	// User
 	MESSAGE_SUCCESS_CREATE_USER     = "success create user"
 	MESSAGE_SUCCESS_GET_LIST_USER   = "success get list user"
 	MESSAGE_SUCCESS_GET_DETAIL_USER = "success get detail user"
 	MESSAGE_SUCCESS_UPDATE_USER     = "success update user"
 	MESSAGE_SUCCESS_DELETE_USER     = "success delete user"
+	// Crew
+	MESSAGE_SUCCESS_CREATE_CREW_BULK = "success create crew bulk"
+	MESSAGE_SUCCESS_GET_LIST_CREW    = "success get list crew"
+	MESSAGE_SUCCESS_EXPIRE_CREW_BULK = "success expire crew bulk"
 	// Ticket
 	MESSAGE_SUCCESS_CREATE_TICKET     = "success create ticket"
 	MESSAGE_SUCCESS_GET_LIST_TICKET   = "success get list ticket"
@@ -149,16 +241,82 @@ const (
 	// Check-in
 	MESSAGE_SUCCESS_CHECK_IN                 = "success create check-in"
 	MESSAGE_SUCCESS_GET_LIST_TICKET_CHECK_IN = "success get list ticket check-in"
+	MESSAGE_SUCCESS_REVOKE_TICKET_QR         = "success revoke ticket qr"
+	MESSAGE_SUCCESS_REISSUE_TICKET_QR        = "success reissue ticket qr"
+	MESSAGE_SUCCESS_GET_CHECK_IN_MANIFEST    = "success get check-in manifest"
+	MESSAGE_SUCCESS_SYNC_CHECK_IN            = "success sync check-in"
+	MESSAGE_SUCCESS_GET_EVENT_OCCUPANCY      = "success get event occupancy"
+	MESSAGE_SUCCESS_VOID_CHECK_IN            = "success void check-in"
+	MESSAGE_SUCCESS_LOOKUP_ATTENDEE          = "success lookup attendee"
+	MESSAGE_SUCCESS_HELP_DESK_CHECK_IN       = "success help desk check-in"
+	// Merch Pickup
+	MESSAGE_SUCCESS_GET_MERCH_PICKUP             = "success get merch pickup"
+	MESSAGE_SUCCESS_HAND_OVER_MERCH              = "success hand over merch"
+	MESSAGE_SUCCESS_GET_OUTSTANDING_MERCH_PICKUP = "success get outstanding merch pickup"
+	// Email Outbox
+	MESSAGE_SUCCESS_GET_LIST_EMAIL_OUTBOX = "success get list email outbox"
+	MESSAGE_SUCCESS_RETRY_EMAIL_OUTBOX    = "success retry email outbox"
+	// Email Template
+	MESSAGE_SUCCESS_CREATE_EMAIL_TEMPLATE     = "success create email template"
+	MESSAGE_SUCCESS_GET_LIST_EMAIL_TEMPLATE   = "success get list email template"
+	MESSAGE_SUCCESS_GET_DETAIL_EMAIL_TEMPLATE = "success get detail email template"
+	MESSAGE_SUCCESS_UPDATE_EMAIL_TEMPLATE     = "success update email template"
+	MESSAGE_SUCCESS_DELETE_EMAIL_TEMPLATE     = "success delete email template"
+	MESSAGE_SUCCESS_PREVIEW_EMAIL_TEMPLATE    = "success preview email template"
+	// Resend E-Ticket
+	MESSAGE_SUCCESS_RESEND_E_TICKET = "success resend e-ticket"
+	// Broadcast
+	MESSAGE_SUCCESS_COUNT_BROADCAST_RECIPIENT = "success count broadcast recipient"
+	MESSAGE_SUCCESS_CREATE_BROADCAST          = "success create broadcast"
+	MESSAGE_SUCCESS_GET_LIST_BROADCAST        = "success get list broadcast"
+	MESSAGE_SUCCESS_GET_DETAIL_BROADCAST      = "success get detail broadcast"
+	// Notification
+	MESSAGE_SUCCESS_GET_LIST_NOTIFICATION_OUTBOX = "success get list notification outbox"
+	MESSAGE_SUCCESS_RETRY_NOTIFICATION_OUTBOX    = "success retry notification outbox"
+	MESSAGE_SUCCESS_UPDATE_WHATSAPP_OPT_IN       = "success update whatsapp opt-in"
 	// Dashboard Stats
 	MESSAGE_SUCCESS_GET_ALL_STATS = "success get all stats"
+	// Waitlist
+	MESSAGE_SUCCESS_JOIN_WAITLIST     = "success join waitlist"
+	MESSAGE_SUCCESS_LEAVE_WAITLIST    = "success leave waitlist"
+	MESSAGE_SUCCESS_GET_LIST_WAITLIST = "success get list waitlist"
+	MESSAGE_SUCCESS_CANCEL_WAITLIST   = "success cancel waitlist"
+	MESSAGE_SUCCESS_OFFER_WAITLIST    = "success offer waitlist"
+	// Ticket Transfer
+	MESSAGE_SUCCESS_TRANSFER_TICKET             = "success transfer ticket"
+	MESSAGE_SUCCESS_GET_TICKET_TRANSFER_HISTORY = "success get ticket transfer history"
+	// Event
+	MESSAGE_SUCCESS_CREATE_EVENT     = "success create event"
+	MESSAGE_SUCCESS_GET_LIST_EVENT   = "success get list event"
+	MESSAGE_SUCCESS_GET_DETAIL_EVENT = "success get detail event"
+	MESSAGE_SUCCESS_UPDATE_EVENT     = "success update event"
+	MESSAGE_SUCCESS_DELETE_EVENT     = "success delete event"
+	// Event Session
+	MESSAGE_SUCCESS_CREATE_EVENT_SESSION   = "success create event session"
+	MESSAGE_SUCCESS_GET_LIST_EVENT_SESSION = "success get list event session"
+	MESSAGE_SUCCESS_UPDATE_EVENT_SESSION   = "success update event session"
+	MESSAGE_SUCCESS_DELETE_EVENT_SESSION   = "success delete event session"
+	// Gate
+	MESSAGE_SUCCESS_CREATE_GATE   = "success create gate"
+	MESSAGE_SUCCESS_GET_LIST_GATE = "success get list gate"
+	MESSAGE_SUCCESS_UPDATE_GATE   = "success update gate"
+	MESSAGE_SUCCESS_DELETE_GATE   = "success delete gate"
+	// Ticket Form Field
+	MESSAGE_SUCCESS_CREATE_TICKET_FORM_FIELD   = "success create ticket form field"
+	MESSAGE_SUCCESS_GET_LIST_TICKET_FORM_FIELD = "success get list ticket form field"
+	MESSAGE_SUCCESS_UPDATE_TICKET_FORM_FIELD   = "success update ticket form field"
+	MESSAGE_SUCCESS_DELETE_TICKET_FORM_FIELD   = "success delete ticket form field"
 )
 
 var (
 	// Middleware
 	ErrDeniedAccess = errors.New("denied access")
 	// Email
-	ErrMakeETicketEmail = errors.New("failed create e-ticket email")
-	ErrSendEmail        = errors.New("failed send email")
+	ErrMakeETicketEmail         = errors.New("failed create e-ticket email")
+	ErrMakePurchaseReceiptEmail = errors.New("failed create purchase receipt email")
+	ErrMakeReminderEmail        = errors.New("failed create reminder email")
+	ErrCreateEventReminder      = errors.New("failed create event reminder")
+	ErrSendEmail                = errors.New("failed send email")
 	// File
 	ErrInvalidExtensionPhoto = errors.New("only jpg/jpeg/png allowed")
 	ErrCreateFile            = errors.New("failed create file")
@@ -195,8 +353,29 @@ var (
 	// Email
 	ErrEmailAlreadyExists = errors.New("email already exists")
 	ErrEmailNotFound      = errors.New("email not found")
+	// Email Verification
+	ErrInvalidEmailVerificationToken = errors.New("failed invalid email verification token")
+	ErrEmailVerificationExpired      = errors.New("failed email verification link has expired")
+	ErrEmailVerificationStale        = errors.New("failed email verification link is no longer valid")
+	ErrEmailAlreadyVerified          = errors.New("failed email already verified")
+	ErrEmailNotVerified              = errors.New("failed email must be verified before checkout")
+	ErrMakeEmailVerificationEmail    = errors.New("failed create email verification email")
+	ErrVerifyEmail                   = errors.New("failed verify email")
+	ErrCountEmailVerification        = errors.New("failed count email verification")
+	ErrEmailVerificationRateLimited  = errors.New("failed too many verification email, try again later")
 	// Password
 	ErrPasswordNotMatch = errors.New("password not match")
+	// Password Reset
+	ErrInvalidPasswordResetToken = errors.New("failed invalid password reset token")
+	ErrPasswordResetExpired      = errors.New("failed password reset link has expired")
+	ErrPasswordResetUsed         = errors.New("failed password reset link has already been used")
+	ErrMakePasswordResetEmail    = errors.New("failed create password reset email")
+	ErrCreatePasswordReset       = errors.New("failed create password reset")
+	ErrCountPasswordReset        = errors.New("failed count password reset")
+	ErrForgotPassword            = errors.New("failed forgot password")
+	ErrResetPassword             = errors.New("failed reset password")
+	ErrRevokeSession             = errors.New("failed revoke session")
+	ErrCheckTokenRevoked         = errors.New("failed check token revoked")
 	// User
 	ErrCreateUser               = errors.New("failed create user")
 	ErrGetAllUserNoPagination   = errors.New("failed get all user no pagination")
@@ -205,6 +384,19 @@ var (
 	ErrUpdateUser               = errors.New("failed update user")
 	ErrDeleteUserByID           = errors.New("failed delete user by id")
 	ErrUserAlreadyExists        = errors.New("failed user already exists")
+	// Crew
+	ErrCrewAccountExpired       = errors.New("failed crew account expired")
+	ErrCrewEventOutOfScope      = errors.New("failed event is outside the crew scope")
+	ErrCrewGateOutOfScope       = errors.New("failed gate is outside the crew scope")
+	ErrEmptyCrewAccounts        = errors.New("failed crew accounts are empty")
+	ErrTooManyCrewAccounts      = errors.New("failed too many crew accounts in one request")
+	ErrCrewExpiryRequired       = errors.New("failed crew expiry must be in the future")
+	ErrCrewGateRequiresEvent    = errors.New("failed crew gate requires an event")
+	ErrDuplicateCrewEmail       = errors.New("failed duplicate crew email")
+	ErrGeneratePassword         = errors.New("failed generate password")
+	ErrGetAllCrew               = errors.New("failed get all crew")
+	ErrExpireCrew               = errors.New("failed expire crew")
+	ErrExpireCrewTargetRequired = errors.New("failed user ids or event id is required")
 	// Ticket
 	ErrCreateTicket               = errors.New("failed create ticket")
 	ErrGetAllTicketNoPagination   = errors.New("failed get all ticket no pagination")
@@ -286,7 +478,7 @@ var (
 	ErrCreateTransaction             = errors.New("failed create transaction")
 	ErrMustBeInvitedGuest            = errors.New("failed audience must be invited guest")
 	ErrItemTypeMustBeTicket          = errors.New("failed item type must be ticket")
-	ErrTicketTypeMustBeMainEvent     = errors.New("failed ticket type must be main event")
+	ErrTicketMustHaveEvent           = errors.New("failed ticket must belong to an event")
 	ErrItemTypeMustBeTicketOrBundle  = errors.New("failed item type must be ticket or bundle")
 	ErrGetAllTransactionNoPagination = errors.New("failed get all transaction no pagination")
 	ErrTicketFormNotFound            = errors.New("failed ticket form not found")
@@ -306,9 +498,41 @@ var (
 	ErrCreateGuestAttendance             = errors.New("failed create guest attendance")
 	ErrGetAllTicketCheckInNoPagination   = errors.New("failed get all ticket check-in")
 	ErrGetAllTicketCheckInWithPagination = errors.New("failed get all ticket check-in with pagination")
+	ErrInvalidTicketQR                   = errors.New("failed invalid ticket qr")
+	ErrTicketQRRevoked                   = errors.New("failed ticket qr has been revoked")
+	ErrTicketQREventMismatch             = errors.New("failed ticket qr belongs to another event")
+	ErrTicketQRAlreadyRevoked            = errors.New("failed ticket qr already revoked")
+	ErrInvalidTicketQRLink               = errors.New("failed invalid ticket qr link")
+	ErrTicketQRLinkExpired               = errors.New("failed ticket qr link has expired")
+	ErrGetCheckInManifest                = errors.New("failed get check-in manifest")
+	ErrSignCheckInManifest               = errors.New("failed sign check-in manifest")
+	ErrDeviceIDRequired                  = errors.New("failed device id is required")
+	ErrEmptyCheckInScans                 = errors.New("failed no scans to sync")
+	ErrTooManyCheckInScans               = errors.New("failed too many scans in one batch")
+	ErrCheckInScanOutsideWindow          = errors.New("failed scan time is outside the check-in window")
+	ErrCheckInScanInFuture               = errors.New("failed scan time is after the sync was received")
+	ErrCreateCheckInScan                 = errors.New("failed create check-in scan")
+	ErrUpdateGuestAttendance             = errors.New("failed update guest attendance")
+	ErrInvalidCheckInDirection           = errors.New("failed invalid check-in direction")
+	ErrEventSessionRequired              = errors.New("failed event session is required for this event")
+	ErrEventSessionNotInEvent            = errors.New("failed event session does not belong to the ticket event")
+	ErrEventSessionFull                  = errors.New("failed event session is full")
+	ErrCountEventSessionCheckIn          = errors.New("failed count event session check-in")
+	ErrGateNotInEvent                    = errors.New("failed gate does not belong to the ticket event")
+	ErrAlreadyInside                     = errors.New("failed guest is already inside")
+	ErrNotInside                         = errors.New("failed guest is not inside")
+	ErrAlreadyCheckedInSession           = errors.New("failed already check in for this session")
+	ErrGetEventOccupancy                 = errors.New("failed get event occupancy")
+	ErrGuestAttendanceNotFound           = errors.New("failed guest attendance not found")
+	ErrCheckInAlreadyVoided              = errors.New("failed check-in already voided")
+	ErrVoidReasonRequired                = errors.New("failed void reason is required")
+	ErrGetAllGuestAttendance             = errors.New("failed get all guest attendance")
+	ErrAttendeeLookupSearchTooShort      = errors.New("failed search must be at least 3 characters")
+	ErrLookupAttendee                    = errors.New("failed lookup attendee")
+	ErrIdentityVerificationRequired      = errors.New("failed identity verification is required")
+	ErrIdentityVerificationFailed        = errors.New("failed identity does not match the ticket holder")
+	ErrTicketNotPaid                     = errors.New("failed ticket transaction is not settled")
 	// Dashboard Stats
-	ErrGetAllPreEvent3Stats      = errors.New("failed get all pre-event-3 stats")
-	ErrGetAllMainEventStats      = errors.New("failed get all main-event stats")
 	ErrGetTotalBundleMerch       = errors.New("failed get total bundle merch")
 	ErrGetTotalBundleMerchTicket = errors.New("failed get total bundle merch ticket")
 	ErrGetTotalAdmin             = errors.New("failed get total admin")
@@ -316,6 +540,124 @@ var (
 	ErrGetTotalSponsor           = errors.New("failed get total sponsor")
 	ErrGetTotalPartner           = errors.New("failed get total partner")
 	ErrGetTotalMediaPartner      = errors.New("failed get total media partner")
+	// Waitlist
+	ErrCreateWaitlist               = errors.New("failed create waitlist")
+	ErrWaitlistNotFound             = errors.New("failed waitlist not found")
+	ErrAlreadyInWaitlist            = errors.New("failed already in waitlist")
+	ErrTicketStillAvailable         = errors.New("failed ticket still available")
+	ErrGetAllWaitlistNoPagination   = errors.New("failed get all waitlist no pagination")
+	ErrGetAllWaitlistWithPagination = errors.New("failed get all waitlist with pagination")
+	ErrUpdateWaitlist               = errors.New("failed update waitlist")
+	ErrInvalidWaitlistStatus        = errors.New("failed invalid waitlist status")
+	ErrWaitlistCannotBeCancelled    = errors.New("failed waitlist cannot be cancelled")
+	ErrInvalidWaitlistToken         = errors.New("failed invalid waitlist token")
+	ErrWaitlistOfferExpired         = errors.New("failed waitlist offer expired")
+	ErrWaitlistOfferSeatLimit       = errors.New("failed waitlist offer only reserves one seat")
+	ErrRestoreTicketQuota           = errors.New("failed restore ticket quota")
+	ErrOfferWaitlist                = errors.New("failed offer waitlist")
+	ErrMakeWaitlistOfferEmail       = errors.New("failed create waitlist offer email")
+	// Ticket Transfer
+	ErrTicketTransferred        = errors.New("failed ticket has been transferred")
+	ErrNotTicketOwner           = errors.New("failed ticket not owned by user")
+	ErrTransactionNotSettled    = errors.New("failed transaction not settled")
+	ErrTransferCutoffPassed     = errors.New("failed ticket transfer cutoff has passed")
+	ErrTransferToSameHolder     = errors.New("failed cannot transfer ticket to the same holder")
+	ErrCreateTicketTransfer     = errors.New("failed create ticket transfer")
+	ErrGetTicketTransferHistory = errors.New("failed get ticket transfer history")
+	// Event
+	ErrCreateEvent               = errors.New("failed create event")
+	ErrGetAllEventNoPagination   = errors.New("failed get all event no pagination")
+	ErrGetAllEventWithPagination = errors.New("failed get all event with pagination")
+	ErrEventNotFound             = errors.New("failed event not found")
+	ErrUpdateEvent               = errors.New("failed update event")
+	ErrDeleteEventByID           = errors.New("failed delete event by id")
+	ErrEventAlreadyExists        = errors.New("failed event already exists")
+	ErrEventNameTooShort         = errors.New("failed event name too short (min 3.)")
+	ErrEventEndBeforeStart       = errors.New("failed event end time must be after start time")
+	ErrCapacityOutOfBound        = errors.New("failed capacity out of bound")
+	ErrEventCapacityExceeded     = errors.New("failed ticket quota exceeds event capacity")
+	ErrCountEventSeatAllocation  = errors.New("failed count event seat allocation")
+	ErrGetAllEventStats          = errors.New("failed get all event stats")
+	ErrInvalidReentryPolicy      = errors.New("failed invalid reentry policy")
+	// Event Session
+	ErrCreateEventSession     = errors.New("failed create event session")
+	ErrGetAllEventSession     = errors.New("failed get all event session")
+	ErrEventSessionNotFound   = errors.New("failed event session not found")
+	ErrUpdateEventSession     = errors.New("failed update event session")
+	ErrDeleteEventSessionByID = errors.New("failed delete event session by id")
+	ErrEventSessionOutOfRange = errors.New("failed event session must be within the event time")
+	// Gate
+	ErrCreateGate     = errors.New("failed create gate")
+	ErrGetAllGate     = errors.New("failed get all gate")
+	ErrGateNotFound   = errors.New("failed gate not found")
+	ErrUpdateGate     = errors.New("failed update gate")
+	ErrDeleteGateByID = errors.New("failed delete gate by id")
+	// Ticket Form Field
+	ErrCreateTicketFormField     = errors.New("failed create ticket form field")
+	ErrGetAllTicketFormField     = errors.New("failed get all ticket form field")
+	ErrTicketFormFieldNotFound   = errors.New("failed ticket form field not found")
+	ErrUpdateTicketFormField     = errors.New("failed update ticket form field")
+	ErrDeleteTicketFormFieldByID = errors.New("failed delete ticket form field by id")
+	ErrInvalidFormFieldType      = errors.New("failed invalid form field type")
+	ErrInvalidFormFieldKey       = errors.New("failed invalid form field key (lowercase letters, numbers and underscore)")
+	ErrFormFieldKeyAlreadyExists = errors.New("failed form field key already exists")
+	ErrFormFieldOptionsRequired  = errors.New("failed form field options required")
+	ErrRequiredAnswerMissing     = errors.New("failed required answer missing")
+	ErrInvalidAnswer             = errors.New("failed invalid answer")
+	ErrUnknownAnswerField        = errors.New("failed unknown answer field")
+	ErrExportAttendee            = errors.New("failed export attendee")
+	ErrInvalidBadgeFormat        = errors.New("failed invalid badge format")
+	ErrLoadBadgeLayout           = errors.New("failed load badge layout")
+	ErrRenderBadge               = errors.New("failed render badge")
+	// Merch Pickup
+	ErrNoMerchOwed               = errors.New("failed no merch owed for this ticket")
+	ErrMerchPickupLineNotFound   = errors.New("failed merch pickup line not found")
+	ErrMerchAlreadyHandedOver    = errors.New("failed merch already handed over")
+	ErrNothingToHandOver         = errors.New("failed all merch already handed over")
+	ErrCreateMerchPickup         = errors.New("failed create merch pickup")
+	ErrGetOutstandingMerchPickup = errors.New("failed get outstanding merch pickup")
+	// Email Outbox
+	ErrCreateEmailOutbox               = errors.New("failed create email outbox")
+	ErrInvalidEmailOutboxStatus        = errors.New("failed invalid email outbox status")
+	ErrGetAllEmailOutboxWithPagination = errors.New("failed get all email outbox with pagination")
+	ErrEmailOutboxNotFound             = errors.New("failed email outbox not found")
+	ErrEmailOutboxNotRetryable         = errors.New("failed only failed or dead email can be retried")
+	ErrRetryEmailOutbox                = errors.New("failed retry email outbox")
+	// Email Template
+	ErrInvalidEmailTemplateKey    = errors.New("failed invalid email template key")
+	ErrInvalidEmailTemplate       = errors.New("failed invalid email template")
+	ErrEmailTemplateAlreadyExists = errors.New("failed email template for this key already exists")
+	ErrEmailTemplateNotFound      = errors.New("failed email template not found")
+	ErrGetEmailTemplate           = errors.New("failed get email template")
+	ErrGetAllEmailTemplate        = errors.New("failed get all email template")
+	ErrCreateEmailTemplate        = errors.New("failed create email template")
+	ErrUpdateEmailTemplate        = errors.New("failed update email template")
+	ErrDeleteEmailTemplateByID    = errors.New("failed delete email template by id")
+	// Resend E-Ticket
+	ErrTicketFormNotInTransaction = errors.New("failed ticket form not in this transaction")
+	ErrNoTicketToResend           = errors.New("failed no active ticket to resend")
+	ErrResendEmailNeedsTicketForm = errors.New("failed corrected email needs a single ticket form")
+	ErrResendETicketRateLimited   = errors.New("failed too many e-ticket resend, try again later")
+	ErrCountResendETicket         = errors.New("failed count e-ticket resend")
+	// Broadcast
+	ErrInvalidCheckedInFilter        = errors.New("failed checked_in must be true or false")
+	ErrInvalidTransactionStatus      = errors.New("failed invalid transaction status")
+	ErrGetBroadcastRecipient         = errors.New("failed get broadcast recipient")
+	ErrNoBroadcastRecipient          = errors.New("failed no recipient matches the broadcast filter")
+	ErrMakeBroadcastEmail            = errors.New("failed create broadcast email")
+	ErrCreateBroadcast               = errors.New("failed create broadcast")
+	ErrGetAllBroadcastWithPagination = errors.New("failed get all broadcast with pagination")
+	ErrBroadcastNotFound             = errors.New("failed broadcast not found")
+	ErrCountBroadcastDelivery        = errors.New("failed count broadcast delivery")
+	// Notification
+	ErrCreateNotificationOutbox               = errors.New("failed create notification outbox")
+	ErrInvalidNotificationOutboxStatus        = errors.New("failed invalid notification outbox status")
+	ErrInvalidNotificationKind                = errors.New("failed invalid notification kind")
+	ErrGetAllNotificationOutboxWithPagination = errors.New("failed get all notification outbox with pagination")
+	ErrNotificationOutboxNotFound             = errors.New("failed notification outbox not found")
+	ErrNotificationOutboxNotRetryable         = errors.New("failed only failed or dead notification can be retried")
+	ErrRetryNotificationOutbox                = errors.New("failed retry notification outbox")
+	ErrUpdateWhatsAppOptIn                    = errors.New("failed update whatsapp opt-in")
 )
 
 // All About Image Request
@@ -333,6 +675,27 @@ type (
 	LoginResponse struct {
 		Token string `json:"token"`
 	}
+	VerifyEmailRequest struct {
+		Token string `json:"token" form:"token" binding:"required"`
+	}
+	EmailVerificationResponse struct {
+		UserID        uuid.UUID  `json:"user_id"`
+		Email         string     `json:"user_email"`
+		PendingEmail  string     `json:"pending_email,omitempty"`
+		EmailVerified *time.Time `json:"email_verified"`
+	}
+	ForgotPasswordRequest struct {
+		Email string `json:"email" form:"email" binding:"required"`
+	}
+	ResetPasswordRequest struct {
+		Token    string `json:"token" form:"token" binding:"required"`
+		Password string `json:"password" form:"password" binding:"required"`
+	}
+	ResetPasswordResponse struct {
+		UserID            uuid.UUID `json:"user_id"`
+		Email             string    `json:"user_email"`
+		PasswordChangedAt time.Time `json:"password_changed_at"`
+	}
 )
 
 // User
@@ -342,9 +705,42 @@ type (
 		Name          string      `json:"user_name"`
 		Email         string      `json:"user_email"`
 		EmailVerified *time.Time  `json:"email_verified"`
+		PendingEmail  string      `json:"pending_email,omitempty"`
 		Password      string      `json:"user_password"`
 		Role          entity.Role `json:"user_role"`
 	}
+	CrewAccountRequest struct {
+		Name   string `json:"name"`
+		Email  string `json:"email"`
+		GateID string `json:"gate_id"`
+	}
+	CreateCrewBulkRequest struct {
+		EventID   string               `json:"event_id"`
+		GateID    string               `json:"gate_id"`
+		ExpiresAt *time.Time           `json:"expires_at"`
+		Crews     []CrewAccountRequest `json:"crews"`
+	}
+	CrewResponse struct {
+		ID        uuid.UUID  `json:"user_id"`
+		Name      string     `json:"user_name"`
+		Email     string     `json:"user_email"`
+		Password  string     `json:"password,omitempty"`
+		EventID   *uuid.UUID `json:"event_id"`
+		GateID    *uuid.UUID `json:"gate_id"`
+		ExpiresAt *time.Time `json:"expires_at"`
+		Expired   bool       `json:"expired"`
+	}
+	CrewFilterQuery struct {
+		EventID string `form:"event_id"`
+		Active  string `form:"active"`
+	}
+	ExpireCrewBulkRequest struct {
+		UserIDs []string `json:"user_ids"`
+		EventID string   `json:"event_id"`
+	}
+	ExpireCrewBulkResponse struct {
+		Expired int64 `json:"expired"`
+	}
 	CreateUserRequest struct {
 		Name          string     `json:"user_name" form:"user_name"`
 		Email         string     `json:"user_email" form:"user_email"`
@@ -374,15 +770,18 @@ type (
 // Ticket
 type (
 	TicketResponse struct {
-		ID          string            `json:"ticket_id"`
-		Name        string            `json:"ticket_name"`
-		Type        entity.TicketType `json:"ticket_type"`
-		Price       float64           `json:"ticket_price"`
-		Image       string            `json:"ticket_image"`
-		Quota       int               `json:"ticket_quota"`
-		Description string            `json:"ticket_description"`
-		EventDate   string            `json:"ticket_event_date"`
-		IsAvailable *bool             `json:"ticket_is_available,omitempty"`
+		ID          string                    `json:"ticket_id"`
+		Name        string                    `json:"ticket_name"`
+		Type        entity.TicketType         `json:"ticket_type"`
+		Price       float64                   `json:"ticket_price"`
+		Image       string                    `json:"ticket_image"`
+		Quota       int                       `json:"ticket_quota"`
+		Description string                    `json:"ticket_description"`
+		EventDate   string                    `json:"ticket_event_date"`
+		IsAvailable *bool                     `json:"ticket_is_available,omitempty"`
+		EventID     *uuid.UUID                `json:"event_id"`
+		EventName   string                    `json:"event_name,omitempty"`
+		FormFields  []TicketFormFieldResponse `json:"ticket_form_fields,omitempty"`
 	}
 	CreateTicketRequest struct {
 		Name        string            `json:"ticket_name" form:"ticket_name"`
@@ -392,6 +791,7 @@ type (
 		Quota       int               `json:"ticket_quota" form:"ticket_quota"`
 		Description string            `json:"ticket_description" form:"ticket_description"`
 		EventDate   string            `json:"ticket_event_date" form:"ticket_event_date"`
+		EventID     *uuid.UUID        `json:"event_id" form:"event_id"`
 		ImageUpload
 	}
 	UpdateTicketRequest struct {
@@ -403,6 +803,7 @@ type (
 		Quota       *int              `json:"ticket_quota,omitempty" form:"ticket_quota"`
 		Description string            `json:"ticket_description" form:"ticket_description"`
 		EventDate   string            `json:"ticket_event_date" form:"ticket_event_date"`
+		EventID     *uuid.UUID        `json:"event_id,omitempty" form:"event_id"`
 		ImageUpload
 	}
 	TicketPaginationResponse struct {
@@ -547,6 +948,7 @@ type (
 		Description string               `json:"bundle_description"`
 		EventDate   string               `json:"bundle_event_date"`
 		IsAvailable *bool                `json:"ticket_is_available,omitempty"`
+		EventID     *uuid.UUID           `json:"event_id"`
 		BundleItems []BundleItemResponse `json:"bundle_items"`
 	}
 	BundleItemResponse struct {
@@ -563,6 +965,7 @@ type (
 		Quota       int               `json:"bundle_quota" form:"bundle_quota"`
 		Description string            `json:"bundle_description" form:"bundle_description"`
 		EventDate   string            `json:"bundle_event_date" form:"bundle_event_date"`
+		EventID     *uuid.UUID        `json:"event_id" form:"event_id"`
 		BundleItems []*uuid.UUID      `json:"bundle_items"`
 		ImageUpload
 	}
@@ -575,6 +978,7 @@ type (
 		Quota       *int              `json:"bundle_quota,omitempty" form:"bundle_quota"`
 		Description string            `json:"bundle_description" form:"bundle_description"`
 		EventDate   string            `json:"bundle_event_date" form:"bundle_event_date"`
+		EventID     *uuid.UUID        `json:"event_id,omitempty" form:"event_id"`
 		BundleItems []*uuid.UUID      `json:"bundle_items,omitempty" form:"bundle_items"`
 		ImageUpload
 	}
@@ -631,21 +1035,120 @@ type (
 	CheckInFilterQuery struct {
 		Search     string `form:"search"`
 		TicketType string `form:"ticket_type"`
+		EventID    string `form:"event_id"`
 		Status     string `form:"status"`
 	}
 	TicketCheckInResponse struct {
-		TicketFormID  uuid.UUID           `json:"ticket_form_id"`
-		TicketID      uuid.UUID           `json:"ticket_id"`
-		TransactionID uuid.UUID           `json:"transaction_id"`
-		TicketName    string              `json:"ticket_name"`
-		TicketType    entity.TicketType   `json:"ticket_type"`
-		AudienceType  entity.AudienceType `json:"audience_type"`
-		Email         string              `json:"email"`
-		FullName      string              `json:"full_name"`
-		PhoneNumber   string              `json:"phone_number"`
-		LineID        string              `json:"line_id"`
-		Status        bool                `json:"status"`
-		EmailChecker  string              `json:"email_checker"`
+		TicketFormID  uuid.UUID                 `json:"ticket_form_id"`
+		TicketID      uuid.UUID                 `json:"ticket_id"`
+		TransactionID uuid.UUID                 `json:"transaction_id"`
+		TicketName    string                    `json:"ticket_name"`
+		TicketType    entity.TicketType         `json:"ticket_type"`
+		EventID       *uuid.UUID                `json:"event_id"`
+		EventName     string                    `json:"event_name"`
+		AudienceType  entity.AudienceType       `json:"audience_type"`
+		Email         string                    `json:"email"`
+		FullName      string                    `json:"full_name"`
+		PhoneNumber   string                    `json:"phone_number"`
+		LineID        string                    `json:"line_id"`
+		Answers       entity.FormAnswers        `json:"answers,omitempty"`
+		Status        bool                      `json:"status"`
+		Inside        bool                      `json:"inside"`
+		EmailChecker  string                    `json:"email_checker"`
+		Attendances   []GuestAttendanceResponse `json:"attendances,omitempty"`
+	}
+	GuestAttendanceResponse struct {
+		ID               uuid.UUID               `json:"guest_attendance_id"`
+		Direction        entity.CheckInDirection `json:"direction"`
+		Source           entity.CheckInSource    `json:"source"`
+		DeviceID         string                  `json:"device_id,omitempty"`
+		GateID           *uuid.UUID              `json:"gate_id"`
+		GateName         string                  `json:"gate_name,omitempty"`
+		EventSessionID   *uuid.UUID              `json:"event_session_id"`
+		EventSessionName string                  `json:"event_session_name,omitempty"`
+		CheckedAt        time.Time               `json:"checked_at"`
+		CheckedBy        *uuid.UUID              `json:"checked_by"`
+		CheckedByEmail   string                  `json:"checked_by_email"`
+		VoidedAt         *time.Time              `json:"voided_at,omitempty"`
+		VoidedBy         *uuid.UUID              `json:"voided_by,omitempty"`
+		VoidedByEmail    string                  `json:"voided_by_email,omitempty"`
+		VoidReason       string                  `json:"void_reason,omitempty"`
+	}
+	CheckInFeedEvent struct {
+		TicketFormID     *uuid.UUID               `json:"ticket_form_id,omitempty"`
+		FullName         string                   `json:"full_name,omitempty"`
+		TicketName       string                   `json:"ticket_name,omitempty"`
+		EventName        string                   `json:"event_name,omitempty"`
+		GateName         string                   `json:"gate_name,omitempty"`
+		EventSessionName string                   `json:"event_session_name,omitempty"`
+		Direction        entity.CheckInDirection  `json:"direction"`
+		Operator         string                   `json:"operator"`
+		Result           entity.CheckInScanResult `json:"result"`
+		Reason           string                   `json:"reason,omitempty"`
+		ScannedAt        time.Time                `json:"scanned_at"`
+		Totals           *GuestStatResponse       `json:"totals,omitempty"`
+	}
+	VoidCheckInRequest struct {
+		ID     string `json:"-"`
+		Reason string `json:"reason" form:"reason"`
+	}
+	AttendeeLookupQuery struct {
+		Search  string `form:"search"`
+		EventID string `form:"event_id"`
+		Phone   string `form:"-"`
+	}
+	AttendeeLookupCandidateResponse struct {
+		TicketFormID uuid.UUID         `json:"ticket_form_id"`
+		FullName     string            `json:"full_name"`
+		Email        string            `json:"email"`
+		PhoneNumber  string            `json:"phone_number"`
+		OrderID      string            `json:"order_id"`
+		TicketName   string            `json:"ticket_name"`
+		TicketType   entity.TicketType `json:"ticket_type"`
+		EventID      *uuid.UUID        `json:"event_id"`
+		EventName    string            `json:"event_name"`
+		Status       bool              `json:"status"`
+		Inside       bool              `json:"inside"`
+		Score        int               `json:"score"`
+		MatchedOn    []string          `json:"matched_on"`
+	}
+	CheckInResponse struct {
+		TicketFormID uuid.UUID `json:"ticket_form_id"`
+		FullName     string    `json:"full_name"`
+		BadgeURL     string    `json:"badge_url"`
+	}
+	HelpDeskCheckInRequest struct {
+		TicketFormID   string                  `json:"-"`
+		Verification   string                  `json:"verification" form:"verification"`
+		GateID         string                  `json:"gate_id" form:"gate_id"`
+		EventSessionID string                  `json:"event_session_id" form:"event_session_id"`
+		Direction      entity.CheckInDirection `json:"direction" form:"direction"`
+	}
+	CheckInRequest struct {
+		QRToken        string                  `json:"-"`
+		GateID         string                  `json:"gate_id" form:"gate_id"`
+		EventSessionID string                  `json:"event_session_id" form:"event_session_id"`
+		Direction      entity.CheckInDirection `json:"direction" form:"direction"`
+	}
+	GateOccupancyResponse struct {
+		GateID   *uuid.UUID `json:"gate_id"`
+		GateName string     `json:"gate_name"`
+		TotalIn  int64      `json:"total_in"`
+		TotalOut int64      `json:"total_out"`
+	}
+	SessionOccupancyResponse struct {
+		EventSessionID uuid.UUID `json:"event_session_id"`
+		Name           string    `json:"event_session_name"`
+		Capacity       int       `json:"event_session_capacity"`
+		TotalCheckIn   int64     `json:"total_check_in"`
+	}
+	EventOccupancyResponse struct {
+		EventID  uuid.UUID                  `json:"event_id"`
+		Name     string                     `json:"event_name"`
+		Capacity int                        `json:"event_capacity"`
+		Inside   int64                      `json:"inside"`
+		Gates    []GateOccupancyResponse    `json:"gates"`
+		Sessions []SessionOccupancyResponse `json:"sessions"`
 	}
 	TicketFormPaginationResponse struct {
 		PaginationResponse
@@ -682,13 +1185,16 @@ type (
 		RedirectURL       string               `json:"redirect_url"`
 	}
 	TicketFormResponse struct {
-		ID           uuid.UUID           `json:"ticket_form_id"`
-		AudienceType entity.AudienceType `json:"audience_type"`
-		Instansi     entity.Instansi     `json:"instansi"`
-		Email        string              `json:"email"`
-		FullName     string              `json:"full_name"`
-		PhoneNumber  string              `json:"phone_number"`
-		LineID       string              `json:"line_id"`
+		ID              uuid.UUID           `json:"ticket_form_id"`
+		AudienceType    entity.AudienceType `json:"audience_type"`
+		Instansi        entity.Instansi     `json:"instansi"`
+		Email           string              `json:"email"`
+		FullName        string              `json:"full_name"`
+		PhoneNumber     string              `json:"phone_number"`
+		LineID          string              `json:"line_id"`
+		Answers         entity.FormAnswers  `json:"answers,omitempty"`
+		WhatsAppOptIn   bool                `json:"whatsapp_opt_in"`
+		TransferredToID *uuid.UUID          `json:"transferred_to_id,omitempty"`
 	}
 	TicketFormRequest struct {
 		AudienceType entity.AudienceType `json:"audience_type" form:"audience_type"`
@@ -697,14 +1203,18 @@ type (
 		FullName     string              `json:"full_name" form:"full_name"`
 		PhoneNumber  string              `json:"phone_number" form:"phone_number"`
 		LineID       string              `json:"line_id" form:"line_id"`
+		Answers      map[string]any      `json:"answers" form:"answers"`
+		// WhatsAppOptIn: pemegang tiket setuju menerima notifikasi WhatsApp.
+		WhatsAppOptIn bool `json:"whatsapp_opt_in" form:"whatsapp_opt_in"`
 	}
 	CreateTransactionTicketRequest struct {
-		ReferalCode string              `json:"referal_code"`
-		Total       float64             `json:"total"`
-		ItemType    entity.ItemType     `json:"item_type" form:"item_type"`
-		TicketID    *uuid.UUID          `json:"ticket_id" form:"ticket_id"`
-		BundleID    *uuid.UUID          `json:"bundle_id" form:"bundle_id"`
-		TicketForms []TicketFormRequest `json:"ticket_forms" form:"ticket_forms"`
+		ReferalCode   string              `json:"referal_code"`
+		Total         float64             `json:"total"`
+		ItemType      entity.ItemType     `json:"item_type" form:"item_type"`
+		TicketID      *uuid.UUID          `json:"ticket_id" form:"ticket_id"`
+		BundleID      *uuid.UUID          `json:"bundle_id" form:"bundle_id"`
+		TicketForms   []TicketFormRequest `json:"ticket_forms" form:"ticket_forms"`
+		WaitlistToken string              `json:"waitlist_token,omitempty" form:"waitlist_token"`
 	}
 	UpdateMidtransTransactionTicketRequest struct {
 		TransactionType          string `json:"transaction_type"`
@@ -737,12 +1247,16 @@ type (
 
 // Dashboard Stats
 type (
-	// Ticket Type Stat Response
-	TicketTypeStatResponse struct {
-		Revenue          float64 `json:"revenue"`
-		TicketSold       int64   `json:"ticket_sold"`
-		TotalTransaction int64   `json:"total_transaction"`
-		TotalTicket      int64   `json:"total_ticket"`
+	// Event Stat Response
+	EventStatResponse struct {
+		EventID          uuid.UUID `json:"event_id"`
+		EventName        string    `json:"event_name"`
+		Capacity         int       `json:"capacity"`
+		Revenue          float64   `json:"revenue"`
+		TicketSold       int64     `json:"ticket_sold"`
+		TotalTransaction int64     `json:"total_transaction"`
+		TotalTicket      int64     `json:"total_ticket"`
+		TotalCheckIn     int64     `json:"total_check_in"`
 	}
 	// Guest Stat Response
 	GuestStatResponse struct {
@@ -753,14 +1267,503 @@ type (
 	}
 	// Response
 	DashboardStatResponse struct {
-		PreEvent3              TicketTypeStatResponse `json:"pre-event-3"`
-		MainEvent              TicketTypeStatResponse `json:"main-event"`
-		TotalBundleMerch       int64                  `json:"total_bundle_merch"`
-		TotalBundleMerchTicket int64                  `json:"total_bundle_merch_ticket"`
-		TotalAdmin             int64                  `json:"total_admin"`
-		Guest                  GuestStatResponse      `json:"guest"`
-		Sponsor                int64                  `json:"sponsor"`
-		Partner                int64                  `json:"partner"`
-		MediaPartner           int64                  `json:"media partner"`
+		Events                 []EventStatResponse `json:"events"`
+		TotalBundleMerch       int64               `json:"total_bundle_merch"`
+		TotalBundleMerchTicket int64               `json:"total_bundle_merch_ticket"`
+		TotalAdmin             int64               `json:"total_admin"`
+		Guest                  GuestStatResponse   `json:"guest"`
+		Sponsor                int64               `json:"sponsor"`
+		Partner                int64               `json:"partner"`
+		MediaPartner           int64               `json:"media partner"`
+	}
+)
+
+// Waitlist
+type (
+	WaitlistResponse struct {
+		ID             uuid.UUID             `json:"waitlist_id"`
+		Status         entity.WaitlistStatus `json:"waitlist_status"`
+		TicketID       *uuid.UUID            `json:"ticket_id"`
+		TicketName     string                `json:"ticket_name"`
+		UserID         *uuid.UUID            `json:"user_id"`
+		UserName       string                `json:"user_name"`
+		UserEmail      string                `json:"user_email"`
+		TransactionID  *uuid.UUID            `json:"transaction_id,omitempty"`
+		OfferedAt      *time.Time            `json:"offered_at"`
+		OfferExpiresAt *time.Time            `json:"offer_expires_at"`
+		ClaimedAt      *time.Time            `json:"claimed_at"`
+		JoinedAt       time.Time             `json:"joined_at"`
+	}
+	WaitlistFilterQuery struct {
+		TicketID string `form:"ticket_id"`
+		Status   string `form:"status"`
+	}
+	WaitlistPaginationResponse struct {
+		PaginationResponse
+		Data []WaitlistResponse `json:"data"`
+	}
+	WaitlistPaginationRepositoryResponse struct {
+		PaginationResponse
+		Waitlists []entity.Waitlist
+	}
+)
+
+// Ticket Transfer
+type (
+	TransferTicketRequest struct {
+		Email       string `json:"email" form:"email"`
+		FullName    string `json:"full_name" form:"full_name"`
+		PhoneNumber string `json:"phone_number" form:"phone_number"`
+		LineID      string `json:"line_id" form:"line_id"`
+		// Persetujuan WhatsApp pemegang lama tidak ikut pindah.
+		WhatsAppOptIn bool `json:"whatsapp_opt_in" form:"whatsapp_opt_in"`
+	}
+	TicketTransferResponse struct {
+		ID                 uuid.UUID  `json:"ticket_transfer_id"`
+		OriginTicketFormID *uuid.UUID `json:"origin_ticket_form_id"`
+		FromTicketFormID   *uuid.UUID `json:"from_ticket_form_id"`
+		ToTicketFormID     *uuid.UUID `json:"to_ticket_form_id"`
+		FromFullName       string     `json:"from_full_name"`
+		FromEmail          string     `json:"from_email"`
+		FromPhoneNumber    string     `json:"from_phone_number"`
+		ToFullName         string     `json:"to_full_name"`
+		ToEmail            string     `json:"to_email"`
+		ToPhoneNumber      string     `json:"to_phone_number"`
+		TransferredBy      *uuid.UUID `json:"transferred_by"`
+		TransferredAt      time.Time  `json:"transferred_at"`
+	}
+)
+
+// Event
+type (
+	EventResponse struct {
+		ID          uuid.UUID              `json:"event_id"`
+		Name        string                 `json:"event_name"`
+		Venue       string                 `json:"event_venue"`
+		Description string                 `json:"event_description"`
+		StartAt     time.Time              `json:"event_start_at"`
+		EndAt       time.Time              `json:"event_end_at"`
+		Capacity    int                    `json:"event_capacity"`
+		Policy      entity.ReentryPolicy   `json:"event_reentry_policy"`
+		Sessions    []EventSessionResponse `json:"event_sessions,omitempty"`
+		Gates       []GateResponse         `json:"event_gates,omitempty"`
+		Tickets     []TicketResponse       `json:"event_tickets,omitempty"`
+	}
+	CreateEventRequest struct {
+		Name        string `json:"event_name" form:"event_name"`
+		Venue       string `json:"event_venue" form:"event_venue"`
+		Description string `json:"event_description" form:"event_description"`
+		StartAt     string `json:"event_start_at" form:"event_start_at"`
+		EndAt       string `json:"event_end_at" form:"event_end_at"`
+		Capacity    int    `json:"event_capacity" form:"event_capacity"`
+		Policy      string `json:"event_reentry_policy" form:"event_reentry_policy"`
+	}
+	UpdateEventRequest struct {
+		ID          string `json:"-"`
+		Name        string `json:"event_name,omitempty" form:"event_name"`
+		Venue       string `json:"event_venue,omitempty" form:"event_venue"`
+		Description string `json:"event_description,omitempty" form:"event_description"`
+		StartAt     string `json:"event_start_at,omitempty" form:"event_start_at"`
+		EndAt       string `json:"event_end_at,omitempty" form:"event_end_at"`
+		Capacity    *int   `json:"event_capacity,omitempty" form:"event_capacity"`
+		Policy      string `json:"event_reentry_policy,omitempty" form:"event_reentry_policy"`
+	}
+	EventPaginationResponse struct {
+		PaginationResponse
+		Data []EventResponse `json:"data"`
+	}
+	EventPaginationRepositoryResponse struct {
+		PaginationResponse
+		Events []entity.Event
+	}
+	DeleteEventRequest struct {
+		EventID string `json:"-"`
+	}
+)
+
+// Event Session
+type (
+	EventSessionResponse struct {
+		ID       uuid.UUID  `json:"event_session_id"`
+		EventID  *uuid.UUID `json:"event_id"`
+		Name     string     `json:"event_session_name"`
+		Venue    string     `json:"event_session_venue"`
+		StartAt  time.Time  `json:"event_session_start_at"`
+		EndAt    time.Time  `json:"event_session_end_at"`
+		Capacity int        `json:"event_session_capacity"`
+	}
+	CreateEventSessionRequest struct {
+		EventID  string `json:"-"`
+		Name     string `json:"event_session_name" form:"event_session_name"`
+		Venue    string `json:"event_session_venue" form:"event_session_venue"`
+		StartAt  string `json:"event_session_start_at" form:"event_session_start_at"`
+		EndAt    string `json:"event_session_end_at" form:"event_session_end_at"`
+		Capacity int    `json:"event_session_capacity" form:"event_session_capacity"`
+	}
+	UpdateEventSessionRequest struct {
+		ID       string `json:"-"`
+		Name     string `json:"event_session_name,omitempty" form:"event_session_name"`
+		Venue    string `json:"event_session_venue,omitempty" form:"event_session_venue"`
+		StartAt  string `json:"event_session_start_at,omitempty" form:"event_session_start_at"`
+		EndAt    string `json:"event_session_end_at,omitempty" form:"event_session_end_at"`
+		Capacity *int   `json:"event_session_capacity,omitempty" form:"event_session_capacity"`
+	}
+)
+
+// Gate
+type (
+	GateResponse struct {
+		ID      uuid.UUID  `json:"gate_id"`
+		EventID *uuid.UUID `json:"event_id"`
+		Name    string     `json:"gate_name"`
+	}
+	CreateGateRequest struct {
+		EventID string `json:"-"`
+		Name    string `json:"gate_name" form:"gate_name"`
+	}
+	UpdateGateRequest struct {
+		ID   string `json:"-"`
+		Name string `json:"gate_name,omitempty" form:"gate_name"`
+	}
+)
+
+// Ticket Form Field
+type (
+	TicketFormFieldResponse struct {
+		ID          uuid.UUID            `json:"ticket_form_field_id"`
+		TicketID    *uuid.UUID           `json:"ticket_id"`
+		Key         string               `json:"ticket_form_field_key"`
+		Label       string               `json:"ticket_form_field_label"`
+		Type        entity.FormFieldType `json:"ticket_form_field_type"`
+		Required    bool                 `json:"ticket_form_field_required"`
+		Options     []string             `json:"ticket_form_field_options"`
+		Placeholder string               `json:"ticket_form_field_placeholder"`
+		Position    int                  `json:"ticket_form_field_position"`
+	}
+	CreateTicketFormFieldRequest struct {
+		TicketID    string               `json:"-"`
+		Key         string               `json:"ticket_form_field_key" form:"ticket_form_field_key"`
+		Label       string               `json:"ticket_form_field_label" form:"ticket_form_field_label"`
+		Type        entity.FormFieldType `json:"ticket_form_field_type" form:"ticket_form_field_type"`
+		Required    bool                 `json:"ticket_form_field_required" form:"ticket_form_field_required"`
+		Options     []string             `json:"ticket_form_field_options" form:"ticket_form_field_options"`
+		Placeholder string               `json:"ticket_form_field_placeholder" form:"ticket_form_field_placeholder"`
+		Position    int                  `json:"ticket_form_field_position" form:"ticket_form_field_position"`
+	}
+	UpdateTicketFormFieldRequest struct {
+		ID          string               `json:"-"`
+		Label       string               `json:"ticket_form_field_label,omitempty" form:"ticket_form_field_label"`
+		Type        entity.FormFieldType `json:"ticket_form_field_type,omitempty" form:"ticket_form_field_type"`
+		Required    *bool                `json:"ticket_form_field_required,omitempty" form:"ticket_form_field_required"`
+		Options     []string             `json:"ticket_form_field_options,omitempty" form:"ticket_form_field_options"`
+		Placeholder string               `json:"ticket_form_field_placeholder,omitempty" form:"ticket_form_field_placeholder"`
+		Position    *int                 `json:"ticket_form_field_position,omitempty" form:"ticket_form_field_position"`
+	}
+	AttendeeExportFilterQuery struct {
+		EventID  string `form:"event_id"`
+		TicketID string `form:"ticket_id"`
+	}
+	MerchPickupItemResponse struct {
+		TicketFormID  uuid.UUID            `json:"ticket_form_id"`
+		FullName      string               `json:"full_name"`
+		BundleItemID  uuid.UUID            `json:"bundle_item_id"`
+		MerchID       *uuid.UUID           `json:"merch_id"`
+		MerchName     string               `json:"merch_name"`
+		MerchCategory entity.MerchCategory `json:"merch_category"`
+		Size          string               `json:"size,omitempty"`
+		PickedUp      bool                 `json:"picked_up"`
+		HandedOverAt  *time.Time           `json:"handed_over_at,omitempty"`
+		HandedOverBy  string               `json:"handed_over_by,omitempty"`
+	}
+	MerchPickupResponse struct {
+		TransactionID uuid.UUID                 `json:"transaction_id"`
+		OrderID       string                    `json:"order_id"`
+		BundleID      *uuid.UUID                `json:"bundle_id"`
+		BundleName    string                    `json:"bundle_name"`
+		Outstanding   int                       `json:"outstanding"`
+		Items         []MerchPickupItemResponse `json:"items"`
+	}
+	HandOverMerchItemRequest struct {
+		TicketFormID string `json:"ticket_form_id"`
+		BundleItemID string `json:"bundle_item_id"`
+		Size         string `json:"size"`
+	}
+	HandOverMerchRequest struct {
+		QRToken string                     `json:"-"`
+		Items   []HandOverMerchItemRequest `json:"items"`
+	}
+	MerchPickupFilterQuery struct {
+		EventID  string `form:"event_id"`
+		BundleID string `form:"bundle_id"`
+	}
+	MerchPickupSummaryResponse struct {
+		MerchID     *uuid.UUID `json:"merch_id"`
+		MerchName   string     `json:"merch_name"`
+		Size        string     `json:"size"`
+		Owed        int        `json:"owed"`
+		PickedUp    int        `json:"picked_up"`
+		Outstanding int        `json:"outstanding"`
+	}
+	MerchPickupReportResponse struct {
+		Owed        int                          `json:"owed"`
+		PickedUp    int                          `json:"picked_up"`
+		Outstanding int                          `json:"outstanding"`
+		Summary     []MerchPickupSummaryResponse `json:"summary"`
+		Items       []MerchPickupItemResponse    `json:"items"`
+	}
+	BadgeQuery struct {
+		Format string `form:"format"`
+	}
+	BadgeBulkFilterQuery struct {
+		TicketID  string `form:"ticket_id"`
+		CheckedIn string `form:"checked_in"`
+	}
+)
+
+// Availability
+type (
+	AvailabilityResponse struct {
+		ItemType    entity.ItemType `json:"item_type"`
+		ID          uuid.UUID       `json:"item_id"`
+		Name        string          `json:"item_name,omitempty"`
+		Quota       int             `json:"remaining_quota"`
+		IsAvailable bool            `json:"is_available"`
+		IsRemoved   bool            `json:"is_removed,omitempty"`
+	}
+)
+
+// Offline Check-in
+type (
+	CheckInManifestTicketResponse struct {
+		TicketFormID uuid.UUID           `json:"ticket_form_id"`
+		QRVersion    int                 `json:"qr_version"`
+		TicketName   string              `json:"ticket_name"`
+		AudienceType entity.AudienceType `json:"audience_type"`
+		FullName     string              `json:"full_name"`
+		CheckedIn    bool                `json:"checked_in"`
+		CheckedAt    *time.Time          `json:"checked_at,omitempty"`
+	}
+	CheckInManifest struct {
+		EventID     uuid.UUID                       `json:"event_id"`
+		EventName   string                          `json:"event_name"`
+		GeneratedAt time.Time                       `json:"generated_at"`
+		ValidFrom   time.Time                       `json:"valid_from"`
+		ValidUntil  time.Time                       `json:"valid_until"`
+		Tickets     []CheckInManifestTicketResponse `json:"tickets"`
+	}
+	// Manifest disimpan sebagai raw bytes supaya tanda tangannya diverifikasi atas byte yang sama.
+	CheckInManifestResponse struct {
+		Manifest  json.RawMessage `json:"manifest"`
+		Algorithm string          `json:"algorithm"`
+		PublicKey string          `json:"public_key"`
+		Signature string          `json:"signature"`
+	}
+	CheckInScanRequest struct {
+		QRToken        string                  `json:"qr_token"`
+		ScannedAt      time.Time               `json:"scanned_at"`
+		GateID         string                  `json:"gate_id"`
+		EventSessionID string                  `json:"event_session_id"`
+		Direction      entity.CheckInDirection `json:"direction"`
+	}
+	SyncCheckInRequest struct {
+		EventID  string               `json:"event_id"`
+		DeviceID string               `json:"device_id"`
+		Scans    []CheckInScanRequest `json:"scans"`
+	}
+	CheckInScanResultResponse struct {
+		Index        int                      `json:"index"`
+		QRToken      string                   `json:"qr_token"`
+		TicketFormID *uuid.UUID               `json:"ticket_form_id,omitempty"`
+		FullName     string                   `json:"full_name,omitempty"`
+		ScannedAt    time.Time                `json:"scanned_at"`
+		Result       entity.CheckInScanResult `json:"result"`
+		Reason       string                   `json:"reason,omitempty"`
+	}
+	SyncCheckInResponse struct {
+		Accepted  int                         `json:"accepted"`
+		Duplicate int                         `json:"duplicate"`
+		Rejected  int                         `json:"rejected"`
+		Results   []CheckInScanResultResponse `json:"results"`
+	}
+)
+
+// Email Outbox
+type (
+	EmailOutboxResponse struct {
+		ID            uuid.UUID                `json:"email_outbox_id"`
+		Kind          string                   `json:"kind"`
+		ToEmail       string                   `json:"to_email"`
+		Subject       string                   `json:"subject"`
+		Status        entity.EmailOutboxStatus `json:"status"`
+		Attempts      int                      `json:"attempts"`
+		MaxAttempts   int                      `json:"max_attempts"`
+		NextAttemptAt time.Time                `json:"next_attempt_at"`
+		LastError     string                   `json:"last_error,omitempty"`
+		SentAt        *time.Time               `json:"sent_at,omitempty"`
+		TicketFormID  *uuid.UUID               `json:"ticket_form_id,omitempty"`
+		RequestedBy   *uuid.UUID               `json:"requested_by,omitempty"`
+		BroadcastID   *uuid.UUID               `json:"broadcast_id,omitempty"`
+		CreatedAt     time.Time                `json:"created_at"`
+	}
+	EmailOutboxFilterQuery struct {
+		Status       string `form:"status"`
+		Kind         string `form:"kind"`
+		TicketFormID string `form:"ticket_form_id"`
+		BroadcastID  string `form:"broadcast_id"`
+	}
+	EmailOutboxPaginationResponse struct {
+		PaginationResponse
+		Data []EmailOutboxResponse `json:"data"`
+	}
+	EmailOutboxPaginationRepositoryResponse struct {
+		PaginationResponse
+		EmailOutboxes []entity.EmailOutbox
+	}
+)
+
+// Email Template
+type (
+	EmailTemplateResponse struct {
+		ID        *uuid.UUID              `json:"email_template_id"`
+		Key       entity.EmailTemplateKey `json:"email_template_key"`
+		Subject   string                  `json:"email_template_subject"`
+		HTML      string                  `json:"email_template_html"`
+		Text      string                  `json:"email_template_text"`
+		Version   int                     `json:"email_template_version"`
+		IsDefault bool                    `json:"is_default"`
+	}
+	CreateEmailTemplateRequest struct {
+		Key     entity.EmailTemplateKey `json:"email_template_key" form:"email_template_key"`
+		Subject string                  `json:"email_template_subject" form:"email_template_subject"`
+		HTML    string                  `json:"email_template_html" form:"email_template_html"`
+		Text    string                  `json:"email_template_text" form:"email_template_text"`
+	}
+	UpdateEmailTemplateRequest struct {
+		ID      string  `json:"-"`
+		Subject string  `json:"email_template_subject,omitempty" form:"email_template_subject"`
+		HTML    string  `json:"email_template_html,omitempty" form:"email_template_html"`
+		Text    *string `json:"email_template_text,omitempty" form:"email_template_text"`
+	}
+	// Subject/HTML/Text kosong berarti memakai template yang sedang aktif.
+	PreviewEmailTemplateRequest struct {
+		Key          entity.EmailTemplateKey `json:"email_template_key" form:"email_template_key"`
+		Subject      string                  `json:"email_template_subject" form:"email_template_subject"`
+		HTML         string                  `json:"email_template_html" form:"email_template_html"`
+		Text         string                  `json:"email_template_text" form:"email_template_text"`
+		TicketFormID string                  `json:"ticket_form_id" form:"ticket_form_id"`
+	}
+	EmailTemplatePreviewResponse struct {
+		Subject string `json:"subject"`
+		HTML    string `json:"html"`
+		Text    string `json:"text"`
+	}
+)
+
+// Resend E-Ticket
+type (
+	// Email hanya dipakai admin, untuk mengirim ke alamat yang sudah dikoreksi.
+	ResendETicketRequest struct {
+		TransactionID string `json:"-"`
+		TicketFormID  string `json:"ticket_form_id" form:"ticket_form_id"`
+		Email         string `json:"email" form:"email"`
+	}
+	ResendETicketItemResponse struct {
+		TicketFormID  uuid.UUID `json:"ticket_form_id"`
+		FullName      string    `json:"full_name"`
+		Email         string    `json:"email"`
+		EmailOutboxID uuid.UUID `json:"email_outbox_id"`
+	}
+	ResendETicketResponse struct {
+		TransactionID uuid.UUID                   `json:"transaction_id"`
+		Tickets       []ResendETicketItemResponse `json:"tickets"`
+	}
+)
+
+// Broadcast
+type (
+	// filter kosong berarti tidak dibatasi, kecuali TransactionStatus yang default-nya settlement
+	BroadcastFilterRequest struct {
+		EventID           string              `json:"event_id"`
+		TicketType        entity.TicketType   `json:"ticket_type"`
+		AudienceType      entity.AudienceType `json:"audience_type"`
+		Instansi          entity.Instansi     `json:"instansi"`
+		CheckedIn         string              `json:"checked_in"`
+		TransactionStatus string              `json:"transaction_status"`
+	}
+	BroadcastRecipientCountResponse struct {
+		Filter         BroadcastFilterRequest `json:"filter"`
+		TicketCount    int                    `json:"ticket_count"`
+		RecipientCount int                    `json:"recipient_count"`
+	}
+	CreateBroadcastRequest struct {
+		Subject string                 `json:"subject" binding:"required"`
+		Message string                 `json:"message" binding:"required"`
+		Filter  BroadcastFilterRequest `json:"filter"`
+	}
+	BroadcastDeliveryResponse struct {
+		Pending int64 `json:"pending"`
+		Sending int64 `json:"sending"`
+		Sent    int64 `json:"sent"`
+		Failed  int64 `json:"failed"`
+		Dead    int64 `json:"dead"`
+	}
+	BroadcastResponse struct {
+		ID             uuid.UUID                  `json:"broadcast_id"`
+		Subject        string                     `json:"broadcast_subject"`
+		Message        string                     `json:"broadcast_message"`
+		Filter         BroadcastFilterRequest     `json:"broadcast_filter"`
+		RecipientCount int                        `json:"broadcast_recipient_count"`
+		CreatedBy      *uuid.UUID                 `json:"broadcast_created_by"`
+		CreatedAt      time.Time                  `json:"broadcast_created_at"`
+		Delivery       *BroadcastDeliveryResponse `json:"broadcast_delivery,omitempty"`
+	}
+	BroadcastPaginationResponse struct {
+		PaginationResponse
+		Data []BroadcastResponse `json:"data"`
+	}
+	BroadcastPaginationRepositoryResponse struct {
+		PaginationResponse
+		Broadcasts []entity.Broadcast
+	}
+)
+
+// Notification
+type (
+	NotificationOutboxResponse struct {
+		ID                uuid.UUID                       `json:"notification_outbox_id"`
+		Channel           string                          `json:"channel"`
+		Kind              entity.NotificationKind         `json:"kind"`
+		ToPhone           string                          `json:"to_phone"`
+		Body              string                          `json:"body"`
+		Status            entity.NotificationOutboxStatus `json:"status"`
+		Attempts          int                             `json:"attempts"`
+		MaxAttempts       int                             `json:"max_attempts"`
+		NextAttemptAt     time.Time                       `json:"next_attempt_at"`
+		LastError         string                          `json:"last_error,omitempty"`
+		ProviderMessageID string                          `json:"provider_message_id,omitempty"`
+		SentAt            *time.Time                      `json:"sent_at,omitempty"`
+		TicketFormID      *uuid.UUID                      `json:"ticket_form_id,omitempty"`
+		CreatedAt         time.Time                       `json:"created_at"`
+	}
+	NotificationOutboxFilterQuery struct {
+		Status       string `form:"status"`
+		Kind         string `form:"kind"`
+		Channel      string `form:"channel"`
+		TicketFormID string `form:"ticket_form_id"`
+	}
+	NotificationOutboxPaginationResponse struct {
+		PaginationResponse
+		Data []NotificationOutboxResponse `json:"data"`
+	}
+	NotificationOutboxPaginationRepositoryResponse struct {
+		PaginationResponse
+		NotificationOutboxes []entity.NotificationOutbox
+	}
+	UpdateWhatsAppOptInRequest struct {
+		WhatsAppOptIn *bool `json:"whatsapp_opt_in" binding:"required"`
+	}
+	WhatsAppOptInResponse struct {
+		TicketFormID  uuid.UUID `json:"ticket_form_id"`
+		WhatsAppOptIn bool      `json:"whatsapp_opt_in"`
 	}
 )
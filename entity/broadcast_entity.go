@@ -0,0 +1,24 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+)
+
+// Broadcast: status pengiriman per penerima ada di EmailOutbox dengan BroadcastID yang sama.
+type Broadcast struct {
+	ID      uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	Subject string    `gorm:"not null" json:"subject"`
+	Message string    `gorm:"type:text;not null" json:"message"`
+
+	EventID           *uuid.UUID   `gorm:"type:uuid" json:"event_id"`
+	TicketType        TicketType   `json:"ticket_type"`
+	AudienceType      AudienceType `json:"audience_type"`
+	Instansi          Instansi     `json:"instansi"`
+	CheckedIn         string       `json:"checked_in"`
+	TransactionStatus string       `json:"transaction_status"`
+
+	RecipientCount int        `gorm:"not null;default:0" json:"recipient_count"`
+	CreatedBy      *uuid.UUID `gorm:"type:uuid" json:"created_by"`
+
+	TimeStamp
+}
@@ -18,6 +18,9 @@ type Bundle struct {
 	Description string     `json:"description"`
 	EventDate   time.Time  `gorm:"not null" json:"event_date"`
 
+	EventID *uuid.UUID `gorm:"type:uuid" json:"event_id"`
+	Event   Event      `gorm:"foreignKey:EventID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+
 	BundleItems  []BundleItem  `gorm:"foreignKey:BundleID"`
 	Transactions []Transaction `gorm:"foreignKey:BundleID"`
 
@@ -0,0 +1,25 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CheckInScan struct {
+	ID        uuid.UUID         `gorm:"type:uuid;primaryKey" json:"id"`
+	DeviceID  string            `gorm:"not null" json:"device_id"`
+	QRToken   string            `gorm:"not null" json:"qr_token"`
+	ScannedAt time.Time         `gorm:"not null" json:"scanned_at"`
+	Result    CheckInScanResult `gorm:"not null" json:"result"`
+	Reason    string            `json:"reason"`
+
+	TicketFormID *uuid.UUID `gorm:"type:uuid" json:"ticket_form_id"`
+	TicketForm   TicketForm `gorm:"foreignKey:TicketFormID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	EventID      *uuid.UUID `gorm:"type:uuid" json:"event_id"`
+	Event        Event      `gorm:"foreignKey:EventID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	SyncedBy     *uuid.UUID `gorm:"type:uuid" json:"synced_by"`
+	SyncedByUser User       `gorm:"foreignKey:SyncedBy;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+
+	TimeStamp
+}
@@ -22,11 +22,23 @@ type (
 	ItemType            string
 	BundleType          string
 	TicketType          string
+	WaitlistStatus      string
+	FormFieldType       string
+	CheckInSource       string
+	CheckInScanResult   string
+	CheckInDirection    string
+	ReentryPolicy       string
+	EmailOutboxStatus   string
+	EmailTemplateKey    string
+
+	NotificationOutboxStatus string
+	NotificationKind         string
 )
 
 const (
 	Admin Role = constants.ENUM_ROLE_ADMIN
 	Guest Role = constants.ENUM_ROLE_GUEST
+	Crew  Role = constants.ENUM_ROLE_CREW
 
 	PreEvent3 TicketType = constants.ENUM_TICKET_PRE_EVENT_3
 	MainEvent TicketType = constants.ENUM_TICKET_MAIN_EVENT
@@ -52,10 +64,64 @@ const (
 
 	Regular AudienceType = constants.ENUM_AUDIENCE_REGULAR
 	Invited AudienceType = constants.ENUM_AUDIENCE_INVITED
+
+	WaitlistWaiting   WaitlistStatus = constants.ENUM_WAITLIST_STATUS_WAITING
+	WaitlistOffered   WaitlistStatus = constants.ENUM_WAITLIST_STATUS_OFFERED
+	WaitlistClaimed   WaitlistStatus = constants.ENUM_WAITLIST_STATUS_CLAIMED
+	WaitlistExpired   WaitlistStatus = constants.ENUM_WAITLIST_STATUS_EXPIRED
+	WaitlistCancelled WaitlistStatus = constants.ENUM_WAITLIST_STATUS_CANCELLED
+
+	FormFieldText        FormFieldType = constants.ENUM_FORM_FIELD_TEXT
+	FormFieldTextarea    FormFieldType = constants.ENUM_FORM_FIELD_TEXTAREA
+	FormFieldNumber      FormFieldType = constants.ENUM_FORM_FIELD_NUMBER
+	FormFieldEmail       FormFieldType = constants.ENUM_FORM_FIELD_EMAIL
+	FormFieldSelect      FormFieldType = constants.ENUM_FORM_FIELD_SELECT
+	FormFieldMultiSelect FormFieldType = constants.ENUM_FORM_FIELD_MULTI_SELECT
+	FormFieldCheckbox    FormFieldType = constants.ENUM_FORM_FIELD_CHECKBOX
+
+	CheckInOnline   CheckInSource = constants.ENUM_CHECK_IN_SOURCE_ONLINE
+	CheckInOffline  CheckInSource = constants.ENUM_CHECK_IN_SOURCE_OFFLINE
+	CheckInHelpDesk CheckInSource = constants.ENUM_CHECK_IN_SOURCE_HELP_DESK
+
+	CheckInScanAccepted  CheckInScanResult = constants.ENUM_CHECK_IN_SCAN_ACCEPTED
+	CheckInScanDuplicate CheckInScanResult = constants.ENUM_CHECK_IN_SCAN_DUPLICATE
+	CheckInScanRejected  CheckInScanResult = constants.ENUM_CHECK_IN_SCAN_REJECTED
+
+	CheckInDirectionIn  CheckInDirection = constants.ENUM_CHECK_IN_DIRECTION_IN
+	CheckInDirectionOut CheckInDirection = constants.ENUM_CHECK_IN_DIRECTION_OUT
+
+	ReentrySingleEntry ReentryPolicy = constants.ENUM_REENTRY_POLICY_SINGLE_ENTRY
+	ReentryAllowed     ReentryPolicy = constants.ENUM_REENTRY_POLICY_REENTRY
+	ReentryPerSession  ReentryPolicy = constants.ENUM_REENTRY_POLICY_PER_SESSION
+
+	EmailOutboxPending EmailOutboxStatus = constants.ENUM_EMAIL_OUTBOX_STATUS_PENDING
+	EmailOutboxSending EmailOutboxStatus = constants.ENUM_EMAIL_OUTBOX_STATUS_SENDING
+	EmailOutboxSent    EmailOutboxStatus = constants.ENUM_EMAIL_OUTBOX_STATUS_SENT
+	EmailOutboxFailed  EmailOutboxStatus = constants.ENUM_EMAIL_OUTBOX_STATUS_FAILED
+	EmailOutboxDead    EmailOutboxStatus = constants.ENUM_EMAIL_OUTBOX_STATUS_DEAD
+
+	EmailTemplateETicket         EmailTemplateKey = constants.ENUM_EMAIL_TEMPLATE_E_TICKET
+	EmailTemplateInvitation      EmailTemplateKey = constants.ENUM_EMAIL_TEMPLATE_INVITATION
+	EmailTemplateWaitlistOffer   EmailTemplateKey = constants.ENUM_EMAIL_TEMPLATE_WAITLIST_OFFER
+	EmailTemplatePurchaseReceipt EmailTemplateKey = constants.ENUM_EMAIL_TEMPLATE_PURCHASE_RECEIPT
+	EmailTemplateBroadcast       EmailTemplateKey = constants.ENUM_EMAIL_TEMPLATE_BROADCAST
+	EmailTemplateReminder        EmailTemplateKey = constants.ENUM_EMAIL_TEMPLATE_REMINDER
+	EmailTemplateVerification    EmailTemplateKey = constants.ENUM_EMAIL_TEMPLATE_VERIFICATION
+	EmailTemplatePasswordReset   EmailTemplateKey = constants.ENUM_EMAIL_TEMPLATE_PASSWORD_RESET
+
+	NotificationOutboxPending NotificationOutboxStatus = constants.ENUM_NOTIFICATION_OUTBOX_STATUS_PENDING
+	NotificationOutboxSending NotificationOutboxStatus = constants.ENUM_NOTIFICATION_OUTBOX_STATUS_SENDING
+	NotificationOutboxSent    NotificationOutboxStatus = constants.ENUM_NOTIFICATION_OUTBOX_STATUS_SENT
+	NotificationOutboxFailed  NotificationOutboxStatus = constants.ENUM_NOTIFICATION_OUTBOX_STATUS_FAILED
+	NotificationOutboxDead    NotificationOutboxStatus = constants.ENUM_NOTIFICATION_OUTBOX_STATUS_DEAD
+
+	NotificationETicket         NotificationKind = constants.ENUM_NOTIFICATION_KIND_E_TICKET
+	NotificationPaymentReminder NotificationKind = constants.ENUM_NOTIFICATION_KIND_PAYMENT_REMINDER
+	NotificationEventDay        NotificationKind = constants.ENUM_NOTIFICATION_KIND_EVENT_DAY
 )
 
 func IsValidRole(r Role) bool {
-	return r == Admin || r == Guest
+	return r == Admin || r == Guest || r == Crew
 }
 
 func IsValidAudienceType(at AudienceType) bool {
@@ -85,3 +151,35 @@ func IsValidItemType(it ItemType) bool {
 func IsValidBundleType(bt BundleType) bool {
 	return bt == BundleMerchTicketType || bt == BundleMerchType
 }
+
+func IsValidWaitlistStatus(ws WaitlistStatus) bool {
+	return ws == WaitlistWaiting || ws == WaitlistOffered || ws == WaitlistClaimed || ws == WaitlistExpired || ws == WaitlistCancelled
+}
+
+func IsValidFormFieldType(ft FormFieldType) bool {
+	return ft == FormFieldText || ft == FormFieldTextarea || ft == FormFieldNumber || ft == FormFieldEmail || ft == FormFieldSelect || ft == FormFieldMultiSelect || ft == FormFieldCheckbox
+}
+
+func IsValidCheckInDirection(d CheckInDirection) bool {
+	return d == CheckInDirectionIn || d == CheckInDirectionOut
+}
+
+func IsValidReentryPolicy(rp ReentryPolicy) bool {
+	return rp == ReentrySingleEntry || rp == ReentryAllowed || rp == ReentryPerSession
+}
+
+func IsValidEmailOutboxStatus(s EmailOutboxStatus) bool {
+	return s == EmailOutboxPending || s == EmailOutboxSending || s == EmailOutboxSent || s == EmailOutboxFailed || s == EmailOutboxDead
+}
+
+func IsValidEmailTemplateKey(k EmailTemplateKey) bool {
+	return k == EmailTemplateETicket || k == EmailTemplateInvitation || k == EmailTemplateWaitlistOffer || k == EmailTemplatePurchaseReceipt || k == EmailTemplateBroadcast || k == EmailTemplateReminder || k == EmailTemplateVerification || k == EmailTemplatePasswordReset
+}
+
+func IsValidNotificationOutboxStatus(s NotificationOutboxStatus) bool {
+	return s == NotificationOutboxPending || s == NotificationOutboxSending || s == NotificationOutboxSent || s == NotificationOutboxFailed || s == NotificationOutboxDead
+}
+
+func IsValidNotificationKind(k NotificationKind) bool {
+	return k == NotificationETicket || k == NotificationPaymentReminder || k == NotificationEventDay
+}
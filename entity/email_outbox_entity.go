@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// EmailOutbox ditulis bersama perubahan datanya lalu dikirim worker di background.
+type EmailOutbox struct {
+	ID            uuid.UUID         `gorm:"type:uuid;primaryKey" json:"id"`
+	Kind          string            `gorm:"index" json:"kind"`
+	ToEmail       string            `gorm:"not null" json:"to_email"`
+	Subject       string            `json:"subject"`
+	Body          string            `gorm:"type:text" json:"-"`
+	TextBody      string            `gorm:"type:text" json:"-"`
+	Status        EmailOutboxStatus `gorm:"not null;default:'pending';index:idx_email_outbox_due,priority:1" json:"status"`
+	Attempts      int               `gorm:"not null;default:0" json:"attempts"`
+	MaxAttempts   int               `gorm:"not null" json:"max_attempts"`
+	NextAttemptAt time.Time         `gorm:"not null;index:idx_email_outbox_due,priority:2" json:"next_attempt_at"`
+	LockedUntil   *time.Time        `json:"locked_until"`
+	LastError     string            `gorm:"type:text" json:"last_error"`
+	SentAt        *time.Time        `json:"sent_at"`
+	Attachments   EmailAttachments  `gorm:"type:jsonb;default:'[]'" json:"-"`
+
+	TicketFormID *uuid.UUID `gorm:"type:uuid;index" json:"ticket_form_id"`
+	RequestedBy  *uuid.UUID `gorm:"type:uuid;index" json:"requested_by"`
+	BroadcastID  *uuid.UUID `gorm:"type:uuid;index" json:"broadcast_id"`
+
+	TimeStamp
+}
+
+// EmailAttachment menyimpan isi file langsung, atau hanya Path untuk file statis seperti header.
+type EmailAttachment struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type"`
+	ContentID   string `json:"content_id,omitempty"`
+	Path        string `json:"path,omitempty"`
+	Data        []byte `json:"data,omitempty"`
+}
+
+func (eo *EmailOutbox) BeforeCreate(tx *gorm.DB) error {
+	if !IsValidEmailOutboxStatus(eo.Status) {
+		return errors.New("invalid email outbox status")
+	}
+
+	return nil
+}
@@ -0,0 +1,28 @@
+package entity
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// EmailTemplate menimpa template bawaan (embedded) untuk key yang sama.
+type EmailTemplate struct {
+	ID      uuid.UUID        `gorm:"type:uuid;primaryKey" json:"id"`
+	Key     EmailTemplateKey `gorm:"not null;index" json:"key"`
+	Subject string           `gorm:"not null" json:"subject"`
+	HTML    string           `gorm:"type:text;not null" json:"html"`
+	Text    string           `gorm:"type:text" json:"text"`
+	Version int              `gorm:"not null;default:1" json:"version"`
+
+	TimeStamp
+}
+
+func (et *EmailTemplate) BeforeCreate(tx *gorm.DB) error {
+	if !IsValidEmailTemplateKey(et.Key) {
+		return errors.New("invalid email template key")
+	}
+
+	return nil
+}
@@ -0,0 +1,26 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Event struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	Name        string    `gorm:"not null" json:"name"`
+	Venue       string    `json:"venue"`
+	Description string    `json:"description"`
+	StartAt     time.Time `gorm:"not null" json:"start_at"`
+	EndAt       time.Time `gorm:"not null" json:"end_at"`
+	Capacity    int       `gorm:"not null;default:0" json:"capacity"`
+
+	ReentryPolicy ReentryPolicy `gorm:"not null;default:'single-entry'" json:"reentry_policy"`
+
+	Sessions []EventSession `gorm:"foreignKey:EventID"`
+	Tickets  []Ticket       `gorm:"foreignKey:EventID"`
+	Bundles  []Bundle       `gorm:"foreignKey:EventID"`
+	Gates    []Gate         `gorm:"foreignKey:EventID"`
+
+	TimeStamp
+}
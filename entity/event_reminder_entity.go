@@ -0,0 +1,18 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+)
+
+// EventReminder: unique index per ticket form dan offset mencegah pengiriman ganda.
+type EventReminder struct {
+	ID            uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	OffsetMinutes int       `gorm:"not null;uniqueIndex:idx_event_reminder_form_offset,priority:2" json:"offset_minutes"`
+
+	TicketFormID uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_event_reminder_form_offset,priority:1" json:"ticket_form_id"`
+	TicketForm   TicketForm `gorm:"foreignKey:TicketFormID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+
+	EmailOutboxID *uuid.UUID `gorm:"type:uuid" json:"email_outbox_id"`
+
+	TimeStamp
+}
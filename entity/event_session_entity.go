@@ -0,0 +1,21 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type EventSession struct {
+	ID       uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	Name     string    `gorm:"not null" json:"name"`
+	Venue    string    `json:"venue"`
+	StartAt  time.Time `gorm:"not null" json:"start_at"`
+	EndAt    time.Time `gorm:"not null" json:"end_at"`
+	Capacity int       `gorm:"not null;default:0" json:"capacity"`
+
+	EventID *uuid.UUID `gorm:"type:uuid" json:"event_id"`
+	Event   Event      `gorm:"foreignKey:EventID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+
+	TimeStamp
+}
@@ -0,0 +1,13 @@
+package entity
+
+import "github.com/google/uuid"
+
+type Gate struct {
+	ID   uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	Name string    `gorm:"not null" json:"name"`
+
+	EventID *uuid.UUID `gorm:"type:uuid" json:"event_id"`
+	Event   Event      `gorm:"foreignKey:EventID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+
+	TimeStamp
+}
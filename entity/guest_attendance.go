@@ -1,6 +1,10 @@
 package entity
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type GuestAttendance struct {
 	ID uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
@@ -10,5 +14,20 @@ type GuestAttendance struct {
 	CheckedBy     *uuid.UUID `gorm:"type:uuid" json:"checked_by"`
 	CheckedByUser User       `gorm:"foreignKey:CheckedBy;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
 
+	CheckedAt time.Time     `json:"checked_at"`
+	Source    CheckInSource `gorm:"default:'online'" json:"source"`
+	DeviceID  string        `json:"device_id"`
+
+	Direction      CheckInDirection `gorm:"not null;default:'in'" json:"direction"`
+	GateID         *uuid.UUID       `gorm:"type:uuid" json:"gate_id"`
+	Gate           Gate             `gorm:"foreignKey:GateID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	EventSessionID *uuid.UUID       `gorm:"type:uuid" json:"event_session_id"`
+	EventSession   EventSession     `gorm:"foreignKey:EventSessionID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+
+	VoidedAt     *time.Time `json:"voided_at"`
+	VoidReason   string     `json:"void_reason"`
+	VoidedBy     *uuid.UUID `gorm:"type:uuid" json:"voided_by"`
+	VoidedByUser User       `gorm:"foreignKey:VoidedBy;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+
 	TimeStamp
 }
@@ -0,0 +1,107 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+)
+
+type (
+	StringList       []string
+	FormAnswers      map[string]any
+	EmailAttachments []EmailAttachment
+)
+
+func (sl StringList) Value() (driver.Value, error) {
+	if sl == nil {
+		return "[]", nil
+	}
+
+	b, err := json.Marshal(sl)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(b), nil
+}
+
+func (sl *StringList) Scan(value any) error {
+	b, err := jsonbBytes(value)
+	if err != nil {
+		return err
+	}
+
+	if len(b) == 0 {
+		*sl = StringList{}
+		return nil
+	}
+
+	return json.Unmarshal(b, sl)
+}
+
+func (fa FormAnswers) Value() (driver.Value, error) {
+	if fa == nil {
+		return "{}", nil
+	}
+
+	b, err := json.Marshal(fa)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(b), nil
+}
+
+func (fa *FormAnswers) Scan(value any) error {
+	b, err := jsonbBytes(value)
+	if err != nil {
+		return err
+	}
+
+	if len(b) == 0 {
+		*fa = FormAnswers{}
+		return nil
+	}
+
+	return json.Unmarshal(b, fa)
+}
+
+func (ea EmailAttachments) Value() (driver.Value, error) {
+	if ea == nil {
+		return "[]", nil
+	}
+
+	b, err := json.Marshal(ea)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(b), nil
+}
+
+func (ea *EmailAttachments) Scan(value any) error {
+	b, err := jsonbBytes(value)
+	if err != nil {
+		return err
+	}
+
+	if len(b) == 0 {
+		*ea = EmailAttachments{}
+		return nil
+	}
+
+	return json.Unmarshal(b, ea)
+}
+
+func jsonbBytes(value any) ([]byte, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case []byte:
+		return v, nil
+	case string:
+		return []byte(v), nil
+	default:
+		return nil, errors.New("unsupported jsonb value")
+	}
+}
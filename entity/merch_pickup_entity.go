@@ -0,0 +1,24 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type MerchPickup struct {
+	ID   uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	Size string    `json:"size"`
+
+	TicketFormID     *uuid.UUID `gorm:"type:uuid;uniqueIndex:idx_merch_pickup_line" json:"ticket_form_id"`
+	TicketForm       TicketForm `gorm:"foreignKey:TicketFormID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	BundleItemID     *uuid.UUID `gorm:"type:uuid;uniqueIndex:idx_merch_pickup_line" json:"bundle_item_id"`
+	BundleItem       BundleItem `gorm:"foreignKey:BundleItemID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	MerchID          *uuid.UUID `gorm:"type:uuid" json:"merch_id"`
+	Merch            Merch      `gorm:"foreignKey:MerchID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	HandedOverBy     *uuid.UUID `gorm:"type:uuid" json:"handed_over_by"`
+	HandedOverByUser User       `gorm:"foreignKey:HandedOverBy;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	HandedOverAt     time.Time  `gorm:"not null" json:"handed_over_at"`
+
+	TimeStamp
+}
@@ -0,0 +1,44 @@
+package entity
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// NotificationOutbox sama seperti EmailOutbox untuk WhatsApp; DedupeKey mencegah pesan diantrikan dua kali.
+type NotificationOutbox struct {
+	ID                uuid.UUID                `gorm:"type:uuid;primaryKey" json:"id"`
+	Channel           string                   `gorm:"not null;index" json:"channel"`
+	Kind              NotificationKind         `gorm:"not null;index" json:"kind"`
+	ToPhone           string                   `gorm:"not null" json:"to_phone"`
+	Body              string                   `gorm:"type:text" json:"body"`
+	Params            StringList               `gorm:"type:jsonb;default:'[]'" json:"params"`
+	DedupeKey         string                   `gorm:"not null;uniqueIndex" json:"dedupe_key"`
+	Status            NotificationOutboxStatus `gorm:"not null;default:'pending';index:idx_notification_outbox_due,priority:1" json:"status"`
+	Attempts          int                      `gorm:"not null;default:0" json:"attempts"`
+	MaxAttempts       int                      `gorm:"not null" json:"max_attempts"`
+	NextAttemptAt     time.Time                `gorm:"not null;index:idx_notification_outbox_due,priority:2" json:"next_attempt_at"`
+	LockedUntil       *time.Time               `json:"locked_until"`
+	LastError         string                   `gorm:"type:text" json:"last_error"`
+	ProviderMessageID string                   `json:"provider_message_id"`
+	SentAt            *time.Time               `json:"sent_at"`
+
+	TicketFormID *uuid.UUID `gorm:"type:uuid;index" json:"ticket_form_id"`
+
+	TimeStamp
+}
+
+func (no *NotificationOutbox) BeforeCreate(tx *gorm.DB) error {
+	if !IsValidNotificationOutboxStatus(no.Status) {
+		return errors.New("invalid notification outbox status")
+	}
+
+	if !IsValidNotificationKind(no.Kind) {
+		return errors.New("invalid notification kind")
+	}
+
+	return nil
+}
@@ -0,0 +1,21 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PasswordReset hanya menyimpan hash dari token reset.
+type PasswordReset struct {
+	ID        uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	Email     string     `gorm:"not null;index" json:"email"`
+	TokenHash string     `gorm:"unique;not null" json:"-"`
+	ExpiresAt time.Time  `gorm:"not null" json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at"`
+
+	UserID uuid.UUID `gorm:"type:uuid;not null;index" json:"user_id"`
+	User   User      `gorm:"foreignKey:UserID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+
+	TimeStamp
+}
@@ -16,7 +16,11 @@ type Ticket struct {
 	Description string     `json:"description"`
 	EventDate   time.Time  `gorm:"not null" json:"event_date"`
 
-	Transactions []Transaction `gorm:"foreignKey:TicketID"`
+	EventID *uuid.UUID `gorm:"type:uuid" json:"event_id"`
+	Event   Event      `gorm:"foreignKey:EventID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+
+	FormFields   []TicketFormField `gorm:"foreignKey:TicketID"`
+	Transactions []Transaction     `gorm:"foreignKey:TicketID"`
 
 	TimeStamp
 }
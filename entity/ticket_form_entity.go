@@ -2,6 +2,7 @@ package entity
 
 import (
 	"errors"
+	"time"
 
 	"github.com/Amierza/TedXBackend/helpers"
 	"github.com/google/uuid"
@@ -16,8 +17,19 @@ type TicketForm struct {
 	FullName     string       `gorm:"not null" json:"full_name"`
 	PhoneNumber  string       `gorm:"not null" json:"phone_number"`
 	LineID       string       `json:"line_id"`
+	Answers      FormAnswers  `gorm:"type:jsonb;default:'{}'" json:"answers"`
+
+	// notifikasi WhatsApp hanya dikirim ke pemegang tiket yang menyetujuinya
+	WhatsAppOptIn bool `gorm:"not null;default:false" json:"whatsapp_opt_in"`
+
+	TransferredToID *uuid.UUID `gorm:"type:uuid" json:"transferred_to_id"`
+	TransferredAt   *time.Time `json:"transferred_at"`
+
+	QRVersion   int        `gorm:"not null;default:0" json:"qr_version"`
+	QRRevokedAt *time.Time `json:"qr_revoked_at"`
 
 	GuestAttendances []GuestAttendance `gorm:"foreignKey:TicketFormID"`
+	MerchPickups     []MerchPickup     `gorm:"foreignKey:TicketFormID"`
 
 	TransactionID *uuid.UUID  `gorm:"type:uuid" json:"transaction_id"`
 	Transaction   Transaction `gorm:"foreignKey:TransactionID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
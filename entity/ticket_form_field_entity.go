@@ -0,0 +1,32 @@
+package entity
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type TicketFormField struct {
+	ID          uuid.UUID     `gorm:"type:uuid;primaryKey" json:"id"`
+	Key         string        `gorm:"not null" json:"key"`
+	Label       string        `gorm:"not null" json:"label"`
+	Type        FormFieldType `gorm:"not null;default:'text'" json:"type"`
+	Required    bool          `gorm:"not null;default:false" json:"required"`
+	Options     StringList    `gorm:"type:jsonb;default:'[]'" json:"options"`
+	Placeholder string        `json:"placeholder"`
+	Position    int           `gorm:"not null;default:0" json:"position"`
+
+	TicketID *uuid.UUID `gorm:"type:uuid" json:"ticket_id"`
+	Ticket   Ticket     `gorm:"foreignKey:TicketID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+
+	TimeStamp
+}
+
+func (tff *TicketFormField) BeforeCreate(tx *gorm.DB) error {
+	if !IsValidFormFieldType(tff.Type) {
+		return errors.New("invalid form field type")
+	}
+
+	return nil
+}
@@ -0,0 +1,25 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+)
+
+type TicketTransfer struct {
+	ID              uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	FromFullName    string    `gorm:"not null" json:"from_full_name"`
+	FromEmail       string    `gorm:"not null" json:"from_email"`
+	FromPhoneNumber string    `gorm:"not null" json:"from_phone_number"`
+	ToFullName      string    `gorm:"not null" json:"to_full_name"`
+	ToEmail         string    `gorm:"not null" json:"to_email"`
+	ToPhoneNumber   string    `gorm:"not null" json:"to_phone_number"`
+
+	OriginTicketFormID *uuid.UUID `gorm:"type:uuid;index" json:"origin_ticket_form_id"`
+	FromTicketFormID   *uuid.UUID `gorm:"type:uuid" json:"from_ticket_form_id"`
+	FromTicketForm     TicketForm `gorm:"foreignKey:FromTicketFormID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	ToTicketFormID     *uuid.UUID `gorm:"type:uuid;index" json:"to_ticket_form_id"`
+	ToTicketForm       TicketForm `gorm:"foreignKey:ToTicketFormID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	TransferredBy      *uuid.UUID `gorm:"type:uuid" json:"transferred_by"`
+	TransferredByUser  User       `gorm:"foreignKey:TransferredBy;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+
+	TimeStamp
+}
@@ -19,6 +19,7 @@ type Transaction struct {
 	Acquire           string     `json:"acquire"`
 	SettlementTime    *time.Time `json:"settlement_time"`
 	GrossAmount       float64    `json:"gross_amount"`
+	PaymentURL        string     `json:"payment_url"`
 
 	UserID *uuid.UUID `gorm:"type:uuid" json:"user_id"`
 	User   User       `gorm:"foreignKey:UserID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
@@ -18,6 +18,17 @@ type User struct {
 	Password      string     `json:"password"`
 	Role          Role       `gorm:"not null;default:'guest'" json:"role"`
 
+	// email baru hanya dipakai setelah dikonfirmasi lewat link verifikasi
+	PendingEmail string `json:"pending_email"`
+
+	// token/session yang diterbitkan sebelum password diganti dianggap dicabut
+	PasswordChangedAt *time.Time `json:"password_changed_at"`
+
+	// crew hanya bisa akses endpoint check-in, opsional dibatasi ke satu event/gate
+	CrewEventID   *uuid.UUID `gorm:"type:uuid" json:"crew_event_id"`
+	CrewGateID    *uuid.UUID `gorm:"type:uuid" json:"crew_gate_id"`
+	CrewExpiresAt *time.Time `json:"crew_expires_at"`
+
 	GuestAttendances []GuestAttendance `gorm:"foreignKey:CheckedBy"`
 	Accounts         []Account         `gorm:"foreignKey:UserID"`
 	Sessions         []Session         `gorm:"foreignKey:UserID"`
@@ -0,0 +1,35 @@
+package entity
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Waitlist struct {
+	ID             uuid.UUID      `gorm:"type:uuid;primaryKey" json:"id"`
+	Status         WaitlistStatus `gorm:"not null;default:'waiting'" json:"status"`
+	OfferTokenHash string         `gorm:"index" json:"-"`
+	OfferedAt      *time.Time     `json:"offered_at"`
+	OfferExpiresAt *time.Time     `json:"offer_expires_at"`
+	ClaimedAt      *time.Time     `json:"claimed_at"`
+
+	UserID        *uuid.UUID  `gorm:"type:uuid" json:"user_id"`
+	User          User        `gorm:"foreignKey:UserID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	TicketID      *uuid.UUID  `gorm:"type:uuid" json:"ticket_id"`
+	Ticket        Ticket      `gorm:"foreignKey:TicketID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	TransactionID *uuid.UUID  `gorm:"type:uuid" json:"transaction_id"`
+	Transaction   Transaction `gorm:"foreignKey:TransactionID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+
+	TimeStamp
+}
+
+func (w *Waitlist) BeforeCreate(tx *gorm.DB) error {
+	if !IsValidWaitlistStatus(w.Status) {
+		return errors.New("invalid waitlist status")
+	}
+
+	return nil
+}
@@ -1,9 +1,13 @@
 package handler
 
 import (
+	"fmt"
+	"io"
 	"net/http"
 	"strconv"
+	"time"
 
+	"github.com/Amierza/TedXBackend/constants"
 	"github.com/Amierza/TedXBackend/dto"
 	"github.com/Amierza/TedXBackend/entity"
 	"github.com/Amierza/TedXBackend/service"
@@ -17,6 +21,10 @@ type (
 		// Authentication
 		Login(ctx *gin.Context)
 
+		// Password Reset
+		ForgotPassword(ctx *gin.Context)
+		ResetPassword(ctx *gin.Context)
+
 		// User
 		CreateUser(ctx *gin.Context)
 		GetAllUser(ctx *gin.Context)
@@ -24,6 +32,30 @@ type (
 		UpdateUser(ctx *gin.Context)
 		DeleteUser(ctx *gin.Context)
 
+		// Crew
+		CreateCrewBulk(ctx *gin.Context)
+		GetAllCrew(ctx *gin.Context)
+		ExpireCrewBulk(ctx *gin.Context)
+
+		// Event
+		CreateEvent(ctx *gin.Context)
+		GetAllEvent(ctx *gin.Context)
+		GetDetailEvent(ctx *gin.Context)
+		UpdateEvent(ctx *gin.Context)
+		DeleteEvent(ctx *gin.Context)
+
+		// Event Session
+		CreateEventSession(ctx *gin.Context)
+		GetAllEventSession(ctx *gin.Context)
+		UpdateEventSession(ctx *gin.Context)
+		DeleteEventSession(ctx *gin.Context)
+
+		// Gate
+		CreateGate(ctx *gin.Context)
+		GetAllGate(ctx *gin.Context)
+		UpdateGate(ctx *gin.Context)
+		DeleteGate(ctx *gin.Context)
+
 		// Ticket
 		CreateTicket(ctx *gin.Context)
 		GetAllTicket(ctx *gin.Context)
@@ -31,6 +63,12 @@ type (
 		UpdateTicket(ctx *gin.Context)
 		DeleteTicket(ctx *gin.Context)
 
+		// Ticket Form Field
+		CreateTicketFormField(ctx *gin.Context)
+		GetAllTicketFormField(ctx *gin.Context)
+		UpdateTicketFormField(ctx *gin.Context)
+		DeleteTicketFormField(ctx *gin.Context)
+
 		// Sponsorship
 		CreateSponsorship(ctx *gin.Context)
 		GetAllSponsorship(ctx *gin.Context)
@@ -75,9 +113,67 @@ type (
 		GetDetailTicketCheckIn(ctx *gin.Context)
 		CheckIn(ctx *gin.Context)
 		GetAllTicketCheckIn(ctx *gin.Context)
+		RevokeTicketQR(ctx *gin.Context)
+		ReissueTicketQR(ctx *gin.Context)
+		GetEventOccupancy(ctx *gin.Context)
+		VoidCheckIn(ctx *gin.Context)
+		StreamCheckInFeed(ctx *gin.Context)
+
+		// Help Desk
+		LookupAttendee(ctx *gin.Context)
+		HelpDeskCheckIn(ctx *gin.Context)
+
+		// Offline Check-in
+		GetCheckInManifest(ctx *gin.Context)
+		SyncCheckIn(ctx *gin.Context)
+
+		// Attendee Export
+		ExportAttendee(ctx *gin.Context)
+
+		// Badge
+		PrintBadge(ctx *gin.Context)
+		PrintEventBadges(ctx *gin.Context)
+
+		// Merch Pickup
+		GetMerchPickup(ctx *gin.Context)
+		HandOverMerch(ctx *gin.Context)
+		GetOutstandingMerchPickup(ctx *gin.Context)
 
 		// Dashboard Stats
 		GetAllStats(ctx *gin.Context)
+
+		// Waitlist
+		GetAllWaitlist(ctx *gin.Context)
+		CancelWaitlist(ctx *gin.Context)
+		OfferWaitlist(ctx *gin.Context)
+
+		// Ticket Transfer
+		GetTicketTransferHistory(ctx *gin.Context)
+
+		// Email Outbox
+		GetAllEmailOutbox(ctx *gin.Context)
+		RetryEmailOutbox(ctx *gin.Context)
+
+		// Notification Outbox
+		GetAllNotificationOutbox(ctx *gin.Context)
+		RetryNotificationOutbox(ctx *gin.Context)
+
+		// Email Template
+		CreateEmailTemplate(ctx *gin.Context)
+		GetAllEmailTemplate(ctx *gin.Context)
+		GetDetailEmailTemplate(ctx *gin.Context)
+		UpdateEmailTemplate(ctx *gin.Context)
+		DeleteEmailTemplate(ctx *gin.Context)
+		PreviewEmailTemplate(ctx *gin.Context)
+
+		// Resend E-Ticket
+		ResendETicket(ctx *gin.Context)
+
+		// Broadcast
+		CountBroadcastRecipient(ctx *gin.Context)
+		CreateBroadcast(ctx *gin.Context)
+		GetAllBroadcast(ctx *gin.Context)
+		GetDetailBroadcast(ctx *gin.Context)
 	}
 
 	AdminHandler struct {
@@ -111,6 +207,43 @@ func (ah *AdminHandler) Login(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, res)
 }
 
+// Password Reset
+func (ah *AdminHandler) ForgotPassword(ctx *gin.Context) {
+	var payload dto.ForgotPasswordRequest
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	if err := ah.adminService.ForgotPassword(ctx, payload); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_FORGOT_PASSWORD, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_FORGOT_PASSWORD, nil)
+	ctx.JSON(http.StatusOK, res)
+}
+func (ah *AdminHandler) ResetPassword(ctx *gin.Context) {
+	var payload dto.ResetPasswordRequest
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := ah.adminService.ResetPassword(ctx, payload)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_RESET_PASSWORD, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_RESET_PASSWORD, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
 // User
 func (ah *AdminHandler) CreateUser(ctx *gin.Context) {
 	var payload dto.CreateUserRequest
@@ -225,6 +358,302 @@ func (ah *AdminHandler) DeleteUser(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, res)
 }
 
+// Crew
+func (ah *AdminHandler) CreateCrewBulk(ctx *gin.Context) {
+	var payload dto.CreateCrewBulkRequest
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := ah.adminService.CreateCrewBulk(ctx, payload)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_CREATE_CREW_BULK, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_CREATE_CREW_BULK, result)
+	ctx.JSON(http.StatusOK, res)
+}
+func (ah *AdminHandler) GetAllCrew(ctx *gin.Context) {
+	var filter dto.CrewFilterQuery
+	if err := ctx.ShouldBindQuery(&filter); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_INVALID_QUERY_PARAMS, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := ah.adminService.GetAllCrew(ctx, filter)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_LIST_CREW, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_LIST_CREW, result)
+	ctx.JSON(http.StatusOK, res)
+}
+func (ah *AdminHandler) ExpireCrewBulk(ctx *gin.Context) {
+	var payload dto.ExpireCrewBulkRequest
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := ah.adminService.ExpireCrewBulk(ctx, payload)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_EXPIRE_CREW_BULK, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_EXPIRE_CREW_BULK, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+// Event
+func (ah *AdminHandler) CreateEvent(ctx *gin.Context) {
+	var payload dto.CreateEventRequest
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := ah.adminService.CreateEvent(ctx, payload)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_CREATE_EVENT, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_CREATE_EVENT, result)
+	ctx.JSON(http.StatusOK, res)
+}
+func (ah *AdminHandler) GetAllEvent(ctx *gin.Context) {
+	paginationParam := ctx.DefaultQuery("pagination", "true")
+	usePagination := paginationParam != "false"
+
+	if !usePagination {
+		// Tanpa pagination
+		result, err := ah.adminService.GetAllEvent(ctx)
+		if err != nil {
+			res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_LIST_EVENT, err.Error(), nil)
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+			return
+		}
+
+		res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_LIST_EVENT, result)
+		ctx.JSON(http.StatusOK, res)
+		return
+	}
+
+	var payload dto.PaginationRequest
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := ah.adminService.GetAllEventWithPagination(ctx, payload)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_LIST_EVENT, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.Response{
+		Status:   true,
+		Messsage: dto.MESSAGE_SUCCESS_GET_LIST_EVENT,
+		Data:     result.Data,
+		Meta:     result.PaginationResponse,
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+func (ah *AdminHandler) GetDetailEvent(ctx *gin.Context) {
+	idStr := ctx.Param("id")
+	result, err := ah.adminService.GetDetailEvent(ctx, idStr)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DETAIL_EVENT, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_DETAIL_EVENT, result)
+	ctx.JSON(http.StatusOK, res)
+}
+func (ah *AdminHandler) UpdateEvent(ctx *gin.Context) {
+	idStr := ctx.Param("id")
+	var payload dto.UpdateEventRequest
+	payload.ID = idStr
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := ah.adminService.UpdateEvent(ctx, payload)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_UPDATE_EVENT, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_UPDATE_EVENT, result)
+	ctx.JSON(http.StatusOK, res)
+}
+func (ah *AdminHandler) DeleteEvent(ctx *gin.Context) {
+	idStr := ctx.Param("id")
+	var payload dto.DeleteEventRequest
+	payload.EventID = idStr
+
+	result, err := ah.adminService.DeleteEvent(ctx, payload)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_DELETE_EVENT, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_DELETE_EVENT, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+// Event Session
+func (ah *AdminHandler) CreateEventSession(ctx *gin.Context) {
+	eventIDStr := ctx.Param("event-id")
+	var payload dto.CreateEventSessionRequest
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	payload.EventID = eventIDStr
+
+	result, err := ah.adminService.CreateEventSession(ctx, payload)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_CREATE_EVENT_SESSION, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_CREATE_EVENT_SESSION, result)
+	ctx.JSON(http.StatusOK, res)
+}
+func (ah *AdminHandler) GetAllEventSession(ctx *gin.Context) {
+	eventIDStr := ctx.Param("event-id")
+	result, err := ah.adminService.GetAllEventSession(ctx, eventIDStr)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_LIST_EVENT_SESSION, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_LIST_EVENT_SESSION, result)
+	ctx.JSON(http.StatusOK, res)
+}
+func (ah *AdminHandler) UpdateEventSession(ctx *gin.Context) {
+	idStr := ctx.Param("id")
+	var payload dto.UpdateEventSessionRequest
+	payload.ID = idStr
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := ah.adminService.UpdateEventSession(ctx, payload)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_UPDATE_EVENT_SESSION, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_UPDATE_EVENT_SESSION, result)
+	ctx.JSON(http.StatusOK, res)
+}
+func (ah *AdminHandler) DeleteEventSession(ctx *gin.Context) {
+	idStr := ctx.Param("id")
+	result, err := ah.adminService.DeleteEventSession(ctx, idStr)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_DELETE_EVENT_SESSION, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_DELETE_EVENT_SESSION, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+// Gate
+func (ah *AdminHandler) CreateGate(ctx *gin.Context) {
+	eventIDStr := ctx.Param("event-id")
+	var payload dto.CreateGateRequest
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	payload.EventID = eventIDStr
+
+	result, err := ah.adminService.CreateGate(ctx, payload)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_CREATE_GATE, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_CREATE_GATE, result)
+	ctx.JSON(http.StatusOK, res)
+}
+func (ah *AdminHandler) GetAllGate(ctx *gin.Context) {
+	eventIDStr := ctx.Param("event-id")
+	result, err := ah.adminService.GetAllGate(ctx, eventIDStr)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_LIST_GATE, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_LIST_GATE, result)
+	ctx.JSON(http.StatusOK, res)
+}
+func (ah *AdminHandler) UpdateGate(ctx *gin.Context) {
+	idStr := ctx.Param("id")
+	var payload dto.UpdateGateRequest
+	payload.ID = idStr
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := ah.adminService.UpdateGate(ctx, payload)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_UPDATE_GATE, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_UPDATE_GATE, result)
+	ctx.JSON(http.StatusOK, res)
+}
+func (ah *AdminHandler) DeleteGate(ctx *gin.Context) {
+	idStr := ctx.Param("id")
+	result, err := ah.adminService.DeleteGate(ctx, idStr)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_DELETE_GATE, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_DELETE_GATE, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
 // Ticket
 func (ah *AdminHandler) CreateTicket(ctx *gin.Context) {
 	var payload dto.CreateTicketRequest
@@ -273,6 +702,16 @@ func (ah *AdminHandler) CreateTicket(ctx *gin.Context) {
 	payload.Description = ctx.PostForm("ticket_description")
 	payload.EventDate = ctx.PostForm("ticket_event_date")
 
+	if eventIDStr := ctx.PostForm("event_id"); eventIDStr != "" {
+		if eventID, err := uuid.Parse(eventIDStr); err == nil {
+			payload.EventID = &eventID
+		} else {
+			res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_PARSE_UUID, err.Error(), nil)
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+			return
+		}
+	}
+
 	result, err := ah.adminService.CreateTicket(ctx, payload)
 	if err != nil {
 		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_CREATE_TICKET, err.Error(), nil)
@@ -385,7 +824,17 @@ func (ah *AdminHandler) UpdateTicket(ctx *gin.Context) {
 	payload.Description = ctx.PostForm("ticket_description")
 	payload.EventDate = ctx.PostForm("ticket_event_date")
 
-	result, err := ah.adminService.UpdateTicket(ctx, payload)
+	if eventIDStr := ctx.PostForm("event_id"); eventIDStr != "" {
+		if eventID, err := uuid.Parse(eventIDStr); err == nil {
+			payload.EventID = &eventID
+		} else {
+			res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_PARSE_UUID, err.Error(), nil)
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+			return
+		}
+	}
+
+	result, err := ah.adminService.UpdateTicket(ctx, payload)
 	if err != nil {
 		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_UPDATE_TICKET, err.Error(), nil)
 		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
@@ -416,6 +865,72 @@ func (ah *AdminHandler) DeleteTicket(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, res)
 }
 
+// Ticket Form Field
+func (ah *AdminHandler) CreateTicketFormField(ctx *gin.Context) {
+	ticketIDStr := ctx.Param("ticket-id")
+	var payload dto.CreateTicketFormFieldRequest
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	payload.TicketID = ticketIDStr
+
+	result, err := ah.adminService.CreateTicketFormField(ctx, payload)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_CREATE_TICKET_FORM_FIELD, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_CREATE_TICKET_FORM_FIELD, result)
+	ctx.JSON(http.StatusOK, res)
+}
+func (ah *AdminHandler) GetAllTicketFormField(ctx *gin.Context) {
+	ticketIDStr := ctx.Param("ticket-id")
+	result, err := ah.adminService.GetAllTicketFormField(ctx, ticketIDStr)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_LIST_TICKET_FORM_FIELD, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_LIST_TICKET_FORM_FIELD, result)
+	ctx.JSON(http.StatusOK, res)
+}
+func (ah *AdminHandler) UpdateTicketFormField(ctx *gin.Context) {
+	idStr := ctx.Param("id")
+	var payload dto.UpdateTicketFormFieldRequest
+	payload.ID = idStr
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := ah.adminService.UpdateTicketFormField(ctx, payload)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_UPDATE_TICKET_FORM_FIELD, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_UPDATE_TICKET_FORM_FIELD, result)
+	ctx.JSON(http.StatusOK, res)
+}
+func (ah *AdminHandler) DeleteTicketFormField(ctx *gin.Context) {
+	idStr := ctx.Param("id")
+	result, err := ah.adminService.DeleteTicketFormField(ctx, idStr)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_DELETE_TICKET_FORM_FIELD, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_DELETE_TICKET_FORM_FIELD, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
 // Sponsorship
 func (ah *AdminHandler) CreateSponsorship(ctx *gin.Context) {
 	var payload dto.CreateSponsorshipRequest
@@ -959,6 +1474,16 @@ func (ah *AdminHandler) CreateBundle(ctx *gin.Context) {
 	payload.Description = ctx.PostForm("bundle_description")
 	payload.EventDate = ctx.PostForm("bundle_event_date")
 
+	if eventIDStr := ctx.PostForm("event_id"); eventIDStr != "" {
+		if eventID, err := uuid.Parse(eventIDStr); err == nil {
+			payload.EventID = &eventID
+		} else {
+			res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_PARSE_UUID, err.Error(), nil)
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+			return
+		}
+	}
+
 	result, err := ah.adminService.CreateBundle(ctx, payload)
 	if err != nil {
 		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_CREATE_BUNDLE, err.Error(), nil)
@@ -1083,6 +1608,16 @@ func (ah *AdminHandler) UpdateBundle(ctx *gin.Context) {
 	payload.Description = ctx.PostForm("bundle_description")
 	payload.EventDate = ctx.PostForm("bundle_event_date")
 
+	if eventIDStr := ctx.PostForm("event_id"); eventIDStr != "" {
+		if eventID, err := uuid.Parse(eventIDStr); err == nil {
+			payload.EventID = &eventID
+		} else {
+			res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_PARSE_UUID, err.Error(), nil)
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+			return
+		}
+	}
+
 	result, err := ah.adminService.UpdateBundle(ctx, payload)
 	if err != nil {
 		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_UPDATE_BUNDLE, err.Error(), nil)
@@ -1304,8 +1839,8 @@ func (ah *AdminHandler) GetDetailTransactionTicket(ctx *gin.Context) {
 
 // Check-in
 func (ah *AdminHandler) GetDetailTicketCheckIn(ctx *gin.Context) {
-	ticketFormIDStr := ctx.Param("ticket-form-id")
-	result, err := ah.adminService.GetDetailTicketCheckIn(ctx, ticketFormIDStr)
+	qrToken := ctx.Param("qr-token")
+	result, err := ah.adminService.GetDetailTicketCheckIn(ctx, qrToken)
 	if err != nil {
 		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DETAIL_TICKET, err.Error(), nil)
 		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
@@ -1316,15 +1851,25 @@ func (ah *AdminHandler) GetDetailTicketCheckIn(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, res)
 }
 func (ah *AdminHandler) CheckIn(ctx *gin.Context) {
-	ticketFormIDStr := ctx.Param("ticket-form-id")
-	err := ah.adminService.CheckIn(ctx, ticketFormIDStr)
+	var payload dto.CheckInRequest
+	// gate, session dan direction opsional, scanner lama mengirim body kosong
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBind(&payload); err != nil {
+			res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+			return
+		}
+	}
+	payload.QRToken = ctx.Param("qr-token")
+
+	result, err := ah.adminService.CheckIn(ctx, payload)
 	if err != nil {
 		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_CHECK_IN, err.Error(), nil)
 		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
 		return
 	}
 
-	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_CHECK_IN, "")
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_CHECK_IN, result)
 	ctx.JSON(http.StatusOK, res)
 }
 func (ah *AdminHandler) GetAllTicketCheckIn(ctx *gin.Context) {
@@ -1384,6 +1929,288 @@ func (ah *AdminHandler) GetAllTicketCheckIn(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, res)
 }
+func (ah *AdminHandler) RevokeTicketQR(ctx *gin.Context) {
+	ticketFormIDStr := ctx.Param("ticket-form-id")
+	err := ah.adminService.RevokeTicketQR(ctx, ticketFormIDStr)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_REVOKE_TICKET_QR, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_REVOKE_TICKET_QR, "")
+	ctx.JSON(http.StatusOK, res)
+}
+func (ah *AdminHandler) ReissueTicketQR(ctx *gin.Context) {
+	ticketFormIDStr := ctx.Param("ticket-form-id")
+	err := ah.adminService.ReissueTicketQR(ctx, ticketFormIDStr)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_REISSUE_TICKET_QR, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_REISSUE_TICKET_QR, "")
+	ctx.JSON(http.StatusOK, res)
+}
+func (ah *AdminHandler) GetEventOccupancy(ctx *gin.Context) {
+	eventIDStr := ctx.Param("event-id")
+	result, err := ah.adminService.GetEventOccupancy(ctx, eventIDStr)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_EVENT_OCCUPANCY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_EVENT_OCCUPANCY, result)
+	ctx.JSON(http.StatusOK, res)
+}
+func (ah *AdminHandler) VoidCheckIn(ctx *gin.Context) {
+	idStr := ctx.Param("id")
+	var payload dto.VoidCheckInRequest
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	payload.ID = idStr
+
+	result, err := ah.adminService.VoidCheckIn(ctx, payload)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_VOID_CHECK_IN, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_VOID_CHECK_IN, result)
+	ctx.JSON(http.StatusOK, res)
+}
+func (ah *AdminHandler) StreamCheckInFeed(ctx *gin.Context) {
+	snapshot, err := ah.adminService.GetCheckInFeedSnapshot(ctx)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_STREAM_CHECK_IN_FEED, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	scans, unsubscribe := ah.adminService.SubscribeCheckInFeed()
+	defer unsubscribe()
+
+	ctx.Header("Content-Type", "text/event-stream")
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("Connection", "keep-alive")
+	ctx.Header("X-Accel-Buffering", "no")
+
+	ctx.SSEvent("snapshot", snapshot)
+	ctx.Writer.Flush()
+
+	heartbeat := time.NewTicker(constants.ENUM_CHECK_IN_FEED_HEARTBEAT_SECONDS * time.Second)
+	defer heartbeat.Stop()
+
+	ctx.Stream(func(w io.Writer) bool {
+		select {
+		case <-ctx.Request.Context().Done():
+			return false
+		case scan, ok := <-scans:
+			if !ok {
+				return false
+			}
+			ctx.SSEvent("check-in", scan)
+			return true
+		case <-heartbeat.C:
+			ctx.SSEvent("ping", time.Now().Unix())
+			return true
+		}
+	})
+}
+
+// Help Desk
+func (ah *AdminHandler) LookupAttendee(ctx *gin.Context) {
+	var filter dto.AttendeeLookupQuery
+	if err := ctx.ShouldBindQuery(&filter); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_INVALID_QUERY_PARAMS, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := ah.adminService.LookupAttendee(ctx, filter)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_LOOKUP_ATTENDEE, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_LOOKUP_ATTENDEE, result)
+	ctx.JSON(http.StatusOK, res)
+}
+func (ah *AdminHandler) HelpDeskCheckIn(ctx *gin.Context) {
+	idStr := ctx.Param("ticket-form-id")
+	var payload dto.HelpDeskCheckInRequest
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+	payload.TicketFormID = idStr
+
+	result, err := ah.adminService.HelpDeskCheckIn(ctx, payload)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_HELP_DESK_CHECK_IN, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_HELP_DESK_CHECK_IN, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+// Offline Check-in
+func (ah *AdminHandler) GetCheckInManifest(ctx *gin.Context) {
+	eventIDStr := ctx.Param("event-id")
+	result, err := ah.adminService.GetCheckInManifest(ctx, eventIDStr)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_CHECK_IN_MANIFEST, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_CHECK_IN_MANIFEST, result)
+	ctx.JSON(http.StatusOK, res)
+}
+func (ah *AdminHandler) SyncCheckIn(ctx *gin.Context) {
+	var payload dto.SyncCheckInRequest
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := ah.adminService.SyncCheckIn(ctx, payload)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_SYNC_CHECK_IN, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_SYNC_CHECK_IN, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+// Attendee Export
+func (ah *AdminHandler) ExportAttendee(ctx *gin.Context) {
+	var filter dto.AttendeeExportFilterQuery
+	if err := ctx.ShouldBindQuery(&filter); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_INVALID_QUERY_PARAMS, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := ah.adminService.ExportAttendee(ctx, filter)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_EXPORT_ATTENDEE, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	fileName := fmt.Sprintf("attendees_%s.csv", time.Now().Format("060102150405"))
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", fileName))
+	ctx.Data(http.StatusOK, "text/csv; charset=utf-8", result)
+}
+
+// Badge
+func (ah *AdminHandler) PrintBadge(ctx *gin.Context) {
+	idStr := ctx.Param("ticket-form-id")
+	var query dto.BadgeQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_INVALID_QUERY_PARAMS, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := ah.adminService.PrintBadge(ctx, idStr, query)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_PRINT_BADGE, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf("inline; filename=badge_%s.pdf", idStr))
+	ctx.Data(http.StatusOK, "application/pdf", result)
+}
+func (ah *AdminHandler) PrintEventBadges(ctx *gin.Context) {
+	eventIDStr := ctx.Param("event-id")
+	var filter dto.BadgeBulkFilterQuery
+	if err := ctx.ShouldBindQuery(&filter); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_INVALID_QUERY_PARAMS, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := ah.adminService.PrintEventBadges(ctx, eventIDStr, filter)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_PRINT_BADGE, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	fileName := fmt.Sprintf("badges_%s.pdf", time.Now().Format("060102150405"))
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", fileName))
+	ctx.Data(http.StatusOK, "application/pdf", result)
+}
+
+// Merch Pickup
+func (ah *AdminHandler) GetMerchPickup(ctx *gin.Context) {
+	qrToken := ctx.Param("qr-token")
+	result, err := ah.adminService.GetMerchPickup(ctx, qrToken)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_MERCH_PICKUP, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_MERCH_PICKUP, result)
+	ctx.JSON(http.StatusOK, res)
+}
+func (ah *AdminHandler) HandOverMerch(ctx *gin.Context) {
+	var payload dto.HandOverMerchRequest
+	// items opsional, body kosong berarti serahkan semua yang belum diambil
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBind(&payload); err != nil {
+			res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+			return
+		}
+	}
+	payload.QRToken = ctx.Param("qr-token")
+
+	result, err := ah.adminService.HandOverMerch(ctx, payload)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_HAND_OVER_MERCH, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_HAND_OVER_MERCH, result)
+	ctx.JSON(http.StatusOK, res)
+}
+func (ah *AdminHandler) GetOutstandingMerchPickup(ctx *gin.Context) {
+	var filter dto.MerchPickupFilterQuery
+	if err := ctx.ShouldBindQuery(&filter); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_INVALID_QUERY_PARAMS, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := ah.adminService.GetOutstandingMerchPickup(ctx, filter)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_OUTSTANDING_MERCH_PICKUP, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_OUTSTANDING_MERCH_PICKUP, result)
+	ctx.JSON(http.StatusOK, res)
+}
 
 // Dashboard Stats
 func (ah *AdminHandler) GetAllStats(ctx *gin.Context) {
@@ -1397,3 +2224,383 @@ func (ah *AdminHandler) GetAllStats(ctx *gin.Context) {
 	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_ALL_STATS, result)
 	ctx.JSON(http.StatusOK, res)
 }
+
+// Waitlist
+func (ah *AdminHandler) GetAllWaitlist(ctx *gin.Context) {
+	var (
+		payload dto.PaginationRequest
+		filter  dto.WaitlistFilterQuery
+	)
+
+	paginationParam := ctx.DefaultQuery("pagination", "true")
+	usePagination := paginationParam != "false"
+
+	if err := ctx.ShouldBindQuery(&filter); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_INVALID_QUERY_PARAMS, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	if !usePagination {
+		// Tanpa pagination
+		result, err := ah.adminService.GetAllWaitlist(ctx, filter)
+		if err != nil {
+			res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_LIST_WAITLIST, err.Error(), nil)
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+			return
+		}
+
+		res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_LIST_WAITLIST, result)
+		ctx.JSON(http.StatusOK, res)
+		return
+	}
+
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := ah.adminService.GetAllWaitlistWithPagination(ctx, payload, filter)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_LIST_WAITLIST, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.Response{
+		Status:   true,
+		Messsage: dto.MESSAGE_SUCCESS_GET_LIST_WAITLIST,
+		Data:     result.Data,
+		Meta:     result.PaginationResponse,
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+func (ah *AdminHandler) CancelWaitlist(ctx *gin.Context) {
+	idStr := ctx.Param("id")
+	result, err := ah.adminService.CancelWaitlist(ctx, idStr)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_CANCEL_WAITLIST, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_CANCEL_WAITLIST, result)
+	ctx.JSON(http.StatusOK, res)
+}
+func (ah *AdminHandler) OfferWaitlist(ctx *gin.Context) {
+	ticketIDStr := ctx.Param("ticket-id")
+	err := ah.adminService.OfferWaitlist(ctx, ticketIDStr)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_OFFER_WAITLIST, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_OFFER_WAITLIST, "")
+	ctx.JSON(http.StatusOK, res)
+}
+
+// Ticket Transfer
+func (ah *AdminHandler) GetTicketTransferHistory(ctx *gin.Context) {
+	ticketFormIDStr := ctx.Param("ticket-form-id")
+	result, err := ah.adminService.GetTicketTransferHistory(ctx, ticketFormIDStr)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_TICKET_TRANSFER_HISTORY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_TICKET_TRANSFER_HISTORY, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+// Email Outbox
+func (ah *AdminHandler) GetAllEmailOutbox(ctx *gin.Context) {
+	var (
+		payload dto.PaginationRequest
+		filter  dto.EmailOutboxFilterQuery
+	)
+
+	if err := ctx.ShouldBindQuery(&filter); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_INVALID_QUERY_PARAMS, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := ah.adminService.GetAllEmailOutboxWithPagination(ctx, payload, filter)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_LIST_EMAIL_OUTBOX, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.Response{
+		Status:   true,
+		Messsage: dto.MESSAGE_SUCCESS_GET_LIST_EMAIL_OUTBOX,
+		Data:     result.Data,
+		Meta:     result.PaginationResponse,
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+func (ah *AdminHandler) RetryEmailOutbox(ctx *gin.Context) {
+	idStr := ctx.Param("id")
+	result, err := ah.adminService.RetryEmailOutbox(ctx, idStr)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_RETRY_EMAIL_OUTBOX, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_RETRY_EMAIL_OUTBOX, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+// Notification Outbox
+func (ah *AdminHandler) GetAllNotificationOutbox(ctx *gin.Context) {
+	var (
+		payload dto.PaginationRequest
+		filter  dto.NotificationOutboxFilterQuery
+	)
+
+	if err := ctx.ShouldBindQuery(&filter); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_INVALID_QUERY_PARAMS, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := ah.adminService.GetAllNotificationOutboxWithPagination(ctx, payload, filter)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_LIST_NOTIFICATION_OUTBOX, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.Response{
+		Status:   true,
+		Messsage: dto.MESSAGE_SUCCESS_GET_LIST_NOTIFICATION_OUTBOX,
+		Data:     result.Data,
+		Meta:     result.PaginationResponse,
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+func (ah *AdminHandler) RetryNotificationOutbox(ctx *gin.Context) {
+	idStr := ctx.Param("id")
+	result, err := ah.adminService.RetryNotificationOutbox(ctx, idStr)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_RETRY_NOTIFICATION_OUTBOX, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_RETRY_NOTIFICATION_OUTBOX, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+// Email Template
+func (ah *AdminHandler) CreateEmailTemplate(ctx *gin.Context) {
+	var payload dto.CreateEmailTemplateRequest
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := ah.adminService.CreateEmailTemplate(ctx, payload)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_CREATE_EMAIL_TEMPLATE, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_CREATE_EMAIL_TEMPLATE, result)
+	ctx.JSON(http.StatusOK, res)
+}
+func (ah *AdminHandler) GetAllEmailTemplate(ctx *gin.Context) {
+	result, err := ah.adminService.GetAllEmailTemplate(ctx)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_LIST_EMAIL_TEMPLATE, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_LIST_EMAIL_TEMPLATE, result)
+	ctx.JSON(http.StatusOK, res)
+}
+func (ah *AdminHandler) GetDetailEmailTemplate(ctx *gin.Context) {
+	idStr := ctx.Param("id")
+	result, err := ah.adminService.GetDetailEmailTemplate(ctx, idStr)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DETAIL_EMAIL_TEMPLATE, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_DETAIL_EMAIL_TEMPLATE, result)
+	ctx.JSON(http.StatusOK, res)
+}
+func (ah *AdminHandler) UpdateEmailTemplate(ctx *gin.Context) {
+	idStr := ctx.Param("id")
+	var payload dto.UpdateEmailTemplateRequest
+	payload.ID = idStr
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := ah.adminService.UpdateEmailTemplate(ctx, payload)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_UPDATE_EMAIL_TEMPLATE, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_UPDATE_EMAIL_TEMPLATE, result)
+	ctx.JSON(http.StatusOK, res)
+}
+func (ah *AdminHandler) DeleteEmailTemplate(ctx *gin.Context) {
+	idStr := ctx.Param("id")
+	result, err := ah.adminService.DeleteEmailTemplate(ctx, idStr)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_DELETE_EMAIL_TEMPLATE, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_DELETE_EMAIL_TEMPLATE, result)
+	ctx.JSON(http.StatusOK, res)
+}
+func (ah *AdminHandler) PreviewEmailTemplate(ctx *gin.Context) {
+	var payload dto.PreviewEmailTemplateRequest
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := ah.adminService.PreviewEmailTemplate(ctx, payload)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_PREVIEW_EMAIL_TEMPLATE, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_PREVIEW_EMAIL_TEMPLATE, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+// Resend E-Ticket
+func (ah *AdminHandler) ResendETicket(ctx *gin.Context) {
+	var payload dto.ResendETicketRequest
+	// body opsional: tanpa body semua tiket di transaksi dikirim ulang
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBind(&payload); err != nil {
+			res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+			return
+		}
+	}
+
+	payload.TransactionID = ctx.Param("transaction-id")
+	result, err := ah.adminService.ResendETicket(ctx, payload)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_RESEND_E_TICKET, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_RESEND_E_TICKET, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+// Broadcast
+func (ah *AdminHandler) CountBroadcastRecipient(ctx *gin.Context) {
+	var payload dto.BroadcastFilterRequest
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBind(&payload); err != nil {
+			res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+			return
+		}
+	}
+
+	result, err := ah.adminService.CountBroadcastRecipient(ctx, payload)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_COUNT_BROADCAST_RECIPIENT, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_COUNT_BROADCAST_RECIPIENT, result)
+	ctx.JSON(http.StatusOK, res)
+}
+func (ah *AdminHandler) CreateBroadcast(ctx *gin.Context) {
+	var payload dto.CreateBroadcastRequest
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := ah.adminService.CreateBroadcast(ctx, payload)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_CREATE_BROADCAST, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_CREATE_BROADCAST, result)
+	ctx.JSON(http.StatusOK, res)
+}
+func (ah *AdminHandler) GetAllBroadcast(ctx *gin.Context) {
+	var payload dto.PaginationRequest
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := ah.adminService.GetAllBroadcastWithPagination(ctx, payload)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_LIST_BROADCAST, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.Response{
+		Status:   true,
+		Messsage: dto.MESSAGE_SUCCESS_GET_LIST_BROADCAST,
+		Data:     result.Data,
+		Meta:     result.PaginationResponse,
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+func (ah *AdminHandler) GetDetailBroadcast(ctx *gin.Context) {
+	idStr := ctx.Param("id")
+	result, err := ah.adminService.GetDetailBroadcast(ctx, idStr)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DETAIL_BROADCAST, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_DETAIL_BROADCAST, result)
+	ctx.JSON(http.StatusOK, res)
+}
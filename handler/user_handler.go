@@ -1,8 +1,11 @@
 package handler
 
 import (
+	"io"
 	"net/http"
+	"time"
 
+	"github.com/Amierza/TedXBackend/constants"
 	"github.com/Amierza/TedXBackend/dto"
 	"github.com/Amierza/TedXBackend/service"
 	"github.com/Amierza/TedXBackend/utils"
@@ -14,14 +17,29 @@ type (
 		// Authentication
 		Login(ctx *gin.Context)
 
+		// Email Verification
+		VerifyEmail(ctx *gin.Context)
+		ResendEmailVerification(ctx *gin.Context)
+
+		// Password Reset
+		ForgotPassword(ctx *gin.Context)
+		ResetPassword(ctx *gin.Context)
+
 		// User
 		GetDetailUser(ctx *gin.Context)
 		UpdateUser(ctx *gin.Context)
 
+		// Event
+		GetAllEvent(ctx *gin.Context)
+		GetDetailEvent(ctx *gin.Context)
+
 		// Ticket
 		GetAllTicket(ctx *gin.Context)
 		GetDetailTicket(ctx *gin.Context)
 
+		// Availability
+		StreamAvailability(ctx *gin.Context)
+
 		// Sponsorship
 		GetAllSponsorship(ctx *gin.Context)
 
@@ -42,6 +60,24 @@ type (
 
 		// Webhook for Midtrans
 		UpdateTransactionTicket(ctx *gin.Context)
+
+		// Waitlist
+		JoinWaitlist(ctx *gin.Context)
+		GetMyWaitlist(ctx *gin.Context)
+		LeaveWaitlist(ctx *gin.Context)
+
+		// Ticket Transfer
+		TransferTicket(ctx *gin.Context)
+		GetTicketTransferHistory(ctx *gin.Context)
+
+		// Ticket QR
+		GetTicketQRCode(ctx *gin.Context)
+
+		// Resend E-Ticket
+		ResendETicket(ctx *gin.Context)
+
+		// Notification
+		UpdateWhatsAppOptIn(ctx *gin.Context)
 	}
 
 	UserHandler struct {
@@ -75,6 +111,74 @@ func (uh *UserHandler) Login(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, res)
 }
 
+// Email Verification
+func (uh *UserHandler) VerifyEmail(ctx *gin.Context) {
+	var payload dto.VerifyEmailRequest
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := uh.userService.VerifyEmail(ctx, payload)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_VERIFY_EMAIL, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_VERIFY_EMAIL, result)
+	ctx.JSON(http.StatusOK, res)
+}
+func (uh *UserHandler) ResendEmailVerification(ctx *gin.Context) {
+	result, err := uh.userService.ResendEmailVerification(ctx)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_RESEND_EMAIL_VERIFICATION, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_RESEND_EMAIL_VERIFICATION, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+// Password Reset
+func (uh *UserHandler) ForgotPassword(ctx *gin.Context) {
+	var payload dto.ForgotPasswordRequest
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	if err := uh.userService.ForgotPassword(ctx, payload); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_FORGOT_PASSWORD, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_FORGOT_PASSWORD, nil)
+	ctx.JSON(http.StatusOK, res)
+}
+func (uh *UserHandler) ResetPassword(ctx *gin.Context) {
+	var payload dto.ResetPasswordRequest
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := uh.userService.ResetPassword(ctx, payload)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_RESET_PASSWORD, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_RESET_PASSWORD, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
 // User
 func (uh *UserHandler) GetDetailUser(ctx *gin.Context) {
 	result, err := uh.userService.GetDetailUser(ctx)
@@ -106,9 +210,35 @@ func (uh *UserHandler) UpdateUser(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, res)
 }
 
+// Event
+func (uh *UserHandler) GetAllEvent(ctx *gin.Context) {
+	result, err := uh.userService.GetAllEvent(ctx)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_LIST_EVENT, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_LIST_EVENT, result)
+	ctx.JSON(http.StatusOK, res)
+}
+func (uh *UserHandler) GetDetailEvent(ctx *gin.Context) {
+	idStr := ctx.Param("id")
+	result, err := uh.userService.GetDetailEvent(ctx, idStr)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DETAIL_EVENT, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_DETAIL_EVENT, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
 // Ticket
 func (uh *UserHandler) GetAllTicket(ctx *gin.Context) {
-	result, err := uh.userService.GetAllTicket(ctx)
+	eventID := ctx.Query("event_id")
+	result, err := uh.userService.GetAllTicket(ctx, eventID)
 	if err != nil {
 		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_LIST_TICKET, err.Error(), nil)
 		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
@@ -131,6 +261,46 @@ func (uh *UserHandler) GetDetailTicket(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, res)
 }
 
+// Availability
+func (uh *UserHandler) StreamAvailability(ctx *gin.Context) {
+	snapshot, err := uh.userService.GetAllAvailability(ctx)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_STREAM_AVAILABILITY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	updates, unsubscribe := uh.userService.SubscribeAvailability()
+	defer unsubscribe()
+
+	ctx.Header("Content-Type", "text/event-stream")
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("Connection", "keep-alive")
+	ctx.Header("X-Accel-Buffering", "no")
+
+	ctx.SSEvent("snapshot", snapshot)
+	ctx.Writer.Flush()
+
+	heartbeat := time.NewTicker(constants.ENUM_AVAILABILITY_HEARTBEAT_SECONDS * time.Second)
+	defer heartbeat.Stop()
+
+	ctx.Stream(func(w io.Writer) bool {
+		select {
+		case <-ctx.Request.Context().Done():
+			return false
+		case update, ok := <-updates:
+			if !ok {
+				return false
+			}
+			ctx.SSEvent("availability", update)
+			return true
+		case <-heartbeat.C:
+			ctx.SSEvent("ping", time.Now().Unix())
+			return true
+		}
+	})
+}
+
 // Sponsorship
 func (uh *UserHandler) GetAllSponsorship(ctx *gin.Context) {
 	result, err := uh.userService.GetAllSponsorship(ctx)
@@ -242,3 +412,131 @@ func (uh *UserHandler) UpdateTransactionTicket(ctx *gin.Context) {
 	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_UPDATE_TRANSACTION_TICKET, "")
 	ctx.JSON(http.StatusOK, res)
 }
+
+// Waitlist
+func (uh *UserHandler) JoinWaitlist(ctx *gin.Context) {
+	ticketIDStr := ctx.Param("ticket-id")
+	result, err := uh.userService.JoinWaitlist(ctx, ticketIDStr)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_JOIN_WAITLIST, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_JOIN_WAITLIST, result)
+	ctx.JSON(http.StatusOK, res)
+}
+func (uh *UserHandler) GetMyWaitlist(ctx *gin.Context) {
+	result, err := uh.userService.GetMyWaitlist(ctx)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_LIST_WAITLIST, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_LIST_WAITLIST, result)
+	ctx.JSON(http.StatusOK, res)
+}
+func (uh *UserHandler) LeaveWaitlist(ctx *gin.Context) {
+	idStr := ctx.Param("id")
+	result, err := uh.userService.LeaveWaitlist(ctx, idStr)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_LEAVE_WAITLIST, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_LEAVE_WAITLIST, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+// Ticket Transfer
+func (uh *UserHandler) TransferTicket(ctx *gin.Context) {
+	var payload dto.TransferTicketRequest
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	ticketFormIDStr := ctx.Param("ticket-form-id")
+	result, err := uh.userService.TransferTicket(ctx, ticketFormIDStr, payload)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_TRANSFER_TICKET, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_TRANSFER_TICKET, result)
+	ctx.JSON(http.StatusOK, res)
+}
+func (uh *UserHandler) GetTicketTransferHistory(ctx *gin.Context) {
+	ticketFormIDStr := ctx.Param("ticket-form-id")
+	result, err := uh.userService.GetTicketTransferHistory(ctx, ticketFormIDStr)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_TICKET_TRANSFER_HISTORY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_TICKET_TRANSFER_HISTORY, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+// Ticket QR
+func (uh *UserHandler) GetTicketQRCode(ctx *gin.Context) {
+	result, err := uh.userService.GetTicketQRCode(ctx, ctx.Query("token"))
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_TICKET_QR_CODE, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	ctx.Header("Cache-Control", "no-store")
+	ctx.Data(http.StatusOK, "image/png", result)
+}
+
+// Resend E-Ticket
+func (uh *UserHandler) ResendETicket(ctx *gin.Context) {
+	var payload dto.ResendETicketRequest
+	// body opsional: tanpa body semua tiket di transaksi dikirim ulang
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBind(&payload); err != nil {
+			res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+			return
+		}
+	}
+
+	payload.TransactionID = ctx.Param("transaction-id")
+	result, err := uh.userService.ResendETicket(ctx, payload)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_RESEND_E_TICKET, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_RESEND_E_TICKET, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+// Notification
+func (uh *UserHandler) UpdateWhatsAppOptIn(ctx *gin.Context) {
+	var payload dto.UpdateWhatsAppOptInRequest
+	if err := ctx.ShouldBind(&payload); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	ticketFormIDStr := ctx.Param("ticket-form-id")
+	result, err := uh.userService.UpdateWhatsAppOptIn(ctx, ticketFormIDStr, payload)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_UPDATE_WHATSAPP_OPT_IN, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_UPDATE_WHATSAPP_OPT_IN, result)
+	ctx.JSON(http.StatusOK, res)
+}
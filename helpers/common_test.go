@@ -0,0 +1,43 @@
+package helpers
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMain(m *testing.M) {
+	if err := SetQRSigningKey(strings.Repeat("k", minQRSigningKeyLength)); err != nil {
+		panic(err)
+	}
+
+	os.Exit(m.Run())
+}
+
+// splice memakai payload dari token a dengan tanda tangan dari token b.
+func splice(a, b string) string {
+	pa, pb := strings.Split(a, "."), strings.Split(b, ".")
+	return pa[0] + "." + pa[1] + "." + pb[2]
+}
+
+type tokenCase struct {
+	name  string
+	token string
+	now   time.Time
+	want  error
+}
+
+// runTokenCases menjalankan verify untuk setiap case; verify mengecek claims sendiri kalau token valid.
+func runTokenCases(t *testing.T, tests []tokenCase, verify func(t *testing.T, token string, now time.Time) error) {
+	t.Helper()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := verify(t, tt.token, tt.now); !errors.Is(err, tt.want) {
+				t.Fatalf("error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package helpers
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const emailVerificationPrefix = "EV1"
+
+var (
+	ErrMalformedEmailVerification = errors.New("malformed email verification token")
+	ErrInvalidEmailVerification   = errors.New("invalid email verification signature")
+	ErrExpiredEmailVerification   = errors.New("email verification token has expired")
+)
+
+// EmailVerificationClaims terikat ke alamat email, jadi token email lama tidak berlaku setelah email diganti.
+type EmailVerificationClaims struct {
+	UserID    uuid.UUID
+	Email     string
+	ExpiresAt time.Time
+}
+
+// getEmailVerificationKey dibedakan dari key QR supaya token keduanya tidak bisa saling dipakai.
+func getEmailVerificationKey() []byte {
+	key := sha256.Sum256(append([]byte("email-verification:"), getQRSigningKey()...))
+	return key[:]
+}
+
+func SignEmailVerification(claims EmailVerificationClaims) string {
+	payload := make([]byte, 24, 24+len(claims.Email))
+	copy(payload[0:16], claims.UserID[:])
+	binary.BigEndian.PutUint64(payload[16:24], uint64(claims.ExpiresAt.Unix()))
+	payload = append(payload, claims.Email...)
+
+	encoded := emailVerificationPrefix + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(signEmailVerification(encoded))
+}
+func VerifyEmailVerification(token string, now time.Time) (EmailVerificationClaims, error) {
+	parts := strings.Split(strings.TrimSpace(token), ".")
+	if len(parts) != 3 || parts[0] != emailVerificationPrefix {
+		return EmailVerificationClaims{}, ErrMalformedEmailVerification
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return EmailVerificationClaims{}, ErrMalformedEmailVerification
+	}
+
+	if !hmac.Equal(signature, signEmailVerification(parts[0]+"."+parts[1])) {
+		return EmailVerificationClaims{}, ErrInvalidEmailVerification
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil || len(payload) <= 24 {
+		return EmailVerificationClaims{}, ErrMalformedEmailVerification
+	}
+
+	var claims EmailVerificationClaims
+	copy(claims.UserID[:], payload[0:16])
+	claims.ExpiresAt = time.Unix(int64(binary.BigEndian.Uint64(payload[16:24])), 0)
+	claims.Email = string(payload[24:])
+
+	if !now.Before(claims.ExpiresAt) {
+		return EmailVerificationClaims{}, ErrExpiredEmailVerification
+	}
+
+	return claims, nil
+}
+func signEmailVerification(data string) []byte {
+	mac := hmac.New(sha256.New, getEmailVerificationKey())
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
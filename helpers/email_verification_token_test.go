@@ -0,0 +1,32 @@
+package helpers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestVerifyEmailVerification(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	claims := EmailVerificationClaims{
+		UserID:    uuid.New(),
+		Email:     "guest@example.com",
+		ExpiresAt: now.Add(time.Hour),
+	}
+	token := SignEmailVerification(claims)
+	other := SignEmailVerification(EmailVerificationClaims{UserID: claims.UserID, Email: "attacker@example.com", ExpiresAt: claims.ExpiresAt})
+
+	runTokenCases(t, []tokenCase{
+		{"valid", token, now, nil},
+		{"expired", token, claims.ExpiresAt.Add(time.Second), ErrExpiredEmailVerification},
+		{"email swapped", splice(other, token), now, ErrInvalidEmailVerification},
+		{"garbage", "a.b", now, ErrMalformedEmailVerification},
+	}, func(t *testing.T, token string, now time.Time) error {
+		got, err := VerifyEmailVerification(token, now)
+		if err == nil && (got.UserID != claims.UserID || got.Email != claims.Email) {
+			t.Fatalf("VerifyEmailVerification() = %+v, want %+v", got, claims)
+		}
+		return err
+	})
+}
@@ -0,0 +1,65 @@
+package helpers
+
+import "strings"
+
+func LevenshteinDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
+
+// FuzzyWordMatch mengizinkan typo 1 huruf untuk kata pendek dan 2 huruf untuk kata panjang
+func FuzzyWordMatch(query, word string) bool {
+	if strings.HasPrefix(word, query) {
+		return true
+	}
+
+	allowed := 0
+	switch {
+	case len(query) >= 6:
+		allowed = 2
+	case len(query) >= 4:
+		allowed = 1
+	}
+
+	return allowed > 0 && LevenshteinDistance(query, word) <= allowed
+}
+
+func MaskEmail(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at <= 0 {
+		return email
+	}
+
+	local := email[:at]
+	if len(local) <= 2 {
+		return local[:1] + "***" + email[at:]
+	}
+
+	return local[:1] + strings.Repeat("*", len(local)-2) + local[len(local)-1:] + email[at:]
+}
+
+func MaskPhoneNumber(phone string) string {
+	if len(phone) <= 4 {
+		return phone
+	}
+
+	return strings.Repeat("*", len(phone)-4) + phone[len(phone)-4:]
+}
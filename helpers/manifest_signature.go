@@ -0,0 +1,20 @@
+package helpers
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+)
+
+// getManifestSigningKey diturunkan dari QR signing key; scanner cukup memegang public key-nya.
+func getManifestSigningKey() ed25519.PrivateKey {
+	seed := sha256.Sum256(append([]byte("check-in-manifest:"), getQRSigningKey()...))
+	return ed25519.NewKeyFromSeed(seed[:])
+}
+
+func SignManifest(payload []byte) string {
+	return base64.StdEncoding.EncodeToString(ed25519.Sign(getManifestSigningKey(), payload))
+}
+func ManifestPublicKey() string {
+	return base64.StdEncoding.EncodeToString(getManifestSigningKey().Public().(ed25519.PublicKey))
+}
@@ -0,0 +1,37 @@
+package helpers
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"testing"
+)
+
+func TestSignManifest(t *testing.T) {
+	publicKey, err := base64.StdEncoding.DecodeString(ManifestPublicKey())
+	if err != nil {
+		t.Fatalf("decode public key: %v", err)
+	}
+
+	payload := []byte(`{"event_id":"e1","tickets":[]}`)
+	signature, err := base64.StdEncoding.DecodeString(SignManifest(payload))
+	if err != nil {
+		t.Fatalf("decode signature: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		payload []byte
+		want    bool
+	}{
+		{"signed payload", payload, true},
+		{"modified payload", []byte(`{"event_id":"e2","tickets":[]}`), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ed25519.Verify(ed25519.PublicKey(publicKey), tt.payload, signature); got != tt.want {
+				t.Fatalf("ed25519.Verify() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
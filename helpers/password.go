@@ -1,9 +1,14 @@
 package helpers
 
 import (
+	"crypto/rand"
+	"math/big"
+
 	"golang.org/x/crypto/bcrypt"
 )
 
+const passwordAlphabet = "abcdefghjkmnpqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
 func HashPassword(password string) (string, error) {
 	bytes, err := bcrypt.GenerateFromPassword([]byte(password), 4)
 	return string(bytes), err
@@ -17,3 +22,17 @@ func CheckPassword(hashPassword string, plainPassword []byte) (bool, error) {
 
 	return true, nil
 }
+
+// GenerateRandomPassword tanpa huruf yang mirip (0/O, 1/l/I) supaya mudah dibacakan ke volunteer
+func GenerateRandomPassword(length int) (string, error) {
+	password := make([]byte, length)
+	for i := range password {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(passwordAlphabet))))
+		if err != nil {
+			return "", err
+		}
+		password[i] = passwordAlphabet[n.Int64()]
+	}
+
+	return string(password), nil
+}
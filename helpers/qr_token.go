@@ -0,0 +1,100 @@
+package helpers
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ticketQRPrefix        = "TX1"
+	minQRSigningKeyLength = 32
+)
+
+var (
+	ErrMalformedTicketQR   = errors.New("malformed ticket qr token")
+	ErrInvalidTicketQR     = errors.New("invalid ticket qr signature")
+	ErrMissingQRSigningKey = errors.New("QR_SIGNING_KEY is not set")
+	ErrWeakQRSigningKey    = errors.New("QR_SIGNING_KEY must be at least 32 characters")
+
+	qrSigningKey []byte
+)
+
+type TicketQRClaims struct {
+	TicketFormID uuid.UUID
+	EventID      uuid.UUID
+	Version      int
+	IssuedAt     time.Time
+}
+
+// SetQRSigningKey dipanggil sekali saat startup; semua key token lain diturunkan dari key ini.
+func SetQRSigningKey(key string) error {
+	if key == "" {
+		return ErrMissingQRSigningKey
+	}
+	if len(key) < minQRSigningKeyLength {
+		return ErrWeakQRSigningKey
+	}
+
+	qrSigningKey = []byte(key)
+	return nil
+}
+func getQRSigningKey() []byte {
+	if len(qrSigningKey) == 0 {
+		panic(ErrMissingQRSigningKey)
+	}
+
+	return qrSigningKey
+}
+
+// SignTicketQR membuat token "TX1.<payload>.<signature>" dengan HMAC-SHA256.
+func SignTicketQR(claims TicketQRClaims) string {
+	payload := make([]byte, 44)
+	copy(payload[0:16], claims.TicketFormID[:])
+	copy(payload[16:32], claims.EventID[:])
+	binary.BigEndian.PutUint32(payload[32:36], uint32(claims.Version))
+	binary.BigEndian.PutUint64(payload[36:44], uint64(claims.IssuedAt.Unix()))
+
+	encoded := ticketQRPrefix + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(signTicketQR(encoded))
+}
+func VerifyTicketQR(token string) (TicketQRClaims, error) {
+	parts := strings.Split(strings.TrimSpace(token), ".")
+	if len(parts) != 3 || parts[0] != ticketQRPrefix {
+		return TicketQRClaims{}, ErrMalformedTicketQR
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return TicketQRClaims{}, ErrMalformedTicketQR
+	}
+
+	if !hmac.Equal(signature, signTicketQR(parts[0]+"."+parts[1])) {
+		return TicketQRClaims{}, ErrInvalidTicketQR
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil || len(payload) != 44 {
+		return TicketQRClaims{}, ErrMalformedTicketQR
+	}
+
+	var claims TicketQRClaims
+	copy(claims.TicketFormID[:], payload[0:16])
+	copy(claims.EventID[:], payload[16:32])
+	claims.Version = int(binary.BigEndian.Uint32(payload[32:36]))
+	claims.IssuedAt = time.Unix(int64(binary.BigEndian.Uint64(payload[36:44])), 0)
+
+	return claims, nil
+}
+func signTicketQR(data string) []byte {
+	mac := hmac.New(sha256.New, getQRSigningKey())
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,59 @@
+package helpers
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestSetQRSigningKey(t *testing.T) {
+	defer SetQRSigningKey(strings.Repeat("k", minQRSigningKeyLength))
+
+	tests := []struct {
+		name string
+		key  string
+		want error
+	}{
+		{"empty", "", ErrMissingQRSigningKey},
+		{"too short", "short-key", ErrWeakQRSigningKey},
+		{"valid", strings.Repeat("x", minQRSigningKeyLength), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := SetQRSigningKey(tt.key); !errors.Is(err, tt.want) {
+				t.Fatalf("SetQRSigningKey() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerifyTicketQR(t *testing.T) {
+	claims := TicketQRClaims{
+		TicketFormID: uuid.New(),
+		EventID:      uuid.New(),
+		Version:      3,
+		IssuedAt:     time.Unix(1700000000, 0),
+	}
+	token := SignTicketQR(claims)
+	other := SignTicketQR(TicketQRClaims{TicketFormID: uuid.New(), EventID: claims.EventID, Version: 1, IssuedAt: claims.IssuedAt})
+
+	runTokenCases(t, []tokenCase{
+		{name: "valid", token: token},
+		{name: "valid with whitespace", token: "  " + token + "\n"},
+		{name: "wrong prefix", token: "TX2" + strings.TrimPrefix(token, ticketQRPrefix), want: ErrMalformedTicketQR},
+		{name: "missing part", token: strings.Join(strings.Split(token, ".")[:2], "."), want: ErrMalformedTicketQR},
+		{name: "signature from another ticket", token: splice(token, other), want: ErrInvalidTicketQR},
+		{name: "qr link used as qr", token: SignTicketQRLink(TicketQRLinkClaims{TicketFormID: claims.TicketFormID, ExpiresAt: time.Now().Add(time.Hour)}), want: ErrMalformedTicketQR},
+	}, func(t *testing.T, token string, now time.Time) error {
+		got, err := VerifyTicketQR(token)
+		if err == nil && (got.TicketFormID != claims.TicketFormID || got.EventID != claims.EventID || got.Version != claims.Version || !got.IssuedAt.Equal(claims.IssuedAt)) {
+			t.Fatalf("VerifyTicketQR() = %+v, want %+v", got, claims)
+		}
+
+		return err
+	})
+}
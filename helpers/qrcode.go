@@ -1,31 +1,23 @@
 package helpers
 
 import (
-	"fmt"
 	"os"
 	"path/filepath"
 
 	"github.com/skip2/go-qrcode"
 )
 
-func GenerateQRCodeFile(content string, filename string) (string, error) {
-	localPath := filepath.Join("assets", "qrcodes", filename)
-
-	err := os.MkdirAll(filepath.Dir(localPath), os.ModePerm)
-	if err != nil {
-		return "", err
-	}
-
-	err = qrcode.WriteFile(content, qrcode.Medium, 256, localPath)
-	if err != nil {
-		return "", err
-	}
+// GenerateQRCode hanya merender PNG di memori, tidak lagi ditulis ke folder publik.
+func GenerateQRCode(content string) ([]byte, error) {
+	return qrcode.Encode(content, qrcode.Medium, 256)
+}
 
-	baseURL := os.Getenv("BASE_URL")
-	if baseURL == "" {
-		baseURL = "http://localhost:8000"
+// RemoveLegacyQRCodeFile menghapus file QR lama di assets/qrcodes.
+func RemoveLegacyQRCodeFile(ticketFormID string) error {
+	err := os.Remove(filepath.Join("assets", "qrcodes", ticketFormID+".png"))
+	if err != nil && !os.IsNotExist(err) {
+		return err
 	}
 
-	publicURL := fmt.Sprintf("%s/assets/qrcodes/%s", baseURL, filename)
-	return publicURL, nil
+	return nil
 }
@@ -0,0 +1,81 @@
+package helpers
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const ticketQRLinkPrefix = "QL1"
+
+var (
+	ErrMalformedTicketQRLink = errors.New("malformed ticket qr link")
+	ErrInvalidTicketQRLink   = errors.New("invalid ticket qr link signature")
+	ErrExpiredTicketQRLink   = errors.New("ticket qr link has expired")
+)
+
+// TicketQRLinkClaims terikat ke QRVersion, jadi link lama berhenti berlaku setelah reissue.
+type TicketQRLinkClaims struct {
+	TicketFormID uuid.UUID
+	Version      int
+	ExpiresAt    time.Time
+}
+
+// getTicketQRLinkKey dibedakan dari key QR supaya link download tidak bisa dipakai di gate.
+func getTicketQRLinkKey() []byte {
+	key := sha256.Sum256(append([]byte("ticket-qr-link:"), getQRSigningKey()...))
+	return key[:]
+}
+
+func SignTicketQRLink(claims TicketQRLinkClaims) string {
+	payload := make([]byte, 28)
+	copy(payload[0:16], claims.TicketFormID[:])
+	binary.BigEndian.PutUint32(payload[16:20], uint32(claims.Version))
+	binary.BigEndian.PutUint64(payload[20:28], uint64(claims.ExpiresAt.Unix()))
+
+	encoded := ticketQRLinkPrefix + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(signTicketQRLink(encoded))
+}
+func VerifyTicketQRLink(token string, now time.Time) (TicketQRLinkClaims, error) {
+	parts := strings.Split(strings.TrimSpace(token), ".")
+	if len(parts) != 3 || parts[0] != ticketQRLinkPrefix {
+		return TicketQRLinkClaims{}, ErrMalformedTicketQRLink
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return TicketQRLinkClaims{}, ErrMalformedTicketQRLink
+	}
+
+	if !hmac.Equal(signature, signTicketQRLink(parts[0]+"."+parts[1])) {
+		return TicketQRLinkClaims{}, ErrInvalidTicketQRLink
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil || len(payload) != 28 {
+		return TicketQRLinkClaims{}, ErrMalformedTicketQRLink
+	}
+
+	var claims TicketQRLinkClaims
+	copy(claims.TicketFormID[:], payload[0:16])
+	claims.Version = int(binary.BigEndian.Uint32(payload[16:20]))
+	claims.ExpiresAt = time.Unix(int64(binary.BigEndian.Uint64(payload[20:28])), 0)
+
+	if !now.Before(claims.ExpiresAt) {
+		return TicketQRLinkClaims{}, ErrExpiredTicketQRLink
+	}
+
+	return claims, nil
+}
+func signTicketQRLink(data string) []byte {
+	mac := hmac.New(sha256.New, getTicketQRLinkKey())
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
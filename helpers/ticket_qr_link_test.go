@@ -0,0 +1,34 @@
+package helpers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestVerifyTicketQRLink(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	claims := TicketQRLinkClaims{
+		TicketFormID: uuid.New(),
+		Version:      2,
+		ExpiresAt:    now.Add(time.Hour),
+	}
+	token := SignTicketQRLink(claims)
+	other := SignTicketQRLink(TicketQRLinkClaims{TicketFormID: claims.TicketFormID, Version: 3, ExpiresAt: claims.ExpiresAt})
+
+	runTokenCases(t, []tokenCase{
+		{name: "valid", token: token, now: now},
+		{name: "expired", token: token, now: claims.ExpiresAt, want: ErrExpiredTicketQRLink},
+		{name: "signature from another version", token: splice(token, other), now: now, want: ErrInvalidTicketQRLink},
+		{name: "qr token used as link", token: SignTicketQR(TicketQRClaims{TicketFormID: claims.TicketFormID}), now: now, want: ErrMalformedTicketQRLink},
+		{name: "garbage", token: "not-a-token", now: now, want: ErrMalformedTicketQRLink},
+	}, func(t *testing.T, token string, now time.Time) error {
+		got, err := VerifyTicketQRLink(token, now)
+		if err == nil && (got.TicketFormID != claims.TicketFormID || got.Version != claims.Version) {
+			t.Fatalf("VerifyTicketQRLink() = %+v, want %+v", got, claims)
+		}
+
+		return err
+	})
+}
@@ -3,15 +3,21 @@ package main
 import (
 	"log"
 	"os"
+	"time"
 
 	"github.com/Amierza/TedXBackend/cmd"
+	"github.com/Amierza/TedXBackend/config"
 	"github.com/Amierza/TedXBackend/config/database"
 	"github.com/Amierza/TedXBackend/config/midtrans"
+	"github.com/Amierza/TedXBackend/constants"
 	"github.com/Amierza/TedXBackend/handler"
+	"github.com/Amierza/TedXBackend/helpers"
 	"github.com/Amierza/TedXBackend/middleware"
 	"github.com/Amierza/TedXBackend/repository"
 	"github.com/Amierza/TedXBackend/routes"
 	"github.com/Amierza/TedXBackend/service"
+	"github.com/Amierza/TedXBackend/utils/mailer"
+	"github.com/Amierza/TedXBackend/utils/notifier"
 	"github.com/gin-gonic/gin"
 )
 
@@ -27,25 +33,86 @@ func main() {
 		return
 	}
 
+	if err := helpers.SetQRSigningKey(os.Getenv("QR_SIGNING_KEY")); err != nil {
+		log.Fatalf("error loading qr signing key: %v", err)
+	}
+
+	emailConfig, err := config.NewEmailConfig()
+	if err != nil {
+		log.Fatalf("error loading email config: %v", err)
+	}
+
+	mailTransport, err := mailer.NewMailer(emailConfig)
+	if err != nil {
+		log.Fatalf("error creating mailer: %v", err)
+	}
+
+	whatsAppConfig, err := config.NewWhatsAppConfig()
+	if err != nil {
+		log.Fatalf("error loading whatsapp config: %v", err)
+	}
+
+	whatsAppNotifier, err := notifier.NewWhatsAppNotifier(whatsAppConfig)
+	if err != nil {
+		log.Fatalf("error creating whatsapp notifier: %v", err)
+	}
+
+	reminderOffsets, err := service.ParseEventReminderOffsets(os.Getenv("EVENT_REMINDER_OFFSETS"))
+	if err != nil {
+		log.Fatalf("error reading EVENT_REMINDER_OFFSETS: %v", err)
+	}
+
 	var (
-		jwtService = service.NewJWTService()
+		userRepo   = repository.NewUserRepository(db)
+		jwtService = service.NewJWTService(userRepo)
+
+		availabilityRepo    = repository.NewAvailabilityRepository(db)
+		availabilityService = service.NewAvailabilityService(availabilityRepo)
+
+		checkInFeedService = service.NewCheckInFeedService()
+
+		emailTemplateRepo    = repository.NewEmailTemplateRepository(db)
+		emailTemplateService = service.NewEmailTemplateService(emailTemplateRepo)
+
+		emailOutboxRepo    = repository.NewEmailOutboxRepository(db)
+		emailOutboxService = service.NewEmailOutboxService(emailOutboxRepo, mailTransport)
+
+		eventReminderRepo    = repository.NewEventReminderRepository(db)
+		eventReminderService = service.NewEventReminderService(eventReminderRepo, emailTemplateService, reminderOffsets)
 
-		userRepo    = repository.NewUserRepository(db)
-		userService = service.NewUserService(userRepo, jwtService)
+		notificationOutboxRepo = repository.NewNotificationOutboxRepository(db)
+		notificationService    = service.NewNotificationService(notificationOutboxRepo, whatsAppNotifier)
+
+		passwordResetRepo    = repository.NewPasswordResetRepository(db)
+		passwordResetService = service.NewPasswordResetService(passwordResetRepo, emailTemplateService)
+
+		waitlistRepo    = repository.NewWaitlistRepository(db)
+		waitlistService = service.NewWaitlistService(waitlistRepo, availabilityService, emailTemplateService)
+
+		userService = service.NewUserService(userRepo, jwtService, waitlistService, availabilityService, emailTemplateService, passwordResetService)
 		userHandler = handler.NewUserHandler(userService)
 
 		adminRepo    = repository.NewAdminRepository(db)
-		adminService = service.NewAdminService(adminRepo, jwtService)
+		adminService = service.NewAdminService(adminRepo, jwtService, waitlistService, availabilityService, checkInFeedService, emailTemplateService, passwordResetService)
 		adminHandler = handler.NewAdminHandler(adminService)
 	)
 
+	go waitlistService.StartOfferExpiryWorker(time.Minute)
+	go emailOutboxService.StartWorker(constants.ENUM_EMAIL_OUTBOX_WORKERS, constants.ENUM_EMAIL_OUTBOX_POLL_SECONDS*time.Second)
+	go eventReminderService.StartWorker(constants.ENUM_EVENT_REMINDER_POLL_MINUTES * time.Minute)
+	go notificationService.StartWorker(constants.ENUM_NOTIFICATION_OUTBOX_POLL_SECONDS * time.Second)
+	go notificationService.StartScheduler(constants.ENUM_NOTIFICATION_SCHEDULE_POLL_MINUTES * time.Minute)
+
 	server := gin.Default()
 	server.Use(middleware.CORSMiddleware())
 
 	routes.User(server, userHandler, jwtService)
 	routes.Admin(server, adminHandler, jwtService)
 
-	server.Static("/assets", "./assets")
+	// assets/qrcodes sengaja tidak dilayani; QR check-in hanya lewat link bertanda tangan
+	for _, dir := range []string{"bundle", "merch", "speaker", "sponsorship", "ticket"} {
+		server.Static("/assets/"+dir, "./assets/"+dir)
+	}
 
 	port := os.Getenv("PORT")
 	if port == "" {
@@ -46,6 +46,19 @@ func Authentication(jwtService service.IJWTService) gin.HandlerFunc {
 			return
 		}
 
+		revoked, err := jwtService.IsTokenRevoked(ctx, authHeader)
+		if err != nil {
+			res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_PROSES_REQUEST, err.Error(), nil)
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, res)
+			return
+		}
+
+		if revoked {
+			res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_PROSES_REQUEST, dto.MESSAGE_FAILED_TOKEN_REVOKED, nil)
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, res)
+			return
+		}
+
 		ctx.Set("Authorization", authHeader)
 		ctx.Set("user_id", userID)
 		ctx.Next()
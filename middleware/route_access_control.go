@@ -1,7 +1,9 @@
 package middleware
 
 import (
+	"fmt"
 	"net/http"
+	"slices"
 	"strings"
 
 	"github.com/Amierza/TedXBackend/dto"
@@ -11,7 +13,7 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 )
 
-func RouteAccessControl(jwtService service.IJWTService) gin.HandlerFunc {
+func RouteAccessControl(jwtService service.IJWTService, allowedRoles ...string) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		authHeader := ctx.GetHeader("Authorization")
 		if authHeader == "" {
@@ -54,7 +56,7 @@ func RouteAccessControl(jwtService service.IJWTService) gin.HandlerFunc {
 			return
 		}
 
-		if roleName != "admin" {
+		if !slices.Contains(allowedRoles, fmt.Sprintf("%v", roleName)) {
 			res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_PROSES_REQUEST, dto.MESSAGE_FAILED_ACCESS_DENIED, nil)
 			ctx.AbortWithStatusJSON(http.StatusForbidden, res)
 			return
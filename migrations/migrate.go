@@ -1,7 +1,10 @@
 package migrations
 
 import (
+	"time"
+
 	"github.com/Amierza/TedXBackend/entity"
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
@@ -19,16 +22,79 @@ func Migrate(db *gorm.DB) error {
 		&entity.MerchImage{},
 		&entity.Merch{},
 
+		&entity.Event{},
+		&entity.EventSession{},
+		&entity.Gate{},
 		&entity.Bundle{},
 		&entity.Ticket{},
+		&entity.TicketFormField{},
 		&entity.BundleItem{},
 
 		&entity.Account{},
 		&entity.Session{},
 		&entity.Session{},
+
+		&entity.Waitlist{},
+		&entity.TicketTransfer{},
+		&entity.CheckInScan{},
+		&entity.MerchPickup{},
+		&entity.EmailOutbox{},
+		&entity.EmailTemplate{},
+		&entity.Broadcast{},
+		&entity.EventReminder{},
+		&entity.NotificationOutbox{},
+		&entity.PasswordReset{},
 	); err != nil {
 		return err
 	}
 
+	if err := backfillTicketEvents(db); err != nil {
+		return err
+	}
+
+	if err := db.Model(&entity.GuestAttendance{}).Where("checked_at IS NULL").Update("checked_at", gorm.Expr(`"createdAt"`)).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// backfillTicketEvents membuat satu event per ticket type lama lalu menautkan ticket dan bundle-nya.
+func backfillTicketEvents(db *gorm.DB) error {
+	var ticketTypes []string
+	if err := db.Model(&entity.Ticket{}).Where("event_id IS NULL").Distinct("type").Pluck("type", &ticketTypes).Error; err != nil {
+		return err
+	}
+
+	for _, ticketType := range ticketTypes {
+		var tickets []entity.Ticket
+		if err := db.Where("event_id IS NULL AND type = ?", ticketType).Order("event_date ASC").Find(&tickets).Error; err != nil {
+			return err
+		}
+
+		if len(tickets) == 0 {
+			continue
+		}
+
+		event := entity.Event{
+			ID:      uuid.New(),
+			Name:    ticketType,
+			StartAt: tickets[0].EventDate,
+			EndAt:   tickets[len(tickets)-1].EventDate.Add(24 * time.Hour),
+		}
+
+		if err := db.Create(&event).Error; err != nil {
+			return err
+		}
+
+		if err := db.Model(&entity.Ticket{}).Where("event_id IS NULL AND type = ?", ticketType).Update("event_id", event.ID).Error; err != nil {
+			return err
+		}
+
+		if err := db.Model(&entity.Bundle{}).Where("event_id IS NULL AND event_date::date BETWEEN ?::date AND ?::date", event.StartAt, tickets[len(tickets)-1].EventDate).Update("event_id", event.ID).Error; err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
@@ -7,13 +7,28 @@ import (
 
 func Rollback(db *gorm.DB) error {
 	tables := []interface{}{
+		&entity.PasswordReset{},
+		&entity.NotificationOutbox{},
+		&entity.EventReminder{},
+		&entity.Broadcast{},
+		&entity.EmailTemplate{},
+		&entity.EmailOutbox{},
+		&entity.MerchPickup{},
+		&entity.CheckInScan{},
+		&entity.TicketTransfer{},
+		&entity.Waitlist{},
+
 		&entity.Session{},
 		&entity.Session{},
 		&entity.Account{},
 
 		&entity.BundleItem{},
+		&entity.TicketFormField{},
 		&entity.Ticket{},
 		&entity.Bundle{},
+		&entity.Gate{},
+		&entity.EventSession{},
+		&entity.Event{},
 
 		&entity.Merch{},
 		&entity.MerchImage{},
@@ -5,10 +5,14 @@ import (
 	"errors"
 	"math"
 	"strings"
+	"time"
 
+	"github.com/Amierza/TedXBackend/constants"
 	"github.com/Amierza/TedXBackend/dto"
 	"github.com/Amierza/TedXBackend/entity"
+	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type (
@@ -28,6 +32,16 @@ type (
 		CreateTicketForm(ctx context.Context, tx *gorm.DB, ticketForm entity.TicketForm) error
 		CreateStudentAmbassador(ctx context.Context, tx *gorm.DB, studentAmbassador entity.StudentAmbassador) error
 		CreateGuestAttendance(ctx context.Context, tx *gorm.DB, guestAttendance entity.GuestAttendance) error
+		CreateCheckInScan(ctx context.Context, tx *gorm.DB, checkInScan entity.CheckInScan) error
+		CreateMerchPickup(ctx context.Context, tx *gorm.DB, merchPickup entity.MerchPickup) error
+		CreateEvent(ctx context.Context, tx *gorm.DB, event entity.Event) error
+		CreateEventSession(ctx context.Context, tx *gorm.DB, eventSession entity.EventSession) error
+		CreateGate(ctx context.Context, tx *gorm.DB, gate entity.Gate) error
+		CreateTicketFormField(ctx context.Context, tx *gorm.DB, ticketFormField entity.TicketFormField) error
+		CreateEmailOutbox(ctx context.Context, tx *gorm.DB, outbox entity.EmailOutbox) error
+		CreateNotificationOutbox(ctx context.Context, tx *gorm.DB, outbox entity.NotificationOutbox) error
+		CreateEmailTemplate(ctx context.Context, tx *gorm.DB, emailTemplate entity.EmailTemplate) error
+		CreateBroadcast(ctx context.Context, tx *gorm.DB, broadcast entity.Broadcast) error
 
 		// READ / GET
 		GetUserByID(ctx context.Context, tx *gorm.DB, userID string) (entity.User, bool, error)
@@ -35,6 +49,7 @@ type (
 		GetAllUser(ctx context.Context, tx *gorm.DB, roleName string) ([]entity.User, error)
 		GetAllUserWithPagination(ctx context.Context, tx *gorm.DB, req dto.PaginationRequest, roleName string) (dto.UserPaginationRepositoryResponse, error)
 		GetTicketByID(ctx context.Context, tx *gorm.DB, ticketID string) (entity.Ticket, bool, error)
+		GetTicketByIDForUpdate(ctx context.Context, tx *gorm.DB, ticketID string) (entity.Ticket, bool, error)
 		GetTicketByName(ctx context.Context, tx *gorm.DB, ticketName string) (entity.Ticket, bool, error)
 		GetAllTicket(ctx context.Context, tx *gorm.DB) ([]entity.Ticket, error)
 		GetAllTicketWithPagination(ctx context.Context, tx *gorm.DB, req dto.PaginationRequest) (dto.TicketPaginationRepositoryResponse, error)
@@ -64,13 +79,53 @@ type (
 		GetAllStudentAmbassadorWithPagination(ctx context.Context, tx *gorm.DB, req dto.PaginationRequest) (dto.StudentAmbassadorPaginationRepositoryResponse, error)
 		GetStudentAmbassadorByID(ctx context.Context, tx *gorm.DB, studentAmbassadorID string) (entity.StudentAmbassador, bool, error)
 		GetTicketFormByID(ctx context.Context, tx *gorm.DB, ticketFormID string) (entity.TicketForm, bool, error)
+		GetTicketFormByIDForUpdate(ctx context.Context, tx *gorm.DB, ticketFormID string) (entity.TicketForm, bool, error)
 		GetAllTicketForm(ctx context.Context, tx *gorm.DB, filter dto.CheckInFilterQuery) ([]entity.TicketForm, error)
+		GetAllTicketFormCandidate(ctx context.Context, tx *gorm.DB, filter dto.AttendeeLookupQuery) ([]entity.TicketForm, error)
 		GetAllTicketFormWithPagination(ctx context.Context, tx *gorm.DB, req dto.PaginationRequest, filter dto.CheckInFilterQuery) (dto.TicketFormPaginationRepositoryResponse, error)
-		GetAllTicketStats(ctx context.Context, tx *gorm.DB, ticketType string) (*dto.TicketTypeStatResponse, error)
+		GetEventStats(ctx context.Context, tx *gorm.DB, event entity.Event) (*dto.EventStatResponse, error)
 		GetTotalBundle(ctx context.Context, tx *gorm.DB, bundleType string) (int64, error)
 		GetTotalAdmin(ctx context.Context, tx *gorm.DB) (int64, error)
 		GetAllGuestStats(ctx context.Context, tx *gorm.DB) (*dto.GuestStatResponse, error)
 		GetTotalSponsor(ctx context.Context, tx *gorm.DB, sponsorType string) (int64, error)
+		GetAllWaitlist(ctx context.Context, tx *gorm.DB, filter dto.WaitlistFilterQuery) ([]entity.Waitlist, error)
+		GetAllWaitlistWithPagination(ctx context.Context, tx *gorm.DB, req dto.PaginationRequest, filter dto.WaitlistFilterQuery) (dto.WaitlistPaginationRepositoryResponse, error)
+		GetWaitlistByID(ctx context.Context, tx *gorm.DB, waitlistID string) (entity.Waitlist, bool, error)
+		GetTicketTransferByToTicketFormID(ctx context.Context, tx *gorm.DB, ticketFormID string) (entity.TicketTransfer, bool, error)
+		GetAllTicketTransferByOriginTicketFormID(ctx context.Context, tx *gorm.DB, originTicketFormID string) ([]entity.TicketTransfer, error)
+		GetEventByID(ctx context.Context, tx *gorm.DB, eventID string) (entity.Event, bool, error)
+		GetEventByName(ctx context.Context, tx *gorm.DB, name string) (entity.Event, bool, error)
+		GetAllEvent(ctx context.Context, tx *gorm.DB) ([]entity.Event, error)
+		GetAllEventWithPagination(ctx context.Context, tx *gorm.DB, req dto.PaginationRequest) (dto.EventPaginationRepositoryResponse, error)
+		GetEventByIDForUpdate(ctx context.Context, tx *gorm.DB, eventID string) (entity.Event, bool, error)
+		CountEventSeatAllocation(ctx context.Context, tx *gorm.DB, eventID string, excludeTicketID string) (int64, error)
+		GetEventSessionByID(ctx context.Context, tx *gorm.DB, eventSessionID string) (entity.EventSession, bool, error)
+		GetEventSessionByIDForUpdate(ctx context.Context, tx *gorm.DB, eventSessionID string) (entity.EventSession, bool, error)
+		CountEventSessionCheckIn(ctx context.Context, tx *gorm.DB, eventSessionID string, excludeTicketFormID string) (int64, error)
+		GetAllEventSessionByEventID(ctx context.Context, tx *gorm.DB, eventID string) ([]entity.EventSession, error)
+		GetGateByID(ctx context.Context, tx *gorm.DB, gateID string) (entity.Gate, bool, error)
+		GetGuestAttendanceByID(ctx context.Context, tx *gorm.DB, guestAttendanceID string) (entity.GuestAttendance, bool, error)
+		GetAllGuestAttendanceByTicketFormID(ctx context.Context, tx *gorm.DB, ticketFormID string) ([]entity.GuestAttendance, error)
+		GetAllGateByEventID(ctx context.Context, tx *gorm.DB, eventID string) ([]entity.Gate, error)
+		GetEventOccupancy(ctx context.Context, tx *gorm.DB, event entity.Event) (*dto.EventOccupancyResponse, error)
+		GetAllCrew(ctx context.Context, tx *gorm.DB, filter dto.CrewFilterQuery) ([]entity.User, error)
+		GetTransactionForPickup(ctx context.Context, tx *gorm.DB, transactionID string) (entity.Transaction, bool, error)
+		GetAllTicketFormForPickup(ctx context.Context, tx *gorm.DB, filter dto.MerchPickupFilterQuery) ([]entity.TicketForm, error)
+		GetTicketFormFieldByID(ctx context.Context, tx *gorm.DB, ticketFormFieldID string) (entity.TicketFormField, bool, error)
+		GetTicketFormFieldByTicketIDAndKey(ctx context.Context, tx *gorm.DB, ticketID, key string) (entity.TicketFormField, bool, error)
+		GetAllTicketFormFieldByTicketID(ctx context.Context, tx *gorm.DB, ticketID string) ([]entity.TicketFormField, error)
+		GetAllAttendeeForExport(ctx context.Context, tx *gorm.DB, filter dto.AttendeeExportFilterQuery) ([]entity.TicketForm, error)
+		GetAllEmailOutboxWithPagination(ctx context.Context, tx *gorm.DB, req dto.PaginationRequest, filter dto.EmailOutboxFilterQuery) (dto.EmailOutboxPaginationRepositoryResponse, error)
+		GetEmailOutboxByID(ctx context.Context, tx *gorm.DB, outboxID string) (entity.EmailOutbox, bool, error)
+		GetAllNotificationOutboxWithPagination(ctx context.Context, tx *gorm.DB, req dto.PaginationRequest, filter dto.NotificationOutboxFilterQuery) (dto.NotificationOutboxPaginationRepositoryResponse, error)
+		GetNotificationOutboxByID(ctx context.Context, tx *gorm.DB, outboxID string) (entity.NotificationOutbox, bool, error)
+		GetAllEmailTemplate(ctx context.Context, tx *gorm.DB) ([]entity.EmailTemplate, error)
+		GetEmailTemplateByID(ctx context.Context, tx *gorm.DB, emailTemplateID string) (entity.EmailTemplate, bool, error)
+		GetEmailTemplateByKey(ctx context.Context, tx *gorm.DB, key string) (entity.EmailTemplate, bool, error)
+		GetAllBroadcastRecipient(ctx context.Context, tx *gorm.DB, filter dto.BroadcastFilterRequest) ([]entity.TicketForm, error)
+		GetAllBroadcastWithPagination(ctx context.Context, tx *gorm.DB, req dto.PaginationRequest) (dto.BroadcastPaginationRepositoryResponse, error)
+		GetBroadcastByID(ctx context.Context, tx *gorm.DB, broadcastID string) (entity.Broadcast, bool, error)
+		CountEmailOutboxByBroadcastID(ctx context.Context, tx *gorm.DB, broadcastID string) (map[entity.EmailOutboxStatus]int64, error)
 
 		// UPDATE / PATCH
 		UpdateUser(ctx context.Context, tx *gorm.DB, user entity.User) error
@@ -81,6 +136,19 @@ type (
 		UpdateBundle(ctx context.Context, tx *gorm.DB, bundle entity.Bundle) error
 		UpdateTicketQuota(ctx context.Context, tx *gorm.DB, ticketID string, newQuota int) error
 		UpdateStudentAmbassador(ctx context.Context, tx *gorm.DB, studentAmbassador entity.StudentAmbassador) error
+		UpdateWaitlist(ctx context.Context, tx *gorm.DB, waitlist entity.Waitlist, fromStatus entity.WaitlistStatus) (bool, error)
+		RestoreTicketQuota(ctx context.Context, tx *gorm.DB, ticketID string, amount int) error
+		UpdateEvent(ctx context.Context, tx *gorm.DB, event entity.Event) error
+		UpdateEventSession(ctx context.Context, tx *gorm.DB, eventSession entity.EventSession) error
+		UpdateGate(ctx context.Context, tx *gorm.DB, gate entity.Gate) error
+		UpdateTicketFormField(ctx context.Context, tx *gorm.DB, ticketFormField entity.TicketFormField) error
+		UpdateTicketFormQR(ctx context.Context, tx *gorm.DB, ticketFormID string, qrVersion int, qrRevokedAt *time.Time) error
+		UpdateGuestAttendance(ctx context.Context, tx *gorm.DB, guestAttendance entity.GuestAttendance) error
+		ExpireCrew(ctx context.Context, tx *gorm.DB, req dto.ExpireCrewBulkRequest, expiresAt time.Time) (int64, error)
+		RetryEmailOutbox(ctx context.Context, tx *gorm.DB, outboxID string, nextAttemptAt time.Time) error
+		RetryNotificationOutbox(ctx context.Context, tx *gorm.DB, outboxID string, nextAttemptAt time.Time) error
+		UpdateEmailTemplate(ctx context.Context, tx *gorm.DB, emailTemplate entity.EmailTemplate) error
+		UpdateTicketFormEmail(ctx context.Context, tx *gorm.DB, ticketFormID, email string) error
 
 		// DELETE / DELETE
 		DeleteUserByID(ctx context.Context, tx *gorm.DB, userID string) error
@@ -93,6 +161,11 @@ type (
 		DeleteBundleByID(ctx context.Context, tx *gorm.DB, bundleID string) error
 		DeleteBundleItemsByBundleID(ctx context.Context, tx *gorm.DB, bundleID string) error
 		DeleteStudentAmbassadorByID(ctx context.Context, tx *gorm.DB, studentAmbassadorID string) error
+		DeleteEventByID(ctx context.Context, tx *gorm.DB, eventID string) error
+		DeleteEventSessionByID(ctx context.Context, tx *gorm.DB, eventSessionID string) error
+		DeleteGateByID(ctx context.Context, tx *gorm.DB, gateID string) error
+		DeleteTicketFormFieldByID(ctx context.Context, tx *gorm.DB, ticketFormFieldID string) error
+		DeleteEmailTemplateByID(ctx context.Context, tx *gorm.DB, emailTemplateID string) error
 	}
 
 	AdminRepository struct {
@@ -198,6 +271,76 @@ func (ar *AdminRepository) CreateGuestAttendance(ctx context.Context, tx *gorm.D
 
 	return tx.WithContext(ctx).Create(&guestAttendance).Error
 }
+func (ar *AdminRepository) CreateCheckInScan(ctx context.Context, tx *gorm.DB, checkInScan entity.CheckInScan) error {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	return tx.WithContext(ctx).Create(&checkInScan).Error
+}
+func (ar *AdminRepository) CreateMerchPickup(ctx context.Context, tx *gorm.DB, merchPickup entity.MerchPickup) error {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	return tx.WithContext(ctx).Omit(clause.Associations).Create(&merchPickup).Error
+}
+func (ar *AdminRepository) CreateEvent(ctx context.Context, tx *gorm.DB, event entity.Event) error {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	return tx.WithContext(ctx).Create(&event).Error
+}
+func (ar *AdminRepository) CreateEventSession(ctx context.Context, tx *gorm.DB, eventSession entity.EventSession) error {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	return tx.WithContext(ctx).Create(&eventSession).Error
+}
+func (ar *AdminRepository) CreateGate(ctx context.Context, tx *gorm.DB, gate entity.Gate) error {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	return tx.WithContext(ctx).Create(&gate).Error
+}
+func (ar *AdminRepository) CreateTicketFormField(ctx context.Context, tx *gorm.DB, ticketFormField entity.TicketFormField) error {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	return tx.WithContext(ctx).Create(&ticketFormField).Error
+}
+func (ar *AdminRepository) CreateEmailOutbox(ctx context.Context, tx *gorm.DB, outbox entity.EmailOutbox) error {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	return tx.WithContext(ctx).Create(&outbox).Error
+}
+func (ar *AdminRepository) CreateNotificationOutbox(ctx context.Context, tx *gorm.DB, outbox entity.NotificationOutbox) error {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	return tx.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&outbox).Error
+}
+func (ar *AdminRepository) CreateEmailTemplate(ctx context.Context, tx *gorm.DB, emailTemplate entity.EmailTemplate) error {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	return tx.WithContext(ctx).Create(&emailTemplate).Error
+}
+func (ar *AdminRepository) CreateBroadcast(ctx context.Context, tx *gorm.DB, broadcast entity.Broadcast) error {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	return tx.WithContext(ctx).Create(&broadcast).Error
+}
 
 // READ / GET
 func (ar *AdminRepository) GetUserByID(ctx context.Context, tx *gorm.DB, userID string) (entity.User, bool, error) {
@@ -300,7 +443,28 @@ func (ar *AdminRepository) GetTicketByID(ctx context.Context, tx *gorm.DB, ticke
 	}
 
 	var ticket entity.Ticket
-	if err := tx.WithContext(ctx).Where("id = ?", ticketID).Take(&ticket).Error; err != nil {
+	if err := tx.WithContext(ctx).
+		Preload("Event").
+		Preload("FormFields", func(db *gorm.DB) *gorm.DB { return db.Order("position ASC") }).
+		Where("id = ?", ticketID).
+		Take(&ticket).Error; err != nil {
+		return entity.Ticket{}, false, err
+	}
+
+	return ticket, true, nil
+}
+func (ar *AdminRepository) GetTicketByIDForUpdate(ctx context.Context, tx *gorm.DB, ticketID string) (entity.Ticket, bool, error) {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	var ticket entity.Ticket
+	if err := tx.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Preload("Event").
+		Preload("FormFields", func(db *gorm.DB) *gorm.DB { return db.Order("position ASC") }).
+		Where("id = ?", ticketID).
+		Take(&ticket).Error; err != nil {
 		return entity.Ticket{}, false, err
 	}
 
@@ -328,7 +492,7 @@ func (ar *AdminRepository) GetAllTicket(ctx context.Context, tx *gorm.DB) ([]ent
 		err     error
 	)
 
-	query := tx.WithContext(ctx).Model(&entity.Ticket{})
+	query := tx.WithContext(ctx).Model(&entity.Ticket{}).Preload("Event")
 
 	if err := query.Order(`"createdAt" DESC`).Find(&tickets).Error; err != nil {
 		return []entity.Ticket{}, err
@@ -353,7 +517,7 @@ func (ar *AdminRepository) GetAllTicketWithPagination(ctx context.Context, tx *g
 		req.Page = 1
 	}
 
-	query := tx.WithContext(ctx).Model(&entity.Ticket{})
+	query := tx.WithContext(ctx).Model(&entity.Ticket{}).Preload("Event")
 
 	if req.Search != "" {
 		searchValue := "%" + strings.ToLower(req.Search) + "%"
@@ -672,7 +836,7 @@ func (ar *AdminRepository) GetAllBundle(ctx context.Context, tx *gorm.DB) ([]ent
 		err     error
 	)
 
-	query := tx.WithContext(ctx).Model(&entity.Bundle{}).Preload("BundleItems.Merch")
+	query := tx.WithContext(ctx).Model(&entity.Bundle{}).Preload("Event").Preload("BundleItems.Merch")
 
 	if err := query.Order(`"createdAt" DESC`).Find(&bundles).Error; err != nil {
 		return []entity.Bundle{}, err
@@ -697,7 +861,7 @@ func (ar *AdminRepository) GetAllBundleWithPagination(ctx context.Context, tx *g
 		req.Page = 1
 	}
 
-	query := tx.WithContext(ctx).Model(&entity.Bundle{}).Preload("BundleItems.Merch")
+	query := tx.WithContext(ctx).Model(&entity.Bundle{}).Preload("Event").Preload("BundleItems.Merch")
 
 	if req.Search != "" {
 		searchValue := "%" + strings.ToLower(req.Search) + "%"
@@ -730,7 +894,7 @@ func (ar *AdminRepository) GetBundleByID(ctx context.Context, tx *gorm.DB, bundl
 	}
 
 	var bundle entity.Bundle
-	if err := tx.WithContext(ctx).Preload("BundleItems.Merch.MerchImages").Where("id = ?", bundleID).Take(&bundle).Error; err != nil {
+	if err := tx.WithContext(ctx).Preload("Event").Preload("BundleItems.Merch.MerchImages").Where("id = ?", bundleID).Take(&bundle).Error; err != nil {
 		return entity.Bundle{}, false, err
 	}
 
@@ -838,7 +1002,7 @@ func (ar *AdminRepository) GetTransactionByID(ctx context.Context, tx *gorm.DB,
 	}
 
 	var transaction entity.Transaction
-	if err := tx.WithContext(ctx).Preload("TicketForms").Preload("Ticket").Preload("Bundle").Where("id = ?", transactionID).Take(&transaction).Error; err != nil {
+	if err := tx.WithContext(ctx).Preload("TicketForms").Preload("Ticket.Event").Preload("Bundle.Event").Preload("User").Where("id = ?", transactionID).Take(&transaction).Error; err != nil {
 		return entity.Transaction{}, false, err
 	}
 
@@ -936,7 +1100,24 @@ func (ar *AdminRepository) GetTicketFormByID(ctx context.Context, tx *gorm.DB, t
 	}
 
 	var ticketForm entity.TicketForm
-	if err := tx.WithContext(ctx).Preload("GuestAttendances").Preload("Transaction.Ticket").Where("id = ?", ticketFormID).Take(&ticketForm).Error; err != nil {
+	if err := tx.WithContext(ctx).Preload("GuestAttendances", ActiveGuestAttendances).Preload("GuestAttendances.CheckedByUser").Preload("Transaction.Ticket.Event").Where("id = ?", ticketFormID).Take(&ticketForm).Error; err != nil {
+		return entity.TicketForm{}, false, err
+	}
+
+	return ticketForm, true, nil
+}
+
+// GetTicketFormByIDForUpdate hanya mengunci baris ticket form, preload tidak ikut terkunci.
+func (ar *AdminRepository) GetTicketFormByIDForUpdate(ctx context.Context, tx *gorm.DB, ticketFormID string) (entity.TicketForm, bool, error) {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	var ticketForm entity.TicketForm
+	if err := tx.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).Preload("GuestAttendances", ActiveGuestAttendances).Preload("Transaction.Ticket.Event").Where("id = ?", ticketFormID).Take(&ticketForm).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.TicketForm{}, false, nil
+		}
 		return entity.TicketForm{}, false, err
 	}
 
@@ -951,9 +1132,11 @@ func (ar *AdminRepository) GetAllTicketForm(ctx context.Context, tx *gorm.DB, fi
 
 	query := tx.WithContext(ctx).
 		Model(&entity.TicketForm{}).
-		Joins("JOIN guest_attendances ON guest_attendances.ticket_form_id = ticket_forms.id").
-		Preload("GuestAttendances").
-		Preload("Transaction.Ticket")
+		Where(`EXISTS (SELECT 1 FROM guest_attendances ga WHERE ga.ticket_form_id = ticket_forms.id AND ga.voided_at IS NULL AND ga."deletedAt" IS NULL)`).
+		Where("ticket_forms.transferred_to_id IS NULL").
+		Preload("GuestAttendances", ActiveGuestAttendances).
+		Preload("GuestAttendances.CheckedByUser").
+		Preload("Transaction.Ticket.Event")
 
 	// --- Apply Filter ---
 	if filter.Search != "" {
@@ -961,18 +1144,25 @@ func (ar *AdminRepository) GetAllTicketForm(ctx context.Context, tx *gorm.DB, fi
 		query = query.Where("ticket_forms.full_name ILIKE ? OR ticket_forms.email ILIKE ? OR ticket_forms.phone_number ILIKE ?", search, search, search)
 	}
 
-	if filter.TicketType != "" {
-		// diasumsikan TicketType ada di Transaction.Ticket
+	if filter.TicketType != "" || filter.EventID != "" {
+		// diasumsikan TicketType dan EventID ada di Transaction.Ticket
 		query = query.Joins("JOIN transactions ON transactions.id = ticket_forms.transaction_id").
-			Joins("JOIN tickets ON tickets.id = transactions.ticket_id").
-			Where("tickets.type = ?", filter.TicketType)
+			Joins("JOIN tickets ON tickets.id = transactions.ticket_id")
+	}
+
+	if filter.TicketType != "" {
+		query = query.Where("tickets.type = ?", filter.TicketType)
+	}
+
+	if filter.EventID != "" {
+		query = query.Where("tickets.event_id = ?", filter.EventID)
 	}
 
 	if filter.Status != "" {
 		if filter.Status == "true" {
-			query = query.Where("EXISTS (SELECT 1 FROM guest_attendances ga WHERE ga.ticket_form_id = ticket_forms.id)")
+			query = query.Where("EXISTS (SELECT 1 FROM guest_attendances ga WHERE ga.ticket_form_id = ticket_forms.id AND ga.voided_at IS NULL)")
 		} else if filter.Status == "false" {
-			query = query.Where("NOT EXISTS (SELECT 1 FROM guest_attendances ga WHERE ga.ticket_form_id = ticket_forms.id)")
+			query = query.Where("NOT EXISTS (SELECT 1 FROM guest_attendances ga WHERE ga.ticket_form_id = ticket_forms.id AND ga.voided_at IS NULL)")
 		}
 	}
 
@@ -986,6 +1176,75 @@ func (ar *AdminRepository) GetAllTicketForm(ctx context.Context, tx *gorm.DB, fi
 
 	return ticketForms, nil
 }
+func ticketFormCandidateQuery(ctx context.Context, tx *gorm.DB, filter dto.AttendeeLookupQuery) *gorm.DB {
+	query := tx.WithContext(ctx).
+		Model(&entity.TicketForm{}).
+		Joins("JOIN transactions ON transactions.id = ticket_forms.transaction_id").
+		Joins("JOIN tickets ON tickets.id = transactions.ticket_id").
+		Where("transactions.transaction_status = ?", "settlement").
+		Where("ticket_forms.transferred_to_id IS NULL").
+		Preload("GuestAttendances", ActiveGuestAttendances).
+		Preload("Transaction.Ticket.Event")
+
+	if filter.EventID != "" {
+		query = query.Where("tickets.event_id = ?", filter.EventID)
+	}
+
+	return query
+}
+
+// GetAllTicketFormCandidate: kecocokan persis tidak dibatasi, hanya kandidat mirip yang kena limit.
+func (ar *AdminRepository) GetAllTicketFormCandidate(ctx context.Context, tx *gorm.DB, filter dto.AttendeeLookupQuery) ([]entity.TicketForm, error) {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	var exact []entity.TicketForm
+
+	exactConditions := tx.Where("LOWER(ticket_forms.email) = LOWER(?)", filter.Search).
+		Or("LOWER(transactions.order_id) = LOWER(?)", filter.Search).
+		Or("LOWER(ticket_forms.full_name) = LOWER(?)", filter.Search)
+
+	if filter.Phone != "" {
+		exactConditions = exactConditions.Or("ticket_forms.phone_number LIKE ?", "%"+escapeLike(filter.Phone))
+	}
+
+	if err := ticketFormCandidateQuery(ctx, tx, filter).Where(exactConditions).Find(&exact).Error; err != nil {
+		return nil, err
+	}
+
+	var similar []entity.TicketForm
+
+	search := "%" + escapeLike(filter.Search) + "%"
+	conditions := tx.Where("ticket_forms.email ILIKE ?", search).
+		Or("transactions.order_id ILIKE ?", search).
+		Or("ticket_forms.full_name ILIKE ?", search)
+
+	// potongan awal tiap kata supaya nama yang typo tetap masuk kandidat
+	for _, word := range strings.Fields(filter.Search) {
+		if runes := []rune(word); len(runes) > 3 {
+			word = string(runes[:3])
+		}
+		conditions = conditions.Or("ticket_forms.full_name ILIKE ?", "%"+escapeLike(word)+"%")
+	}
+
+	if err := ticketFormCandidateQuery(ctx, tx, filter).Where(conditions).Order(`ticket_forms."createdAt" DESC`).Limit(constants.ENUM_ATTENDEE_LOOKUP_CANDIDATE_LIMIT).Find(&similar).Error; err != nil {
+		return nil, err
+	}
+
+	ticketForms := exact
+	seen := make(map[uuid.UUID]bool, len(exact))
+	for _, ticketForm := range exact {
+		seen[ticketForm.ID] = true
+	}
+	for _, ticketForm := range similar {
+		if !seen[ticketForm.ID] {
+			ticketForms = append(ticketForms, ticketForm)
+		}
+	}
+
+	return ticketForms, nil
+}
 func (ar *AdminRepository) GetAllTicketFormWithPagination(ctx context.Context, tx *gorm.DB, req dto.PaginationRequest, filter dto.CheckInFilterQuery) (dto.TicketFormPaginationRepositoryResponse, error) {
 	if tx == nil {
 		tx = ar.db
@@ -1005,9 +1264,10 @@ func (ar *AdminRepository) GetAllTicketFormWithPagination(ctx context.Context, t
 
 	query := tx.WithContext(ctx).
 		Model(&entity.TicketForm{}).
-		Joins("LEFT JOIN guest_attendances ON guest_attendances.ticket_form_id = ticket_forms.id").
+		Where("ticket_forms.transferred_to_id IS NULL").
+		Preload("GuestAttendances", ActiveGuestAttendances).
 		Preload("GuestAttendances.CheckedByUser").
-		Preload("Transaction.Ticket")
+		Preload("Transaction.Ticket.Event")
 
 	// --- Apply Filters (CheckInFilterQuery) ---
 	if filter.Search != "" {
@@ -1018,18 +1278,25 @@ func (ar *AdminRepository) GetAllTicketFormWithPagination(ctx context.Context, t
 		)
 	}
 
-	if filter.TicketType != "" {
+	if filter.TicketType != "" || filter.EventID != "" {
 		query = query.
 			Joins("JOIN transactions ON transactions.id = ticket_forms.transaction_id").
-			Joins("JOIN tickets ON tickets.id = transactions.ticket_id").
-			Where("tickets.type = ?", filter.TicketType)
+			Joins("JOIN tickets ON tickets.id = transactions.ticket_id")
+	}
+
+	if filter.TicketType != "" {
+		query = query.Where("tickets.type = ?", filter.TicketType)
+	}
+
+	if filter.EventID != "" {
+		query = query.Where("tickets.event_id = ?", filter.EventID)
 	}
 
 	if filter.Status != "" {
 		if filter.Status == "true" {
-			query = query.Where("EXISTS (SELECT 1 FROM guest_attendances ga WHERE ga.ticket_form_id = ticket_forms.id)")
+			query = query.Where("EXISTS (SELECT 1 FROM guest_attendances ga WHERE ga.ticket_form_id = ticket_forms.id AND ga.voided_at IS NULL)")
 		} else if filter.Status == "false" {
-			query = query.Where("NOT EXISTS (SELECT 1 FROM guest_attendances ga WHERE ga.ticket_form_id = ticket_forms.id)")
+			query = query.Where("NOT EXISTS (SELECT 1 FROM guest_attendances ga WHERE ga.ticket_form_id = ticket_forms.id AND ga.voided_at IS NULL)")
 		}
 	}
 
@@ -1058,18 +1325,22 @@ func (ar *AdminRepository) GetAllTicketFormWithPagination(ctx context.Context, t
 		},
 	}, nil
 }
-func (ar *AdminRepository) GetAllTicketStats(ctx context.Context, tx *gorm.DB, ticketType string) (*dto.TicketTypeStatResponse, error) {
+func (ar *AdminRepository) GetEventStats(ctx context.Context, tx *gorm.DB, event entity.Event) (*dto.EventStatResponse, error) {
 	if tx == nil {
 		tx = ar.db
 	}
 
-	stat := &dto.TicketTypeStatResponse{}
+	stat := &dto.EventStatResponse{
+		EventID:   event.ID,
+		EventName: event.Name,
+		Capacity:  event.Capacity,
+	}
 
 	// total ticket (quota)
 	if err := tx.WithContext(ctx).
 		Model(&entity.Ticket{}).
 		Select("COALESCE(SUM(quota),0)").
-		Where("type = ?", ticketType).
+		Where("event_id = ?", event.ID).
 		Scan(&stat.TotalTicket).Error; err != nil {
 		return stat, err
 	}
@@ -1080,7 +1351,7 @@ func (ar *AdminRepository) GetAllTicketStats(ctx context.Context, tx *gorm.DB, t
 		Select("COUNT(DISTINCT transactions.id)").
 		Joins("JOIN tickets ON transactions.ticket_id = tickets.id").
 		Joins("JOIN ticket_forms ON ticket_forms.transaction_id = transactions.id").
-		Where("tickets.type = ? AND transactions.transaction_status = ? AND ticket_forms.audience_type != ?", ticketType, "settlement", "invited").
+		Where("tickets.event_id = ? AND transactions.transaction_status = ? AND ticket_forms.audience_type != ?", event.ID, "settlement", "invited").
 		Count(&stat.TotalTransaction).Error; err != nil {
 		return stat, err
 	}
@@ -1090,7 +1361,8 @@ func (ar *AdminRepository) GetAllTicketStats(ctx context.Context, tx *gorm.DB, t
 		Model(&entity.TicketForm{}).
 		Joins("JOIN transactions ON ticket_forms.transaction_id = transactions.id").
 		Joins("JOIN tickets ON transactions.ticket_id = tickets.id").
-		Where("tickets.type = ? AND transactions.transaction_status = ? AND ticket_forms.audience_type != ? ", ticketType, "settlement", "invited").
+		Where("tickets.event_id = ? AND transactions.transaction_status = ? AND ticket_forms.audience_type != ? ", event.ID, "settlement", "invited").
+		Where("ticket_forms.transferred_to_id IS NULL").
 		Count(&stat.TicketSold).Error; err != nil {
 		return stat, err
 	}
@@ -1100,11 +1372,23 @@ func (ar *AdminRepository) GetAllTicketStats(ctx context.Context, tx *gorm.DB, t
 		Model(&entity.Transaction{}).
 		Select("COALESCE(SUM(gross_amount),0)").
 		Joins("JOIN tickets ON transactions.ticket_id = tickets.id").
-		Where("tickets.type = ? AND transactions.transaction_status = ?", ticketType, "settlement").
+		Where("tickets.event_id = ? AND transactions.transaction_status = ?", event.ID, "settlement").
 		Scan(&stat.Revenue).Error; err != nil {
 		return stat, err
 	}
 
+	// total check-in
+	if err := tx.WithContext(ctx).
+		Model(&entity.GuestAttendance{}).
+		Joins("JOIN ticket_forms ON guest_attendances.ticket_form_id = ticket_forms.id").
+		Joins("JOIN transactions ON ticket_forms.transaction_id = transactions.id").
+		Joins("JOIN tickets ON transactions.ticket_id = tickets.id").
+		Where("tickets.event_id = ? AND guest_attendances.voided_at IS NULL", event.ID).
+		Distinct("guest_attendances.ticket_form_id").
+		Count(&stat.TotalCheckIn).Error; err != nil {
+		return stat, err
+	}
+
 	return stat, nil
 }
 func (ar *AdminRepository) GetTotalBundle(ctx context.Context, tx *gorm.DB, bundleType string) (int64, error) {
@@ -1151,6 +1435,7 @@ func (ar *AdminRepository) GetAllGuestStats(ctx context.Context, tx *gorm.DB) (*
 	// total guest (quota)
 	if err := tx.WithContext(ctx).
 		Model(&entity.TicketForm{}).
+		Where("transferred_to_id IS NULL").
 		Count(&stat.Total).Error; err != nil {
 		return stat, err
 	}
@@ -1158,7 +1443,8 @@ func (ar *AdminRepository) GetAllGuestStats(ctx context.Context, tx *gorm.DB) (*
 	// total check-in guest (unique guest yang sudah pernah check-in)
 	if err := tx.WithContext(ctx).
 		Model(&entity.GuestAttendance{}).
-		Select("COUNT(DISTINCT id)").
+		Select("COUNT(DISTINCT ticket_form_id)").
+		Where("voided_at IS NULL").
 		Scan(&stat.TotalCheckInGuest).Error; err != nil {
 		return stat, err
 	}
@@ -1169,7 +1455,7 @@ func (ar *AdminRepository) GetAllGuestStats(ctx context.Context, tx *gorm.DB) (*
 	// total invited guest
 	if err := tx.WithContext(ctx).
 		Model(&entity.TicketForm{}).
-		Where("audience_type = ?", "invited").
+		Where("audience_type = ? AND transferred_to_id IS NULL", "invited").
 		Count(&stat.TotalInvitedGuest).Error; err != nil {
 		return stat, err
 	}
@@ -1193,146 +1479,1200 @@ func (ar *AdminRepository) GetTotalSponsor(ctx context.Context, tx *gorm.DB, spo
 
 	return total, nil
 }
-
-// UPDATE / PATCH
-func (ar *AdminRepository) UpdateUser(ctx context.Context, tx *gorm.DB, user entity.User) error {
+func (ar *AdminRepository) GetAllWaitlist(ctx context.Context, tx *gorm.DB, filter dto.WaitlistFilterQuery) ([]entity.Waitlist, error) {
 	if tx == nil {
 		tx = ar.db
 	}
 
-	return tx.WithContext(ctx).Where("id = ?", user.ID).Updates(&user).Error
-}
-func (ar *AdminRepository) UpdateTicket(ctx context.Context, tx *gorm.DB, ticket entity.Ticket) error {
-	if tx == nil {
-		tx = ar.db
+	var (
+		waitlists []entity.Waitlist
+		err       error
+	)
+
+	query := tx.WithContext(ctx).Model(&entity.Waitlist{}).Preload("User").Preload("Ticket")
+
+	if filter.TicketID != "" {
+		query = query.Where("ticket_id = ?", filter.TicketID)
 	}
 
-	return tx.WithContext(ctx).Where("id = ?", ticket.ID).Updates(&ticket).Error
-}
-func (ar *AdminRepository) UpdateSponsorship(ctx context.Context, tx *gorm.DB, sponsorship entity.Sponsorship) error {
-	if tx == nil {
-		tx = ar.db
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
 	}
 
-	return tx.WithContext(ctx).Where("id = ?", sponsorship.ID).Updates(&sponsorship).Error
-}
-func (ar *AdminRepository) UpdateSpeaker(ctx context.Context, tx *gorm.DB, speaker entity.Speaker) error {
-	if tx == nil {
-		tx = ar.db
+	if err := query.Order(`"createdAt" ASC`).Find(&waitlists).Error; err != nil {
+		return []entity.Waitlist{}, err
 	}
 
-	return tx.WithContext(ctx).Where("id = ?", speaker.ID).Updates(&speaker).Error
+	return waitlists, err
 }
-func (ar *AdminRepository) UpdateMerch(ctx context.Context, tx *gorm.DB, merch entity.Merch) error {
+func (ar *AdminRepository) GetAllWaitlistWithPagination(ctx context.Context, tx *gorm.DB, req dto.PaginationRequest, filter dto.WaitlistFilterQuery) (dto.WaitlistPaginationRepositoryResponse, error) {
 	if tx == nil {
 		tx = ar.db
 	}
 
-	return tx.WithContext(ctx).Where("id = ?", merch.ID).Save(&merch).Error
-}
-func (ar *AdminRepository) UpdateBundle(ctx context.Context, tx *gorm.DB, bundle entity.Bundle) error {
-	if tx == nil {
-		tx = ar.db
+	var (
+		waitlists []entity.Waitlist
+		err       error
+		count     int64
+	)
+
+	if req.PerPage == 0 {
+		req.PerPage = 10
 	}
 
-	return tx.WithContext(ctx).Where("id = ?", bundle.ID).Save(&bundle).Error
-}
-func (ar *AdminRepository) UpdateTicketQuota(ctx context.Context, tx *gorm.DB, ticketID string, newQuota int) error {
-	if tx == nil {
-		tx = ar.db
+	if req.Page == 0 {
+		req.Page = 1
 	}
 
-	result := tx.WithContext(ctx).
-		Model(&entity.Ticket{}).
-		Where("id = ?", ticketID).
-		Update("quota", newQuota)
+	query := tx.WithContext(ctx).Model(&entity.Waitlist{}).Joins("JOIN users ON users.id = waitlists.user_id").Preload("User").Preload("Ticket")
 
-	if result.Error != nil {
-		return result.Error
+	if filter.TicketID != "" {
+		query = query.Where("waitlists.ticket_id = ?", filter.TicketID)
 	}
 
-	if result.RowsAffected == 0 {
-		return errors.New("ticket not found or no change made")
+	if filter.Status != "" {
+		query = query.Where("waitlists.status = ?", filter.Status)
 	}
 
-	return nil
-}
-func (ar *AdminRepository) UpdateStudentAmbassador(ctx context.Context, tx *gorm.DB, studentAmbassador entity.StudentAmbassador) error {
-	if tx == nil {
-		tx = ar.db
+	if req.Search != "" {
+		searchValue := "%" + strings.ToLower(req.Search) + "%"
+		query = query.Where("LOWER(users.name) LIKE ? OR LOWER(users.email) LIKE ?", searchValue, searchValue)
 	}
 
-	return tx.WithContext(ctx).Where("id = ?", studentAmbassador.ID).Save(&studentAmbassador).Error
-}
+	if err := query.Count(&count).Error; err != nil {
+		return dto.WaitlistPaginationRepositoryResponse{}, err
+	}
 
-// DELETE / DELETE
-func (ar *AdminRepository) DeleteUserByID(ctx context.Context, tx *gorm.DB, userID string) error {
-	if tx == nil {
-		tx = ar.db
+	if err := query.Order(`waitlists."createdAt" ASC`).Scopes(Paginate(req.Page, req.PerPage)).Find(&waitlists).Error; err != nil {
+		return dto.WaitlistPaginationRepositoryResponse{}, err
 	}
 
-	return tx.WithContext(ctx).Where("id = ?", userID).Delete(&entity.User{}).Error
+	totalPage := int64(math.Ceil(float64(count) / float64(req.PerPage)))
+
+	return dto.WaitlistPaginationRepositoryResponse{
+		Waitlists: waitlists,
+		PaginationResponse: dto.PaginationResponse{
+			Page:    req.Page,
+			PerPage: req.PerPage,
+			MaxPage: totalPage,
+			Count:   count,
+		},
+	}, err
 }
-func (ar *AdminRepository) DeleteTicketByID(ctx context.Context, tx *gorm.DB, ticketID string) error {
+func (ar *AdminRepository) GetWaitlistByID(ctx context.Context, tx *gorm.DB, waitlistID string) (entity.Waitlist, bool, error) {
 	if tx == nil {
 		tx = ar.db
 	}
 
-	return tx.WithContext(ctx).Where("id = ?", ticketID).Delete(&entity.Ticket{}).Error
-}
-func (ar *AdminRepository) DeleteSponsorshipByID(ctx context.Context, tx *gorm.DB, sponsorshipID string) error {
-	if tx == nil {
-		tx = ar.db
+	var waitlist entity.Waitlist
+	if err := tx.WithContext(ctx).Preload("User").Preload("Ticket").Where("id = ?", waitlistID).Take(&waitlist).Error; err != nil {
+		return entity.Waitlist{}, false, err
 	}
 
-	return tx.WithContext(ctx).Where("id = ?", sponsorshipID).Delete(&entity.Sponsorship{}).Error
+	return waitlist, true, nil
 }
-func (ar *AdminRepository) DeleteSpeakerByID(ctx context.Context, tx *gorm.DB, speakerID string) error {
+func (ar *AdminRepository) GetTicketTransferByToTicketFormID(ctx context.Context, tx *gorm.DB, ticketFormID string) (entity.TicketTransfer, bool, error) {
 	if tx == nil {
 		tx = ar.db
 	}
 
-	return tx.WithContext(ctx).Where("id = ?", speakerID).Delete(&entity.Speaker{}).Error
-}
-func (ar *AdminRepository) DeleteMerchImageByID(ctx context.Context, tx *gorm.DB, merchImageID string) error {
-	if tx == nil {
-		tx = ar.db
+	var ticketTransfer entity.TicketTransfer
+	if err := tx.WithContext(ctx).Where("to_ticket_form_id = ?", ticketFormID).Take(&ticketTransfer).Error; err != nil {
+		return entity.TicketTransfer{}, false, err
 	}
 
-	return tx.WithContext(ctx).Where("id = ?", merchImageID).Delete(&entity.MerchImage{}).Error
+	return ticketTransfer, true, nil
 }
-func (ar *AdminRepository) DeleteMerchByID(ctx context.Context, tx *gorm.DB, merchID string) error {
+func (ar *AdminRepository) GetAllTicketTransferByOriginTicketFormID(ctx context.Context, tx *gorm.DB, originTicketFormID string) ([]entity.TicketTransfer, error) {
 	if tx == nil {
 		tx = ar.db
 	}
 
-	return tx.WithContext(ctx).Where("id = ?", merchID).Delete(&entity.Merch{}).Error
+	var ticketTransfers []entity.TicketTransfer
+	if err := tx.WithContext(ctx).Where("origin_ticket_form_id = ?", originTicketFormID).Order(`"createdAt" ASC`).Find(&ticketTransfers).Error; err != nil {
+		return []entity.TicketTransfer{}, err
+	}
+
+	return ticketTransfers, nil
 }
-func (ar *AdminRepository) DeleteMerchImagesByMerchID(ctx context.Context, tx *gorm.DB, merchID string) error {
+func (ar *AdminRepository) GetEventByID(ctx context.Context, tx *gorm.DB, eventID string) (entity.Event, bool, error) {
 	if tx == nil {
 		tx = ar.db
 	}
 
-	return tx.WithContext(ctx).Where("merch_id = ?", merchID).Delete(&entity.MerchImage{}).Error
+	var event entity.Event
+	if err := tx.WithContext(ctx).
+		Preload("Sessions", func(db *gorm.DB) *gorm.DB { return db.Order("start_at ASC") }).
+		Preload("Tickets").
+		Preload("Gates", func(db *gorm.DB) *gorm.DB { return db.Order("name ASC") }).
+		Where("id = ?", eventID).
+		Take(&event).Error; err != nil {
+		return entity.Event{}, false, err
+	}
+
+	return event, true, nil
 }
-func (ar *AdminRepository) DeleteBundleByID(ctx context.Context, tx *gorm.DB, bundleID string) error {
+func (ar *AdminRepository) GetEventByName(ctx context.Context, tx *gorm.DB, name string) (entity.Event, bool, error) {
 	if tx == nil {
 		tx = ar.db
 	}
 
-	return tx.WithContext(ctx).Where("id = ?", bundleID).Delete(&entity.Bundle{}).Error
+	var event entity.Event
+	if err := tx.WithContext(ctx).Where("name = ?", name).Take(&event).Error; err != nil {
+		return entity.Event{}, false, err
+	}
+
+	return event, true, nil
 }
-func (ar *AdminRepository) DeleteBundleItemsByBundleID(ctx context.Context, tx *gorm.DB, bundleID string) error {
+func (ar *AdminRepository) GetAllEvent(ctx context.Context, tx *gorm.DB) ([]entity.Event, error) {
 	if tx == nil {
 		tx = ar.db
 	}
 
-	return tx.WithContext(ctx).Where("bundle_id = ?", bundleID).Delete(&entity.BundleItem{}).Error
+	var (
+		events []entity.Event
+		err    error
+	)
+
+	query := tx.WithContext(ctx).Model(&entity.Event{}).Preload("Sessions", func(db *gorm.DB) *gorm.DB { return db.Order("start_at ASC") })
+
+	if err := query.Order("start_at ASC").Find(&events).Error; err != nil {
+		return []entity.Event{}, err
+	}
+
+	return events, err
 }
-func (ar *AdminRepository) DeleteStudentAmbassadorByID(ctx context.Context, tx *gorm.DB, studentAmbassadorID string) error {
+func (ar *AdminRepository) GetAllEventWithPagination(ctx context.Context, tx *gorm.DB, req dto.PaginationRequest) (dto.EventPaginationRepositoryResponse, error) {
 	if tx == nil {
 		tx = ar.db
 	}
 
-	return tx.WithContext(ctx).Where("id = ?", studentAmbassadorID).Delete(&entity.StudentAmbassador{}).Error
+	var (
+		events []entity.Event
+		err    error
+		count  int64
+	)
+
+	if req.PerPage == 0 {
+		req.PerPage = 10
+	}
+
+	if req.Page == 0 {
+		req.Page = 1
+	}
+
+	query := tx.WithContext(ctx).Model(&entity.Event{}).Preload("Sessions", func(db *gorm.DB) *gorm.DB { return db.Order("start_at ASC") })
+
+	if req.Search != "" {
+		searchValue := "%" + strings.ToLower(req.Search) + "%"
+		query = query.Where("LOWER(name) LIKE ? OR LOWER(venue) LIKE ?", searchValue, searchValue)
+	}
+
+	if err := query.Count(&count).Error; err != nil {
+		return dto.EventPaginationRepositoryResponse{}, err
+	}
+
+	if err := query.Order("start_at ASC").Scopes(Paginate(req.Page, req.PerPage)).Find(&events).Error; err != nil {
+		return dto.EventPaginationRepositoryResponse{}, err
+	}
+
+	totalPage := int64(math.Ceil(float64(count) / float64(req.PerPage)))
+
+	return dto.EventPaginationRepositoryResponse{
+		Events: events,
+		PaginationResponse: dto.PaginationResponse{
+			Page:    req.Page,
+			PerPage: req.PerPage,
+			MaxPage: totalPage,
+			Count:   count,
+		},
+	}, err
+}
+func (ar *AdminRepository) GetEventByIDForUpdate(ctx context.Context, tx *gorm.DB, eventID string) (entity.Event, bool, error) {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	var event entity.Event
+	if err := tx.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", eventID).Take(&event).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.Event{}, false, nil
+		}
+		return entity.Event{}, false, err
+	}
+
+	return event, true, nil
+}
+
+// CountEventSeatAllocation: sisa quota ticket dan bundle, kursi transaksi aktif, dan offer waitlist.
+func (ar *AdminRepository) CountEventSeatAllocation(ctx context.Context, tx *gorm.DB, eventID string, excludeTicketID string) (int64, error) {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	var quota int64
+	query := tx.WithContext(ctx).Model(&entity.Ticket{}).Select("COALESCE(SUM(quota), 0)").Where("event_id = ?", eventID)
+	if excludeTicketID != "" {
+		query = query.Where("id <> ?", excludeTicketID)
+	}
+	if err := query.Scan(&quota).Error; err != nil {
+		return 0, err
+	}
+
+	var bundleQuota int64
+	if err := tx.WithContext(ctx).Model(&entity.Bundle{}).Select("COALESCE(SUM(quota), 0)").Where("event_id = ?", eventID).Scan(&bundleQuota).Error; err != nil {
+		return 0, err
+	}
+
+	var held int64
+	if err := tx.WithContext(ctx).
+		Model(&entity.TicketForm{}).
+		Joins("JOIN transactions ON transactions.id = ticket_forms.transaction_id").
+		Joins("LEFT JOIN tickets ON tickets.id = transactions.ticket_id").
+		Joins("LEFT JOIN bundles ON bundles.id = transactions.bundle_id").
+		Where("COALESCE(tickets.event_id, bundles.event_id) = ?", eventID).
+		Where("ticket_forms.transferred_to_id IS NULL").
+		Where("COALESCE(transactions.transaction_status, '') IN ?", []string{"", "pending", "settlement"}).
+		Count(&held).Error; err != nil {
+		return 0, err
+	}
+
+	var offered int64
+	if err := tx.WithContext(ctx).
+		Model(&entity.Waitlist{}).
+		Joins("JOIN tickets ON tickets.id = waitlists.ticket_id").
+		Where("tickets.event_id = ? AND waitlists.status = ?", eventID, entity.WaitlistOffered).
+		Count(&offered).Error; err != nil {
+		return 0, err
+	}
+
+	return quota + bundleQuota + held + offered, nil
+}
+func (ar *AdminRepository) GetEventSessionByID(ctx context.Context, tx *gorm.DB, eventSessionID string) (entity.EventSession, bool, error) {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	var eventSession entity.EventSession
+	if err := tx.WithContext(ctx).Preload("Event").Where("id = ?", eventSessionID).Take(&eventSession).Error; err != nil {
+		return entity.EventSession{}, false, err
+	}
+
+	return eventSession, true, nil
+}
+func (ar *AdminRepository) GetEventSessionByIDForUpdate(ctx context.Context, tx *gorm.DB, eventSessionID string) (entity.EventSession, bool, error) {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	var eventSession entity.EventSession
+	if err := tx.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", eventSessionID).Take(&eventSession).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.EventSession{}, false, nil
+		}
+		return entity.EventSession{}, false, err
+	}
+
+	return eventSession, true, nil
+}
+
+// CountEventSessionCheckIn menghitung guest unik yang sudah masuk ke session.
+func (ar *AdminRepository) CountEventSessionCheckIn(ctx context.Context, tx *gorm.DB, eventSessionID string, excludeTicketFormID string) (int64, error) {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	var count int64
+	if err := tx.WithContext(ctx).
+		Model(&entity.GuestAttendance{}).
+		Where("event_session_id = ? AND direction = ? AND voided_at IS NULL", eventSessionID, entity.CheckInDirectionIn).
+		Where("ticket_form_id <> ?", excludeTicketFormID).
+		Distinct("ticket_form_id").
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+func (ar *AdminRepository) GetAllEventSessionByEventID(ctx context.Context, tx *gorm.DB, eventID string) ([]entity.EventSession, error) {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	var eventSessions []entity.EventSession
+	if err := tx.WithContext(ctx).Where("event_id = ?", eventID).Order("start_at ASC").Find(&eventSessions).Error; err != nil {
+		return []entity.EventSession{}, err
+	}
+
+	return eventSessions, nil
+}
+func (ar *AdminRepository) GetGateByID(ctx context.Context, tx *gorm.DB, gateID string) (entity.Gate, bool, error) {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	var gate entity.Gate
+	if err := tx.WithContext(ctx).Where("id = ?", gateID).Take(&gate).Error; err != nil {
+		return entity.Gate{}, false, err
+	}
+
+	return gate, true, nil
+}
+func (ar *AdminRepository) GetGuestAttendanceByID(ctx context.Context, tx *gorm.DB, guestAttendanceID string) (entity.GuestAttendance, bool, error) {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	var guestAttendance entity.GuestAttendance
+	if err := tx.WithContext(ctx).
+		Preload("CheckedByUser").
+		Preload("VoidedByUser").
+		Preload("Gate").
+		Preload("EventSession").
+		Where("id = ?", guestAttendanceID).
+		Take(&guestAttendance).Error; err != nil {
+		return entity.GuestAttendance{}, false, err
+	}
+
+	return guestAttendance, true, nil
+}
+func (ar *AdminRepository) GetAllGuestAttendanceByTicketFormID(ctx context.Context, tx *gorm.DB, ticketFormID string) ([]entity.GuestAttendance, error) {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	var guestAttendances []entity.GuestAttendance
+	if err := tx.WithContext(ctx).
+		Preload("CheckedByUser").
+		Preload("VoidedByUser").
+		Preload("Gate").
+		Preload("EventSession").
+		Where("ticket_form_id = ?", ticketFormID).
+		Order("checked_at ASC").
+		Find(&guestAttendances).Error; err != nil {
+		return []entity.GuestAttendance{}, err
+	}
+
+	return guestAttendances, nil
+}
+func (ar *AdminRepository) GetTransactionForPickup(ctx context.Context, tx *gorm.DB, transactionID string) (entity.Transaction, bool, error) {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	var transaction entity.Transaction
+	if err := tx.WithContext(ctx).
+		Preload("Bundle.BundleItems.Merch").
+		Preload("TicketForms", func(db *gorm.DB) *gorm.DB {
+			return db.Where("transferred_to_id IS NULL").Order(`"createdAt" ASC`)
+		}).
+		Preload("TicketForms.MerchPickups.HandedOverByUser").
+		Where("id = ?", transactionID).
+		Take(&transaction).Error; err != nil {
+		return entity.Transaction{}, false, err
+	}
+
+	return transaction, true, nil
+}
+func (ar *AdminRepository) GetAllTicketFormForPickup(ctx context.Context, tx *gorm.DB, filter dto.MerchPickupFilterQuery) ([]entity.TicketForm, error) {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	var ticketForms []entity.TicketForm
+
+	query := tx.WithContext(ctx).
+		Model(&entity.TicketForm{}).
+		Joins("JOIN transactions ON transactions.id = ticket_forms.transaction_id").
+		Joins("JOIN bundles ON bundles.id = transactions.bundle_id").
+		Where("ticket_forms.transferred_to_id IS NULL").
+		Where("transactions.transaction_status = ?", "settlement").
+		Preload("Transaction.Bundle.BundleItems.Merch").
+		Preload("MerchPickups")
+
+	if filter.EventID != "" {
+		query = query.Where("bundles.event_id = ?", filter.EventID)
+	}
+
+	if filter.BundleID != "" {
+		query = query.Where("bundles.id = ?", filter.BundleID)
+	}
+
+	if err := query.Order(`ticket_forms.full_name ASC`).Find(&ticketForms).Error; err != nil {
+		return nil, err
+	}
+
+	return ticketForms, nil
+}
+func (ar *AdminRepository) GetAllCrew(ctx context.Context, tx *gorm.DB, filter dto.CrewFilterQuery) ([]entity.User, error) {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	var users []entity.User
+
+	query := tx.WithContext(ctx).Model(&entity.User{}).Where("role = ?", entity.Crew)
+
+	if filter.EventID != "" {
+		query = query.Where("crew_event_id = ?", filter.EventID)
+	}
+
+	if filter.Active == "true" {
+		query = query.Where("crew_expires_at IS NULL OR crew_expires_at > ?", time.Now())
+	} else if filter.Active == "false" {
+		query = query.Where("crew_expires_at <= ?", time.Now())
+	}
+
+	if err := query.Order(`"createdAt" DESC`).Find(&users).Error; err != nil {
+		return []entity.User{}, err
+	}
+
+	return users, nil
+}
+func (ar *AdminRepository) GetAllGateByEventID(ctx context.Context, tx *gorm.DB, eventID string) ([]entity.Gate, error) {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	var gates []entity.Gate
+	if err := tx.WithContext(ctx).Where("event_id = ?", eventID).Order("name ASC").Find(&gates).Error; err != nil {
+		return []entity.Gate{}, err
+	}
+
+	return gates, nil
+}
+func (ar *AdminRepository) GetEventOccupancy(ctx context.Context, tx *gorm.DB, event entity.Event) (*dto.EventOccupancyResponse, error) {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	stat := &dto.EventOccupancyResponse{
+		EventID:  event.ID,
+		Name:     event.Name,
+		Capacity: event.Capacity,
+	}
+
+	// guest yang scan terakhirnya "in" dihitung sedang di dalam
+	if err := tx.WithContext(ctx).Raw(`
+		SELECT COUNT(*) FROM (
+			SELECT DISTINCT ON (ga.ticket_form_id) ga.direction
+			FROM guest_attendances ga
+			JOIN ticket_forms ON ticket_forms.id = ga.ticket_form_id
+			JOIN transactions ON transactions.id = ticket_forms.transaction_id
+			JOIN tickets ON tickets.id = transactions.ticket_id
+			WHERE tickets.event_id = ? AND ga.voided_at IS NULL AND ga."deletedAt" IS NULL
+			ORDER BY ga.ticket_form_id, ga.checked_at DESC
+		) latest
+		WHERE latest.direction = ?`, event.ID, entity.CheckInDirectionIn).
+		Scan(&stat.Inside).Error; err != nil {
+		return stat, err
+	}
+
+	// total scan in/out per gate
+	if err := tx.WithContext(ctx).
+		Model(&entity.GuestAttendance{}).
+		Select(`guest_attendances.gate_id, COALESCE(gates.name, '') AS gate_name,
+			SUM(CASE WHEN guest_attendances.direction = ? THEN 1 ELSE 0 END) AS total_in,
+			SUM(CASE WHEN guest_attendances.direction = ? THEN 1 ELSE 0 END) AS total_out`, entity.CheckInDirectionIn, entity.CheckInDirectionOut).
+		Joins("LEFT JOIN gates ON gates.id = guest_attendances.gate_id").
+		Joins("JOIN ticket_forms ON ticket_forms.id = guest_attendances.ticket_form_id").
+		Joins("JOIN transactions ON transactions.id = ticket_forms.transaction_id").
+		Joins("JOIN tickets ON tickets.id = transactions.ticket_id").
+		Where("tickets.event_id = ? AND guest_attendances.voided_at IS NULL", event.ID).
+		Group("guest_attendances.gate_id, gates.name").
+		Order("gate_name ASC").
+		Scan(&stat.Gates).Error; err != nil {
+		return stat, err
+	}
+
+	// total guest unik yang masuk per session
+	if err := tx.WithContext(ctx).
+		Model(&entity.EventSession{}).
+		Select("event_sessions.id AS event_session_id, event_sessions.name, event_sessions.capacity, COUNT(DISTINCT guest_attendances.ticket_form_id) AS total_check_in").
+		Joins(`LEFT JOIN guest_attendances ON guest_attendances.event_session_id = event_sessions.id AND guest_attendances.direction = ? AND guest_attendances.voided_at IS NULL AND guest_attendances."deletedAt" IS NULL`, entity.CheckInDirectionIn).
+		Where("event_sessions.event_id = ?", event.ID).
+		Group("event_sessions.id, event_sessions.name, event_sessions.capacity, event_sessions.start_at").
+		Order("event_sessions.start_at ASC").
+		Scan(&stat.Sessions).Error; err != nil {
+		return stat, err
+	}
+
+	return stat, nil
+}
+func (ar *AdminRepository) GetTicketFormFieldByID(ctx context.Context, tx *gorm.DB, ticketFormFieldID string) (entity.TicketFormField, bool, error) {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	var ticketFormField entity.TicketFormField
+	if err := tx.WithContext(ctx).Where("id = ?", ticketFormFieldID).Take(&ticketFormField).Error; err != nil {
+		return entity.TicketFormField{}, false, err
+	}
+
+	return ticketFormField, true, nil
+}
+func (ar *AdminRepository) GetTicketFormFieldByTicketIDAndKey(ctx context.Context, tx *gorm.DB, ticketID, key string) (entity.TicketFormField, bool, error) {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	var ticketFormField entity.TicketFormField
+	if err := tx.WithContext(ctx).Where("ticket_id = ? AND key = ?", ticketID, key).Take(&ticketFormField).Error; err != nil {
+		return entity.TicketFormField{}, false, err
+	}
+
+	return ticketFormField, true, nil
+}
+func (ar *AdminRepository) GetAllTicketFormFieldByTicketID(ctx context.Context, tx *gorm.DB, ticketID string) ([]entity.TicketFormField, error) {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	var ticketFormFields []entity.TicketFormField
+	if err := tx.WithContext(ctx).Where("ticket_id = ?", ticketID).Order(`position ASC, "createdAt" ASC`).Find(&ticketFormFields).Error; err != nil {
+		return []entity.TicketFormField{}, err
+	}
+
+	return ticketFormFields, nil
+}
+func (ar *AdminRepository) GetAllAttendeeForExport(ctx context.Context, tx *gorm.DB, filter dto.AttendeeExportFilterQuery) ([]entity.TicketForm, error) {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	var ticketForms []entity.TicketForm
+
+	query := tx.WithContext(ctx).
+		Model(&entity.TicketForm{}).
+		Joins("JOIN transactions ON transactions.id = ticket_forms.transaction_id").
+		Joins("JOIN tickets ON tickets.id = transactions.ticket_id").
+		Where("ticket_forms.transferred_to_id IS NULL").
+		Where("transactions.transaction_status = ?", "settlement").
+		Preload("GuestAttendances", ActiveGuestAttendances).
+		Preload("Transaction.Ticket.Event").
+		Preload("Transaction.Ticket.FormFields", func(db *gorm.DB) *gorm.DB { return db.Order("position ASC") })
+
+	if filter.EventID != "" {
+		query = query.Where("tickets.event_id = ?", filter.EventID)
+	}
+
+	if filter.TicketID != "" {
+		query = query.Where("tickets.id = ?", filter.TicketID)
+	}
+
+	if err := query.Order(`ticket_forms."createdAt" ASC`).Find(&ticketForms).Error; err != nil {
+		return nil, err
+	}
+
+	return ticketForms, nil
+}
+func (ar *AdminRepository) GetAllEmailOutboxWithPagination(ctx context.Context, tx *gorm.DB, req dto.PaginationRequest, filter dto.EmailOutboxFilterQuery) (dto.EmailOutboxPaginationRepositoryResponse, error) {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	var (
+		outboxes []entity.EmailOutbox
+		err      error
+		count    int64
+	)
+
+	if req.PerPage == 0 {
+		req.PerPage = 10
+	}
+
+	if req.Page == 0 {
+		req.Page = 1
+	}
+
+	query := tx.WithContext(ctx).Model(&entity.EmailOutbox{})
+
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	if filter.Kind != "" {
+		query = query.Where("kind = ?", filter.Kind)
+	}
+
+	if filter.TicketFormID != "" {
+		query = query.Where("ticket_form_id = ?", filter.TicketFormID)
+	}
+
+	if filter.BroadcastID != "" {
+		query = query.Where("broadcast_id = ?", filter.BroadcastID)
+	}
+
+	if req.Search != "" {
+		searchValue := "%" + strings.ToLower(req.Search) + "%"
+		query = query.Where("LOWER(to_email) LIKE ? OR LOWER(subject) LIKE ?", searchValue, searchValue)
+	}
+
+	if err := query.Count(&count).Error; err != nil {
+		return dto.EmailOutboxPaginationRepositoryResponse{}, err
+	}
+
+	if err := query.Order(`"createdAt" DESC`).Scopes(Paginate(req.Page, req.PerPage)).Find(&outboxes).Error; err != nil {
+		return dto.EmailOutboxPaginationRepositoryResponse{}, err
+	}
+
+	totalPage := int64(math.Ceil(float64(count) / float64(req.PerPage)))
+
+	return dto.EmailOutboxPaginationRepositoryResponse{
+		EmailOutboxes: outboxes,
+		PaginationResponse: dto.PaginationResponse{
+			Page:    req.Page,
+			PerPage: req.PerPage,
+			MaxPage: totalPage,
+			Count:   count,
+		},
+	}, err
+}
+func (ar *AdminRepository) GetEmailOutboxByID(ctx context.Context, tx *gorm.DB, outboxID string) (entity.EmailOutbox, bool, error) {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	var outbox entity.EmailOutbox
+	if err := tx.WithContext(ctx).Where("id = ?", outboxID).Take(&outbox).Error; err != nil {
+		return entity.EmailOutbox{}, false, err
+	}
+
+	return outbox, true, nil
+}
+func (ar *AdminRepository) GetAllNotificationOutboxWithPagination(ctx context.Context, tx *gorm.DB, req dto.PaginationRequest, filter dto.NotificationOutboxFilterQuery) (dto.NotificationOutboxPaginationRepositoryResponse, error) {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	var (
+		outboxes []entity.NotificationOutbox
+		err      error
+		count    int64
+	)
+
+	if req.PerPage == 0 {
+		req.PerPage = 10
+	}
+
+	if req.Page == 0 {
+		req.Page = 1
+	}
+
+	query := tx.WithContext(ctx).Model(&entity.NotificationOutbox{})
+
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	if filter.Kind != "" {
+		query = query.Where("kind = ?", filter.Kind)
+	}
+
+	if filter.Channel != "" {
+		query = query.Where("channel = ?", filter.Channel)
+	}
+
+	if filter.TicketFormID != "" {
+		query = query.Where("ticket_form_id = ?", filter.TicketFormID)
+	}
+
+	if req.Search != "" {
+		searchValue := "%" + strings.ToLower(req.Search) + "%"
+		query = query.Where("to_phone LIKE ? OR LOWER(body) LIKE ?", searchValue, searchValue)
+	}
+
+	if err := query.Count(&count).Error; err != nil {
+		return dto.NotificationOutboxPaginationRepositoryResponse{}, err
+	}
+
+	if err := query.Order(`"createdAt" DESC`).Scopes(Paginate(req.Page, req.PerPage)).Find(&outboxes).Error; err != nil {
+		return dto.NotificationOutboxPaginationRepositoryResponse{}, err
+	}
+
+	totalPage := int64(math.Ceil(float64(count) / float64(req.PerPage)))
+
+	return dto.NotificationOutboxPaginationRepositoryResponse{
+		NotificationOutboxes: outboxes,
+		PaginationResponse: dto.PaginationResponse{
+			Page:    req.Page,
+			PerPage: req.PerPage,
+			MaxPage: totalPage,
+			Count:   count,
+		},
+	}, err
+}
+func (ar *AdminRepository) GetNotificationOutboxByID(ctx context.Context, tx *gorm.DB, outboxID string) (entity.NotificationOutbox, bool, error) {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	var outbox entity.NotificationOutbox
+	if err := tx.WithContext(ctx).Where("id = ?", outboxID).Take(&outbox).Error; err != nil {
+		return entity.NotificationOutbox{}, false, err
+	}
+
+	return outbox, true, nil
+}
+func (ar *AdminRepository) GetAllEmailTemplate(ctx context.Context, tx *gorm.DB) ([]entity.EmailTemplate, error) {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	var emailTemplates []entity.EmailTemplate
+	if err := tx.WithContext(ctx).Order("key ASC").Find(&emailTemplates).Error; err != nil {
+		return nil, err
+	}
+
+	return emailTemplates, nil
+}
+func (ar *AdminRepository) GetEmailTemplateByID(ctx context.Context, tx *gorm.DB, emailTemplateID string) (entity.EmailTemplate, bool, error) {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	var emailTemplate entity.EmailTemplate
+	if err := tx.WithContext(ctx).Where("id = ?", emailTemplateID).Take(&emailTemplate).Error; err != nil {
+		return entity.EmailTemplate{}, false, err
+	}
+
+	return emailTemplate, true, nil
+}
+func (ar *AdminRepository) GetEmailTemplateByKey(ctx context.Context, tx *gorm.DB, key string) (entity.EmailTemplate, bool, error) {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	var emailTemplate entity.EmailTemplate
+	if err := tx.WithContext(ctx).Where("key = ?", key).Take(&emailTemplate).Error; err != nil {
+		return entity.EmailTemplate{}, false, err
+	}
+
+	return emailTemplate, true, nil
+}
+
+// GetAllBroadcastRecipient: tiket yang sudah ditransfer tidak ikut karena pemegangnya sudah berganti.
+func (ar *AdminRepository) GetAllBroadcastRecipient(ctx context.Context, tx *gorm.DB, filter dto.BroadcastFilterRequest) ([]entity.TicketForm, error) {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	var ticketForms []entity.TicketForm
+
+	query := tx.WithContext(ctx).
+		Model(&entity.TicketForm{}).
+		Joins("JOIN transactions ON transactions.id = ticket_forms.transaction_id").
+		Joins("LEFT JOIN tickets ON tickets.id = transactions.ticket_id").
+		Joins("LEFT JOIN bundles ON bundles.id = transactions.bundle_id").
+		Where("ticket_forms.transferred_to_id IS NULL").
+		Where("transactions.transaction_status = ?", filter.TransactionStatus).
+		Preload("Transaction.Ticket.Event").
+		Preload("Transaction.Bundle.Event")
+
+	if filter.EventID != "" {
+		query = query.Where("COALESCE(tickets.event_id, bundles.event_id) = ?", filter.EventID)
+	}
+
+	if filter.TicketType != "" {
+		query = query.Where("tickets.type = ?", filter.TicketType)
+	}
+
+	if filter.AudienceType != "" {
+		query = query.Where("ticket_forms.audience_type = ?", filter.AudienceType)
+	}
+
+	if filter.Instansi != "" {
+		query = query.Where("ticket_forms.instansi = ?", filter.Instansi)
+	}
+
+	if filter.CheckedIn == "true" {
+		query = query.Where(`EXISTS (SELECT 1 FROM guest_attendances ga WHERE ga.ticket_form_id = ticket_forms.id AND ga.voided_at IS NULL AND ga."deletedAt" IS NULL)`)
+	} else if filter.CheckedIn == "false" {
+		query = query.Where(`NOT EXISTS (SELECT 1 FROM guest_attendances ga WHERE ga.ticket_form_id = ticket_forms.id AND ga.voided_at IS NULL AND ga."deletedAt" IS NULL)`)
+	}
+
+	if err := query.Order(`ticket_forms."createdAt" ASC`).Find(&ticketForms).Error; err != nil {
+		return nil, err
+	}
+
+	return ticketForms, nil
+}
+func (ar *AdminRepository) GetAllBroadcastWithPagination(ctx context.Context, tx *gorm.DB, req dto.PaginationRequest) (dto.BroadcastPaginationRepositoryResponse, error) {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	var (
+		broadcasts []entity.Broadcast
+		err        error
+		count      int64
+	)
+
+	if req.PerPage == 0 {
+		req.PerPage = 10
+	}
+
+	if req.Page == 0 {
+		req.Page = 1
+	}
+
+	query := tx.WithContext(ctx).Model(&entity.Broadcast{})
+
+	if req.Search != "" {
+		searchValue := "%" + strings.ToLower(req.Search) + "%"
+		query = query.Where("LOWER(subject) LIKE ? OR LOWER(message) LIKE ?", searchValue, searchValue)
+	}
+
+	if err := query.Count(&count).Error; err != nil {
+		return dto.BroadcastPaginationRepositoryResponse{}, err
+	}
+
+	if err := query.Order(`"createdAt" DESC`).Scopes(Paginate(req.Page, req.PerPage)).Find(&broadcasts).Error; err != nil {
+		return dto.BroadcastPaginationRepositoryResponse{}, err
+	}
+
+	totalPage := int64(math.Ceil(float64(count) / float64(req.PerPage)))
+
+	return dto.BroadcastPaginationRepositoryResponse{
+		Broadcasts: broadcasts,
+		PaginationResponse: dto.PaginationResponse{
+			Page:    req.Page,
+			PerPage: req.PerPage,
+			MaxPage: totalPage,
+			Count:   count,
+		},
+	}, err
+}
+func (ar *AdminRepository) GetBroadcastByID(ctx context.Context, tx *gorm.DB, broadcastID string) (entity.Broadcast, bool, error) {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	var broadcast entity.Broadcast
+	if err := tx.WithContext(ctx).Where("id = ?", broadcastID).Take(&broadcast).Error; err != nil {
+		return entity.Broadcast{}, false, err
+	}
+
+	return broadcast, true, nil
+}
+func (ar *AdminRepository) CountEmailOutboxByBroadcastID(ctx context.Context, tx *gorm.DB, broadcastID string) (map[entity.EmailOutboxStatus]int64, error) {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	var rows []struct {
+		Status entity.EmailOutboxStatus
+		Count  int64
+	}
+	if err := tx.WithContext(ctx).Model(&entity.EmailOutbox{}).Select("status, COUNT(*) AS count").Where("broadcast_id = ?", broadcastID).Group("status").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	counts := make(map[entity.EmailOutboxStatus]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+
+	return counts, nil
+}
+
+// UPDATE / PATCH
+func (ar *AdminRepository) UpdateUser(ctx context.Context, tx *gorm.DB, user entity.User) error {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	return tx.WithContext(ctx).Where("id = ?", user.ID).Updates(&user).Error
+}
+func (ar *AdminRepository) UpdateTicket(ctx context.Context, tx *gorm.DB, ticket entity.Ticket) error {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	return tx.WithContext(ctx).Omit(clause.Associations).Where("id = ?", ticket.ID).Updates(&ticket).Error
+}
+func (ar *AdminRepository) UpdateSponsorship(ctx context.Context, tx *gorm.DB, sponsorship entity.Sponsorship) error {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	return tx.WithContext(ctx).Where("id = ?", sponsorship.ID).Updates(&sponsorship).Error
+}
+func (ar *AdminRepository) UpdateSpeaker(ctx context.Context, tx *gorm.DB, speaker entity.Speaker) error {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	return tx.WithContext(ctx).Where("id = ?", speaker.ID).Updates(&speaker).Error
+}
+func (ar *AdminRepository) UpdateMerch(ctx context.Context, tx *gorm.DB, merch entity.Merch) error {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	return tx.WithContext(ctx).Where("id = ?", merch.ID).Save(&merch).Error
+}
+func (ar *AdminRepository) UpdateBundle(ctx context.Context, tx *gorm.DB, bundle entity.Bundle) error {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	return tx.WithContext(ctx).Omit("Event").Where("id = ?", bundle.ID).Save(&bundle).Error
+}
+func (ar *AdminRepository) UpdateTicketQuota(ctx context.Context, tx *gorm.DB, ticketID string, newQuota int) error {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	result := tx.WithContext(ctx).
+		Model(&entity.Ticket{}).
+		Where("id = ?", ticketID).
+		Update("quota", newQuota)
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.New("ticket not found or no change made")
+	}
+
+	return nil
+}
+func (ar *AdminRepository) UpdateStudentAmbassador(ctx context.Context, tx *gorm.DB, studentAmbassador entity.StudentAmbassador) error {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	return tx.WithContext(ctx).Where("id = ?", studentAmbassador.ID).Save(&studentAmbassador).Error
+}
+func (ar *AdminRepository) UpdateWaitlist(ctx context.Context, tx *gorm.DB, waitlist entity.Waitlist, fromStatus entity.WaitlistStatus) (bool, error) {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	result := tx.WithContext(ctx).Where("id = ? AND status = ?", waitlist.ID, fromStatus).Updates(&waitlist)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+func (ar *AdminRepository) RestoreTicketQuota(ctx context.Context, tx *gorm.DB, ticketID string, amount int) error {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	return restoreTicketQuota(ctx, tx, ticketID, amount)
+}
+func (ar *AdminRepository) UpdateEvent(ctx context.Context, tx *gorm.DB, event entity.Event) error {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	return tx.WithContext(ctx).Omit("Sessions", "Tickets", "Bundles", "Gates").Where("id = ?", event.ID).Updates(&event).Error
+}
+func (ar *AdminRepository) UpdateEventSession(ctx context.Context, tx *gorm.DB, eventSession entity.EventSession) error {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	return tx.WithContext(ctx).Omit("Event").Where("id = ?", eventSession.ID).Updates(&eventSession).Error
+}
+func (ar *AdminRepository) UpdateGate(ctx context.Context, tx *gorm.DB, gate entity.Gate) error {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	return tx.WithContext(ctx).Omit("Event").Where("id = ?", gate.ID).Updates(&gate).Error
+}
+func (ar *AdminRepository) UpdateTicketFormField(ctx context.Context, tx *gorm.DB, ticketFormField entity.TicketFormField) error {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	return tx.WithContext(ctx).Omit("Ticket").Where("id = ?", ticketFormField.ID).Save(&ticketFormField).Error
+}
+func (ar *AdminRepository) UpdateTicketFormQR(ctx context.Context, tx *gorm.DB, ticketFormID string, qrVersion int, qrRevokedAt *time.Time) error {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	return tx.WithContext(ctx).Model(&entity.TicketForm{}).Where("id = ?", ticketFormID).Updates(map[string]interface{}{
+		"qr_version":    qrVersion,
+		"qr_revoked_at": qrRevokedAt,
+	}).Error
+}
+func (ar *AdminRepository) UpdateGuestAttendance(ctx context.Context, tx *gorm.DB, guestAttendance entity.GuestAttendance) error {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	return tx.WithContext(ctx).Omit(clause.Associations).Where("id = ?", guestAttendance.ID).Updates(&guestAttendance).Error
+}
+func (ar *AdminRepository) ExpireCrew(ctx context.Context, tx *gorm.DB, req dto.ExpireCrewBulkRequest, expiresAt time.Time) (int64, error) {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	query := tx.WithContext(ctx).Model(&entity.User{}).
+		Where("role = ?", entity.Crew).
+		Where("crew_expires_at IS NULL OR crew_expires_at > ?", expiresAt)
+
+	if len(req.UserIDs) > 0 {
+		query = query.Where("id IN ?", req.UserIDs)
+	}
+
+	if req.EventID != "" {
+		query = query.Where("crew_event_id = ?", req.EventID)
+	}
+
+	result := query.Update("crew_expires_at", expiresAt)
+
+	return result.RowsAffected, result.Error
+}
+func (ar *AdminRepository) RetryEmailOutbox(ctx context.Context, tx *gorm.DB, outboxID string, nextAttemptAt time.Time) error {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	return tx.WithContext(ctx).Model(&entity.EmailOutbox{}).Where("id = ?", outboxID).Updates(map[string]interface{}{
+		"status":          entity.EmailOutboxPending,
+		"attempts":        0,
+		"next_attempt_at": nextAttemptAt,
+		"locked_until":    nil,
+	}).Error
+}
+func (ar *AdminRepository) RetryNotificationOutbox(ctx context.Context, tx *gorm.DB, outboxID string, nextAttemptAt time.Time) error {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	return tx.WithContext(ctx).Model(&entity.NotificationOutbox{}).Where("id = ?", outboxID).Updates(map[string]interface{}{
+		"status":          entity.NotificationOutboxPending,
+		"attempts":        0,
+		"next_attempt_at": nextAttemptAt,
+		"locked_until":    nil,
+	}).Error
+}
+func (ar *AdminRepository) UpdateEmailTemplate(ctx context.Context, tx *gorm.DB, emailTemplate entity.EmailTemplate) error {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	return tx.WithContext(ctx).Where("id = ?", emailTemplate.ID).Save(&emailTemplate).Error
+}
+func (ar *AdminRepository) UpdateTicketFormEmail(ctx context.Context, tx *gorm.DB, ticketFormID, email string) error {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	return tx.WithContext(ctx).Model(&entity.TicketForm{}).Where("id = ?", ticketFormID).Update("email", email).Error
+}
+
+// DELETE / DELETE
+func (ar *AdminRepository) DeleteUserByID(ctx context.Context, tx *gorm.DB, userID string) error {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	return tx.WithContext(ctx).Where("id = ?", userID).Delete(&entity.User{}).Error
+}
+func (ar *AdminRepository) DeleteTicketByID(ctx context.Context, tx *gorm.DB, ticketID string) error {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	return tx.WithContext(ctx).Where("id = ?", ticketID).Delete(&entity.Ticket{}).Error
+}
+func (ar *AdminRepository) DeleteSponsorshipByID(ctx context.Context, tx *gorm.DB, sponsorshipID string) error {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	return tx.WithContext(ctx).Where("id = ?", sponsorshipID).Delete(&entity.Sponsorship{}).Error
+}
+func (ar *AdminRepository) DeleteSpeakerByID(ctx context.Context, tx *gorm.DB, speakerID string) error {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	return tx.WithContext(ctx).Where("id = ?", speakerID).Delete(&entity.Speaker{}).Error
+}
+func (ar *AdminRepository) DeleteMerchImageByID(ctx context.Context, tx *gorm.DB, merchImageID string) error {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	return tx.WithContext(ctx).Where("id = ?", merchImageID).Delete(&entity.MerchImage{}).Error
+}
+func (ar *AdminRepository) DeleteMerchByID(ctx context.Context, tx *gorm.DB, merchID string) error {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	return tx.WithContext(ctx).Where("id = ?", merchID).Delete(&entity.Merch{}).Error
+}
+func (ar *AdminRepository) DeleteMerchImagesByMerchID(ctx context.Context, tx *gorm.DB, merchID string) error {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	return tx.WithContext(ctx).Where("merch_id = ?", merchID).Delete(&entity.MerchImage{}).Error
+}
+func (ar *AdminRepository) DeleteBundleByID(ctx context.Context, tx *gorm.DB, bundleID string) error {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	return tx.WithContext(ctx).Where("id = ?", bundleID).Delete(&entity.Bundle{}).Error
+}
+func (ar *AdminRepository) DeleteBundleItemsByBundleID(ctx context.Context, tx *gorm.DB, bundleID string) error {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	return tx.WithContext(ctx).Where("bundle_id = ?", bundleID).Delete(&entity.BundleItem{}).Error
+}
+func (ar *AdminRepository) DeleteStudentAmbassadorByID(ctx context.Context, tx *gorm.DB, studentAmbassadorID string) error {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	return tx.WithContext(ctx).Where("id = ?", studentAmbassadorID).Delete(&entity.StudentAmbassador{}).Error
+}
+func (ar *AdminRepository) DeleteEventByID(ctx context.Context, tx *gorm.DB, eventID string) error {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	return tx.WithContext(ctx).Where("id = ?", eventID).Delete(&entity.Event{}).Error
+}
+func (ar *AdminRepository) DeleteEventSessionByID(ctx context.Context, tx *gorm.DB, eventSessionID string) error {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	return tx.WithContext(ctx).Where("id = ?", eventSessionID).Delete(&entity.EventSession{}).Error
+}
+func (ar *AdminRepository) DeleteGateByID(ctx context.Context, tx *gorm.DB, gateID string) error {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	return tx.WithContext(ctx).Where("id = ?", gateID).Delete(&entity.Gate{}).Error
+}
+func (ar *AdminRepository) DeleteTicketFormFieldByID(ctx context.Context, tx *gorm.DB, ticketFormFieldID string) error {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	return tx.WithContext(ctx).Where("id = ?", ticketFormFieldID).Delete(&entity.TicketFormField{}).Error
+}
+func (ar *AdminRepository) DeleteEmailTemplateByID(ctx context.Context, tx *gorm.DB, emailTemplateID string) error {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	return tx.WithContext(ctx).Where("id = ?", emailTemplateID).Delete(&entity.EmailTemplate{}).Error
 }
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Amierza/TedXBackend/entity"
+	"gorm.io/gorm"
+)
+
+type (
+	IAvailabilityRepository interface {
+		// READ / GET
+		GetTicketByID(ctx context.Context, tx *gorm.DB, ticketID string) (entity.Ticket, bool, error)
+		GetBundleByID(ctx context.Context, tx *gorm.DB, bundleID string) (entity.Bundle, bool, error)
+		GetAllTicket(ctx context.Context, tx *gorm.DB) ([]entity.Ticket, error)
+		GetAllBundle(ctx context.Context, tx *gorm.DB) ([]entity.Bundle, error)
+	}
+
+	AvailabilityRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewAvailabilityRepository(db *gorm.DB) *AvailabilityRepository {
+	return &AvailabilityRepository{
+		db: db,
+	}
+}
+
+// READ / GET
+func (ar *AvailabilityRepository) GetTicketByID(ctx context.Context, tx *gorm.DB, ticketID string) (entity.Ticket, bool, error) {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	var ticket entity.Ticket
+	if err := tx.WithContext(ctx).Where("id = ?", ticketID).Take(&ticket).Error; err != nil {
+		return entity.Ticket{}, false, err
+	}
+
+	return ticket, true, nil
+}
+func (ar *AvailabilityRepository) GetBundleByID(ctx context.Context, tx *gorm.DB, bundleID string) (entity.Bundle, bool, error) {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	var bundle entity.Bundle
+	if err := tx.WithContext(ctx).Where("id = ?", bundleID).Take(&bundle).Error; err != nil {
+		return entity.Bundle{}, false, err
+	}
+
+	return bundle, true, nil
+}
+func (ar *AvailabilityRepository) GetAllTicket(ctx context.Context, tx *gorm.DB) ([]entity.Ticket, error) {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	var tickets []entity.Ticket
+	if err := tx.WithContext(ctx).Model(&entity.Ticket{}).Order(`"createdAt" DESC`).Find(&tickets).Error; err != nil {
+		return []entity.Ticket{}, err
+	}
+
+	return tickets, nil
+}
+func (ar *AvailabilityRepository) GetAllBundle(ctx context.Context, tx *gorm.DB) ([]entity.Bundle, error) {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	var bundles []entity.Bundle
+	if err := tx.WithContext(ctx).Model(&entity.Bundle{}).Order(`"createdAt" DESC`).Find(&bundles).Error; err != nil {
+		return []entity.Bundle{}, err
+	}
+
+	return bundles, nil
+}
@@ -1,6 +1,13 @@
 package repository
 
-import "gorm.io/gorm"
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/Amierza/TedXBackend/entity"
+	"gorm.io/gorm"
+)
 
 func Paginate(page, perPage int) func(db *gorm.DB) *gorm.DB {
 	return func(db *gorm.DB) *gorm.DB {
@@ -8,3 +15,39 @@ func Paginate(page, perPage int) func(db *gorm.DB) *gorm.DB {
 		return db.Offset(offset).Limit(perPage)
 	}
 }
+
+// ActiveGuestAttendances: preload check-in yang belum di-void, urut dari yang paling lama.
+func ActiveGuestAttendances(db *gorm.DB) *gorm.DB {
+	return db.Where("voided_at IS NULL").Order("checked_at ASC")
+}
+
+// escapeLike supaya "%" dan "_" dari input user dicari sebagai karakter biasa.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
+
+// restoreQuota menambah quota secara atomik, dipakai semua repository yang mengembalikan kursi.
+func restoreQuota(ctx context.Context, tx *gorm.DB, model any, id string, amount int) error {
+	result := tx.WithContext(ctx).
+		Model(model).
+		Where("id = ?", id).
+		Update("quota", gorm.Expr("quota + ?", amount))
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.New("quota owner not found or no change made")
+	}
+
+	return nil
+}
+func restoreTicketQuota(ctx context.Context, tx *gorm.DB, ticketID string, amount int) error {
+	return restoreQuota(ctx, tx, &entity.Ticket{}, ticketID, amount)
+}
+func restoreBundleQuota(ctx context.Context, tx *gorm.DB, bundleID string, amount int) error {
+	return restoreQuota(ctx, tx, &entity.Bundle{}, bundleID, amount)
+}
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Amierza/TedXBackend/constants"
+	"github.com/Amierza/TedXBackend/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	IEmailOutboxRepository interface {
+		// READ / GET
+		ClaimDueEmailOutbox(ctx context.Context, tx *gorm.DB, now, lockedUntil time.Time, limit int) ([]entity.EmailOutbox, error)
+
+		// UPDATE / PATCH
+		RenewEmailOutboxLease(ctx context.Context, tx *gorm.DB, outboxID string, claimedUntil, lockedUntil time.Time) (bool, error)
+		UpdateEmailOutboxDelivery(ctx context.Context, tx *gorm.DB, outbox entity.EmailOutbox, claimedUntil time.Time) (bool, error)
+	}
+
+	EmailOutboxRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewEmailOutboxRepository(db *gorm.DB) *EmailOutboxRepository {
+	return &EmailOutboxRepository{
+		db: db,
+	}
+}
+
+// READ / GET
+// ClaimDueEmailOutbox memakai SKIP LOCKED dan mengambil ulang email yang lease-nya habis.
+func (er *EmailOutboxRepository) ClaimDueEmailOutbox(ctx context.Context, tx *gorm.DB, now, lockedUntil time.Time, limit int) ([]entity.EmailOutbox, error) {
+	if tx == nil {
+		tx = er.db
+	}
+
+	var outboxes []entity.EmailOutbox
+	err := tx.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("(status IN ? AND next_attempt_at <= ?) OR (status = ? AND locked_until < ?)",
+				[]string{constants.ENUM_EMAIL_OUTBOX_STATUS_PENDING, constants.ENUM_EMAIL_OUTBOX_STATUS_FAILED}, now,
+				constants.ENUM_EMAIL_OUTBOX_STATUS_SENDING, now).
+			Order("next_attempt_at ASC").
+			Limit(limit).
+			Find(&outboxes).Error
+		if err != nil || len(outboxes) == 0 {
+			return err
+		}
+
+		ids := make([]string, 0, len(outboxes))
+		for i := range outboxes {
+			ids = append(ids, outboxes[i].ID.String())
+			outboxes[i].Status = entity.EmailOutboxSending
+			outboxes[i].LockedUntil = &lockedUntil
+		}
+
+		return tx.Model(&entity.EmailOutbox{}).Where("id IN ?", ids).Updates(map[string]interface{}{
+			"status":       entity.EmailOutboxSending,
+			"locked_until": lockedUntil,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return outboxes, nil
+}
+
+// UPDATE / PATCH
+// RenewEmailOutboxLease dan UpdateEmailOutboxDelivery hanya berhasil selama lease masih milik worker ini.
+func (er *EmailOutboxRepository) RenewEmailOutboxLease(ctx context.Context, tx *gorm.DB, outboxID string, claimedUntil, lockedUntil time.Time) (bool, error) {
+	if tx == nil {
+		tx = er.db
+	}
+
+	result := tx.WithContext(ctx).Model(&entity.EmailOutbox{}).
+		Where("id = ? AND status = ? AND locked_until = ?", outboxID, constants.ENUM_EMAIL_OUTBOX_STATUS_SENDING, claimedUntil).
+		Update("locked_until", lockedUntil)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+func (er *EmailOutboxRepository) UpdateEmailOutboxDelivery(ctx context.Context, tx *gorm.DB, outbox entity.EmailOutbox, claimedUntil time.Time) (bool, error) {
+	if tx == nil {
+		tx = er.db
+	}
+
+	result := tx.WithContext(ctx).Model(&entity.EmailOutbox{}).
+		Where("id = ? AND status = ? AND locked_until = ?", outbox.ID, constants.ENUM_EMAIL_OUTBOX_STATUS_SENDING, claimedUntil).
+		Updates(map[string]interface{}{
+			"status":          outbox.Status,
+			"attempts":        outbox.Attempts,
+			"next_attempt_at": outbox.NextAttemptAt,
+			"locked_until":    outbox.LockedUntil,
+			"last_error":      outbox.LastError,
+			"sent_at":         outbox.SentAt,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
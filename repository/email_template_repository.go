@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Amierza/TedXBackend/entity"
+	"gorm.io/gorm"
+)
+
+type (
+	IEmailTemplateRepository interface {
+		// READ / GET
+		GetEmailTemplateByKey(ctx context.Context, tx *gorm.DB, key string) (entity.EmailTemplate, bool, error)
+	}
+
+	EmailTemplateRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewEmailTemplateRepository(db *gorm.DB) *EmailTemplateRepository {
+	return &EmailTemplateRepository{
+		db: db,
+	}
+}
+
+// READ / GET
+func (etr *EmailTemplateRepository) GetEmailTemplateByKey(ctx context.Context, tx *gorm.DB, key string) (entity.EmailTemplate, bool, error) {
+	if tx == nil {
+		tx = etr.db
+	}
+
+	var emailTemplate entity.EmailTemplate
+	err := tx.WithContext(ctx).Where("key = ?", key).Take(&emailTemplate).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return entity.EmailTemplate{}, false, nil
+	}
+	if err != nil {
+		return entity.EmailTemplate{}, false, err
+	}
+
+	return emailTemplate, true, nil
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Amierza/TedXBackend/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	IEventReminderRepository interface {
+		RunInTransaction(ctx context.Context, fn func(txRepo IEventReminderRepository) error) error
+
+		// CREATE / POST
+		CreateEventReminder(ctx context.Context, tx *gorm.DB, reminder entity.EventReminder) (bool, error)
+		CreateEmailOutbox(ctx context.Context, tx *gorm.DB, outbox entity.EmailOutbox) error
+
+		// READ / GET
+		GetAllDueReminderTicketForm(ctx context.Context, tx *gorm.DB, offsetMinutes int, from, to time.Time, limit int) ([]entity.TicketForm, error)
+	}
+
+	EventReminderRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewEventReminderRepository(db *gorm.DB) *EventReminderRepository {
+	return &EventReminderRepository{
+		db: db,
+	}
+}
+
+func (er *EventReminderRepository) RunInTransaction(ctx context.Context, fn func(txRepo IEventReminderRepository) error) error {
+	return er.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txRepo := &EventReminderRepository{db: tx}
+		return fn(txRepo)
+	})
+}
+
+// CREATE / POST
+// CreateEventReminder mengembalikan false kalau reminder ini sudah tercatat.
+func (er *EventReminderRepository) CreateEventReminder(ctx context.Context, tx *gorm.DB, reminder entity.EventReminder) (bool, error) {
+	if tx == nil {
+		tx = er.db
+	}
+
+	result := tx.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&reminder)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+func (er *EventReminderRepository) CreateEmailOutbox(ctx context.Context, tx *gorm.DB, outbox entity.EmailOutbox) error {
+	if tx == nil {
+		tx = er.db
+	}
+
+	return tx.WithContext(ctx).Create(&outbox).Error
+}
+
+// READ / GET
+// GetAllDueReminderTicketForm melewati tiket yang dibuat setelah titik offset-nya.
+func (er *EventReminderRepository) GetAllDueReminderTicketForm(ctx context.Context, tx *gorm.DB, offsetMinutes int, from, to time.Time, limit int) ([]entity.TicketForm, error) {
+	if tx == nil {
+		tx = er.db
+	}
+
+	var ticketForms []entity.TicketForm
+	err := tx.WithContext(ctx).
+		Model(&entity.TicketForm{}).
+		Joins("JOIN transactions ON transactions.id = ticket_forms.transaction_id").
+		Joins("LEFT JOIN tickets ON tickets.id = transactions.ticket_id").
+		Joins("LEFT JOIN bundles ON bundles.id = transactions.bundle_id").
+		Where("transactions.transaction_status = ?", "settlement").
+		Where("ticket_forms.transferred_to_id IS NULL AND ticket_forms.qr_revoked_at IS NULL").
+		Where("COALESCE(tickets.event_date, bundles.event_date) > ? AND COALESCE(tickets.event_date, bundles.event_date) <= ?", from, to).
+		Where(`ticket_forms."createdAt" + (? * INTERVAL '1 minute') <= COALESCE(tickets.event_date, bundles.event_date)`, offsetMinutes).
+		Where("NOT EXISTS (SELECT 1 FROM event_reminders er WHERE er.ticket_form_id = ticket_forms.id AND er.offset_minutes = ?)", offsetMinutes).
+		Preload("Transaction.Ticket.Event").
+		Preload("Transaction.Bundle.Event").
+		Order(`ticket_forms."createdAt" ASC`).
+		Limit(limit).
+		Find(&ticketForms).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return ticketForms, nil
+}
@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Amierza/TedXBackend/constants"
+	"github.com/Amierza/TedXBackend/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	INotificationOutboxRepository interface {
+		// CREATE / POST
+		CreateNotificationOutbox(ctx context.Context, tx *gorm.DB, outbox entity.NotificationOutbox) (bool, error)
+
+		// READ / GET
+		ClaimDueNotificationOutbox(ctx context.Context, tx *gorm.DB, now, lockedUntil time.Time, limit int) ([]entity.NotificationOutbox, error)
+		GetAllPaymentReminderTicketForm(ctx context.Context, tx *gorm.DB, createdAfter, createdBefore time.Time, limit int) ([]entity.TicketForm, error)
+		GetAllEventDayTicketForm(ctx context.Context, tx *gorm.DB, from, to time.Time, limit int) ([]entity.TicketForm, error)
+
+		// UPDATE / PATCH
+		UpdateNotificationOutboxDelivery(ctx context.Context, tx *gorm.DB, outbox entity.NotificationOutbox) error
+	}
+
+	NotificationOutboxRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewNotificationOutboxRepository(db *gorm.DB) *NotificationOutboxRepository {
+	return &NotificationOutboxRepository{
+		db: db,
+	}
+}
+
+// CREATE / POST
+// CreateNotificationOutbox mengembalikan false kalau DedupeKey-nya sudah pernah diantrikan.
+func (nr *NotificationOutboxRepository) CreateNotificationOutbox(ctx context.Context, tx *gorm.DB, outbox entity.NotificationOutbox) (bool, error) {
+	if tx == nil {
+		tx = nr.db
+	}
+
+	result := tx.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&outbox)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// READ / GET
+// ClaimDueNotificationOutbox bekerja seperti ClaimDueEmailOutbox.
+func (nr *NotificationOutboxRepository) ClaimDueNotificationOutbox(ctx context.Context, tx *gorm.DB, now, lockedUntil time.Time, limit int) ([]entity.NotificationOutbox, error) {
+	if tx == nil {
+		tx = nr.db
+	}
+
+	var outboxes []entity.NotificationOutbox
+	err := tx.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("(status IN ? AND next_attempt_at <= ?) OR (status = ? AND locked_until < ?)",
+				[]string{constants.ENUM_NOTIFICATION_OUTBOX_STATUS_PENDING, constants.ENUM_NOTIFICATION_OUTBOX_STATUS_FAILED}, now,
+				constants.ENUM_NOTIFICATION_OUTBOX_STATUS_SENDING, now).
+			Order("next_attempt_at ASC").
+			Limit(limit).
+			Find(&outboxes).Error
+		if err != nil || len(outboxes) == 0 {
+			return err
+		}
+
+		ids := make([]string, 0, len(outboxes))
+		for i := range outboxes {
+			ids = append(ids, outboxes[i].ID.String())
+			outboxes[i].Status = entity.NotificationOutboxSending
+			outboxes[i].LockedUntil = &lockedUntil
+		}
+
+		return tx.Model(&entity.NotificationOutbox{}).Where("id IN ?", ids).Updates(map[string]interface{}{
+			"status":       entity.NotificationOutboxSending,
+			"locked_until": lockedUntil,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return outboxes, nil
+}
+
+// GetAllPaymentReminderTicketForm mengambil form opt-in dari transaksi belum dibayar yang belum diingatkan.
+func (nr *NotificationOutboxRepository) GetAllPaymentReminderTicketForm(ctx context.Context, tx *gorm.DB, createdAfter, createdBefore time.Time, limit int) ([]entity.TicketForm, error) {
+	if tx == nil {
+		tx = nr.db
+	}
+
+	var ticketForms []entity.TicketForm
+	err := tx.WithContext(ctx).
+		Model(&entity.TicketForm{}).
+		Joins("JOIN transactions ON transactions.id = ticket_forms.transaction_id").
+		Where("COALESCE(transactions.transaction_status, '') IN ?", []string{"", "pending"}).
+		Where(`transactions."createdAt" > ? AND transactions."createdAt" <= ?`, createdAfter, createdBefore).
+		Where("ticket_forms.whatsapp_opt_in = ?", true).
+		Where("NOT EXISTS (SELECT 1 FROM notification_outboxes no WHERE no.ticket_form_id = ticket_forms.id AND no.kind = ?)", entity.NotificationPaymentReminder).
+		Preload("Transaction.Ticket").
+		Preload("Transaction.Bundle").
+		Order(`ticket_forms."createdAt" ASC`).
+		Limit(limit).
+		Find(&ticketForms).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return ticketForms, nil
+}
+
+// GetAllEventDayTicketForm mengambil tiket opt-in yang belum menerima pemberitahuan hari-H.
+func (nr *NotificationOutboxRepository) GetAllEventDayTicketForm(ctx context.Context, tx *gorm.DB, from, to time.Time, limit int) ([]entity.TicketForm, error) {
+	if tx == nil {
+		tx = nr.db
+	}
+
+	var ticketForms []entity.TicketForm
+	err := tx.WithContext(ctx).
+		Model(&entity.TicketForm{}).
+		Joins("JOIN transactions ON transactions.id = ticket_forms.transaction_id").
+		Joins("LEFT JOIN tickets ON tickets.id = transactions.ticket_id").
+		Joins("LEFT JOIN bundles ON bundles.id = transactions.bundle_id").
+		Where("transactions.transaction_status = ?", "settlement").
+		Where("ticket_forms.transferred_to_id IS NULL AND ticket_forms.qr_revoked_at IS NULL").
+		Where("ticket_forms.whatsapp_opt_in = ?", true).
+		Where("COALESCE(tickets.event_date, bundles.event_date) > ? AND COALESCE(tickets.event_date, bundles.event_date) <= ?", from, to).
+		Where("NOT EXISTS (SELECT 1 FROM notification_outboxes no WHERE no.ticket_form_id = ticket_forms.id AND no.kind = ?)", entity.NotificationEventDay).
+		Preload("Transaction.Ticket.Event").
+		Preload("Transaction.Bundle.Event").
+		Order(`ticket_forms."createdAt" ASC`).
+		Limit(limit).
+		Find(&ticketForms).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return ticketForms, nil
+}
+
+// UPDATE / PATCH
+func (nr *NotificationOutboxRepository) UpdateNotificationOutboxDelivery(ctx context.Context, tx *gorm.DB, outbox entity.NotificationOutbox) error {
+	if tx == nil {
+		tx = nr.db
+	}
+
+	return tx.WithContext(ctx).Model(&entity.NotificationOutbox{}).Where("id = ?", outbox.ID).Updates(map[string]interface{}{
+		"status":              outbox.Status,
+		"attempts":            outbox.Attempts,
+		"next_attempt_at":     outbox.NextAttemptAt,
+		"locked_until":        outbox.LockedUntil,
+		"last_error":          outbox.LastError,
+		"provider_message_id": outbox.ProviderMessageID,
+		"sent_at":             outbox.SentAt,
+	}).Error
+}
@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Amierza/TedXBackend/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	IPasswordResetRepository interface {
+		RunInTransaction(ctx context.Context, fn func(txRepo IPasswordResetRepository) error) error
+
+		// CREATE / POST
+		CreatePasswordReset(ctx context.Context, tx *gorm.DB, passwordReset entity.PasswordReset) error
+		CreateEmailOutbox(ctx context.Context, tx *gorm.DB, outbox entity.EmailOutbox) error
+
+		// READ / GET
+		GetUserByID(ctx context.Context, tx *gorm.DB, userID string) (entity.User, bool, error)
+		GetUserByEmail(ctx context.Context, tx *gorm.DB, email string) (entity.User, bool, error)
+		GetPasswordResetByTokenHash(ctx context.Context, tx *gorm.DB, tokenHash string) (entity.PasswordReset, bool, error)
+		CountPasswordResetByEmail(ctx context.Context, tx *gorm.DB, email string, since time.Time) (int64, error)
+
+		// UPDATE / PATCH
+		UpdateUserPassword(ctx context.Context, tx *gorm.DB, userID string, password string, changedAt time.Time) error
+		UsePasswordResetByUserID(ctx context.Context, tx *gorm.DB, userID string, usedAt time.Time) error
+
+		// DELETE / DELETE
+		DeleteSessionByUserID(ctx context.Context, tx *gorm.DB, userID string) error
+	}
+
+	PasswordResetRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewPasswordResetRepository(db *gorm.DB) *PasswordResetRepository {
+	return &PasswordResetRepository{
+		db: db,
+	}
+}
+
+func (prr *PasswordResetRepository) RunInTransaction(ctx context.Context, fn func(txRepo IPasswordResetRepository) error) error {
+	return prr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txRepo := &PasswordResetRepository{db: tx}
+		return fn(txRepo)
+	})
+}
+
+// CREATE / POST
+func (prr *PasswordResetRepository) CreatePasswordReset(ctx context.Context, tx *gorm.DB, passwordReset entity.PasswordReset) error {
+	if tx == nil {
+		tx = prr.db
+	}
+
+	return tx.WithContext(ctx).Create(&passwordReset).Error
+}
+func (prr *PasswordResetRepository) CreateEmailOutbox(ctx context.Context, tx *gorm.DB, outbox entity.EmailOutbox) error {
+	if tx == nil {
+		tx = prr.db
+	}
+
+	return tx.WithContext(ctx).Create(&outbox).Error
+}
+
+// READ / GET
+func (prr *PasswordResetRepository) GetUserByID(ctx context.Context, tx *gorm.DB, userID string) (entity.User, bool, error) {
+	if tx == nil {
+		tx = prr.db
+	}
+
+	var user entity.User
+	if err := tx.WithContext(ctx).Where("id = ?", userID).Take(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.User{}, false, nil
+		}
+		return entity.User{}, false, err
+	}
+
+	return user, true, nil
+}
+func (prr *PasswordResetRepository) GetUserByEmail(ctx context.Context, tx *gorm.DB, email string) (entity.User, bool, error) {
+	if tx == nil {
+		tx = prr.db
+	}
+
+	var user entity.User
+	if err := tx.WithContext(ctx).Where("email = ?", email).Take(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.User{}, false, nil
+		}
+		return entity.User{}, false, err
+	}
+
+	return user, true, nil
+}
+
+// GetPasswordResetByTokenHash mengunci baris reset supaya token yang sama tidak dipakai dua kali.
+func (prr *PasswordResetRepository) GetPasswordResetByTokenHash(ctx context.Context, tx *gorm.DB, tokenHash string) (entity.PasswordReset, bool, error) {
+	if tx == nil {
+		tx = prr.db
+	}
+
+	var passwordReset entity.PasswordReset
+	if err := tx.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).Where("token_hash = ?", tokenHash).Take(&passwordReset).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.PasswordReset{}, false, nil
+		}
+		return entity.PasswordReset{}, false, err
+	}
+
+	return passwordReset, true, nil
+}
+func (prr *PasswordResetRepository) CountPasswordResetByEmail(ctx context.Context, tx *gorm.DB, email string, since time.Time) (int64, error) {
+	if tx == nil {
+		tx = prr.db
+	}
+
+	var count int64
+	if err := tx.WithContext(ctx).Model(&entity.PasswordReset{}).Where(`LOWER(email) = LOWER(?) AND "createdAt" >= ?`, email, since).Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// UPDATE / PATCH
+func (prr *PasswordResetRepository) UpdateUserPassword(ctx context.Context, tx *gorm.DB, userID string, password string, changedAt time.Time) error {
+	if tx == nil {
+		tx = prr.db
+	}
+
+	return tx.WithContext(ctx).Model(&entity.User{}).Where("id = ?", userID).Updates(map[string]interface{}{
+		"password":            password,
+		"password_changed_at": changedAt,
+	}).Error
+}
+
+// UsePasswordResetByUserID menutup semua token reset user yang belum dipakai.
+func (prr *PasswordResetRepository) UsePasswordResetByUserID(ctx context.Context, tx *gorm.DB, userID string, usedAt time.Time) error {
+	if tx == nil {
+		tx = prr.db
+	}
+
+	return tx.WithContext(ctx).Model(&entity.PasswordReset{}).Where("user_id = ? AND used_at IS NULL", userID).Update("used_at", usedAt).Error
+}
+
+// DELETE / DELETE
+func (prr *PasswordResetRepository) DeleteSessionByUserID(ctx context.Context, tx *gorm.DB, userID string) error {
+	if tx == nil {
+		tx = prr.db
+	}
+
+	return tx.WithContext(ctx).Where("user_id = ?", userID).Delete(&entity.Session{}).Error
+}
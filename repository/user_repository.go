@@ -3,9 +3,12 @@ package repository
 import (
 	"context"
 	"errors"
+	"time"
 
+	"github.com/Amierza/TedXBackend/constants"
 	"github.com/Amierza/TedXBackend/entity"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type (
@@ -15,19 +18,39 @@ type (
 		// CREATE / POST
 		CreateTransaction(ctx context.Context, tx *gorm.DB, transaction entity.Transaction) error
 		CreateTicketForm(ctx context.Context, tx *gorm.DB, ticketForm entity.TicketForm) error
+		CreateWaitlist(ctx context.Context, tx *gorm.DB, waitlist entity.Waitlist) error
+		CreateTicketTransfer(ctx context.Context, tx *gorm.DB, ticketTransfer entity.TicketTransfer) error
+		CreateEmailOutbox(ctx context.Context, tx *gorm.DB, outbox entity.EmailOutbox) error
+		CreateNotificationOutbox(ctx context.Context, tx *gorm.DB, outbox entity.NotificationOutbox) error
 
 		// READ / GET
 		GetUserByID(ctx context.Context, tx *gorm.DB, userID string) (entity.User, bool, error)
 		GetUserByEmail(ctx context.Context, tx *gorm.DB, email string) (entity.User, bool, error)
-		GetAllTicket(ctx context.Context, tx *gorm.DB) ([]entity.Ticket, error)
+		GetAllTicket(ctx context.Context, tx *gorm.DB, eventID string) ([]entity.Ticket, error)
 		GetAllSponsorship(ctx context.Context, tx *gorm.DB) ([]entity.Sponsorship, error)
 		GetAllSpeaker(ctx context.Context, tx *gorm.DB) ([]entity.Speaker, error)
 		GetAllMerch(ctx context.Context, tx *gorm.DB) ([]entity.Merch, error)
 		GetAllBundle(ctx context.Context, tx *gorm.DB, bundleType string) ([]entity.Bundle, error)
 		GetTicketByID(ctx context.Context, tx *gorm.DB, ticketID string) (entity.Ticket, bool, error)
+		GetTicketByIDForUpdate(ctx context.Context, tx *gorm.DB, ticketID string) (entity.Ticket, bool, error)
 		GetBundleByID(ctx context.Context, tx *gorm.DB, bundleID string) (entity.Bundle, bool, error)
+		GetBundleByIDForUpdate(ctx context.Context, tx *gorm.DB, bundleID string) (entity.Bundle, bool, error)
 		GetTransactionByOrderID(ctx context.Context, tx *gorm.DB, orderID string) (entity.Transaction, bool, error)
+		GetTransactionByID(ctx context.Context, tx *gorm.DB, transactionID string) (entity.Transaction, bool, error)
+		GetTransactionByIDForUpdate(ctx context.Context, tx *gorm.DB, transactionID string) (entity.Transaction, bool, error)
+		CountMaxEmailOutboxPerTicketForm(ctx context.Context, tx *gorm.DB, userID string, ticketFormIDs []string, since time.Time) (int64, error)
+		CountEmailOutboxByToEmailAndKind(ctx context.Context, tx *gorm.DB, email, kind string, since time.Time) (int64, error)
 		GetStudentAmbassadorByReferalCode(ctx context.Context, tx *gorm.DB, referalCode string) (entity.StudentAmbassador, bool, error)
+		GetActiveWaitlistByUserIDAndTicketID(ctx context.Context, tx *gorm.DB, userID, ticketID string) (entity.Waitlist, bool, error)
+		GetAllWaitlistByUserID(ctx context.Context, tx *gorm.DB, userID string) ([]entity.Waitlist, error)
+		GetWaitlistByID(ctx context.Context, tx *gorm.DB, waitlistID string) (entity.Waitlist, bool, error)
+		GetWaitlistByOfferTokenHashForUpdate(ctx context.Context, tx *gorm.DB, tokenHash string) (entity.Waitlist, bool, error)
+		GetTicketFormByID(ctx context.Context, tx *gorm.DB, ticketFormID string) (entity.TicketForm, bool, error)
+		GetTicketFormByIDForUpdate(ctx context.Context, tx *gorm.DB, ticketFormID string) (entity.TicketForm, bool, error)
+		GetTicketTransferByToTicketFormID(ctx context.Context, tx *gorm.DB, ticketFormID string) (entity.TicketTransfer, bool, error)
+		GetAllTicketTransferByOriginTicketFormID(ctx context.Context, tx *gorm.DB, originTicketFormID string) ([]entity.TicketTransfer, error)
+		GetAllEvent(ctx context.Context, tx *gorm.DB) ([]entity.Event, error)
+		GetEventByID(ctx context.Context, tx *gorm.DB, eventID string) (entity.Event, bool, error)
 
 		// UPDATE / PATCH
 		UpdateUser(ctx context.Context, tx *gorm.DB, user entity.User) error
@@ -35,6 +58,13 @@ type (
 		UpdateTicketQuota(ctx context.Context, tx *gorm.DB, ticketID string, newQuota int) error
 		UpdateTransactionTicket(ctx context.Context, tx *gorm.DB, transaction entity.Transaction) error
 		UpdateMaxReferal(ctx context.Context, tx *gorm.DB, saID string, maxReferal int) error
+		UpdateWaitlist(ctx context.Context, tx *gorm.DB, waitlist entity.Waitlist, fromStatus entity.WaitlistStatus) (bool, error)
+		UpdateTicketForm(ctx context.Context, tx *gorm.DB, ticketForm entity.TicketForm) error
+		RevokeTicketFormQRByTransactionID(ctx context.Context, tx *gorm.DB, transactionID string) error
+		RestoreTicketQuota(ctx context.Context, tx *gorm.DB, ticketID string, amount int) error
+		RestoreBundleQuota(ctx context.Context, tx *gorm.DB, bundleID string, amount int) error
+		UpdateTicketFormWhatsAppOptIn(ctx context.Context, tx *gorm.DB, ticketFormID string, optIn bool) error
+		UpdateUserEmailVerification(ctx context.Context, tx *gorm.DB, user entity.User) error
 
 		// DELETE / DELETE
 	}
@@ -72,6 +102,36 @@ func (ur *UserRepository) CreateTicketForm(ctx context.Context, tx *gorm.DB, tic
 
 	return tx.WithContext(ctx).Create(&ticketForm).Error
 }
+func (ur *UserRepository) CreateWaitlist(ctx context.Context, tx *gorm.DB, waitlist entity.Waitlist) error {
+	if tx == nil {
+		tx = ur.db
+	}
+
+	return tx.WithContext(ctx).Create(&waitlist).Error
+}
+func (ur *UserRepository) CreateTicketTransfer(ctx context.Context, tx *gorm.DB, ticketTransfer entity.TicketTransfer) error {
+	if tx == nil {
+		tx = ur.db
+	}
+
+	return tx.WithContext(ctx).Create(&ticketTransfer).Error
+}
+func (ur *UserRepository) CreateEmailOutbox(ctx context.Context, tx *gorm.DB, outbox entity.EmailOutbox) error {
+	if tx == nil {
+		tx = ur.db
+	}
+
+	return tx.WithContext(ctx).Create(&outbox).Error
+}
+
+// CreateNotificationOutbox melewati pesan yang DedupeKey-nya sudah ada.
+func (ur *UserRepository) CreateNotificationOutbox(ctx context.Context, tx *gorm.DB, outbox entity.NotificationOutbox) error {
+	if tx == nil {
+		tx = ur.db
+	}
+
+	return tx.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&outbox).Error
+}
 
 // READ / GET
 func (ur *UserRepository) GetUserByID(ctx context.Context, tx *gorm.DB, userID string) (entity.User, bool, error) {
@@ -98,7 +158,7 @@ func (ur *UserRepository) GetUserByEmail(ctx context.Context, tx *gorm.DB, email
 
 	return user, true, nil
 }
-func (ur *UserRepository) GetAllTicket(ctx context.Context, tx *gorm.DB) ([]entity.Ticket, error) {
+func (ur *UserRepository) GetAllTicket(ctx context.Context, tx *gorm.DB, eventID string) ([]entity.Ticket, error) {
 	if tx == nil {
 		tx = ur.db
 	}
@@ -108,7 +168,11 @@ func (ur *UserRepository) GetAllTicket(ctx context.Context, tx *gorm.DB) ([]enti
 		err     error
 	)
 
-	query := tx.WithContext(ctx).Model(&entity.Ticket{})
+	query := tx.WithContext(ctx).Model(&entity.Ticket{}).Preload("Event")
+
+	if eventID != "" {
+		query = query.Where("event_id = ?", eventID)
+	}
 
 	if err := query.Order(`"createdAt" DESC`).Find(&tickets).Error; err != nil {
 		return []entity.Ticket{}, err
@@ -180,7 +244,7 @@ func (ur *UserRepository) GetAllBundle(ctx context.Context, tx *gorm.DB, bundleT
 		err     error
 	)
 
-	query := tx.WithContext(ctx).Model(&entity.Bundle{}).Preload("BundleItems.Merch")
+	query := tx.WithContext(ctx).Model(&entity.Bundle{}).Preload("Event").Preload("BundleItems.Merch")
 
 	if bundleType != "" {
 		query = query.Where("type = ?", bundleType)
@@ -198,7 +262,28 @@ func (ur *UserRepository) GetTicketByID(ctx context.Context, tx *gorm.DB, ticket
 	}
 
 	var ticket entity.Ticket
-	if err := tx.WithContext(ctx).Where("id = ?", ticketID).Take(&ticket).Error; err != nil {
+	if err := tx.WithContext(ctx).
+		Preload("FormFields", func(db *gorm.DB) *gorm.DB { return db.Order("position ASC") }).
+		Where("id = ?", ticketID).
+		Take(&ticket).Error; err != nil {
+		return entity.Ticket{}, false, err
+	}
+
+	return ticket, true, nil
+}
+
+// GetTicketByIDForUpdate mengunci baris ticket selama checkout supaya quota tidak dipakai dua transaksi.
+func (ur *UserRepository) GetTicketByIDForUpdate(ctx context.Context, tx *gorm.DB, ticketID string) (entity.Ticket, bool, error) {
+	if tx == nil {
+		tx = ur.db
+	}
+
+	var ticket entity.Ticket
+	if err := tx.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Preload("FormFields", func(db *gorm.DB) *gorm.DB { return db.Order("position ASC") }).
+		Where("id = ?", ticketID).
+		Take(&ticket).Error; err != nil {
 		return entity.Ticket{}, false, err
 	}
 
@@ -216,18 +301,85 @@ func (ur *UserRepository) GetBundleByID(ctx context.Context, tx *gorm.DB, bundle
 
 	return bundle, true, nil
 }
+func (ur *UserRepository) GetBundleByIDForUpdate(ctx context.Context, tx *gorm.DB, bundleID string) (entity.Bundle, bool, error) {
+	if tx == nil {
+		tx = ur.db
+	}
+
+	var bundle entity.Bundle
+	if err := tx.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).Preload("BundleItems.Merch.MerchImages").Where("id = ?", bundleID).Take(&bundle).Error; err != nil {
+		return entity.Bundle{}, false, err
+	}
+
+	return bundle, true, nil
+}
 func (ur *UserRepository) GetTransactionByOrderID(ctx context.Context, tx *gorm.DB, orderID string) (entity.Transaction, bool, error) {
 	if tx == nil {
 		tx = ur.db
 	}
 
 	var transaction entity.Transaction
-	if err := tx.WithContext(ctx).Preload("TicketForms").Where("order_id = ?", orderID).Take(&transaction).Error; err != nil {
+	if err := tx.WithContext(ctx).Preload("TicketForms").Preload("Ticket.Event").Preload("Bundle.Event").Preload("User").Where("order_id = ?", orderID).Take(&transaction).Error; err != nil {
 		return entity.Transaction{}, false, err
 	}
 
 	return transaction, true, nil
 }
+func (ur *UserRepository) GetTransactionByID(ctx context.Context, tx *gorm.DB, transactionID string) (entity.Transaction, bool, error) {
+	if tx == nil {
+		tx = ur.db
+	}
+
+	var transaction entity.Transaction
+	if err := tx.WithContext(ctx).Preload("TicketForms").Preload("Ticket.Event").Preload("Bundle.Event").Where("id = ?", transactionID).Take(&transaction).Error; err != nil {
+		return entity.Transaction{}, false, err
+	}
+
+	return transaction, true, nil
+}
+func (ur *UserRepository) GetTransactionByIDForUpdate(ctx context.Context, tx *gorm.DB, transactionID string) (entity.Transaction, bool, error) {
+	if tx == nil {
+		tx = ur.db
+	}
+
+	var transaction entity.Transaction
+	if err := tx.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).Preload("TicketForms").Preload("Ticket.Event").Preload("Bundle.Event").Where("id = ?", transactionID).Take(&transaction).Error; err != nil {
+		return entity.Transaction{}, false, err
+	}
+
+	return transaction, true, nil
+}
+
+// CountMaxEmailOutboxPerTicketForm mengembalikan jumlah email terbanyak untuk salah satu ticket form sejak since.
+func (ur *UserRepository) CountMaxEmailOutboxPerTicketForm(ctx context.Context, tx *gorm.DB, userID string, ticketFormIDs []string, since time.Time) (int64, error) {
+	if tx == nil {
+		tx = ur.db
+	}
+
+	perForm := tx.Model(&entity.EmailOutbox{}).
+		Select("COUNT(*) AS sent").
+		Where(`requested_by = ? AND ticket_form_id IN ? AND "createdAt" >= ?`, userID, ticketFormIDs, since).
+		Group("ticket_form_id")
+
+	var count int64
+	if err := tx.WithContext(ctx).Table("(?) AS per_form", perForm).Select("COALESCE(MAX(sent), 0)").Scan(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+func (ur *UserRepository) CountEmailOutboxByToEmailAndKind(ctx context.Context, tx *gorm.DB, email, kind string, since time.Time) (int64, error) {
+	if tx == nil {
+		tx = ur.db
+	}
+
+	var count int64
+	if err := tx.WithContext(ctx).Model(&entity.EmailOutbox{}).Where(`LOWER(to_email) = LOWER(?) AND kind = ? AND "createdAt" >= ?`, email, kind, since).Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
 func (ur *UserRepository) GetStudentAmbassadorByReferalCode(ctx context.Context, tx *gorm.DB, referalCode string) (entity.StudentAmbassador, bool, error) {
 	if tx == nil {
 		tx = ur.db
@@ -240,6 +392,142 @@ func (ur *UserRepository) GetStudentAmbassadorByReferalCode(ctx context.Context,
 
 	return studentAmbassador, true, nil
 }
+func (ur *UserRepository) GetActiveWaitlistByUserIDAndTicketID(ctx context.Context, tx *gorm.DB, userID, ticketID string) (entity.Waitlist, bool, error) {
+	if tx == nil {
+		tx = ur.db
+	}
+
+	var waitlist entity.Waitlist
+	if err := tx.WithContext(ctx).
+		Where("user_id = ? AND ticket_id = ? AND status IN ?", userID, ticketID, []string{constants.ENUM_WAITLIST_STATUS_WAITING, constants.ENUM_WAITLIST_STATUS_OFFERED}).
+		Take(&waitlist).Error; err != nil {
+		return entity.Waitlist{}, false, err
+	}
+
+	return waitlist, true, nil
+}
+func (ur *UserRepository) GetAllWaitlistByUserID(ctx context.Context, tx *gorm.DB, userID string) ([]entity.Waitlist, error) {
+	if tx == nil {
+		tx = ur.db
+	}
+
+	var waitlists []entity.Waitlist
+	if err := tx.WithContext(ctx).Preload("User").Preload("Ticket").Where("user_id = ?", userID).Order(`"createdAt" DESC`).Find(&waitlists).Error; err != nil {
+		return []entity.Waitlist{}, err
+	}
+
+	return waitlists, nil
+}
+func (ur *UserRepository) GetWaitlistByID(ctx context.Context, tx *gorm.DB, waitlistID string) (entity.Waitlist, bool, error) {
+	if tx == nil {
+		tx = ur.db
+	}
+
+	var waitlist entity.Waitlist
+	if err := tx.WithContext(ctx).Preload("User").Preload("Ticket").Where("id = ?", waitlistID).Take(&waitlist).Error; err != nil {
+		return entity.Waitlist{}, false, err
+	}
+
+	return waitlist, true, nil
+}
+
+// GetWaitlistByOfferTokenHashForUpdate mengunci offer supaya token yang sama tidak dipakai dua checkout.
+func (ur *UserRepository) GetWaitlistByOfferTokenHashForUpdate(ctx context.Context, tx *gorm.DB, tokenHash string) (entity.Waitlist, bool, error) {
+	if tx == nil {
+		tx = ur.db
+	}
+
+	var waitlist entity.Waitlist
+	if err := tx.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).Where("offer_token_hash = ?", tokenHash).Take(&waitlist).Error; err != nil {
+		return entity.Waitlist{}, false, err
+	}
+
+	return waitlist, true, nil
+}
+func (ur *UserRepository) GetTicketFormByID(ctx context.Context, tx *gorm.DB, ticketFormID string) (entity.TicketForm, bool, error) {
+	if tx == nil {
+		tx = ur.db
+	}
+
+	var ticketForm entity.TicketForm
+	if err := tx.WithContext(ctx).Preload("GuestAttendances", ActiveGuestAttendances).Preload("Transaction.Ticket").Preload("Transaction.Bundle").Where("id = ?", ticketFormID).Take(&ticketForm).Error; err != nil {
+		return entity.TicketForm{}, false, err
+	}
+
+	return ticketForm, true, nil
+}
+
+// GetTicketFormByIDForUpdate mengunci ticket form supaya dua transfer bersamaan tidak sama-sama lolos.
+func (ur *UserRepository) GetTicketFormByIDForUpdate(ctx context.Context, tx *gorm.DB, ticketFormID string) (entity.TicketForm, bool, error) {
+	if tx == nil {
+		tx = ur.db
+	}
+
+	var ticketForm entity.TicketForm
+	if err := tx.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).Preload("GuestAttendances", ActiveGuestAttendances).Preload("Transaction.Ticket").Preload("Transaction.Bundle").Where("id = ?", ticketFormID).Take(&ticketForm).Error; err != nil {
+		return entity.TicketForm{}, false, err
+	}
+
+	return ticketForm, true, nil
+}
+func (ur *UserRepository) GetTicketTransferByToTicketFormID(ctx context.Context, tx *gorm.DB, ticketFormID string) (entity.TicketTransfer, bool, error) {
+	if tx == nil {
+		tx = ur.db
+	}
+
+	var ticketTransfer entity.TicketTransfer
+	if err := tx.WithContext(ctx).Where("to_ticket_form_id = ?", ticketFormID).Take(&ticketTransfer).Error; err != nil {
+		return entity.TicketTransfer{}, false, err
+	}
+
+	return ticketTransfer, true, nil
+}
+func (ur *UserRepository) GetAllTicketTransferByOriginTicketFormID(ctx context.Context, tx *gorm.DB, originTicketFormID string) ([]entity.TicketTransfer, error) {
+	if tx == nil {
+		tx = ur.db
+	}
+
+	var ticketTransfers []entity.TicketTransfer
+	if err := tx.WithContext(ctx).Where("origin_ticket_form_id = ?", originTicketFormID).Order(`"createdAt" ASC`).Find(&ticketTransfers).Error; err != nil {
+		return []entity.TicketTransfer{}, err
+	}
+
+	return ticketTransfers, nil
+}
+func (ur *UserRepository) GetAllEvent(ctx context.Context, tx *gorm.DB) ([]entity.Event, error) {
+	if tx == nil {
+		tx = ur.db
+	}
+
+	var (
+		events []entity.Event
+		err    error
+	)
+
+	query := tx.WithContext(ctx).Model(&entity.Event{}).Preload("Sessions", func(db *gorm.DB) *gorm.DB { return db.Order("start_at ASC") })
+
+	if err := query.Order("start_at ASC").Find(&events).Error; err != nil {
+		return []entity.Event{}, err
+	}
+
+	return events, err
+}
+func (ur *UserRepository) GetEventByID(ctx context.Context, tx *gorm.DB, eventID string) (entity.Event, bool, error) {
+	if tx == nil {
+		tx = ur.db
+	}
+
+	var event entity.Event
+	if err := tx.WithContext(ctx).
+		Preload("Sessions", func(db *gorm.DB) *gorm.DB { return db.Order("start_at ASC") }).
+		Preload("Tickets", func(db *gorm.DB) *gorm.DB { return db.Order(`"createdAt" DESC`) }).
+		Where("id = ?", eventID).
+		Take(&event).Error; err != nil {
+		return entity.Event{}, false, err
+	}
+
+	return event, true, nil
+}
 
 // UPDATE / PATCH
 func (ur *UserRepository) UpdateUser(ctx context.Context, tx *gorm.DB, user entity.User) error {
@@ -269,6 +557,26 @@ func (ur *UserRepository) UpdateBundleQuota(ctx context.Context, tx *gorm.DB, bu
 
 	return nil
 }
+func (ur *UserRepository) UpdateTicketFormWhatsAppOptIn(ctx context.Context, tx *gorm.DB, ticketFormID string, optIn bool) error {
+	if tx == nil {
+		tx = ur.db
+	}
+
+	return tx.WithContext(ctx).Model(&entity.TicketForm{}).Where("id = ?", ticketFormID).Update("whatsapp_opt_in", optIn).Error
+}
+
+// UpdateUserEmailVerification menulis kolom secara eksplisit karena UpdateUser melewati nilai kosong.
+func (ur *UserRepository) UpdateUserEmailVerification(ctx context.Context, tx *gorm.DB, user entity.User) error {
+	if tx == nil {
+		tx = ur.db
+	}
+
+	return tx.WithContext(ctx).Model(&entity.User{}).Where("id = ?", user.ID).Updates(map[string]interface{}{
+		"email":          user.Email,
+		"pending_email":  user.PendingEmail,
+		"email_verified": user.EmailVerified,
+	}).Error
+}
 func (ur *UserRepository) UpdateTicketQuota(ctx context.Context, tx *gorm.DB, ticketID string, newQuota int) error {
 	if tx == nil {
 		tx = ur.db
@@ -294,7 +602,7 @@ func (ur *UserRepository) UpdateTransactionTicket(ctx context.Context, tx *gorm.
 		tx = ur.db
 	}
 
-	return tx.WithContext(ctx).Where("id = ?", transaction.ID).Updates(&transaction).Error
+	return tx.WithContext(ctx).Omit(clause.Associations).Where("id = ?", transaction.ID).Updates(&transaction).Error
 }
 func (ur *UserRepository) UpdateMaxReferal(ctx context.Context, tx *gorm.DB, saID string, maxReferal int) error {
 	if tx == nil {
@@ -316,5 +624,47 @@ func (ur *UserRepository) UpdateMaxReferal(ctx context.Context, tx *gorm.DB, saI
 
 	return nil
 }
+func (ur *UserRepository) UpdateWaitlist(ctx context.Context, tx *gorm.DB, waitlist entity.Waitlist, fromStatus entity.WaitlistStatus) (bool, error) {
+	if tx == nil {
+		tx = ur.db
+	}
+
+	result := tx.WithContext(ctx).Where("id = ? AND status = ?", waitlist.ID, fromStatus).Updates(&waitlist)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+func (ur *UserRepository) UpdateTicketForm(ctx context.Context, tx *gorm.DB, ticketForm entity.TicketForm) error {
+	if tx == nil {
+		tx = ur.db
+	}
+
+	return tx.WithContext(ctx).Where("id = ?", ticketForm.ID).Updates(&ticketForm).Error
+}
+func (ur *UserRepository) RevokeTicketFormQRByTransactionID(ctx context.Context, tx *gorm.DB, transactionID string) error {
+	if tx == nil {
+		tx = ur.db
+	}
+
+	return tx.WithContext(ctx).Model(&entity.TicketForm{}).
+		Where("transaction_id = ? AND qr_revoked_at IS NULL", transactionID).
+		Update("qr_revoked_at", time.Now()).Error
+}
+func (ur *UserRepository) RestoreTicketQuota(ctx context.Context, tx *gorm.DB, ticketID string, amount int) error {
+	if tx == nil {
+		tx = ur.db
+	}
+
+	return restoreTicketQuota(ctx, tx, ticketID, amount)
+}
+func (ur *UserRepository) RestoreBundleQuota(ctx context.Context, tx *gorm.DB, bundleID string, amount int) error {
+	if tx == nil {
+		tx = ur.db
+	}
+
+	return restoreBundleQuota(ctx, tx, bundleID, amount)
+}
 
 // DELETE / DELETE
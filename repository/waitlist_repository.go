@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Amierza/TedXBackend/constants"
+	"github.com/Amierza/TedXBackend/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	IWaitlistRepository interface {
+		RunInTransaction(ctx context.Context, fn func(txRepo IWaitlistRepository) error) error
+
+		// CREATE / POST
+		CreateEmailOutbox(ctx context.Context, tx *gorm.DB, outbox entity.EmailOutbox) error
+
+		// READ / GET
+		GetTicketByIDForUpdate(ctx context.Context, tx *gorm.DB, ticketID string) (entity.Ticket, bool, error)
+		GetNextWaitingWaitlistByTicketID(ctx context.Context, tx *gorm.DB, ticketID string) (entity.Waitlist, bool, error)
+		GetExpiredWaitlistOffers(ctx context.Context, tx *gorm.DB, now time.Time) ([]entity.Waitlist, error)
+
+		// UPDATE / PATCH
+		UpdateWaitlist(ctx context.Context, tx *gorm.DB, waitlist entity.Waitlist, fromStatus entity.WaitlistStatus) (bool, error)
+		UpdateTicketQuota(ctx context.Context, tx *gorm.DB, ticketID string, newQuota int) error
+		RestoreTicketQuota(ctx context.Context, tx *gorm.DB, ticketID string, amount int) error
+	}
+
+	WaitlistRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewWaitlistRepository(db *gorm.DB) *WaitlistRepository {
+	return &WaitlistRepository{
+		db: db,
+	}
+}
+
+func (wr *WaitlistRepository) RunInTransaction(ctx context.Context, fn func(txRepo IWaitlistRepository) error) error {
+	return wr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txRepo := &WaitlistRepository{db: tx}
+		return fn(txRepo)
+	})
+}
+
+// CREATE / POST
+func (wr *WaitlistRepository) CreateEmailOutbox(ctx context.Context, tx *gorm.DB, outbox entity.EmailOutbox) error {
+	if tx == nil {
+		tx = wr.db
+	}
+
+	return tx.WithContext(ctx).Create(&outbox).Error
+}
+
+// READ / GET
+func (wr *WaitlistRepository) GetTicketByIDForUpdate(ctx context.Context, tx *gorm.DB, ticketID string) (entity.Ticket, bool, error) {
+	if tx == nil {
+		tx = wr.db
+	}
+
+	var ticket entity.Ticket
+	if err := tx.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", ticketID).Take(&ticket).Error; err != nil {
+		return entity.Ticket{}, false, err
+	}
+
+	return ticket, true, nil
+}
+func (wr *WaitlistRepository) GetNextWaitingWaitlistByTicketID(ctx context.Context, tx *gorm.DB, ticketID string) (entity.Waitlist, bool, error) {
+	if tx == nil {
+		tx = wr.db
+	}
+
+	var waitlist entity.Waitlist
+	err := tx.WithContext(ctx).
+		Preload("User").
+		Preload("Ticket").
+		Where("ticket_id = ? AND status = ?", ticketID, constants.ENUM_WAITLIST_STATUS_WAITING).
+		Order(`"createdAt" ASC`).
+		Take(&waitlist).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return entity.Waitlist{}, false, nil
+	}
+	if err != nil {
+		return entity.Waitlist{}, false, err
+	}
+
+	return waitlist, true, nil
+}
+func (wr *WaitlistRepository) GetExpiredWaitlistOffers(ctx context.Context, tx *gorm.DB, now time.Time) ([]entity.Waitlist, error) {
+	if tx == nil {
+		tx = wr.db
+	}
+
+	// offer yang sedang dikunci checkout dilewati, diambil lagi di putaran berikutnya kalau checkout gagal
+	var waitlists []entity.Waitlist
+	if err := tx.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("status = ? AND offer_expires_at < ?", constants.ENUM_WAITLIST_STATUS_OFFERED, now).
+		Find(&waitlists).Error; err != nil {
+		return []entity.Waitlist{}, err
+	}
+
+	return waitlists, nil
+}
+
+// UPDATE / PATCH
+func (wr *WaitlistRepository) UpdateWaitlist(ctx context.Context, tx *gorm.DB, waitlist entity.Waitlist, fromStatus entity.WaitlistStatus) (bool, error) {
+	if tx == nil {
+		tx = wr.db
+	}
+
+	result := tx.WithContext(ctx).Where("id = ? AND status = ?", waitlist.ID, fromStatus).Updates(&waitlist)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+func (wr *WaitlistRepository) UpdateTicketQuota(ctx context.Context, tx *gorm.DB, ticketID string, newQuota int) error {
+	if tx == nil {
+		tx = wr.db
+	}
+
+	result := tx.WithContext(ctx).
+		Model(&entity.Ticket{}).
+		Where("id = ?", ticketID).
+		Update("quota", newQuota)
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.New("ticket not found or no change made")
+	}
+
+	return nil
+}
+func (wr *WaitlistRepository) RestoreTicketQuota(ctx context.Context, tx *gorm.DB, ticketID string, amount int) error {
+	if tx == nil {
+		tx = wr.db
+	}
+
+	return restoreTicketQuota(ctx, tx, ticketID, amount)
+}
@@ -1,6 +1,7 @@
 package routes
 
 import (
+	"github.com/Amierza/TedXBackend/constants"
 	"github.com/Amierza/TedXBackend/handler"
 	"github.com/Amierza/TedXBackend/middleware"
 	"github.com/Amierza/TedXBackend/service"
@@ -13,7 +14,29 @@ func Admin(route *gin.Engine, adminHandler handler.IAdminHandler, jwtService ser
 		// Authentication
 		routes.POST("/login", adminHandler.Login)
 
-		routes.Use(middleware.Authentication(jwtService), middleware.RouteAccessControl(jwtService))
+		// Password Reset
+		routes.POST("/forgot-password", adminHandler.ForgotPassword)
+		routes.POST("/reset-password", adminHandler.ResetPassword)
+
+		// Check-in crew
+		crew := routes.Group("", middleware.Authentication(jwtService), middleware.RouteAccessControl(jwtService, constants.ENUM_ROLE_ADMIN, constants.ENUM_ROLE_CREW))
+		{
+			crew.GET("/get-all-event-session/:event-id", adminHandler.GetAllEventSession)
+			crew.GET("/get-all-gate/:event-id", adminHandler.GetAllGate)
+			crew.GET("/get-detail-ticket-check-in/:qr-token", adminHandler.GetDetailTicketCheckIn)
+			crew.POST("/check-in/:qr-token", adminHandler.CheckIn)
+			crew.GET("/get-all-ticket-check-in", adminHandler.GetAllTicketCheckIn)
+			crew.GET("/get-event-occupancy/:event-id", adminHandler.GetEventOccupancy)
+			crew.GET("/lookup-attendee", adminHandler.LookupAttendee)
+			crew.POST("/help-desk-check-in/:ticket-form-id", adminHandler.HelpDeskCheckIn)
+			crew.GET("/get-check-in-manifest/:event-id", adminHandler.GetCheckInManifest)
+			crew.POST("/sync-check-in", adminHandler.SyncCheckIn)
+			crew.GET("/print-badge/:ticket-form-id", adminHandler.PrintBadge)
+			crew.GET("/get-merch-pickup/:qr-token", adminHandler.GetMerchPickup)
+			crew.POST("/hand-over-merch/:qr-token", adminHandler.HandOverMerch)
+		}
+
+		routes.Use(middleware.Authentication(jwtService), middleware.RouteAccessControl(jwtService, constants.ENUM_ROLE_ADMIN))
 		{
 			// User
 			routes.POST("/create-user", adminHandler.CreateUser)
@@ -22,6 +45,28 @@ func Admin(route *gin.Engine, adminHandler handler.IAdminHandler, jwtService ser
 			routes.PATCH("/update-user/:id", adminHandler.UpdateUser)
 			routes.DELETE("/delete-user/:id", adminHandler.DeleteUser)
 
+			// Crew
+			routes.POST("/create-crew-bulk", adminHandler.CreateCrewBulk)
+			routes.GET("/get-all-crew", adminHandler.GetAllCrew)
+			routes.POST("/expire-crew-bulk", adminHandler.ExpireCrewBulk)
+
+			// Event
+			routes.POST("/create-event", adminHandler.CreateEvent)
+			routes.GET("/get-all-event", adminHandler.GetAllEvent)
+			routes.GET("/get-detail-event/:id", adminHandler.GetDetailEvent)
+			routes.PATCH("/update-event/:id", adminHandler.UpdateEvent)
+			routes.DELETE("/delete-event/:id", adminHandler.DeleteEvent)
+
+			// Event Session
+			routes.POST("/create-event-session/:event-id", adminHandler.CreateEventSession)
+			routes.PATCH("/update-event-session/:id", adminHandler.UpdateEventSession)
+			routes.DELETE("/delete-event-session/:id", adminHandler.DeleteEventSession)
+
+			// Gate
+			routes.POST("/create-gate/:event-id", adminHandler.CreateGate)
+			routes.PATCH("/update-gate/:id", adminHandler.UpdateGate)
+			routes.DELETE("/delete-gate/:id", adminHandler.DeleteGate)
+
 			// Ticket
 			routes.POST("/create-ticket", adminHandler.CreateTicket)
 			routes.GET("/get-all-ticket", adminHandler.GetAllTicket)
@@ -29,6 +74,12 @@ func Admin(route *gin.Engine, adminHandler handler.IAdminHandler, jwtService ser
 			routes.PATCH("/update-ticket/:id", adminHandler.UpdateTicket)
 			routes.DELETE("/delete-ticket/:id", adminHandler.DeleteTicket)
 
+			// Ticket Form Field
+			routes.POST("/create-ticket-form-field/:ticket-id", adminHandler.CreateTicketFormField)
+			routes.GET("/get-all-ticket-form-field/:ticket-id", adminHandler.GetAllTicketFormField)
+			routes.PATCH("/update-ticket-form-field/:id", adminHandler.UpdateTicketFormField)
+			routes.DELETE("/delete-ticket-form-field/:id", adminHandler.DeleteTicketFormField)
+
 			// Sponsorship
 			routes.POST("/create-sponsorship", adminHandler.CreateSponsorship)
 			routes.GET("/get-all-sponsorship", adminHandler.GetAllSponsorship)
@@ -70,12 +121,55 @@ func Admin(route *gin.Engine, adminHandler handler.IAdminHandler, jwtService ser
 			routes.GET("/get-detail-transaction-ticket/:id", adminHandler.GetDetailTransactionTicket)
 
 			// Check-in
-			routes.GET("/get-detail-ticket-check-in/:ticket-form-id", adminHandler.GetDetailTicketCheckIn)
-			routes.POST("/check-in/:ticket-form-id", adminHandler.CheckIn)
-			routes.GET("/get-all-ticket-check-in", adminHandler.GetAllTicketCheckIn)
+			routes.POST("/revoke-ticket-qr/:ticket-form-id", adminHandler.RevokeTicketQR)
+			routes.POST("/reissue-ticket-qr/:ticket-form-id", adminHandler.ReissueTicketQR)
+			routes.POST("/void-check-in/:id", adminHandler.VoidCheckIn)
+			routes.GET("/stream-check-in-feed", adminHandler.StreamCheckInFeed)
+
+			// Attendee Export
+			routes.GET("/export-attendee", adminHandler.ExportAttendee)
+
+			// Badge
+			routes.GET("/print-event-badges/:event-id", adminHandler.PrintEventBadges)
+
+			// Merch Pickup
+			routes.GET("/get-outstanding-merch-pickup", adminHandler.GetOutstandingMerchPickup)
 
 			// Dashboard Stats
 			routes.GET("/get-all-stats", adminHandler.GetAllStats)
+
+			// Waitlist
+			routes.GET("/get-all-waitlist", adminHandler.GetAllWaitlist)
+			routes.PATCH("/cancel-waitlist/:id", adminHandler.CancelWaitlist)
+			routes.POST("/offer-waitlist/:ticket-id", adminHandler.OfferWaitlist)
+
+			// Ticket Transfer
+			routes.GET("/get-ticket-transfer-history/:ticket-form-id", adminHandler.GetTicketTransferHistory)
+
+			// Email Outbox
+			routes.GET("/get-all-email-outbox", adminHandler.GetAllEmailOutbox)
+			routes.POST("/retry-email-outbox/:id", adminHandler.RetryEmailOutbox)
+
+			// Notification Outbox
+			routes.GET("/get-all-notification-outbox", adminHandler.GetAllNotificationOutbox)
+			routes.POST("/retry-notification-outbox/:id", adminHandler.RetryNotificationOutbox)
+
+			// Email Template
+			routes.POST("/create-email-template", adminHandler.CreateEmailTemplate)
+			routes.GET("/get-all-email-template", adminHandler.GetAllEmailTemplate)
+			routes.GET("/get-detail-email-template/:id", adminHandler.GetDetailEmailTemplate)
+			routes.PATCH("/update-email-template/:id", adminHandler.UpdateEmailTemplate)
+			routes.DELETE("/delete-email-template/:id", adminHandler.DeleteEmailTemplate)
+			routes.POST("/preview-email-template", adminHandler.PreviewEmailTemplate)
+
+			// Resend E-Ticket
+			routes.POST("/resend-e-ticket/:transaction-id", adminHandler.ResendETicket)
+
+			// Broadcast
+			routes.POST("/count-broadcast-recipient", adminHandler.CountBroadcastRecipient)
+			routes.POST("/create-broadcast", adminHandler.CreateBroadcast)
+			routes.GET("/get-all-broadcast", adminHandler.GetAllBroadcast)
+			routes.GET("/get-detail-broadcast/:id", adminHandler.GetDetailBroadcast)
 		}
 	}
 }
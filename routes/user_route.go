@@ -13,10 +13,24 @@ func User(route *gin.Engine, userHandler handler.IUserHandler, jwtService servic
 		// Authentication
 		routes.POST("/login", userHandler.Login)
 
+		// Email Verification
+		routes.POST("/verify-email", userHandler.VerifyEmail)
+
+		// Password Reset
+		routes.POST("/forgot-password", userHandler.ForgotPassword)
+		routes.POST("/reset-password", userHandler.ResetPassword)
+
+		// Event
+		routes.GET("/get-all-event", userHandler.GetAllEvent)
+		routes.GET("/get-detail-event/:id", userHandler.GetDetailEvent)
+
 		// Ticket
 		routes.GET("/get-all-ticket", userHandler.GetAllTicket)
 		routes.GET("/get-detail-ticket/:id", userHandler.GetDetailTicket)
 
+		// Availability
+		routes.GET("/stream-availability", userHandler.StreamAvailability)
+
 		// Sponsorship
 		routes.GET("/get-all-sponsorship", userHandler.GetAllSponsorship)
 
@@ -29,6 +43,9 @@ func User(route *gin.Engine, userHandler handler.IUserHandler, jwtService servic
 		// Bundle
 		routes.GET("/get-all-bundle", userHandler.GetAllBundle)
 
+		// Ticket QR
+		routes.GET("/ticket-qr", userHandler.GetTicketQRCode)
+
 		// Webhook for Midtrans
 		routes.POST("/update-transaction-ticket", userHandler.UpdateTransactionTicket)
 
@@ -37,12 +54,28 @@ func User(route *gin.Engine, userHandler handler.IUserHandler, jwtService servic
 			// User
 			routes.GET("/get-detail-user", userHandler.GetDetailUser)
 			routes.PATCH("/update-user/:id", userHandler.UpdateUser)
+			routes.POST("/resend-email-verification", userHandler.ResendEmailVerification)
 
 			// Check Referal Code
 			routes.POST("/check-referal-code", userHandler.CheckReferalCode)
 
 			// Snap for trigger midtrans
 			routes.POST("/create-transaction-ticket", userHandler.CreateTransactionTicket)
+
+			// Waitlist
+			routes.POST("/join-waitlist/:ticket-id", userHandler.JoinWaitlist)
+			routes.GET("/get-my-waitlist", userHandler.GetMyWaitlist)
+			routes.DELETE("/leave-waitlist/:id", userHandler.LeaveWaitlist)
+
+			// Ticket Transfer
+			routes.POST("/transfer-ticket/:ticket-form-id", userHandler.TransferTicket)
+			routes.GET("/get-ticket-transfer-history/:ticket-form-id", userHandler.GetTicketTransferHistory)
+
+			// Resend E-Ticket
+			routes.POST("/resend-e-ticket/:transaction-id", userHandler.ResendETicket)
+
+			// Notification
+			routes.PATCH("/update-whatsapp-opt-in/:ticket-form-id", userHandler.UpdateWhatsAppOptIn)
 		}
 	}
 }
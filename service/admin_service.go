@@ -1,11 +1,18 @@
 package service
 
 import (
+	"bytes"
 	"context"
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
 	"io"
+	"log"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -14,7 +21,8 @@ import (
 	"github.com/Amierza/TedXBackend/entity"
 	"github.com/Amierza/TedXBackend/helpers"
 	"github.com/Amierza/TedXBackend/repository"
-	"github.com/Amierza/TedXBackend/utils"
+	"github.com/Amierza/TedXBackend/utils/badge"
+	emailtemplate "github.com/Amierza/TedXBackend/utils/email_template"
 	"github.com/google/uuid"
 )
 
@@ -23,6 +31,10 @@ type (
 		// Authentication
 		Login(ctx context.Context, req dto.LoginRequest) (dto.LoginResponse, error)
 
+		// Password Reset
+		ForgotPassword(ctx context.Context, req dto.ForgotPasswordRequest) error
+		ResetPassword(ctx context.Context, req dto.ResetPasswordRequest) (dto.ResetPasswordResponse, error)
+
 		// User
 		CreateUser(ctx context.Context, req dto.CreateUserRequest) (dto.UserResponse, error)
 		GetAllUser(ctx context.Context, roleName string) ([]dto.UserResponse, error)
@@ -31,6 +43,31 @@ type (
 		UpdateUser(ctx context.Context, req dto.UpdateUserRequest) (dto.UserResponse, error)
 		DeleteUser(ctx context.Context, req dto.DeleteUserRequest) (dto.UserResponse, error)
 
+		// Crew
+		CreateCrewBulk(ctx context.Context, req dto.CreateCrewBulkRequest) ([]dto.CrewResponse, error)
+		GetAllCrew(ctx context.Context, filter dto.CrewFilterQuery) ([]dto.CrewResponse, error)
+		ExpireCrewBulk(ctx context.Context, req dto.ExpireCrewBulkRequest) (dto.ExpireCrewBulkResponse, error)
+
+		// Event
+		CreateEvent(ctx context.Context, req dto.CreateEventRequest) (dto.EventResponse, error)
+		GetAllEvent(ctx context.Context) ([]dto.EventResponse, error)
+		GetAllEventWithPagination(ctx context.Context, req dto.PaginationRequest) (dto.EventPaginationResponse, error)
+		GetDetailEvent(ctx context.Context, eventID string) (dto.EventResponse, error)
+		UpdateEvent(ctx context.Context, req dto.UpdateEventRequest) (dto.EventResponse, error)
+		DeleteEvent(ctx context.Context, req dto.DeleteEventRequest) (dto.EventResponse, error)
+
+		// Event Session
+		CreateEventSession(ctx context.Context, req dto.CreateEventSessionRequest) (dto.EventSessionResponse, error)
+		GetAllEventSession(ctx context.Context, eventID string) ([]dto.EventSessionResponse, error)
+		UpdateEventSession(ctx context.Context, req dto.UpdateEventSessionRequest) (dto.EventSessionResponse, error)
+		DeleteEventSession(ctx context.Context, eventSessionID string) (dto.EventSessionResponse, error)
+
+		// Gate
+		CreateGate(ctx context.Context, req dto.CreateGateRequest) (dto.GateResponse, error)
+		GetAllGate(ctx context.Context, eventID string) ([]dto.GateResponse, error)
+		UpdateGate(ctx context.Context, req dto.UpdateGateRequest) (dto.GateResponse, error)
+		DeleteGate(ctx context.Context, gateID string) (dto.GateResponse, error)
+
 		// Ticket
 		CreateTicket(ctx context.Context, req dto.CreateTicketRequest) (dto.TicketResponse, error)
 		GetAllTicket(ctx context.Context) ([]dto.TicketResponse, error)
@@ -39,6 +76,12 @@ type (
 		UpdateTicket(ctx context.Context, req dto.UpdateTicketRequest) (dto.TicketResponse, error)
 		DeleteTicket(ctx context.Context, req dto.DeleteTicketRequest) (dto.TicketResponse, error)
 
+		// Ticket Form Field
+		CreateTicketFormField(ctx context.Context, req dto.CreateTicketFormFieldRequest) (dto.TicketFormFieldResponse, error)
+		GetAllTicketFormField(ctx context.Context, ticketID string) ([]dto.TicketFormFieldResponse, error)
+		UpdateTicketFormField(ctx context.Context, req dto.UpdateTicketFormFieldRequest) (dto.TicketFormFieldResponse, error)
+		DeleteTicketFormField(ctx context.Context, ticketFormFieldID string) (dto.TicketFormFieldResponse, error)
+
 		// Sponsorship
 		CreateSponsorship(ctx context.Context, req dto.CreateSponsorshipRequest) (dto.SponsorshipResponse, error)
 		GetAllSponsorship(ctx context.Context) ([]dto.SponsorshipResponse, error)
@@ -86,25 +129,107 @@ type (
 		GetDetailTransactionTicket(ctx context.Context, transactionTicketID string) (dto.TransactionResponse, error)
 
 		// Check-in
-		GetDetailTicketCheckIn(ctx context.Context, ticketFormIDStr string) (dto.TicketCheckInResponse, error)
-		CheckIn(ctx context.Context, ticketFormIDStr string) error
+		GetDetailTicketCheckIn(ctx context.Context, qrToken string) (dto.TicketCheckInResponse, error)
+		CheckIn(ctx context.Context, req dto.CheckInRequest) (dto.CheckInResponse, error)
 		GetAllTicketCheckIn(ctx context.Context, filter dto.CheckInFilterQuery) ([]dto.TicketCheckInResponse, error)
 		GetAllTicketCheckInWithPagination(ctx context.Context, req dto.PaginationRequest, filter dto.CheckInFilterQuery) (dto.TicketFormPaginationResponse, error)
+		RevokeTicketQR(ctx context.Context, ticketFormIDStr string) error
+		ReissueTicketQR(ctx context.Context, ticketFormIDStr string) error
+		GetEventOccupancy(ctx context.Context, eventIDStr string) (*dto.EventOccupancyResponse, error)
+		VoidCheckIn(ctx context.Context, req dto.VoidCheckInRequest) (dto.GuestAttendanceResponse, error)
+
+		// Check-in Feed
+		GetCheckInFeedSnapshot(ctx context.Context) (*dto.GuestStatResponse, error)
+		SubscribeCheckInFeed() (<-chan dto.CheckInFeedEvent, func())
+
+		// Help Desk
+		LookupAttendee(ctx context.Context, filter dto.AttendeeLookupQuery) ([]dto.AttendeeLookupCandidateResponse, error)
+		HelpDeskCheckIn(ctx context.Context, req dto.HelpDeskCheckInRequest) (dto.TicketCheckInResponse, error)
+
+		// Offline Check-in
+		GetCheckInManifest(ctx context.Context, eventIDStr string) (dto.CheckInManifestResponse, error)
+		SyncCheckIn(ctx context.Context, req dto.SyncCheckInRequest) (dto.SyncCheckInResponse, error)
+
+		// Attendee Export
+		ExportAttendee(ctx context.Context, filter dto.AttendeeExportFilterQuery) ([]byte, error)
+
+		// Badge
+		PrintBadge(ctx context.Context, ticketFormID string, query dto.BadgeQuery) ([]byte, error)
+		PrintEventBadges(ctx context.Context, eventID string, filter dto.BadgeBulkFilterQuery) ([]byte, error)
+
+		// Merch Pickup
+		GetMerchPickup(ctx context.Context, qrToken string) (dto.MerchPickupResponse, error)
+		HandOverMerch(ctx context.Context, req dto.HandOverMerchRequest) (dto.MerchPickupResponse, error)
+		GetOutstandingMerchPickup(ctx context.Context, filter dto.MerchPickupFilterQuery) (dto.MerchPickupReportResponse, error)
 
 		// Dashboard Stats
 		GetAllStats(ctx context.Context) (dto.DashboardStatResponse, error)
+
+		// Waitlist
+		GetAllWaitlist(ctx context.Context, filter dto.WaitlistFilterQuery) ([]dto.WaitlistResponse, error)
+		GetAllWaitlistWithPagination(ctx context.Context, req dto.PaginationRequest, filter dto.WaitlistFilterQuery) (dto.WaitlistPaginationResponse, error)
+		CancelWaitlist(ctx context.Context, waitlistID string) (dto.WaitlistResponse, error)
+		OfferWaitlist(ctx context.Context, ticketID string) error
+
+		// Ticket Transfer
+		GetTicketTransferHistory(ctx context.Context, ticketFormID string) ([]dto.TicketTransferResponse, error)
+
+		// Email Outbox
+		GetAllEmailOutboxWithPagination(ctx context.Context, req dto.PaginationRequest, filter dto.EmailOutboxFilterQuery) (dto.EmailOutboxPaginationResponse, error)
+		RetryEmailOutbox(ctx context.Context, outboxID string) (dto.EmailOutboxResponse, error)
+
+		// Notification Outbox
+		GetAllNotificationOutboxWithPagination(ctx context.Context, req dto.PaginationRequest, filter dto.NotificationOutboxFilterQuery) (dto.NotificationOutboxPaginationResponse, error)
+		RetryNotificationOutbox(ctx context.Context, outboxID string) (dto.NotificationOutboxResponse, error)
+
+		// Email Template
+		CreateEmailTemplate(ctx context.Context, req dto.CreateEmailTemplateRequest) (dto.EmailTemplateResponse, error)
+		GetAllEmailTemplate(ctx context.Context) ([]dto.EmailTemplateResponse, error)
+		GetDetailEmailTemplate(ctx context.Context, emailTemplateID string) (dto.EmailTemplateResponse, error)
+		UpdateEmailTemplate(ctx context.Context, req dto.UpdateEmailTemplateRequest) (dto.EmailTemplateResponse, error)
+		DeleteEmailTemplate(ctx context.Context, emailTemplateID string) (dto.EmailTemplateResponse, error)
+		PreviewEmailTemplate(ctx context.Context, req dto.PreviewEmailTemplateRequest) (dto.EmailTemplatePreviewResponse, error)
+
+		// Resend E-Ticket
+		ResendETicket(ctx context.Context, req dto.ResendETicketRequest) (dto.ResendETicketResponse, error)
+
+		// Broadcast
+		CountBroadcastRecipient(ctx context.Context, filter dto.BroadcastFilterRequest) (dto.BroadcastRecipientCountResponse, error)
+		CreateBroadcast(ctx context.Context, req dto.CreateBroadcastRequest) (dto.BroadcastResponse, error)
+		GetAllBroadcastWithPagination(ctx context.Context, req dto.PaginationRequest) (dto.BroadcastPaginationResponse, error)
+		GetDetailBroadcast(ctx context.Context, broadcastID string) (dto.BroadcastResponse, error)
+	}
+
+	// checkInRecord menyimpan data yang dimuat selama satu check-in.
+	checkInRecord struct {
+		ticketForm   entity.TicketForm
+		operator     entity.User
+		gate         *entity.Gate
+		eventSession *entity.EventSession
+		direction    entity.CheckInDirection
+		scannedAt    time.Time
 	}
 
 	AdminService struct {
-		adminRepo  repository.IAdminRepository
-		jwtService IJWTService
+		adminRepo            repository.IAdminRepository
+		jwtService           IJWTService
+		waitlistService      IWaitlistService
+		availabilityService  IAvailabilityService
+		checkInFeedService   ICheckInFeedService
+		emailTemplateService IEmailTemplateService
+		passwordResetService IPasswordResetService
 	}
 )
 
-func NewAdminService(adminRepo repository.IAdminRepository, jwtService IJWTService) *AdminService {
+func NewAdminService(adminRepo repository.IAdminRepository, jwtService IJWTService, waitlistService IWaitlistService, availabilityService IAvailabilityService, checkInFeedService ICheckInFeedService, emailTemplateService IEmailTemplateService, passwordResetService IPasswordResetService) *AdminService {
 	return &AdminService{
-		adminRepo:  adminRepo,
-		jwtService: jwtService,
+		adminRepo:            adminRepo,
+		jwtService:           jwtService,
+		waitlistService:      waitlistService,
+		availabilityService:  availabilityService,
+		checkInFeedService:   checkInFeedService,
+		emailTemplateService: emailTemplateService,
+		passwordResetService: passwordResetService,
 	}
 }
 
@@ -123,10 +248,14 @@ func (as *AdminService) Login(ctx context.Context, req dto.LoginRequest) (dto.Lo
 		return dto.LoginResponse{}, dto.ErrEmailNotFound
 	}
 
-	if user.Role != "admin" {
+	if user.Role != entity.Admin && user.Role != entity.Crew {
 		return dto.LoginResponse{}, dto.ErrDeniedAccess
 	}
 
+	if isCrewExpired(user) {
+		return dto.LoginResponse{}, dto.ErrCrewAccountExpired
+	}
+
 	checkPassword, err := helpers.CheckPassword(user.Password, []byte(req.Password))
 	if err != nil || !checkPassword {
 		return dto.LoginResponse{}, dto.ErrPasswordNotMatch
@@ -142,6 +271,14 @@ func (as *AdminService) Login(ctx context.Context, req dto.LoginRequest) (dto.Lo
 	}, nil
 }
 
+// Password Reset
+func (as *AdminService) ForgotPassword(ctx context.Context, req dto.ForgotPasswordRequest) error {
+	return as.passwordResetService.ForgotPassword(ctx, req, adminPasswordReset)
+}
+func (as *AdminService) ResetPassword(ctx context.Context, req dto.ResetPasswordRequest) (dto.ResetPasswordResponse, error) {
+	return as.passwordResetService.ResetPassword(ctx, req, adminPasswordReset)
+}
+
 // User
 func (as *AdminService) CreateUser(ctx context.Context, req dto.CreateUserRequest) (dto.UserResponse, error) {
 	if req.Email == "" || req.Name == "" || req.Password == "" {
@@ -176,9 +313,28 @@ func (as *AdminService) CreateUser(ctx context.Context, req dto.CreateUserReques
 		Role:          entity.Role(role),
 	}
 
-	err = as.adminRepo.CreateUser(ctx, nil, user)
+	// akun yang belum terverifikasi langsung dikirimi link verifikasi di transaksi yang sama
+	err = as.adminRepo.RunInTransaction(ctx, func(txRepo repository.IAdminRepository) error {
+		if err := txRepo.CreateUser(ctx, nil, user); err != nil {
+			return dto.ErrCreateUser
+		}
+
+		if user.EmailVerified != nil {
+			return nil
+		}
+
+		outbox, err := newEmailVerificationEmail(ctx, as.emailTemplateService, user, user.Email)
+		if err != nil {
+			return err
+		}
+		if err := txRepo.CreateEmailOutbox(ctx, nil, outbox); err != nil {
+			return dto.ErrCreateEmailOutbox
+		}
+
+		return nil
+	})
 	if err != nil {
-		return dto.UserResponse{}, dto.ErrCreateUser
+		return dto.UserResponse{}, err
 	}
 
 	return dto.UserResponse{
@@ -336,108 +492,295 @@ func (as *AdminService) DeleteUser(ctx context.Context, req dto.DeleteUserReques
 	return res, nil
 }
 
-// Ticket
-func (as *AdminService) CreateTicket(ctx context.Context, req dto.CreateTicketRequest) (dto.TicketResponse, error) {
-	if req.Name == "" || req.FileHeader == nil || req.FileReader == nil || req.Type == "" || req.EventDate == "" {
-		return dto.TicketResponse{}, dto.ErrEmptyFields
+// Crew
+func isCrewExpired(user entity.User) bool {
+	return user.Role == entity.Crew && user.CrewExpiresAt != nil && !time.Now().Before(*user.CrewExpiresAt)
+}
+
+// checkInOperator membaca ulang masa aktif dan event crew dari database.
+func (as *AdminService) checkInOperator(ctx context.Context) (entity.User, error) {
+	token := ctx.Value("Authorization").(string)
+
+	userIDStr, err := as.jwtService.GetUserIDByToken(token)
+	if err != nil {
+		return entity.User{}, dto.ErrGetUserIDFromToken
 	}
 
-	_, flag, err := as.adminRepo.GetTicketByName(ctx, nil, req.Name)
-	if err == nil || flag {
-		return dto.TicketResponse{}, dto.ErrTicketAlreadyExists
+	user, found, err := as.adminRepo.GetUserByID(ctx, nil, userIDStr)
+	if err != nil || !found {
+		return entity.User{}, dto.ErrUserNotFound
 	}
 
-	if len(req.Name) < 3 {
-		return dto.TicketResponse{}, dto.ErrTicketNameTooShort
+	if isCrewExpired(user) {
+		return entity.User{}, dto.ErrCrewAccountExpired
 	}
 
-	if !entity.IsValidTicketType(req.Type) {
-		return dto.TicketResponse{}, dto.ErrInvalidTicketType
+	return user, nil
+}
+func checkCrewEvent(operator entity.User, eventID *uuid.UUID) error {
+	if operator.Role != entity.Crew || operator.CrewEventID == nil {
+		return nil
 	}
 
-	if req.Price < 0 {
-		return dto.TicketResponse{}, dto.ErrPriceOutOfBound
+	if eventID == nil || *eventID != *operator.CrewEventID {
+		return dto.ErrCrewEventOutOfScope
 	}
 
-	if req.Quota < 0 {
-		return dto.TicketResponse{}, dto.ErrQuotaOutOfBound
+	return nil
+}
+
+// crewEventID: event kosong diisi event crew, event lain ditolak.
+func crewEventID(operator entity.User, eventID string) (string, error) {
+	if operator.Role != entity.Crew || operator.CrewEventID == nil {
+		return eventID, nil
 	}
 
-	ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(req.FileHeader.Filename), "."))
-	if ext != "jpg" && ext != "jpeg" && ext != "png" {
-		return dto.TicketResponse{}, dto.ErrInvalidExtensionPhoto
+	if eventID == "" {
+		return operator.CrewEventID.String(), nil
 	}
 
-	ticketName := strings.ToLower(req.Name)
-	ticketName = strings.ReplaceAll(ticketName, " ", "_")
+	if eventID != operator.CrewEventID.String() {
+		return "", dto.ErrCrewEventOutOfScope
+	}
 
-	fileName := fmt.Sprintf("ticket_%s_%s.%s", time.Now().Format("060102150405"), ticketName, ext)
+	return eventID, nil
+}
+func crewGateID(operator entity.User, gateID string) (string, error) {
+	if operator.Role != entity.Crew || operator.CrewGateID == nil {
+		return gateID, nil
+	}
 
-	saveDir := "assets/ticket"
-	if err := os.MkdirAll(saveDir, os.ModePerm); err != nil {
-		return dto.TicketResponse{}, dto.ErrCreateFile
+	if gateID == "" {
+		return operator.CrewGateID.String(), nil
 	}
-	savePath := filepath.Join(saveDir, fileName)
 
-	out, err := os.Create(savePath)
-	if err != nil {
-		return dto.TicketResponse{}, dto.ErrCreateFile
+	if gateID != operator.CrewGateID.String() {
+		return "", dto.ErrCrewGateOutOfScope
 	}
-	defer out.Close()
 
-	if _, err := io.Copy(out, req.FileReader); err != nil {
-		return dto.TicketResponse{}, dto.ErrSaveFile
+	return gateID, nil
+}
+func toCrewResponse(user entity.User) dto.CrewResponse {
+	return dto.CrewResponse{
+		ID:        user.ID,
+		Name:      user.Name,
+		Email:     user.Email,
+		EventID:   user.CrewEventID,
+		GateID:    user.CrewGateID,
+		ExpiresAt: user.CrewExpiresAt,
+		Expired:   isCrewExpired(user),
+	}
+}
+func (as *AdminService) resolveCrewGate(ctx context.Context, eventID *uuid.UUID, gateIDStr string) (*uuid.UUID, error) {
+	if gateIDStr == "" {
+		return nil, nil
 	}
-	req.Image = fileName
 
-	loc, err := time.LoadLocation("Asia/Jakarta")
+	if eventID == nil {
+		return nil, dto.ErrCrewGateRequiresEvent
+	}
+
+	gate, found, err := as.adminRepo.GetGateByID(ctx, nil, gateIDStr)
+	if err != nil || !found {
+		return nil, dto.ErrGateNotFound
+	}
+
+	if gate.EventID == nil || *gate.EventID != *eventID {
+		return nil, dto.ErrGateNotInEvent
+	}
+
+	return &gate.ID, nil
+}
+
+// CreateCrewBulk: password acak hanya dikembalikan di respons ini.
+func (as *AdminService) CreateCrewBulk(ctx context.Context, req dto.CreateCrewBulkRequest) ([]dto.CrewResponse, error) {
+	if len(req.Crews) == 0 {
+		return nil, dto.ErrEmptyCrewAccounts
+	}
+
+	if len(req.Crews) > constants.ENUM_CREW_BULK_MAX_ACCOUNTS {
+		return nil, dto.ErrTooManyCrewAccounts
+	}
+
+	if req.ExpiresAt == nil || !req.ExpiresAt.After(time.Now()) {
+		return nil, dto.ErrCrewExpiryRequired
+	}
+
+	var eventID *uuid.UUID
+	if req.EventID != "" {
+		event, found, err := as.adminRepo.GetEventByID(ctx, nil, req.EventID)
+		if err != nil || !found {
+			return nil, dto.ErrEventNotFound
+		}
+
+		eventID = &event.ID
+	}
+
+	var (
+		users     []entity.User
+		passwords []string
+		seen      = make(map[string]bool, len(req.Crews))
+	)
+	for _, crew := range req.Crews {
+		crew.Email = strings.ToLower(strings.TrimSpace(crew.Email))
+		crew.Name = strings.TrimSpace(crew.Name)
+
+		if crew.Email == "" || crew.Name == "" {
+			return nil, dto.ErrEmptyFields
+		}
+
+		if !helpers.IsValidEmail(crew.Email) {
+			return nil, dto.ErrInvalidEmail
+		}
+
+		if len(crew.Name) < 3 {
+			return nil, dto.ErrUserNameTooShort
+		}
+
+		if seen[crew.Email] {
+			return nil, dto.ErrDuplicateCrewEmail
+		}
+		seen[crew.Email] = true
+
+		if _, found, err := as.adminRepo.GetUserByEmail(ctx, nil, crew.Email); err == nil || found {
+			return nil, dto.ErrUserAlreadyExists
+		}
+
+		gateIDStr := req.GateID
+		if crew.GateID != "" {
+			gateIDStr = crew.GateID
+		}
+
+		gateID, err := as.resolveCrewGate(ctx, eventID, gateIDStr)
+		if err != nil {
+			return nil, err
+		}
+
+		password, err := helpers.GenerateRandomPassword(constants.ENUM_CREW_PASSWORD_LENGTH)
+		if err != nil {
+			return nil, dto.ErrGeneratePassword
+		}
+
+		users = append(users, entity.User{
+			ID:            uuid.New(),
+			Name:          crew.Name,
+			Email:         crew.Email,
+			Password:      password,
+			Role:          entity.Crew,
+			CrewEventID:   eventID,
+			CrewGateID:    gateID,
+			CrewExpiresAt: req.ExpiresAt,
+		})
+		passwords = append(passwords, password)
+	}
+
+	err := as.adminRepo.RunInTransaction(ctx, func(txRepo repository.IAdminRepository) error {
+		for _, user := range users {
+			if err := txRepo.CreateUser(ctx, nil, user); err != nil {
+				return dto.ErrCreateUser
+			}
+		}
+
+		return nil
+	})
 	if err != nil {
-		loc = time.FixedZone("UTC+7", 7*60*60)
+		return nil, err
+	}
+
+	res := make([]dto.CrewResponse, 0, len(users))
+	for i, user := range users {
+		crew := toCrewResponse(user)
+		crew.Password = passwords[i]
+		res = append(res, crew)
 	}
 
-	eventDate, err := time.ParseInLocation("2006-01-02", req.EventDate, loc)
+	return res, nil
+}
+func (as *AdminService) GetAllCrew(ctx context.Context, filter dto.CrewFilterQuery) ([]dto.CrewResponse, error) {
+	users, err := as.adminRepo.GetAllCrew(ctx, nil, filter)
 	if err != nil {
-		return dto.TicketResponse{}, dto.ErrParseTime
+		return nil, dto.ErrGetAllCrew
 	}
 
-	ticket := entity.Ticket{
-		ID:          uuid.New(),
-		Name:        req.Name,
-		Type:        req.Type,
-		Price:       req.Price,
-		Quota:       req.Quota,
-		Image:       req.Image,
-		Description: req.Description,
-		EventDate:   eventDate,
+	res := make([]dto.CrewResponse, 0, len(users))
+	for _, user := range users {
+		res = append(res, toCrewResponse(user))
+	}
+
+	return res, nil
+}
+func (as *AdminService) ExpireCrewBulk(ctx context.Context, req dto.ExpireCrewBulkRequest) (dto.ExpireCrewBulkResponse, error) {
+	if len(req.UserIDs) == 0 && req.EventID == "" {
+		return dto.ExpireCrewBulkResponse{}, dto.ErrExpireCrewTargetRequired
+	}
+
+	for _, userID := range req.UserIDs {
+		if _, err := uuid.Parse(userID); err != nil {
+			return dto.ExpireCrewBulkResponse{}, dto.ErrParseUUID
+		}
 	}
 
-	err = as.adminRepo.CreateTicket(ctx, nil, ticket)
+	expired, err := as.adminRepo.ExpireCrew(ctx, nil, req, time.Now())
 	if err != nil {
-		return dto.TicketResponse{}, dto.ErrCreateTicket
+		return dto.ExpireCrewBulkResponse{}, dto.ErrExpireCrew
 	}
 
-	return dto.TicketResponse{
-		ID:          ticket.ID.String(),
-		Name:        ticket.Name,
-		Type:        ticket.Type,
-		Price:       ticket.Price,
-		Quota:       ticket.Quota,
-		Image:       ticket.Image,
-		Description: ticket.Description,
-		EventDate:   ticket.EventDate.Format("2006-01-02"),
+	return dto.ExpireCrewBulkResponse{
+		Expired: expired,
 	}, nil
 }
-func (as *AdminService) GetAllTicket(ctx context.Context) ([]dto.TicketResponse, error) {
-	tickets, err := as.adminRepo.GetAllTicket(ctx, nil)
+
+// Event
+func parseEventTime(value string) (time.Time, error) {
+	loc, err := time.LoadLocation("Asia/Jakarta")
 	if err != nil {
-		return nil, dto.ErrGetAllTicketNoPagination
+		loc = time.FixedZone("UTC+7", 7*60*60)
 	}
 
-	var datas []dto.TicketResponse
-	for _, ticket := range tickets {
+	return time.ParseInLocation("2006-01-02 15:04", value, loc)
+}
+func toEventSessionResponse(eventSession entity.EventSession) dto.EventSessionResponse {
+	return dto.EventSessionResponse{
+		ID:       eventSession.ID,
+		EventID:  eventSession.EventID,
+		Name:     eventSession.Name,
+		Venue:    eventSession.Venue,
+		StartAt:  eventSession.StartAt,
+		EndAt:    eventSession.EndAt,
+		Capacity: eventSession.Capacity,
+	}
+}
+func toGateResponse(gate entity.Gate) dto.GateResponse {
+	return dto.GateResponse{
+		ID:      gate.ID,
+		EventID: gate.EventID,
+		Name:    gate.Name,
+	}
+}
+func toEventResponse(event entity.Event) dto.EventResponse {
+	res := dto.EventResponse{
+		ID:          event.ID,
+		Name:        event.Name,
+		Venue:       event.Venue,
+		Description: event.Description,
+		StartAt:     event.StartAt,
+		EndAt:       event.EndAt,
+		Capacity:    event.Capacity,
+		Policy:      event.ReentryPolicy,
+	}
+
+	for _, session := range event.Sessions {
+		res.Sessions = append(res.Sessions, toEventSessionResponse(session))
+	}
+
+	for _, gate := range event.Gates {
+		res.Gates = append(res.Gates, toGateResponse(gate))
+	}
+
+	for _, ticket := range event.Tickets {
 		isAvailable := ticket.Quota > 0 && time.Now().Before(ticket.EventDate)
 
-		data := dto.TicketResponse{
+		res.Tickets = append(res.Tickets, dto.TicketResponse{
 			ID:          ticket.ID.String(),
 			Name:        ticket.Name,
 			Type:        ticket.Type,
@@ -447,39 +790,96 @@ func (as *AdminService) GetAllTicket(ctx context.Context) ([]dto.TicketResponse,
 			Description: ticket.Description,
 			EventDate:   ticket.EventDate.Format("2006-01-02"),
 			IsAvailable: &isAvailable,
+			EventID:     ticket.EventID,
+			EventName:   event.Name,
+		})
+	}
+
+	return res
+}
+func (as *AdminService) CreateEvent(ctx context.Context, req dto.CreateEventRequest) (dto.EventResponse, error) {
+	if req.Name == "" || req.StartAt == "" || req.EndAt == "" {
+		return dto.EventResponse{}, dto.ErrEmptyFields
+	}
+
+	if len(req.Name) < 3 {
+		return dto.EventResponse{}, dto.ErrEventNameTooShort
+	}
+
+	_, flag, err := as.adminRepo.GetEventByName(ctx, nil, req.Name)
+	if err == nil || flag {
+		return dto.EventResponse{}, dto.ErrEventAlreadyExists
+	}
+
+	if req.Capacity < 0 {
+		return dto.EventResponse{}, dto.ErrCapacityOutOfBound
+	}
+
+	startAt, err := parseEventTime(req.StartAt)
+	if err != nil {
+		return dto.EventResponse{}, dto.ErrParseTime
+	}
+
+	endAt, err := parseEventTime(req.EndAt)
+	if err != nil {
+		return dto.EventResponse{}, dto.ErrParseTime
+	}
+
+	if !endAt.After(startAt) {
+		return dto.EventResponse{}, dto.ErrEventEndBeforeStart
+	}
+
+	policy := entity.ReentrySingleEntry
+	if req.Policy != "" {
+		policy = entity.ReentryPolicy(req.Policy)
+		if !entity.IsValidReentryPolicy(policy) {
+			return dto.EventResponse{}, dto.ErrInvalidReentryPolicy
 		}
+	}
 
-		datas = append(datas, data)
+	event := entity.Event{
+		ID:            uuid.New(),
+		Name:          req.Name,
+		Venue:         req.Venue,
+		Description:   req.Description,
+		StartAt:       startAt,
+		EndAt:         endAt,
+		Capacity:      req.Capacity,
+		ReentryPolicy: policy,
 	}
 
-	return datas, nil
+	err = as.adminRepo.CreateEvent(ctx, nil, event)
+	if err != nil {
+		return dto.EventResponse{}, dto.ErrCreateEvent
+	}
+
+	return toEventResponse(event), nil
 }
-func (as *AdminService) GetAllTicketWithPagination(ctx context.Context, req dto.PaginationRequest) (dto.TicketPaginationResponse, error) {
-	dataWithPaginate, err := as.adminRepo.GetAllTicketWithPagination(ctx, nil, req)
+func (as *AdminService) GetAllEvent(ctx context.Context) ([]dto.EventResponse, error) {
+	events, err := as.adminRepo.GetAllEvent(ctx, nil)
 	if err != nil {
-		return dto.TicketPaginationResponse{}, dto.ErrGetAllTicketWithPagination
+		return nil, dto.ErrGetAllEventNoPagination
 	}
 
-	var datas []dto.TicketResponse
-	for _, ticket := range dataWithPaginate.Tickets {
-		isAvailable := ticket.Quota > 0 && time.Now().Before(ticket.EventDate)
+	var datas []dto.EventResponse
+	for _, event := range events {
+		datas = append(datas, toEventResponse(event))
+	}
 
-		data := dto.TicketResponse{
-			ID:          ticket.ID.String(),
-			Name:        ticket.Name,
-			Type:        ticket.Type,
-			Price:       ticket.Price,
-			Quota:       ticket.Quota,
-			Image:       ticket.Image,
-			Description: ticket.Description,
-			EventDate:   ticket.EventDate.Format("2006-01-02"),
-			IsAvailable: &isAvailable,
-		}
+	return datas, nil
+}
+func (as *AdminService) GetAllEventWithPagination(ctx context.Context, req dto.PaginationRequest) (dto.EventPaginationResponse, error) {
+	dataWithPaginate, err := as.adminRepo.GetAllEventWithPagination(ctx, nil, req)
+	if err != nil {
+		return dto.EventPaginationResponse{}, dto.ErrGetAllEventWithPagination
+	}
 
-		datas = append(datas, data)
+	var datas []dto.EventResponse
+	for _, event := range dataWithPaginate.Events {
+		datas = append(datas, toEventResponse(event))
 	}
 
-	return dto.TicketPaginationResponse{
+	return dto.EventPaginationResponse{
 		Data: datas,
 		PaginationResponse: dto.PaginationResponse{
 			Page:    dataWithPaginate.Page,
@@ -489,456 +889,521 @@ func (as *AdminService) GetAllTicketWithPagination(ctx context.Context, req dto.
 		},
 	}, nil
 }
-func (as *AdminService) GetDetailTicket(ctx context.Context, ticketID string) (dto.TicketResponse, error) {
-	ticket, _, err := as.adminRepo.GetTicketByID(ctx, nil, ticketID)
+func (as *AdminService) GetDetailEvent(ctx context.Context, eventID string) (dto.EventResponse, error) {
+	event, _, err := as.adminRepo.GetEventByID(ctx, nil, eventID)
 	if err != nil {
-		return dto.TicketResponse{}, dto.ErrTicketNotFound
+		return dto.EventResponse{}, dto.ErrEventNotFound
 	}
 
-	return dto.TicketResponse{
-		ID:          ticket.ID.String(),
-		Name:        ticket.Name,
-		Type:        ticket.Type,
-		Price:       ticket.Price,
-		Quota:       ticket.Quota,
-		Image:       ticket.Image,
-		Description: ticket.Description,
-		EventDate:   ticket.EventDate.Format("2006-01-02"),
-	}, nil
+	return toEventResponse(event), nil
 }
-func (as *AdminService) UpdateTicket(ctx context.Context, req dto.UpdateTicketRequest) (dto.TicketResponse, error) {
-	ticket, flag, err := as.adminRepo.GetTicketByID(ctx, nil, req.ID)
+func (as *AdminService) UpdateEvent(ctx context.Context, req dto.UpdateEventRequest) (dto.EventResponse, error) {
+	event, flag, err := as.adminRepo.GetEventByID(ctx, nil, req.ID)
 	if err != nil || !flag {
-		return dto.TicketResponse{}, dto.ErrTicketNotFound
+		return dto.EventResponse{}, dto.ErrEventNotFound
 	}
 
-	if req.Name != "" {
+	if req.Name != "" && req.Name != event.Name {
 		if len(req.Name) < 3 {
-			return dto.TicketResponse{}, dto.ErrTicketNameTooShort
+			return dto.EventResponse{}, dto.ErrEventNameTooShort
 		}
 
-		ticket.Name = req.Name
-	}
-
-	if req.Type != "" {
-		if req.Type == ticket.Type {
-			return dto.TicketResponse{}, dto.ErrSameTicketType
+		_, flag, err := as.adminRepo.GetEventByName(ctx, nil, req.Name)
+		if err == nil || flag {
+			return dto.EventResponse{}, dto.ErrEventAlreadyExists
 		}
 
-		if !entity.IsValidTicketType(req.Type) {
-			return dto.TicketResponse{}, dto.ErrInvalidTicketType
-		}
+		event.Name = req.Name
+	}
 
-		ticket.Type = req.Type
+	if req.Venue != "" {
+		event.Venue = req.Venue
 	}
 
-	if req.Price != nil {
-		if *req.Price < 0 {
-			return dto.TicketResponse{}, dto.ErrPriceOutOfBound
+	if req.Description != "" {
+		event.Description = req.Description
+	}
+
+	if req.StartAt != "" {
+		startAt, err := parseEventTime(req.StartAt)
+		if err != nil {
+			return dto.EventResponse{}, dto.ErrParseTime
 		}
 
-		ticket.Price = *req.Price
+		event.StartAt = startAt
 	}
 
-	if req.Quota != nil {
-		if *req.Quota < 0 {
-			return dto.TicketResponse{}, dto.ErrQuotaOutOfBound
+	if req.EndAt != "" {
+		endAt, err := parseEventTime(req.EndAt)
+		if err != nil {
+			return dto.EventResponse{}, dto.ErrParseTime
 		}
 
-		ticket.Quota = *req.Quota
+		event.EndAt = endAt
 	}
 
-	if req.FileHeader != nil || req.FileReader != nil {
-		ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(req.FileHeader.Filename), "."))
-		if ext != "jpg" && ext != "jpeg" && ext != "png" {
-			return dto.TicketResponse{}, dto.ErrInvalidExtensionPhoto
-		}
+	if !event.EndAt.After(event.StartAt) {
+		return dto.EventResponse{}, dto.ErrEventEndBeforeStart
+	}
 
-		if ticket.Image != "" {
-			oldPath := filepath.Join("assets/ticket", ticket.Image)
-			if err := os.Remove(oldPath); err != nil && !os.IsNotExist(err) {
-				return dto.TicketResponse{}, dto.ErrDeleteOldImage
-			}
+	if req.Capacity != nil {
+		if *req.Capacity < 0 {
+			return dto.EventResponse{}, dto.ErrCapacityOutOfBound
 		}
 
-		ticketName := strings.ToLower(ticket.Name)
-		ticketName = strings.ReplaceAll(ticketName, " ", "_")
-
-		fileName := fmt.Sprintf("ticket_%s_%s.%s", time.Now().Format("060102150405"), ticketName, ext)
+		event.Capacity = *req.Capacity
+	}
 
-		saveDir := "assets/ticket"
-		if err := os.MkdirAll(saveDir, os.ModePerm); err != nil {
-			return dto.TicketResponse{}, dto.ErrCreateFile
+	if req.Policy != "" {
+		if !entity.IsValidReentryPolicy(entity.ReentryPolicy(req.Policy)) {
+			return dto.EventResponse{}, dto.ErrInvalidReentryPolicy
 		}
-		savePath := filepath.Join(saveDir, fileName)
 
-		out, err := os.Create(savePath)
-		if err != nil {
-			return dto.TicketResponse{}, dto.ErrCreateFile
+		event.ReentryPolicy = entity.ReentryPolicy(req.Policy)
+	}
+
+	err = as.adminRepo.RunInTransaction(ctx, func(txRepo repository.IAdminRepository) error {
+		if req.Capacity != nil && event.Capacity > 0 {
+			_, allocated, err := eventSeatAllocation(ctx, txRepo, event.ID, "")
+			if err != nil {
+				return err
+			}
+			if allocated > int64(event.Capacity) {
+				return dto.ErrEventCapacityExceeded
+			}
 		}
-		defer out.Close()
 
-		if _, err := io.Copy(out, req.FileReader); err != nil {
-			return dto.TicketResponse{}, dto.ErrSaveFile
+		if err := txRepo.UpdateEvent(ctx, nil, event); err != nil {
+			return dto.ErrUpdateEvent
 		}
-		ticket.Image = fileName
-	}
 
-	if req.Description != "" {
-		ticket.Description = req.Description
+		return nil
+	})
+	if err != nil {
+		return dto.EventResponse{}, err
 	}
 
-	if req.EventDate != "" {
-		loc, err := time.LoadLocation("Asia/Jakarta")
-		if err != nil {
-			loc = time.FixedZone("UTC+7", 7*60*60)
-		}
-		eventDate, err := time.ParseInLocation("2006-01-02", req.EventDate, loc)
-		if err != nil {
-			return dto.TicketResponse{}, dto.ErrParseTime
-		}
+	return toEventResponse(event), nil
+}
 
-		ticket.EventDate = eventDate
+// eventSeatAllocation mengunci baris event supaya perubahan quota dan kapasitas dicek satu per satu.
+func eventSeatAllocation(ctx context.Context, txRepo repository.IAdminRepository, eventID uuid.UUID, excludeTicketID string) (entity.Event, int64, error) {
+	event, found, err := txRepo.GetEventByIDForUpdate(ctx, nil, eventID.String())
+	if err != nil || !found {
+		return entity.Event{}, 0, dto.ErrEventNotFound
 	}
 
-	err = as.adminRepo.UpdateTicket(ctx, nil, ticket)
+	allocated, err := txRepo.CountEventSeatAllocation(ctx, nil, event.ID.String(), excludeTicketID)
 	if err != nil {
-		return dto.TicketResponse{}, dto.ErrCreateTicket
+		return entity.Event{}, 0, dto.ErrCountEventSeatAllocation
 	}
 
-	return dto.TicketResponse{
-		ID:          ticket.ID.String(),
-		Name:        ticket.Name,
-		Type:        ticket.Type,
-		Price:       ticket.Price,
-		Quota:       ticket.Quota,
-		Image:       ticket.Image,
-		Description: ticket.Description,
-		EventDate:   ticket.EventDate.Format("2006-01-02"),
-	}, nil
+	return event, allocated, nil
 }
-func (as *AdminService) DeleteTicket(ctx context.Context, req dto.DeleteTicketRequest) (dto.TicketResponse, error) {
-	deletedTicket, _, err := as.adminRepo.GetTicketByID(ctx, nil, req.TicketID)
+
+// checkEventCapacity: kapasitas 0 berarti tidak dibatasi.
+func checkEventCapacity(ctx context.Context, txRepo repository.IAdminRepository, eventID uuid.UUID, excludeTicketID string, quota int) error {
+	event, allocated, err := eventSeatAllocation(ctx, txRepo, eventID, excludeTicketID)
 	if err != nil {
-		return dto.TicketResponse{}, dto.ErrTicketNotFound
+		return err
 	}
 
-	err = as.adminRepo.DeleteTicketByID(ctx, nil, req.TicketID)
+	if event.Capacity > 0 && allocated+int64(quota) > int64(event.Capacity) {
+		return dto.ErrEventCapacityExceeded
+	}
+
+	return nil
+}
+func (as *AdminService) DeleteEvent(ctx context.Context, req dto.DeleteEventRequest) (dto.EventResponse, error) {
+	deletedEvent, _, err := as.adminRepo.GetEventByID(ctx, nil, req.EventID)
 	if err != nil {
-		return dto.TicketResponse{}, dto.ErrDeleteTicketByID
+		return dto.EventResponse{}, dto.ErrEventNotFound
 	}
 
-	res := dto.TicketResponse{
-		ID:        deletedTicket.ID.String(),
-		Name:      deletedTicket.Name,
-		Type:      deletedTicket.Type,
-		Price:     deletedTicket.Price,
-		Quota:     deletedTicket.Quota,
-		Image:     deletedTicket.Image,
-		EventDate: deletedTicket.EventDate.Format("2006-01-02"),
+	err = as.adminRepo.DeleteEventByID(ctx, nil, req.EventID)
+	if err != nil {
+		return dto.EventResponse{}, dto.ErrDeleteEventByID
 	}
 
-	return res, nil
+	return toEventResponse(deletedEvent), nil
 }
 
-// Sponsorship
-func (as *AdminService) CreateSponsorship(ctx context.Context, req dto.CreateSponsorshipRequest) (dto.SponsorshipResponse, error) {
-	if req.FileHeader == nil || req.FileReader == nil || req.Category == "" || req.Name == "" {
-		return dto.SponsorshipResponse{}, dto.ErrEmptyFields
+// Event Session
+func (as *AdminService) CreateEventSession(ctx context.Context, req dto.CreateEventSessionRequest) (dto.EventSessionResponse, error) {
+	if req.Name == "" || req.StartAt == "" || req.EndAt == "" {
+		return dto.EventSessionResponse{}, dto.ErrEmptyFields
 	}
 
-	_, flag, err := as.adminRepo.GetSponsorshipByNameAndCategory(ctx, nil, req.Category, req.Name)
-	if err == nil || flag {
-		return dto.SponsorshipResponse{}, dto.ErrSponsorshipAlreadyExists
+	event, flag, err := as.adminRepo.GetEventByID(ctx, nil, req.EventID)
+	if err != nil || !flag {
+		return dto.EventSessionResponse{}, dto.ErrEventNotFound
 	}
 
-	sponCat := entity.SponsorshipCategory(req.Category)
-	if !entity.IsValidSponsorshipCategory(sponCat) {
-		return dto.SponsorshipResponse{}, dto.ErrInvalidSponsorshipCategory
+	if req.Capacity < 0 {
+		return dto.EventSessionResponse{}, dto.ErrCapacityOutOfBound
 	}
 
-	if len(req.Name) < 3 {
-		return dto.SponsorshipResponse{}, dto.ErrSponsorshipNameTooShort
+	startAt, err := parseEventTime(req.StartAt)
+	if err != nil {
+		return dto.EventSessionResponse{}, dto.ErrParseTime
 	}
 
-	ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(req.FileHeader.Filename), "."))
-	if ext != "jpg" && ext != "jpeg" && ext != "png" {
-		return dto.SponsorshipResponse{}, dto.ErrInvalidExtensionPhoto
+	endAt, err := parseEventTime(req.EndAt)
+	if err != nil {
+		return dto.EventSessionResponse{}, dto.ErrParseTime
 	}
 
-	sponsorshipName := strings.ToLower(req.Name)
-	sponsorshipName = strings.ReplaceAll(sponsorshipName, " ", "_")
-
-	fileName := fmt.Sprintf("sponsorship_%s_%s.%s", time.Now().Format("060102150405"), sponsorshipName, ext)
-
-	saveDir := "assets/sponsorship"
-	if err := os.MkdirAll(saveDir, os.ModePerm); err != nil {
-		return dto.SponsorshipResponse{}, dto.ErrCreateFile
+	if !endAt.After(startAt) {
+		return dto.EventSessionResponse{}, dto.ErrEventEndBeforeStart
 	}
-	savePath := filepath.Join(saveDir, fileName)
 
-	out, err := os.Create(savePath)
-	if err != nil {
-		return dto.SponsorshipResponse{}, dto.ErrCreateFile
+	if startAt.Before(event.StartAt) || endAt.After(event.EndAt) {
+		return dto.EventSessionResponse{}, dto.ErrEventSessionOutOfRange
 	}
-	defer out.Close()
 
-	if _, err := io.Copy(out, req.FileReader); err != nil {
-		return dto.SponsorshipResponse{}, dto.ErrSaveFile
+	venue := req.Venue
+	if venue == "" {
+		venue = event.Venue
 	}
-	req.Image = fileName
 
-	spon := entity.Sponsorship{
+	eventSession := entity.EventSession{
 		ID:       uuid.New(),
-		Category: sponCat,
 		Name:     req.Name,
-		Image:    req.Image,
+		Venue:    venue,
+		StartAt:  startAt,
+		EndAt:    endAt,
+		Capacity: req.Capacity,
+		EventID:  &event.ID,
 	}
 
-	err = as.adminRepo.CreateSponsorship(ctx, nil, spon)
+	err = as.adminRepo.CreateEventSession(ctx, nil, eventSession)
 	if err != nil {
-		return dto.SponsorshipResponse{}, dto.ErrCreateSponsorship
+		return dto.EventSessionResponse{}, dto.ErrCreateEventSession
 	}
 
-	return dto.SponsorshipResponse{
-		ID:       spon.ID,
-		Category: string(spon.Category),
-		Name:     spon.Name,
-		Image:    req.Image,
-	}, nil
+	return toEventSessionResponse(eventSession), nil
 }
-func (as *AdminService) GetAllSponsorship(ctx context.Context) ([]dto.SponsorshipResponse, error) {
-	sponsorships, err := as.adminRepo.GetAllSponsorship(ctx, nil)
+func (as *AdminService) GetAllEventSession(ctx context.Context, eventID string) ([]dto.EventSessionResponse, error) {
+	operator, err := as.checkInOperator(ctx)
 	if err != nil {
-		return nil, dto.ErrGetAllSponsorship
-	}
-
-	var datas []dto.SponsorshipResponse
-	for _, sponsorship := range sponsorships {
-		data := dto.SponsorshipResponse{
-			ID:       sponsorship.ID,
-			Category: string(sponsorship.Category),
-			Name:     sponsorship.Name,
-			Image:    sponsorship.Image,
-		}
-
-		datas = append(datas, data)
+		return nil, err
 	}
 
-	return datas, nil
-}
-func (as *AdminService) GetAllSponsorshipWithPagination(ctx context.Context, req dto.PaginationRequest) (dto.SponsorshipPaginationResponse, error) {
-	dataWithPaginate, err := as.adminRepo.GetAllSponsorshipWithPagination(ctx, nil, req)
+	eventID, err = crewEventID(operator, eventID)
 	if err != nil {
-		return dto.SponsorshipPaginationResponse{}, dto.ErrGetAllSponsorshipWithPagination
+		return nil, err
 	}
 
-	var datas []dto.SponsorshipResponse
-	for _, sponsorship := range dataWithPaginate.Sponsorships {
-		data := dto.SponsorshipResponse{
-			ID:       sponsorship.ID,
-			Category: string(sponsorship.Category),
-			Name:     sponsorship.Name,
-			Image:    sponsorship.Image,
-		}
-
-		datas = append(datas, data)
+	_, flag, err := as.adminRepo.GetEventByID(ctx, nil, eventID)
+	if err != nil || !flag {
+		return nil, dto.ErrEventNotFound
 	}
 
-	return dto.SponsorshipPaginationResponse{
-		Data: datas,
-		PaginationResponse: dto.PaginationResponse{
-			Page:    dataWithPaginate.Page,
-			PerPage: dataWithPaginate.PerPage,
-			MaxPage: dataWithPaginate.MaxPage,
-			Count:   dataWithPaginate.Count,
-		},
-	}, nil
-}
-func (as *AdminService) GetDetailSponsorship(ctx context.Context, sponsorshipID string) (dto.SponsorshipResponse, error) {
-	sponsorship, _, err := as.adminRepo.GetSponsorshipByID(ctx, nil, sponsorshipID)
+	eventSessions, err := as.adminRepo.GetAllEventSessionByEventID(ctx, nil, eventID)
 	if err != nil {
-		return dto.SponsorshipResponse{}, dto.ErrSponsorshipNotFound
+		return nil, dto.ErrGetAllEventSession
 	}
 
-	return dto.SponsorshipResponse{
-		ID:       sponsorship.ID,
-		Category: string(sponsorship.Category),
-		Name:     sponsorship.Name,
-		Image:    sponsorship.Image,
-	}, nil
+	var datas []dto.EventSessionResponse
+	for _, eventSession := range eventSessions {
+		datas = append(datas, toEventSessionResponse(eventSession))
+	}
+
+	return datas, nil
 }
-func (as *AdminService) UpdateSponsorship(ctx context.Context, req dto.UpdateSponsorshipRequest) (dto.SponsorshipResponse, error) {
-	sponsorship, _, err := as.adminRepo.GetSponsorshipByID(ctx, nil, req.ID)
-	if err != nil {
-		return dto.SponsorshipResponse{}, dto.ErrSponsorshipNotFound
+func (as *AdminService) UpdateEventSession(ctx context.Context, req dto.UpdateEventSessionRequest) (dto.EventSessionResponse, error) {
+	eventSession, flag, err := as.adminRepo.GetEventSessionByID(ctx, nil, req.ID)
+	if err != nil || !flag {
+		return dto.EventSessionResponse{}, dto.ErrEventSessionNotFound
 	}
 
 	if req.Name != "" {
-		if len(req.Name) < 3 {
-			return dto.SponsorshipResponse{}, dto.ErrSponsorshipNameTooShort
-		}
+		eventSession.Name = req.Name
+	}
 
-		sponsorship.Name = req.Name
+	if req.Venue != "" {
+		eventSession.Venue = req.Venue
 	}
 
-	if req.Category != "" {
-		sponCat := entity.SponsorshipCategory(req.Category)
-		if !entity.IsValidSponsorshipCategory(sponCat) {
-			return dto.SponsorshipResponse{}, dto.ErrInvalidSponsorshipCategory
+	if req.StartAt != "" {
+		startAt, err := parseEventTime(req.StartAt)
+		if err != nil {
+			return dto.EventSessionResponse{}, dto.ErrParseTime
 		}
 
-		sponsorship.Category = sponCat
+		eventSession.StartAt = startAt
 	}
 
-	if req.FileHeader != nil || req.FileReader != nil {
-		ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(req.FileHeader.Filename), "."))
-		if ext != "jpg" && ext != "jpeg" && ext != "png" {
-			return dto.SponsorshipResponse{}, dto.ErrInvalidExtensionPhoto
+	if req.EndAt != "" {
+		endAt, err := parseEventTime(req.EndAt)
+		if err != nil {
+			return dto.EventSessionResponse{}, dto.ErrParseTime
 		}
 
-		if sponsorship.Image != "" {
-			oldPath := filepath.Join("assets/sponsorship", sponsorship.Image)
-			if err := os.Remove(oldPath); err != nil && !os.IsNotExist(err) {
-				return dto.SponsorshipResponse{}, dto.ErrDeleteOldImage
-			}
-		}
+		eventSession.EndAt = endAt
+	}
 
-		sponsorshipName := strings.ToLower(sponsorship.Name)
-		sponsorshipName = strings.ReplaceAll(sponsorshipName, " ", "_")
+	if !eventSession.EndAt.After(eventSession.StartAt) {
+		return dto.EventSessionResponse{}, dto.ErrEventEndBeforeStart
+	}
 
-		fileName := fmt.Sprintf("sponsorship_%s_%s.%s", time.Now().Format("060102150405"), sponsorshipName, ext)
+	if eventSession.StartAt.Before(eventSession.Event.StartAt) || eventSession.EndAt.After(eventSession.Event.EndAt) {
+		return dto.EventSessionResponse{}, dto.ErrEventSessionOutOfRange
+	}
 
-		saveDir := "assets/sponsorship"
-		if err := os.MkdirAll(saveDir, os.ModePerm); err != nil {
-			return dto.SponsorshipResponse{}, dto.ErrCreateFile
+	if req.Capacity != nil {
+		if *req.Capacity < 0 {
+			return dto.EventSessionResponse{}, dto.ErrCapacityOutOfBound
 		}
-		savePath := filepath.Join(saveDir, fileName)
 
-		out, err := os.Create(savePath)
-		if err != nil {
-			return dto.SponsorshipResponse{}, dto.ErrCreateFile
-		}
-		defer out.Close()
+		eventSession.Capacity = *req.Capacity
+	}
 
-		if _, err := io.Copy(out, req.FileReader); err != nil {
-			return dto.SponsorshipResponse{}, dto.ErrSaveFile
-		}
-		sponsorship.Image = fileName
+	err = as.adminRepo.UpdateEventSession(ctx, nil, eventSession)
+	if err != nil {
+		return dto.EventSessionResponse{}, dto.ErrUpdateEventSession
 	}
 
-	err = as.adminRepo.UpdateSponsorship(ctx, nil, sponsorship)
+	return toEventSessionResponse(eventSession), nil
+}
+func (as *AdminService) DeleteEventSession(ctx context.Context, eventSessionID string) (dto.EventSessionResponse, error) {
+	deletedEventSession, _, err := as.adminRepo.GetEventSessionByID(ctx, nil, eventSessionID)
 	if err != nil {
-		return dto.SponsorshipResponse{}, dto.ErrUpdateSponsorship
+		return dto.EventSessionResponse{}, dto.ErrEventSessionNotFound
 	}
 
-	res := dto.SponsorshipResponse{
-		ID:       sponsorship.ID,
-		Category: string(sponsorship.Category),
-		Name:     sponsorship.Name,
-		Image:    sponsorship.Image,
+	err = as.adminRepo.DeleteEventSessionByID(ctx, nil, eventSessionID)
+	if err != nil {
+		return dto.EventSessionResponse{}, dto.ErrDeleteEventSessionByID
 	}
 
-	return res, nil
+	return toEventSessionResponse(deletedEventSession), nil
 }
-func (as *AdminService) DeleteSponsorship(ctx context.Context, req dto.DeleteSponsorshipRequest) (dto.SponsorshipResponse, error) {
-	deletedSponsorship, _, err := as.adminRepo.GetSponsorshipByID(ctx, nil, req.SponsorshipID)
+
+// Gate
+func (as *AdminService) CreateGate(ctx context.Context, req dto.CreateGateRequest) (dto.GateResponse, error) {
+	if strings.TrimSpace(req.Name) == "" {
+		return dto.GateResponse{}, dto.ErrEmptyFields
+	}
+
+	event, flag, err := as.adminRepo.GetEventByID(ctx, nil, req.EventID)
+	if err != nil || !flag {
+		return dto.GateResponse{}, dto.ErrEventNotFound
+	}
+
+	gate := entity.Gate{
+		ID:      uuid.New(),
+		Name:    strings.TrimSpace(req.Name),
+		EventID: &event.ID,
+	}
+
+	err = as.adminRepo.CreateGate(ctx, nil, gate)
 	if err != nil {
-		return dto.SponsorshipResponse{}, dto.ErrSponsorshipNotFound
+		return dto.GateResponse{}, dto.ErrCreateGate
 	}
 
-	err = as.adminRepo.DeleteSponsorshipByID(ctx, nil, req.SponsorshipID)
+	return toGateResponse(gate), nil
+}
+func (as *AdminService) GetAllGate(ctx context.Context, eventID string) ([]dto.GateResponse, error) {
+	operator, err := as.checkInOperator(ctx)
 	if err != nil {
-		return dto.SponsorshipResponse{}, dto.ErrDeleteSponsorshipByID
+		return nil, err
 	}
 
-	res := dto.SponsorshipResponse{
-		ID:       deletedSponsorship.ID,
-		Category: string(deletedSponsorship.Category),
-		Name:     deletedSponsorship.Name,
-		Image:    deletedSponsorship.Image,
+	eventID, err = crewEventID(operator, eventID)
+	if err != nil {
+		return nil, err
 	}
 
-	return res, nil
+	_, flag, err := as.adminRepo.GetEventByID(ctx, nil, eventID)
+	if err != nil || !flag {
+		return nil, dto.ErrEventNotFound
+	}
+
+	gates, err := as.adminRepo.GetAllGateByEventID(ctx, nil, eventID)
+	if err != nil {
+		return nil, dto.ErrGetAllGate
+	}
+
+	var datas []dto.GateResponse
+	for _, gate := range gates {
+		datas = append(datas, toGateResponse(gate))
+	}
+
+	return datas, nil
 }
+func (as *AdminService) UpdateGate(ctx context.Context, req dto.UpdateGateRequest) (dto.GateResponse, error) {
+	gate, flag, err := as.adminRepo.GetGateByID(ctx, nil, req.ID)
+	if err != nil || !flag {
+		return dto.GateResponse{}, dto.ErrGateNotFound
+	}
 
-// Speaker
-func (as *AdminService) CreateSpeaker(ctx context.Context, req dto.CreateSpeakerRequest) (dto.SpeakerResponse, error) {
-	if req.FileHeader == nil || req.FileReader == nil || req.Name == "" || req.Description == "" {
-		return dto.SpeakerResponse{}, dto.ErrEmptyFields
+	if strings.TrimSpace(req.Name) != "" {
+		gate.Name = strings.TrimSpace(req.Name)
 	}
 
-	if len(req.Name) < 3 {
-		return dto.SpeakerResponse{}, dto.ErrSpeakerNameTooShort
+	err = as.adminRepo.UpdateGate(ctx, nil, gate)
+	if err != nil {
+		return dto.GateResponse{}, dto.ErrUpdateGate
 	}
 
-	if len(req.Description) < 5 {
-		return dto.SpeakerResponse{}, dto.ErrSpeakerDescriptionTooShort
+	return toGateResponse(gate), nil
+}
+func (as *AdminService) DeleteGate(ctx context.Context, gateID string) (dto.GateResponse, error) {
+	deletedGate, _, err := as.adminRepo.GetGateByID(ctx, nil, gateID)
+	if err != nil {
+		return dto.GateResponse{}, dto.ErrGateNotFound
 	}
 
-	_, flag, err := as.adminRepo.GetSpeakerByName(ctx, nil, req.Name)
+	err = as.adminRepo.DeleteGateByID(ctx, nil, gateID)
+	if err != nil {
+		return dto.GateResponse{}, dto.ErrDeleteGateByID
+	}
+
+	return toGateResponse(deletedGate), nil
+}
+
+// Ticket
+func (as *AdminService) CreateTicket(ctx context.Context, req dto.CreateTicketRequest) (dto.TicketResponse, error) {
+	if req.Name == "" || req.FileHeader == nil || req.FileReader == nil || (req.Type == "" && req.EventID == nil) || (req.EventDate == "" && req.EventID == nil) {
+		return dto.TicketResponse{}, dto.ErrEmptyFields
+	}
+
+	_, flag, err := as.adminRepo.GetTicketByName(ctx, nil, req.Name)
 	if err == nil || flag {
-		return dto.SpeakerResponse{}, dto.ErrSpeakerAlreadyExists
+		return dto.TicketResponse{}, dto.ErrTicketAlreadyExists
+	}
+
+	if len(req.Name) < 3 {
+		return dto.TicketResponse{}, dto.ErrTicketNameTooShort
+	}
+
+	if req.Type != "" && !entity.IsValidTicketType(req.Type) {
+		return dto.TicketResponse{}, dto.ErrInvalidTicketType
+	}
+
+	var event entity.Event
+	if req.EventID != nil {
+		event, flag, err = as.adminRepo.GetEventByID(ctx, nil, req.EventID.String())
+		if err != nil || !flag {
+			return dto.TicketResponse{}, dto.ErrEventNotFound
+		}
+	}
+
+	if req.Price < 0 {
+		return dto.TicketResponse{}, dto.ErrPriceOutOfBound
+	}
+
+	if req.Quota < 0 {
+		return dto.TicketResponse{}, dto.ErrQuotaOutOfBound
 	}
 
 	ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(req.FileHeader.Filename), "."))
 	if ext != "jpg" && ext != "jpeg" && ext != "png" {
-		return dto.SpeakerResponse{}, dto.ErrInvalidExtensionPhoto
+		return dto.TicketResponse{}, dto.ErrInvalidExtensionPhoto
 	}
 
-	speakerName := strings.ToLower(req.Name)
-	speakerName = strings.ReplaceAll(speakerName, " ", "_")
+	ticketName := strings.ToLower(req.Name)
+	ticketName = strings.ReplaceAll(ticketName, " ", "_")
 
-	fileName := fmt.Sprintf("speaker_%s_%s.%s", time.Now().Format("060102150405"), speakerName, ext)
-	saveDir := "assets/speaker"
+	fileName := fmt.Sprintf("ticket_%s_%s.%s", time.Now().Format("060102150405"), ticketName, ext)
+
+	saveDir := "assets/ticket"
 	if err := os.MkdirAll(saveDir, os.ModePerm); err != nil {
-		return dto.SpeakerResponse{}, dto.ErrCreateFile
+		return dto.TicketResponse{}, dto.ErrCreateFile
 	}
 	savePath := filepath.Join(saveDir, fileName)
 
 	out, err := os.Create(savePath)
 	if err != nil {
-		return dto.SpeakerResponse{}, dto.ErrCreateFile
+		return dto.TicketResponse{}, dto.ErrCreateFile
 	}
 	defer out.Close()
 
 	if _, err := io.Copy(out, req.FileReader); err != nil {
-		return dto.SpeakerResponse{}, dto.ErrSaveFile
+		return dto.TicketResponse{}, dto.ErrSaveFile
 	}
 	req.Image = fileName
 
-	speaker := entity.Speaker{
+	loc, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		loc = time.FixedZone("UTC+7", 7*60*60)
+	}
+
+	eventDate := event.StartAt.In(loc)
+	if req.EventDate != "" {
+		eventDate, err = time.ParseInLocation("2006-01-02", req.EventDate, loc)
+		if err != nil {
+			return dto.TicketResponse{}, dto.ErrParseTime
+		}
+	}
+
+	ticket := entity.Ticket{
 		ID:          uuid.New(),
 		Name:        req.Name,
+		Type:        req.Type,
+		Price:       req.Price,
+		Quota:       req.Quota,
 		Image:       req.Image,
 		Description: req.Description,
+		EventDate:   eventDate,
+		EventID:     req.EventID,
 	}
 
-	err = as.adminRepo.CreateSpeaker(ctx, nil, speaker)
+	err = as.adminRepo.RunInTransaction(ctx, func(txRepo repository.IAdminRepository) error {
+		if ticket.EventID != nil {
+			if err := checkEventCapacity(ctx, txRepo, *ticket.EventID, "", ticket.Quota); err != nil {
+				return err
+			}
+		}
+
+		if err := txRepo.CreateTicket(ctx, nil, ticket); err != nil {
+			return dto.ErrCreateTicket
+		}
+
+		return nil
+	})
 	if err != nil {
-		return dto.SpeakerResponse{}, dto.ErrCreateSpeaker
+		return dto.TicketResponse{}, err
 	}
 
-	return dto.SpeakerResponse{
-		ID:          speaker.ID,
-		Name:        speaker.Name,
-		Image:       speaker.Image,
-		Description: speaker.Description,
+	as.availabilityService.PublishTicket(ctx, ticket.ID.String())
+
+	return dto.TicketResponse{
+		ID:          ticket.ID.String(),
+		Name:        ticket.Name,
+		Type:        ticket.Type,
+		Price:       ticket.Price,
+		Quota:       ticket.Quota,
+		Image:       ticket.Image,
+		Description: ticket.Description,
+		EventDate:   ticket.EventDate.Format("2006-01-02"),
+		EventID:     ticket.EventID,
+		EventName:   event.Name,
 	}, nil
 }
-func (as *AdminService) GetAllSpeaker(ctx context.Context) ([]dto.SpeakerResponse, error) {
-	speakers, err := as.adminRepo.GetAllSpeaker(ctx, nil)
+func (as *AdminService) GetAllTicket(ctx context.Context) ([]dto.TicketResponse, error) {
+	tickets, err := as.adminRepo.GetAllTicket(ctx, nil)
 	if err != nil {
-		return nil, dto.ErrGetAllSpeakerNoPagination
+		return nil, dto.ErrGetAllTicketNoPagination
 	}
 
-	var datas []dto.SpeakerResponse
-	for _, speaker := range speakers {
-		data := dto.SpeakerResponse{
-			ID:          speaker.ID,
-			Name:        speaker.Name,
-			Image:       speaker.Image,
-			Description: speaker.Description,
+	var datas []dto.TicketResponse
+	for _, ticket := range tickets {
+		isAvailable := ticket.Quota > 0 && time.Now().Before(ticket.EventDate)
+
+		data := dto.TicketResponse{
+			ID:          ticket.ID.String(),
+			Name:        ticket.Name,
+			Type:        ticket.Type,
+			Price:       ticket.Price,
+			Quota:       ticket.Quota,
+			Image:       ticket.Image,
+			Description: ticket.Description,
+			EventDate:   ticket.EventDate.Format("2006-01-02"),
+			EventID:     ticket.EventID,
+			EventName:   ticket.Event.Name,
+			IsAvailable: &isAvailable,
 		}
 
 		datas = append(datas, data)
@@ -946,25 +1411,34 @@ func (as *AdminService) GetAllSpeaker(ctx context.Context) ([]dto.SpeakerRespons
 
 	return datas, nil
 }
-func (as *AdminService) GetAllSpeakerWithPagination(ctx context.Context, req dto.PaginationRequest) (dto.SpeakerPaginationResponse, error) {
-	dataWithPaginate, err := as.adminRepo.GetAllSpeakerWithPagination(ctx, nil, req)
+func (as *AdminService) GetAllTicketWithPagination(ctx context.Context, req dto.PaginationRequest) (dto.TicketPaginationResponse, error) {
+	dataWithPaginate, err := as.adminRepo.GetAllTicketWithPagination(ctx, nil, req)
 	if err != nil {
-		return dto.SpeakerPaginationResponse{}, dto.ErrGetAllSpeakerWithPagination
+		return dto.TicketPaginationResponse{}, dto.ErrGetAllTicketWithPagination
 	}
 
-	var datas []dto.SpeakerResponse
-	for _, speaker := range dataWithPaginate.Speakers {
-		data := dto.SpeakerResponse{
-			ID:          speaker.ID,
-			Name:        speaker.Name,
-			Image:       speaker.Image,
-			Description: speaker.Description,
+	var datas []dto.TicketResponse
+	for _, ticket := range dataWithPaginate.Tickets {
+		isAvailable := ticket.Quota > 0 && time.Now().Before(ticket.EventDate)
+
+		data := dto.TicketResponse{
+			ID:          ticket.ID.String(),
+			Name:        ticket.Name,
+			Type:        ticket.Type,
+			Price:       ticket.Price,
+			Quota:       ticket.Quota,
+			Image:       ticket.Image,
+			Description: ticket.Description,
+			EventDate:   ticket.EventDate.Format("2006-01-02"),
+			EventID:     ticket.EventID,
+			EventName:   ticket.Event.Name,
+			IsAvailable: &isAvailable,
 		}
 
 		datas = append(datas, data)
 	}
 
-	return dto.SpeakerPaginationResponse{
+	return dto.TicketPaginationResponse{
 		Data: datas,
 		PaginationResponse: dto.PaginationResponse{
 			Page:    dataWithPaginate.Page,
@@ -974,1016 +1448,4166 @@ func (as *AdminService) GetAllSpeakerWithPagination(ctx context.Context, req dto
 		},
 	}, nil
 }
-func (as *AdminService) GetDetailSpeaker(ctx context.Context, speakerID string) (dto.SpeakerResponse, error) {
-	speaker, _, err := as.adminRepo.GetSpeakerByID(ctx, nil, speakerID)
+func (as *AdminService) GetDetailTicket(ctx context.Context, ticketID string) (dto.TicketResponse, error) {
+	ticket, _, err := as.adminRepo.GetTicketByID(ctx, nil, ticketID)
 	if err != nil {
-		return dto.SpeakerResponse{}, dto.ErrSpeakerNotFound
+		return dto.TicketResponse{}, dto.ErrTicketNotFound
 	}
 
-	return dto.SpeakerResponse{
-		ID:          speaker.ID,
-		Name:        speaker.Name,
-		Image:       speaker.Image,
-		Description: speaker.Description,
+	var formFields []dto.TicketFormFieldResponse
+	for _, field := range ticket.FormFields {
+		formFields = append(formFields, toTicketFormFieldResponse(field))
+	}
+
+	return dto.TicketResponse{
+		ID:          ticket.ID.String(),
+		Name:        ticket.Name,
+		Type:        ticket.Type,
+		Price:       ticket.Price,
+		Quota:       ticket.Quota,
+		Image:       ticket.Image,
+		Description: ticket.Description,
+		EventDate:   ticket.EventDate.Format("2006-01-02"),
+		EventID:     ticket.EventID,
+		EventName:   ticket.Event.Name,
+		FormFields:  formFields,
 	}, nil
 }
-func (as *AdminService) UpdateSpeaker(ctx context.Context, req dto.UpdateSpeakerRequest) (dto.SpeakerResponse, error) {
-	speaker, _, err := as.adminRepo.GetSpeakerByID(ctx, nil, req.ID)
-	if err != nil {
-		return dto.SpeakerResponse{}, dto.ErrSpeakerNotFound
+func (as *AdminService) UpdateTicket(ctx context.Context, req dto.UpdateTicketRequest) (dto.TicketResponse, error) {
+	ticket, flag, err := as.adminRepo.GetTicketByID(ctx, nil, req.ID)
+	if err != nil || !flag {
+		return dto.TicketResponse{}, dto.ErrTicketNotFound
 	}
 
 	if req.Name != "" {
 		if len(req.Name) < 3 {
-			return dto.SpeakerResponse{}, dto.ErrSpeakerNameTooShort
+			return dto.TicketResponse{}, dto.ErrTicketNameTooShort
 		}
 
-		speaker.Name = req.Name
+		ticket.Name = req.Name
 	}
 
-	if req.Description != "" {
-		if len(req.Description) < 3 {
-			return dto.SpeakerResponse{}, dto.ErrSpeakerDescriptionTooShort
+	if req.Type != "" {
+		if req.Type == ticket.Type {
+			return dto.TicketResponse{}, dto.ErrSameTicketType
 		}
 
-		speaker.Description = req.Description
-	}
+		if !entity.IsValidTicketType(req.Type) {
+			return dto.TicketResponse{}, dto.ErrInvalidTicketType
+		}
 
-	if req.FileReader != nil && req.FileHeader != nil {
-		ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(req.FileHeader.Filename), "."))
+		ticket.Type = req.Type
+	}
 
-		if ext != "jpg" && ext != "jpeg" && ext != "png" {
-			return dto.SpeakerResponse{}, dto.ErrInvalidExtensionPhoto
+	if req.Price != nil {
+		if *req.Price < 0 {
+			return dto.TicketResponse{}, dto.ErrPriceOutOfBound
 		}
 
-		if speaker.Image != "" {
-			oldPath := filepath.Join("assets/speaker", speaker.Image)
-			if err := os.Remove(oldPath); err != nil && !os.IsNotExist(err) {
-				return dto.SpeakerResponse{}, dto.ErrDeleteOldImage
-			}
+		ticket.Price = *req.Price
+	}
+
+	quotaIncreased := false
+	if req.Quota != nil {
+		if *req.Quota < 0 {
+			return dto.TicketResponse{}, dto.ErrQuotaOutOfBound
 		}
 
-		if req.Name == "" {
-			req.Name = speaker.Name
+		quotaIncreased = *req.Quota > ticket.Quota
+		ticket.Quota = *req.Quota
+	}
+
+	if req.FileHeader != nil || req.FileReader != nil {
+		ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(req.FileHeader.Filename), "."))
+		if ext != "jpg" && ext != "jpeg" && ext != "png" {
+			return dto.TicketResponse{}, dto.ErrInvalidExtensionPhoto
 		}
 
-		speakerName := strings.ToLower(req.Name)
-		speakerName = strings.ReplaceAll(speakerName, " ", "_")
+		if ticket.Image != "" {
+			oldPath := filepath.Join("assets/ticket", ticket.Image)
+			if err := os.Remove(oldPath); err != nil && !os.IsNotExist(err) {
+				return dto.TicketResponse{}, dto.ErrDeleteOldImage
+			}
+		}
 
-		fileName := fmt.Sprintf("speaker_%s_%s.%s", time.Now().Format("060102150405"), speakerName, ext)
+		ticketName := strings.ToLower(ticket.Name)
+		ticketName = strings.ReplaceAll(ticketName, " ", "_")
 
-		saveDir := "assets/speaker"
+		fileName := fmt.Sprintf("ticket_%s_%s.%s", time.Now().Format("060102150405"), ticketName, ext)
+
+		saveDir := "assets/ticket"
 		if err := os.MkdirAll(saveDir, os.ModePerm); err != nil {
-			return dto.SpeakerResponse{}, dto.ErrCreateFile
+			return dto.TicketResponse{}, dto.ErrCreateFile
 		}
-
 		savePath := filepath.Join(saveDir, fileName)
 
 		out, err := os.Create(savePath)
 		if err != nil {
-			return dto.SpeakerResponse{}, dto.ErrCreateFile
+			return dto.TicketResponse{}, dto.ErrCreateFile
 		}
 		defer out.Close()
 
 		if _, err := io.Copy(out, req.FileReader); err != nil {
-			return dto.SpeakerResponse{}, dto.ErrSaveFile
+			return dto.TicketResponse{}, dto.ErrSaveFile
 		}
+		ticket.Image = fileName
+	}
 
-		speaker.Image = fileName
+	if req.Description != "" {
+		ticket.Description = req.Description
 	}
 
-	err = as.adminRepo.UpdateSpeaker(ctx, nil, speaker)
+	if req.EventDate != "" {
+		loc, err := time.LoadLocation("Asia/Jakarta")
+		if err != nil {
+			loc = time.FixedZone("UTC+7", 7*60*60)
+		}
+		eventDate, err := time.ParseInLocation("2006-01-02", req.EventDate, loc)
+		if err != nil {
+			return dto.TicketResponse{}, dto.ErrParseTime
+		}
+
+		ticket.EventDate = eventDate
+	}
+
+	if req.EventID != nil {
+		event, flag, err := as.adminRepo.GetEventByID(ctx, nil, req.EventID.String())
+		if err != nil || !flag {
+			return dto.TicketResponse{}, dto.ErrEventNotFound
+		}
+
+		ticket.EventID = &event.ID
+		ticket.Event = event
+	}
+
+	err = as.adminRepo.RunInTransaction(ctx, func(txRepo repository.IAdminRepository) error {
+		if ticket.EventID != nil && (quotaIncreased || req.EventID != nil) {
+			if err := checkEventCapacity(ctx, txRepo, *ticket.EventID, ticket.ID.String(), ticket.Quota); err != nil {
+				return err
+			}
+		}
+
+		if err := txRepo.UpdateTicket(ctx, nil, ticket); err != nil {
+			return dto.ErrCreateTicket
+		}
+
+		return nil
+	})
 	if err != nil {
-		return dto.SpeakerResponse{}, dto.ErrUpdateSpeaker
+		return dto.TicketResponse{}, err
 	}
 
-	res := dto.SpeakerResponse{
-		ID:          speaker.ID,
-		Name:        speaker.Name,
-		Image:       speaker.Image,
-		Description: speaker.Description,
+	if quotaIncreased {
+		if err := as.waitlistService.OfferAvailableSeats(ctx, ticket.ID.String()); err != nil {
+			log.Printf("failed to offer waitlist seats for ticket %s: %v", ticket.ID, err)
+		}
+
+		if t, found, err := as.adminRepo.GetTicketByID(ctx, nil, ticket.ID.String()); err == nil && found {
+			ticket.Quota = t.Quota
+		}
+	} else {
+		as.availabilityService.PublishTicket(ctx, ticket.ID.String())
 	}
 
-	return res, nil
+	return dto.TicketResponse{
+		ID:          ticket.ID.String(),
+		Name:        ticket.Name,
+		Type:        ticket.Type,
+		Price:       ticket.Price,
+		Quota:       ticket.Quota,
+		Image:       ticket.Image,
+		Description: ticket.Description,
+		EventDate:   ticket.EventDate.Format("2006-01-02"),
+		EventID:     ticket.EventID,
+		EventName:   ticket.Event.Name,
+	}, nil
 }
-func (as *AdminService) DeleteSpeaker(ctx context.Context, req dto.DeleteSpeakerRequest) (dto.SpeakerResponse, error) {
-	deletedSpeaker, _, err := as.adminRepo.GetSpeakerByID(ctx, nil, req.SpeakerID)
+func (as *AdminService) DeleteTicket(ctx context.Context, req dto.DeleteTicketRequest) (dto.TicketResponse, error) {
+	deletedTicket, _, err := as.adminRepo.GetTicketByID(ctx, nil, req.TicketID)
 	if err != nil {
-		return dto.SpeakerResponse{}, dto.ErrSpeakerNotFound
+		return dto.TicketResponse{}, dto.ErrTicketNotFound
 	}
 
-	err = as.adminRepo.DeleteSpeakerByID(ctx, nil, req.SpeakerID)
+	err = as.adminRepo.DeleteTicketByID(ctx, nil, req.TicketID)
 	if err != nil {
-		return dto.SpeakerResponse{}, dto.ErrDeleteSpeakerByID
+		return dto.TicketResponse{}, dto.ErrDeleteTicketByID
 	}
 
-	res := dto.SpeakerResponse{
-		ID:          deletedSpeaker.ID,
-		Name:        deletedSpeaker.Name,
-		Image:       deletedSpeaker.Image,
-		Description: deletedSpeaker.Description,
+	as.availabilityService.PublishRemoved(constants.ENUM_TICKET_ITEM_TYPE, deletedTicket.ID)
+
+	res := dto.TicketResponse{
+		ID:        deletedTicket.ID.String(),
+		Name:      deletedTicket.Name,
+		Type:      deletedTicket.Type,
+		Price:     deletedTicket.Price,
+		Quota:     deletedTicket.Quota,
+		Image:     deletedTicket.Image,
+		EventDate: deletedTicket.EventDate.Format("2006-01-02"),
+		EventID:   deletedTicket.EventID,
 	}
 
 	return res, nil
 }
 
-// Merch
-func (as *AdminService) CreateMerch(ctx context.Context, req dto.CreateMerchRequest) (dto.MerchResponse, error) {
-	if req.Name == "" || req.Description == "" || req.Category == "" || len(req.Images) == 0 {
-		return dto.MerchResponse{}, dto.ErrEmptyFields
-	}
-
-	if len(req.Name) < 3 {
-		return dto.MerchResponse{}, dto.ErrMerchNameTooShort
-	}
+// Ticket Form Field
+var formFieldKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,49}$`)
 
-	if len(req.Description) < 5 {
-		return dto.MerchResponse{}, dto.ErrMerchDescriptionTooShort
+func toTicketFormFieldResponse(field entity.TicketFormField) dto.TicketFormFieldResponse {
+	options := []string(field.Options)
+	if options == nil {
+		options = []string{}
 	}
 
-	if !entity.IsValidMerchCategory(req.Category) {
-		return dto.MerchResponse{}, dto.ErrInvalidMerchCategory
+	return dto.TicketFormFieldResponse{
+		ID:          field.ID,
+		TicketID:    field.TicketID,
+		Key:         field.Key,
+		Label:       field.Label,
+		Type:        field.Type,
+		Required:    field.Required,
+		Options:     options,
+		Placeholder: field.Placeholder,
+		Position:    field.Position,
 	}
-
-	if req.Stock < 0 {
-		return dto.MerchResponse{}, dto.ErrStockOutOfBound
+}
+func isChoiceFormField(fieldType entity.FormFieldType) bool {
+	return fieldType == entity.FormFieldSelect || fieldType == entity.FormFieldMultiSelect
+}
+func containsOption(options []string, value string) bool {
+	for _, option := range options {
+		if option == value {
+			return true
+		}
 	}
 
-	if req.Price < 0 {
-		return dto.MerchResponse{}, dto.ErrPriceOutOfBound
-	}
+	return false
+}
 
-	saveDir := "assets/merch"
-	if err := os.MkdirAll(saveDir, os.ModePerm); err != nil {
-		return dto.MerchResponse{}, dto.ErrCreateFile
-	}
+// validateFormAnswers: jawaban di luar custom field ticket dibuang.
+func validateFormAnswers(fields []entity.TicketFormField, answers map[string]any) (entity.FormAnswers, error) {
+	known := make(map[string]bool, len(fields))
+	result := entity.FormAnswers{}
 
-	var merchImages []entity.MerchImage
-	var imageResponses []dto.MerchImageResponse
+	for _, field := range fields {
+		known[field.Key] = true
 
-	for _, img := range req.Images {
-		ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(img.FileHeader.Filename), "."))
-		if ext != "jpg" && ext != "jpeg" && ext != "png" {
-			return dto.MerchResponse{}, dto.ErrInvalidExtensionPhoto
+		value, ok := answers[field.Key]
+		if !ok || value == nil || value == "" {
+			if field.Required {
+				return nil, fmt.Errorf("%w: %s", dto.ErrRequiredAnswerMissing, field.Label)
+			}
+			continue
 		}
 
-		imageID := uuid.New()
-		fileName := fmt.Sprintf("merch_%s.%s", imageID.String(), ext)
-		savePath := filepath.Join(saveDir, fileName)
+		switch field.Type {
+		case entity.FormFieldText, entity.FormFieldTextarea:
+			str, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("%w: %s", dto.ErrInvalidAnswer, field.Label)
+			}
+			result[field.Key] = strings.TrimSpace(str)
+		case entity.FormFieldEmail:
+			str, ok := value.(string)
+			if !ok || !helpers.IsValidEmail(str) {
+				return nil, fmt.Errorf("%w: %s", dto.ErrInvalidAnswer, field.Label)
+			}
+			result[field.Key] = str
+		case entity.FormFieldNumber:
+			switch v := value.(type) {
+			case float64:
+				result[field.Key] = v
+			case string:
+				number, err := strconv.ParseFloat(v, 64)
+				if err != nil {
+					return nil, fmt.Errorf("%w: %s", dto.ErrInvalidAnswer, field.Label)
+				}
+				result[field.Key] = number
+			default:
+				return nil, fmt.Errorf("%w: %s", dto.ErrInvalidAnswer, field.Label)
+			}
+		case entity.FormFieldSelect:
+			str, ok := value.(string)
+			if !ok || !containsOption(field.Options, str) {
+				return nil, fmt.Errorf("%w: %s", dto.ErrInvalidAnswer, field.Label)
+			}
+			result[field.Key] = str
+		case entity.FormFieldMultiSelect:
+			values, ok := value.([]any)
+			if !ok {
+				return nil, fmt.Errorf("%w: %s", dto.ErrInvalidAnswer, field.Label)
+			}
 
-		out, err := os.Create(savePath)
-		if err != nil {
-			return dto.MerchResponse{}, dto.ErrCreateFile
+			selected := make([]string, 0, len(values))
+			for _, v := range values {
+				str, ok := v.(string)
+				if !ok || !containsOption(field.Options, str) {
+					return nil, fmt.Errorf("%w: %s", dto.ErrInvalidAnswer, field.Label)
+				}
+				selected = append(selected, str)
+			}
+
+			if len(selected) == 0 && field.Required {
+				return nil, fmt.Errorf("%w: %s", dto.ErrRequiredAnswerMissing, field.Label)
+			}
+			result[field.Key] = selected
+		case entity.FormFieldCheckbox:
+			checked, ok := value.(bool)
+			if !ok {
+				return nil, fmt.Errorf("%w: %s", dto.ErrInvalidAnswer, field.Label)
+			}
+
+			if !checked && field.Required {
+				return nil, fmt.Errorf("%w: %s", dto.ErrRequiredAnswerMissing, field.Label)
+			}
+			result[field.Key] = checked
 		}
-		defer out.Close()
+	}
 
-		if _, err := io.Copy(out, img.FileReader); err != nil {
-			return dto.MerchResponse{}, dto.ErrSaveFile
+	for key := range answers {
+		if !known[key] {
+			return nil, fmt.Errorf("%w: %s", dto.ErrUnknownAnswerField, key)
 		}
+	}
 
-		merchImages = append(merchImages, entity.MerchImage{
-			ID:      imageID,
-			Name:    fileName,
-			MerchID: nil,
-		})
+	return result, nil
+}
 
-		imageResponses = append(imageResponses, dto.MerchImageResponse{
-			ID:   imageID,
-			Name: fileName,
-		})
+// validateTicketFormRequest dipakai semua alur checkout.
+func validateTicketFormRequest(form dto.TicketFormRequest, fields []entity.TicketFormField) (string, entity.FormAnswers, error) {
+	if form.AudienceType == "" || form.Instansi == "" || form.Email == "" || form.FullName == "" || form.PhoneNumber == "" {
+		return "", nil, dto.ErrEmptyFields
 	}
 
-	merchID := uuid.New()
-	merch := entity.Merch{
-		ID:          merchID,
-		Name:        req.Name,
-		Stock:       req.Stock,
-		Price:       req.Price,
-		Description: req.Description,
-		Category:    req.Category,
+	if !entity.IsValidInstansi(form.Instansi) {
+		return "", nil, dto.ErrInvalidInstansi
 	}
 
-	err := as.adminRepo.RunInTransaction(ctx, func(txRepo repository.IAdminRepository) error {
-		if err := txRepo.CreateMerch(ctx, nil, merch); err != nil {
-			return dto.ErrCreateMerch
-		}
+	if !helpers.IsValidEmail(form.Email) {
+		return "", nil, dto.ErrInvalidEmail
+	}
 
-		for _, img := range merchImages {
-			img.MerchID = &merchID
-			if err := txRepo.CreateMerchImage(ctx, nil, img); err != nil {
-				return dto.ErrCreateMerchImage
-			}
-		}
+	if len(form.FullName) < 5 {
+		return "", nil, dto.ErrUserFullNameTooShort
+	}
 
-		return nil
-	})
+	formattedPhone, err := helpers.StandardizePhoneNumber(form.PhoneNumber)
 	if err != nil {
-		return dto.MerchResponse{}, err
+		return "", nil, dto.ErrInvalidPhoneNumber
 	}
 
-	return dto.MerchResponse{
-		ID:          merch.ID,
-		Name:        merch.Name,
-		Stock:       merch.Stock,
-		Price:       merch.Price,
-		Description: merch.Description,
-		Category:    merch.Category,
-		Images:      imageResponses,
-	}, nil
-}
-func (as *AdminService) GetAllMerch(ctx context.Context) ([]dto.MerchResponse, error) {
-	merchs, err := as.adminRepo.GetAllMerch(ctx, nil)
+	answers, err := validateFormAnswers(fields, form.Answers)
 	if err != nil {
-		return nil, dto.ErrGetAllMerchNoPagination
+		return "", nil, err
 	}
 
-	var datas []dto.MerchResponse
-	for _, merch := range merchs {
-		var merchImages []dto.MerchImageResponse
-		for _, img := range merch.MerchImages {
-			merchImages = append(merchImages, dto.MerchImageResponse{
-				ID:   img.ID,
-				Name: img.Name,
-			})
-		}
+	return formattedPhone, answers, nil
+}
+func (as *AdminService) CreateTicketFormField(ctx context.Context, req dto.CreateTicketFormFieldRequest) (dto.TicketFormFieldResponse, error) {
+	if req.Key == "" || req.Label == "" || req.Type == "" {
+		return dto.TicketFormFieldResponse{}, dto.ErrEmptyFields
+	}
 
-		data := dto.MerchResponse{
-			ID:          merch.ID,
-			Name:        merch.Name,
-			Stock:       merch.Stock,
-			Price:       merch.Price,
-			Description: merch.Description,
-			Category:    merch.Category,
-			Images:      merchImages,
-		}
+	ticket, flag, err := as.adminRepo.GetTicketByID(ctx, nil, req.TicketID)
+	if err != nil || !flag {
+		return dto.TicketFormFieldResponse{}, dto.ErrTicketNotFound
+	}
 
-		datas = append(datas, data)
+	if !formFieldKeyPattern.MatchString(req.Key) {
+		return dto.TicketFormFieldResponse{}, dto.ErrInvalidFormFieldKey
 	}
 
-	return datas, nil
-}
-func (as *AdminService) GetAllMerchWithPagination(ctx context.Context, req dto.PaginationRequest) (dto.MerchPaginationResponse, error) {
-	dataWithPaginate, err := as.adminRepo.GetAllMerchWithPagination(ctx, nil, req)
-	if err != nil {
-		return dto.MerchPaginationResponse{}, dto.ErrGetAllMerchWithPagination
+	if !entity.IsValidFormFieldType(req.Type) {
+		return dto.TicketFormFieldResponse{}, dto.ErrInvalidFormFieldType
 	}
 
-	var datas []dto.MerchResponse
-	for _, merch := range dataWithPaginate.Merchs {
-		var merchImages []dto.MerchImageResponse
-		for _, img := range merch.MerchImages {
-			merchImages = append(merchImages, dto.MerchImageResponse{
-				ID:   img.ID,
+	if isChoiceFormField(req.Type) && len(req.Options) == 0 {
+		return dto.TicketFormFieldResponse{}, dto.ErrFormFieldOptionsRequired
+	}
+
+	_, flag, err = as.adminRepo.GetTicketFormFieldByTicketIDAndKey(ctx, nil, req.TicketID, req.Key)
+	if err == nil || flag {
+		return dto.TicketFormFieldResponse{}, dto.ErrFormFieldKeyAlreadyExists
+	}
+
+	field := entity.TicketFormField{
+		ID:          uuid.New(),
+		Key:         req.Key,
+		Label:       req.Label,
+		Type:        req.Type,
+		Required:    req.Required,
+		Options:     entity.StringList(req.Options),
+		Placeholder: req.Placeholder,
+		Position:    req.Position,
+		TicketID:    &ticket.ID,
+	}
+
+	err = as.adminRepo.CreateTicketFormField(ctx, nil, field)
+	if err != nil {
+		return dto.TicketFormFieldResponse{}, dto.ErrCreateTicketFormField
+	}
+
+	return toTicketFormFieldResponse(field), nil
+}
+func (as *AdminService) GetAllTicketFormField(ctx context.Context, ticketID string) ([]dto.TicketFormFieldResponse, error) {
+	_, flag, err := as.adminRepo.GetTicketByID(ctx, nil, ticketID)
+	if err != nil || !flag {
+		return nil, dto.ErrTicketNotFound
+	}
+
+	fields, err := as.adminRepo.GetAllTicketFormFieldByTicketID(ctx, nil, ticketID)
+	if err != nil {
+		return nil, dto.ErrGetAllTicketFormField
+	}
+
+	var datas []dto.TicketFormFieldResponse
+	for _, field := range fields {
+		datas = append(datas, toTicketFormFieldResponse(field))
+	}
+
+	return datas, nil
+}
+func (as *AdminService) UpdateTicketFormField(ctx context.Context, req dto.UpdateTicketFormFieldRequest) (dto.TicketFormFieldResponse, error) {
+	field, flag, err := as.adminRepo.GetTicketFormFieldByID(ctx, nil, req.ID)
+	if err != nil || !flag {
+		return dto.TicketFormFieldResponse{}, dto.ErrTicketFormFieldNotFound
+	}
+
+	if req.Label != "" {
+		field.Label = req.Label
+	}
+
+	if req.Type != "" {
+		if !entity.IsValidFormFieldType(req.Type) {
+			return dto.TicketFormFieldResponse{}, dto.ErrInvalidFormFieldType
+		}
+
+		field.Type = req.Type
+	}
+
+	if req.Required != nil {
+		field.Required = *req.Required
+	}
+
+	if req.Options != nil {
+		field.Options = entity.StringList(req.Options)
+	}
+
+	if isChoiceFormField(field.Type) && len(field.Options) == 0 {
+		return dto.TicketFormFieldResponse{}, dto.ErrFormFieldOptionsRequired
+	}
+
+	if req.Placeholder != "" {
+		field.Placeholder = req.Placeholder
+	}
+
+	if req.Position != nil {
+		field.Position = *req.Position
+	}
+
+	err = as.adminRepo.UpdateTicketFormField(ctx, nil, field)
+	if err != nil {
+		return dto.TicketFormFieldResponse{}, dto.ErrUpdateTicketFormField
+	}
+
+	return toTicketFormFieldResponse(field), nil
+}
+func (as *AdminService) DeleteTicketFormField(ctx context.Context, ticketFormFieldID string) (dto.TicketFormFieldResponse, error) {
+	deletedField, _, err := as.adminRepo.GetTicketFormFieldByID(ctx, nil, ticketFormFieldID)
+	if err != nil {
+		return dto.TicketFormFieldResponse{}, dto.ErrTicketFormFieldNotFound
+	}
+
+	err = as.adminRepo.DeleteTicketFormFieldByID(ctx, nil, ticketFormFieldID)
+	if err != nil {
+		return dto.TicketFormFieldResponse{}, dto.ErrDeleteTicketFormFieldByID
+	}
+
+	return toTicketFormFieldResponse(deletedField), nil
+}
+
+// Sponsorship
+func (as *AdminService) CreateSponsorship(ctx context.Context, req dto.CreateSponsorshipRequest) (dto.SponsorshipResponse, error) {
+	if req.FileHeader == nil || req.FileReader == nil || req.Category == "" || req.Name == "" {
+		return dto.SponsorshipResponse{}, dto.ErrEmptyFields
+	}
+
+	_, flag, err := as.adminRepo.GetSponsorshipByNameAndCategory(ctx, nil, req.Category, req.Name)
+	if err == nil || flag {
+		return dto.SponsorshipResponse{}, dto.ErrSponsorshipAlreadyExists
+	}
+
+	sponCat := entity.SponsorshipCategory(req.Category)
+	if !entity.IsValidSponsorshipCategory(sponCat) {
+		return dto.SponsorshipResponse{}, dto.ErrInvalidSponsorshipCategory
+	}
+
+	if len(req.Name) < 3 {
+		return dto.SponsorshipResponse{}, dto.ErrSponsorshipNameTooShort
+	}
+
+	ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(req.FileHeader.Filename), "."))
+	if ext != "jpg" && ext != "jpeg" && ext != "png" {
+		return dto.SponsorshipResponse{}, dto.ErrInvalidExtensionPhoto
+	}
+
+	sponsorshipName := strings.ToLower(req.Name)
+	sponsorshipName = strings.ReplaceAll(sponsorshipName, " ", "_")
+
+	fileName := fmt.Sprintf("sponsorship_%s_%s.%s", time.Now().Format("060102150405"), sponsorshipName, ext)
+
+	saveDir := "assets/sponsorship"
+	if err := os.MkdirAll(saveDir, os.ModePerm); err != nil {
+		return dto.SponsorshipResponse{}, dto.ErrCreateFile
+	}
+	savePath := filepath.Join(saveDir, fileName)
+
+	out, err := os.Create(savePath)
+	if err != nil {
+		return dto.SponsorshipResponse{}, dto.ErrCreateFile
+	}
+	defer out.Close()
+
+	if _, err := io.Copy(out, req.FileReader); err != nil {
+		return dto.SponsorshipResponse{}, dto.ErrSaveFile
+	}
+	req.Image = fileName
+
+	spon := entity.Sponsorship{
+		ID:       uuid.New(),
+		Category: sponCat,
+		Name:     req.Name,
+		Image:    req.Image,
+	}
+
+	err = as.adminRepo.CreateSponsorship(ctx, nil, spon)
+	if err != nil {
+		return dto.SponsorshipResponse{}, dto.ErrCreateSponsorship
+	}
+
+	return dto.SponsorshipResponse{
+		ID:       spon.ID,
+		Category: string(spon.Category),
+		Name:     spon.Name,
+		Image:    req.Image,
+	}, nil
+}
+func (as *AdminService) GetAllSponsorship(ctx context.Context) ([]dto.SponsorshipResponse, error) {
+	sponsorships, err := as.adminRepo.GetAllSponsorship(ctx, nil)
+	if err != nil {
+		return nil, dto.ErrGetAllSponsorship
+	}
+
+	var datas []dto.SponsorshipResponse
+	for _, sponsorship := range sponsorships {
+		data := dto.SponsorshipResponse{
+			ID:       sponsorship.ID,
+			Category: string(sponsorship.Category),
+			Name:     sponsorship.Name,
+			Image:    sponsorship.Image,
+		}
+
+		datas = append(datas, data)
+	}
+
+	return datas, nil
+}
+func (as *AdminService) GetAllSponsorshipWithPagination(ctx context.Context, req dto.PaginationRequest) (dto.SponsorshipPaginationResponse, error) {
+	dataWithPaginate, err := as.adminRepo.GetAllSponsorshipWithPagination(ctx, nil, req)
+	if err != nil {
+		return dto.SponsorshipPaginationResponse{}, dto.ErrGetAllSponsorshipWithPagination
+	}
+
+	var datas []dto.SponsorshipResponse
+	for _, sponsorship := range dataWithPaginate.Sponsorships {
+		data := dto.SponsorshipResponse{
+			ID:       sponsorship.ID,
+			Category: string(sponsorship.Category),
+			Name:     sponsorship.Name,
+			Image:    sponsorship.Image,
+		}
+
+		datas = append(datas, data)
+	}
+
+	return dto.SponsorshipPaginationResponse{
+		Data: datas,
+		PaginationResponse: dto.PaginationResponse{
+			Page:    dataWithPaginate.Page,
+			PerPage: dataWithPaginate.PerPage,
+			MaxPage: dataWithPaginate.MaxPage,
+			Count:   dataWithPaginate.Count,
+		},
+	}, nil
+}
+func (as *AdminService) GetDetailSponsorship(ctx context.Context, sponsorshipID string) (dto.SponsorshipResponse, error) {
+	sponsorship, _, err := as.adminRepo.GetSponsorshipByID(ctx, nil, sponsorshipID)
+	if err != nil {
+		return dto.SponsorshipResponse{}, dto.ErrSponsorshipNotFound
+	}
+
+	return dto.SponsorshipResponse{
+		ID:       sponsorship.ID,
+		Category: string(sponsorship.Category),
+		Name:     sponsorship.Name,
+		Image:    sponsorship.Image,
+	}, nil
+}
+func (as *AdminService) UpdateSponsorship(ctx context.Context, req dto.UpdateSponsorshipRequest) (dto.SponsorshipResponse, error) {
+	sponsorship, _, err := as.adminRepo.GetSponsorshipByID(ctx, nil, req.ID)
+	if err != nil {
+		return dto.SponsorshipResponse{}, dto.ErrSponsorshipNotFound
+	}
+
+	if req.Name != "" {
+		if len(req.Name) < 3 {
+			return dto.SponsorshipResponse{}, dto.ErrSponsorshipNameTooShort
+		}
+
+		sponsorship.Name = req.Name
+	}
+
+	if req.Category != "" {
+		sponCat := entity.SponsorshipCategory(req.Category)
+		if !entity.IsValidSponsorshipCategory(sponCat) {
+			return dto.SponsorshipResponse{}, dto.ErrInvalidSponsorshipCategory
+		}
+
+		sponsorship.Category = sponCat
+	}
+
+	if req.FileHeader != nil || req.FileReader != nil {
+		ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(req.FileHeader.Filename), "."))
+		if ext != "jpg" && ext != "jpeg" && ext != "png" {
+			return dto.SponsorshipResponse{}, dto.ErrInvalidExtensionPhoto
+		}
+
+		if sponsorship.Image != "" {
+			oldPath := filepath.Join("assets/sponsorship", sponsorship.Image)
+			if err := os.Remove(oldPath); err != nil && !os.IsNotExist(err) {
+				return dto.SponsorshipResponse{}, dto.ErrDeleteOldImage
+			}
+		}
+
+		sponsorshipName := strings.ToLower(sponsorship.Name)
+		sponsorshipName = strings.ReplaceAll(sponsorshipName, " ", "_")
+
+		fileName := fmt.Sprintf("sponsorship_%s_%s.%s", time.Now().Format("060102150405"), sponsorshipName, ext)
+
+		saveDir := "assets/sponsorship"
+		if err := os.MkdirAll(saveDir, os.ModePerm); err != nil {
+			return dto.SponsorshipResponse{}, dto.ErrCreateFile
+		}
+		savePath := filepath.Join(saveDir, fileName)
+
+		out, err := os.Create(savePath)
+		if err != nil {
+			return dto.SponsorshipResponse{}, dto.ErrCreateFile
+		}
+		defer out.Close()
+
+		if _, err := io.Copy(out, req.FileReader); err != nil {
+			return dto.SponsorshipResponse{}, dto.ErrSaveFile
+		}
+		sponsorship.Image = fileName
+	}
+
+	err = as.adminRepo.UpdateSponsorship(ctx, nil, sponsorship)
+	if err != nil {
+		return dto.SponsorshipResponse{}, dto.ErrUpdateSponsorship
+	}
+
+	res := dto.SponsorshipResponse{
+		ID:       sponsorship.ID,
+		Category: string(sponsorship.Category),
+		Name:     sponsorship.Name,
+		Image:    sponsorship.Image,
+	}
+
+	return res, nil
+}
+func (as *AdminService) DeleteSponsorship(ctx context.Context, req dto.DeleteSponsorshipRequest) (dto.SponsorshipResponse, error) {
+	deletedSponsorship, _, err := as.adminRepo.GetSponsorshipByID(ctx, nil, req.SponsorshipID)
+	if err != nil {
+		return dto.SponsorshipResponse{}, dto.ErrSponsorshipNotFound
+	}
+
+	err = as.adminRepo.DeleteSponsorshipByID(ctx, nil, req.SponsorshipID)
+	if err != nil {
+		return dto.SponsorshipResponse{}, dto.ErrDeleteSponsorshipByID
+	}
+
+	res := dto.SponsorshipResponse{
+		ID:       deletedSponsorship.ID,
+		Category: string(deletedSponsorship.Category),
+		Name:     deletedSponsorship.Name,
+		Image:    deletedSponsorship.Image,
+	}
+
+	return res, nil
+}
+
+// Speaker
+func (as *AdminService) CreateSpeaker(ctx context.Context, req dto.CreateSpeakerRequest) (dto.SpeakerResponse, error) {
+	if req.FileHeader == nil || req.FileReader == nil || req.Name == "" || req.Description == "" {
+		return dto.SpeakerResponse{}, dto.ErrEmptyFields
+	}
+
+	if len(req.Name) < 3 {
+		return dto.SpeakerResponse{}, dto.ErrSpeakerNameTooShort
+	}
+
+	if len(req.Description) < 5 {
+		return dto.SpeakerResponse{}, dto.ErrSpeakerDescriptionTooShort
+	}
+
+	_, flag, err := as.adminRepo.GetSpeakerByName(ctx, nil, req.Name)
+	if err == nil || flag {
+		return dto.SpeakerResponse{}, dto.ErrSpeakerAlreadyExists
+	}
+
+	ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(req.FileHeader.Filename), "."))
+	if ext != "jpg" && ext != "jpeg" && ext != "png" {
+		return dto.SpeakerResponse{}, dto.ErrInvalidExtensionPhoto
+	}
+
+	speakerName := strings.ToLower(req.Name)
+	speakerName = strings.ReplaceAll(speakerName, " ", "_")
+
+	fileName := fmt.Sprintf("speaker_%s_%s.%s", time.Now().Format("060102150405"), speakerName, ext)
+	saveDir := "assets/speaker"
+	if err := os.MkdirAll(saveDir, os.ModePerm); err != nil {
+		return dto.SpeakerResponse{}, dto.ErrCreateFile
+	}
+	savePath := filepath.Join(saveDir, fileName)
+
+	out, err := os.Create(savePath)
+	if err != nil {
+		return dto.SpeakerResponse{}, dto.ErrCreateFile
+	}
+	defer out.Close()
+
+	if _, err := io.Copy(out, req.FileReader); err != nil {
+		return dto.SpeakerResponse{}, dto.ErrSaveFile
+	}
+	req.Image = fileName
+
+	speaker := entity.Speaker{
+		ID:          uuid.New(),
+		Name:        req.Name,
+		Image:       req.Image,
+		Description: req.Description,
+	}
+
+	err = as.adminRepo.CreateSpeaker(ctx, nil, speaker)
+	if err != nil {
+		return dto.SpeakerResponse{}, dto.ErrCreateSpeaker
+	}
+
+	return dto.SpeakerResponse{
+		ID:          speaker.ID,
+		Name:        speaker.Name,
+		Image:       speaker.Image,
+		Description: speaker.Description,
+	}, nil
+}
+func (as *AdminService) GetAllSpeaker(ctx context.Context) ([]dto.SpeakerResponse, error) {
+	speakers, err := as.adminRepo.GetAllSpeaker(ctx, nil)
+	if err != nil {
+		return nil, dto.ErrGetAllSpeakerNoPagination
+	}
+
+	var datas []dto.SpeakerResponse
+	for _, speaker := range speakers {
+		data := dto.SpeakerResponse{
+			ID:          speaker.ID,
+			Name:        speaker.Name,
+			Image:       speaker.Image,
+			Description: speaker.Description,
+		}
+
+		datas = append(datas, data)
+	}
+
+	return datas, nil
+}
+func (as *AdminService) GetAllSpeakerWithPagination(ctx context.Context, req dto.PaginationRequest) (dto.SpeakerPaginationResponse, error) {
+	dataWithPaginate, err := as.adminRepo.GetAllSpeakerWithPagination(ctx, nil, req)
+	if err != nil {
+		return dto.SpeakerPaginationResponse{}, dto.ErrGetAllSpeakerWithPagination
+	}
+
+	var datas []dto.SpeakerResponse
+	for _, speaker := range dataWithPaginate.Speakers {
+		data := dto.SpeakerResponse{
+			ID:          speaker.ID,
+			Name:        speaker.Name,
+			Image:       speaker.Image,
+			Description: speaker.Description,
+		}
+
+		datas = append(datas, data)
+	}
+
+	return dto.SpeakerPaginationResponse{
+		Data: datas,
+		PaginationResponse: dto.PaginationResponse{
+			Page:    dataWithPaginate.Page,
+			PerPage: dataWithPaginate.PerPage,
+			MaxPage: dataWithPaginate.MaxPage,
+			Count:   dataWithPaginate.Count,
+		},
+	}, nil
+}
+func (as *AdminService) GetDetailSpeaker(ctx context.Context, speakerID string) (dto.SpeakerResponse, error) {
+	speaker, _, err := as.adminRepo.GetSpeakerByID(ctx, nil, speakerID)
+	if err != nil {
+		return dto.SpeakerResponse{}, dto.ErrSpeakerNotFound
+	}
+
+	return dto.SpeakerResponse{
+		ID:          speaker.ID,
+		Name:        speaker.Name,
+		Image:       speaker.Image,
+		Description: speaker.Description,
+	}, nil
+}
+func (as *AdminService) UpdateSpeaker(ctx context.Context, req dto.UpdateSpeakerRequest) (dto.SpeakerResponse, error) {
+	speaker, _, err := as.adminRepo.GetSpeakerByID(ctx, nil, req.ID)
+	if err != nil {
+		return dto.SpeakerResponse{}, dto.ErrSpeakerNotFound
+	}
+
+	if req.Name != "" {
+		if len(req.Name) < 3 {
+			return dto.SpeakerResponse{}, dto.ErrSpeakerNameTooShort
+		}
+
+		speaker.Name = req.Name
+	}
+
+	if req.Description != "" {
+		if len(req.Description) < 3 {
+			return dto.SpeakerResponse{}, dto.ErrSpeakerDescriptionTooShort
+		}
+
+		speaker.Description = req.Description
+	}
+
+	if req.FileReader != nil && req.FileHeader != nil {
+		ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(req.FileHeader.Filename), "."))
+
+		if ext != "jpg" && ext != "jpeg" && ext != "png" {
+			return dto.SpeakerResponse{}, dto.ErrInvalidExtensionPhoto
+		}
+
+		if speaker.Image != "" {
+			oldPath := filepath.Join("assets/speaker", speaker.Image)
+			if err := os.Remove(oldPath); err != nil && !os.IsNotExist(err) {
+				return dto.SpeakerResponse{}, dto.ErrDeleteOldImage
+			}
+		}
+
+		if req.Name == "" {
+			req.Name = speaker.Name
+		}
+
+		speakerName := strings.ToLower(req.Name)
+		speakerName = strings.ReplaceAll(speakerName, " ", "_")
+
+		fileName := fmt.Sprintf("speaker_%s_%s.%s", time.Now().Format("060102150405"), speakerName, ext)
+
+		saveDir := "assets/speaker"
+		if err := os.MkdirAll(saveDir, os.ModePerm); err != nil {
+			return dto.SpeakerResponse{}, dto.ErrCreateFile
+		}
+
+		savePath := filepath.Join(saveDir, fileName)
+
+		out, err := os.Create(savePath)
+		if err != nil {
+			return dto.SpeakerResponse{}, dto.ErrCreateFile
+		}
+		defer out.Close()
+
+		if _, err := io.Copy(out, req.FileReader); err != nil {
+			return dto.SpeakerResponse{}, dto.ErrSaveFile
+		}
+
+		speaker.Image = fileName
+	}
+
+	err = as.adminRepo.UpdateSpeaker(ctx, nil, speaker)
+	if err != nil {
+		return dto.SpeakerResponse{}, dto.ErrUpdateSpeaker
+	}
+
+	res := dto.SpeakerResponse{
+		ID:          speaker.ID,
+		Name:        speaker.Name,
+		Image:       speaker.Image,
+		Description: speaker.Description,
+	}
+
+	return res, nil
+}
+func (as *AdminService) DeleteSpeaker(ctx context.Context, req dto.DeleteSpeakerRequest) (dto.SpeakerResponse, error) {
+	deletedSpeaker, _, err := as.adminRepo.GetSpeakerByID(ctx, nil, req.SpeakerID)
+	if err != nil {
+		return dto.SpeakerResponse{}, dto.ErrSpeakerNotFound
+	}
+
+	err = as.adminRepo.DeleteSpeakerByID(ctx, nil, req.SpeakerID)
+	if err != nil {
+		return dto.SpeakerResponse{}, dto.ErrDeleteSpeakerByID
+	}
+
+	res := dto.SpeakerResponse{
+		ID:          deletedSpeaker.ID,
+		Name:        deletedSpeaker.Name,
+		Image:       deletedSpeaker.Image,
+		Description: deletedSpeaker.Description,
+	}
+
+	return res, nil
+}
+
+// Merch
+func (as *AdminService) CreateMerch(ctx context.Context, req dto.CreateMerchRequest) (dto.MerchResponse, error) {
+	if req.Name == "" || req.Description == "" || req.Category == "" || len(req.Images) == 0 {
+		return dto.MerchResponse{}, dto.ErrEmptyFields
+	}
+
+	if len(req.Name) < 3 {
+		return dto.MerchResponse{}, dto.ErrMerchNameTooShort
+	}
+
+	if len(req.Description) < 5 {
+		return dto.MerchResponse{}, dto.ErrMerchDescriptionTooShort
+	}
+
+	if !entity.IsValidMerchCategory(req.Category) {
+		return dto.MerchResponse{}, dto.ErrInvalidMerchCategory
+	}
+
+	if req.Stock < 0 {
+		return dto.MerchResponse{}, dto.ErrStockOutOfBound
+	}
+
+	if req.Price < 0 {
+		return dto.MerchResponse{}, dto.ErrPriceOutOfBound
+	}
+
+	saveDir := "assets/merch"
+	if err := os.MkdirAll(saveDir, os.ModePerm); err != nil {
+		return dto.MerchResponse{}, dto.ErrCreateFile
+	}
+
+	var merchImages []entity.MerchImage
+	var imageResponses []dto.MerchImageResponse
+
+	for _, img := range req.Images {
+		ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(img.FileHeader.Filename), "."))
+		if ext != "jpg" && ext != "jpeg" && ext != "png" {
+			return dto.MerchResponse{}, dto.ErrInvalidExtensionPhoto
+		}
+
+		imageID := uuid.New()
+		fileName := fmt.Sprintf("merch_%s.%s", imageID.String(), ext)
+		savePath := filepath.Join(saveDir, fileName)
+
+		out, err := os.Create(savePath)
+		if err != nil {
+			return dto.MerchResponse{}, dto.ErrCreateFile
+		}
+		defer out.Close()
+
+		if _, err := io.Copy(out, img.FileReader); err != nil {
+			return dto.MerchResponse{}, dto.ErrSaveFile
+		}
+
+		merchImages = append(merchImages, entity.MerchImage{
+			ID:      imageID,
+			Name:    fileName,
+			MerchID: nil,
+		})
+
+		imageResponses = append(imageResponses, dto.MerchImageResponse{
+			ID:   imageID,
+			Name: fileName,
+		})
+	}
+
+	merchID := uuid.New()
+	merch := entity.Merch{
+		ID:          merchID,
+		Name:        req.Name,
+		Stock:       req.Stock,
+		Price:       req.Price,
+		Description: req.Description,
+		Category:    req.Category,
+	}
+
+	err := as.adminRepo.RunInTransaction(ctx, func(txRepo repository.IAdminRepository) error {
+		if err := txRepo.CreateMerch(ctx, nil, merch); err != nil {
+			return dto.ErrCreateMerch
+		}
+
+		for _, img := range merchImages {
+			img.MerchID = &merchID
+			if err := txRepo.CreateMerchImage(ctx, nil, img); err != nil {
+				return dto.ErrCreateMerchImage
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return dto.MerchResponse{}, err
+	}
+
+	return dto.MerchResponse{
+		ID:          merch.ID,
+		Name:        merch.Name,
+		Stock:       merch.Stock,
+		Price:       merch.Price,
+		Description: merch.Description,
+		Category:    merch.Category,
+		Images:      imageResponses,
+	}, nil
+}
+func (as *AdminService) GetAllMerch(ctx context.Context) ([]dto.MerchResponse, error) {
+	merchs, err := as.adminRepo.GetAllMerch(ctx, nil)
+	if err != nil {
+		return nil, dto.ErrGetAllMerchNoPagination
+	}
+
+	var datas []dto.MerchResponse
+	for _, merch := range merchs {
+		var merchImages []dto.MerchImageResponse
+		for _, img := range merch.MerchImages {
+			merchImages = append(merchImages, dto.MerchImageResponse{
+				ID:   img.ID,
+				Name: img.Name,
+			})
+		}
+
+		data := dto.MerchResponse{
+			ID:          merch.ID,
+			Name:        merch.Name,
+			Stock:       merch.Stock,
+			Price:       merch.Price,
+			Description: merch.Description,
+			Category:    merch.Category,
+			Images:      merchImages,
+		}
+
+		datas = append(datas, data)
+	}
+
+	return datas, nil
+}
+func (as *AdminService) GetAllMerchWithPagination(ctx context.Context, req dto.PaginationRequest) (dto.MerchPaginationResponse, error) {
+	dataWithPaginate, err := as.adminRepo.GetAllMerchWithPagination(ctx, nil, req)
+	if err != nil {
+		return dto.MerchPaginationResponse{}, dto.ErrGetAllMerchWithPagination
+	}
+
+	var datas []dto.MerchResponse
+	for _, merch := range dataWithPaginate.Merchs {
+		var merchImages []dto.MerchImageResponse
+		for _, img := range merch.MerchImages {
+			merchImages = append(merchImages, dto.MerchImageResponse{
+				ID:   img.ID,
 				Name: img.Name,
 			})
 		}
 
-		data := dto.MerchResponse{
-			ID:          merch.ID,
-			Name:        merch.Name,
-			Stock:       merch.Stock,
-			Price:       merch.Price,
-			Description: merch.Description,
-			Category:    merch.Category,
-			Images:      merchImages,
+		data := dto.MerchResponse{
+			ID:          merch.ID,
+			Name:        merch.Name,
+			Stock:       merch.Stock,
+			Price:       merch.Price,
+			Description: merch.Description,
+			Category:    merch.Category,
+			Images:      merchImages,
+		}
+
+		datas = append(datas, data)
+	}
+
+	return dto.MerchPaginationResponse{
+		Data: datas,
+		PaginationResponse: dto.PaginationResponse{
+			Page:    dataWithPaginate.Page,
+			PerPage: dataWithPaginate.PerPage,
+			MaxPage: dataWithPaginate.MaxPage,
+			Count:   dataWithPaginate.Count,
+		},
+	}, nil
+}
+func (as *AdminService) GetDetailMerch(ctx context.Context, merchID string) (dto.MerchResponse, error) {
+	merch, _, err := as.adminRepo.GetMerchByID(ctx, nil, merchID)
+	if err != nil {
+		return dto.MerchResponse{}, dto.ErrMerchNotFound
+	}
+
+	var merchImages []dto.MerchImageResponse
+	for _, img := range merch.MerchImages {
+		merchImages = append(merchImages, dto.MerchImageResponse{
+			ID:   img.ID,
+			Name: img.Name,
+		})
+	}
+
+	return dto.MerchResponse{
+		ID:          merch.ID,
+		Name:        merch.Name,
+		Stock:       merch.Stock,
+		Price:       merch.Price,
+		Description: merch.Description,
+		Category:    merch.Category,
+		Images:      merchImages,
+	}, nil
+}
+func (as *AdminService) UpdateMerch(ctx context.Context, req dto.UpdateMerchRequest) (dto.MerchResponse, error) {
+	merch, found, err := as.adminRepo.GetMerchByID(ctx, nil, req.ID)
+	if err != nil || !found {
+		return dto.MerchResponse{}, dto.ErrMerchNotFound
+	}
+
+	if req.Name != "" {
+		if len(req.Name) < 3 {
+			return dto.MerchResponse{}, dto.ErrMerchNameTooShort
+		}
+		merch.Name = req.Name
+	}
+
+	if req.Description != "" {
+		if len(req.Description) < 5 {
+			return dto.MerchResponse{}, dto.ErrMerchDescriptionTooShort
+		}
+		merch.Description = req.Description
+	}
+
+	if req.Stock != nil {
+		if *req.Stock < 0 {
+			return dto.MerchResponse{}, dto.ErrStockOutOfBound
+		}
+		merch.Stock = *req.Stock
+	}
+
+	if req.Price != nil {
+		if *req.Price < 0 {
+			return dto.MerchResponse{}, dto.ErrPriceOutOfBound
+		}
+		merch.Price = *req.Price
+	}
+
+	if req.Category != "" {
+		category := entity.MerchCategory(req.Category)
+		if !entity.IsValidMerchCategory(category) {
+			return dto.MerchResponse{}, dto.ErrInvalidMerchCategory
+		}
+		merch.Category = category
+	}
+
+	err = as.adminRepo.RunInTransaction(ctx, func(txRepo repository.IAdminRepository) error {
+		if err := txRepo.UpdateMerch(ctx, nil, merch); err != nil {
+			return dto.ErrUpdateMerch
+		}
+
+		if len(req.Images) > 0 {
+			oldImages, err := txRepo.GetMerchImagesByMerchID(ctx, nil, merch.ID.String())
+			if err != nil {
+				return dto.ErrGetMerchImages
+			}
+
+			for _, img := range oldImages {
+				path := filepath.Join("assets/merch", img.Name)
+				if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+					return dto.ErrDeleteOldImage
+				}
+				if err := txRepo.DeleteMerchImageByID(ctx, nil, img.ID.String()); err != nil {
+					return dto.ErrDeleteMerchImageByID
+				}
+			}
+
+			for _, img := range req.Images {
+				ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(img.FileHeader.Filename), "."))
+				if ext != "jpg" && ext != "jpeg" && ext != "png" {
+					return dto.ErrInvalidExtensionPhoto
+				}
+
+				newImageID := uuid.New()
+				newFileName := fmt.Sprintf("merch_%s.%s", newImageID, ext)
+
+				saveDir := "assets/merch"
+				if err := os.MkdirAll(saveDir, os.ModePerm); err != nil {
+					return dto.ErrCreateFile
+				}
+				savePath := filepath.Join(saveDir, newFileName)
+
+				out, err := os.Create(savePath)
+				if err != nil {
+					return dto.ErrCreateFile
+				}
+				defer out.Close()
+
+				if _, err := io.Copy(out, img.FileReader); err != nil {
+					return dto.ErrSaveFile
+				}
+
+				newImage := entity.MerchImage{
+					ID:      newImageID,
+					MerchID: &merch.ID,
+					Name:    newFileName,
+				}
+				if err := txRepo.CreateMerchImage(ctx, nil, newImage); err != nil {
+					return dto.ErrCreateMerchImage
+				}
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return dto.MerchResponse{}, err
+	}
+
+	images, err := as.adminRepo.GetMerchImagesByMerchID(ctx, nil, merch.ID.String())
+	if err != nil {
+		return dto.MerchResponse{}, dto.ErrGetMerchImages
+	}
+
+	var imageResponses []dto.MerchImageResponse
+	for _, img := range images {
+		imageResponses = append(imageResponses, dto.MerchImageResponse{
+			ID:   img.ID,
+			Name: img.Name,
+		})
+	}
+
+	return dto.MerchResponse{
+		ID:          merch.ID,
+		Name:        merch.Name,
+		Stock:       merch.Stock,
+		Price:       merch.Price,
+		Description: merch.Description,
+		Category:    merch.Category,
+		Images:      imageResponses,
+	}, nil
+}
+func (as *AdminService) DeleteMerch(ctx context.Context, req dto.DeleteMerchRequest) (dto.MerchResponse, error) {
+	deletedMerch, flag, err := as.adminRepo.GetMerchByID(ctx, nil, req.MerchID)
+	if err != nil || !flag {
+		return dto.MerchResponse{}, dto.ErrMerchNotFound
+	}
+
+	var merchImages []dto.MerchImageResponse
+	for _, img := range deletedMerch.MerchImages {
+		merchImages = append(merchImages, dto.MerchImageResponse{
+			ID:   img.ID,
+			Name: img.Name,
+		})
+	}
+
+	if err = as.adminRepo.DeleteMerchImagesByMerchID(ctx, nil, req.MerchID); err != nil {
+		return dto.MerchResponse{}, dto.ErrDeleteMerchImagesByMerchID
+	}
+
+	if err = as.adminRepo.DeleteMerchByID(ctx, nil, req.MerchID); err != nil {
+		return dto.MerchResponse{}, dto.ErrDeleteMerchByID
+	}
+
+	res := dto.MerchResponse{
+		ID:          deletedMerch.ID,
+		Name:        deletedMerch.Name,
+		Stock:       deletedMerch.Stock,
+		Price:       deletedMerch.Price,
+		Description: deletedMerch.Description,
+		Category:    deletedMerch.Category,
+		Images:      merchImages,
+	}
+
+	return res, nil
+}
+
+// Bundle
+func (as *AdminService) CreateBundle(ctx context.Context, req dto.CreateBundleRequest) (dto.BundleResponse, error) {
+	if req.Name == "" || req.FileHeader == nil || req.FileReader == nil || len(req.BundleItems) == 0 || req.Type == "" || (req.EventDate == "" && req.EventID == nil) {
+		return dto.BundleResponse{}, dto.ErrEmptyFields
+	}
+
+	if len(req.Name) < 3 {
+		return dto.BundleResponse{}, dto.ErrBundleNameTooShort
+	}
+
+	if !entity.IsValidBundleType(req.Type) {
+		return dto.BundleResponse{}, dto.ErrInvalidBundleType
+	}
+
+	if req.Price < 0 {
+		return dto.BundleResponse{}, dto.ErrPriceOutOfBound
+	}
+
+	if req.Quota < 0 {
+		return dto.BundleResponse{}, dto.ErrQuotaOutOfBound
+	}
+
+	var event entity.Event
+	if req.EventID != nil {
+		e, flag, err := as.adminRepo.GetEventByID(ctx, nil, req.EventID.String())
+		if err != nil || !flag {
+			return dto.BundleResponse{}, dto.ErrEventNotFound
+		}
+
+		event = e
+	}
+
+	var fileName string
+	if req.FileHeader != nil && req.FileReader != nil {
+		ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(req.FileHeader.Filename), "."))
+		if ext != "jpg" && ext != "jpeg" && ext != "png" {
+			return dto.BundleResponse{}, dto.ErrInvalidExtensionPhoto
+		}
+
+		bundleName := strings.ToLower(req.Name)
+		bundleName = strings.ReplaceAll(bundleName, " ", "_")
+
+		fileName = fmt.Sprintf("bundle_%s_%s.%s", time.Now().Format("060102150405"), bundleName, ext)
+
+		saveDir := "assets/bundle"
+		if err := os.MkdirAll(saveDir, os.ModePerm); err != nil {
+			return dto.BundleResponse{}, dto.ErrCreateFile
+		}
+		savePath := filepath.Join(saveDir, fileName)
+
+		out, err := os.Create(savePath)
+		if err != nil {
+			return dto.BundleResponse{}, dto.ErrCreateFile
+		}
+		defer out.Close()
+
+		if _, err := io.Copy(out, req.FileReader); err != nil {
+			return dto.BundleResponse{}, dto.ErrSaveFile
+		}
+	}
+
+	loc, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		loc = time.FixedZone("UTC+7", 7*60*60)
+	}
+	eventDate := event.StartAt.In(loc)
+	if req.EventDate != "" {
+		eventDate, err = time.ParseInLocation("2006-01-02", req.EventDate, loc)
+		if err != nil {
+			return dto.BundleResponse{}, dto.ErrParseTime
+		}
+	}
+
+	bundleID := uuid.New()
+	bundle := entity.Bundle{
+		ID:          bundleID,
+		Name:        req.Name,
+		Type:        req.Type,
+		Price:       req.Price,
+		Quota:       req.Quota,
+		Image:       fileName,
+		Description: req.Description,
+		EventDate:   eventDate,
+		EventID:     req.EventID,
+	}
+
+	var bundleItems []entity.BundleItem
+	for _, merchID := range req.BundleItems {
+		bundleItems = append(bundleItems, entity.BundleItem{
+			ID:       uuid.New(),
+			BundleID: &bundleID,
+			MerchID:  merchID,
+		})
+	}
+
+	err = as.adminRepo.RunInTransaction(ctx, func(txRepo repository.IAdminRepository) error {
+		if err := txRepo.CreateBundle(ctx, nil, bundle); err != nil {
+			return err
+		}
+		for _, item := range bundleItems {
+			_, _, err := txRepo.GetMerchByID(ctx, nil, item.MerchID.String())
+			if err != nil {
+				return dto.ErrMerchNotFound
+			}
+
+			if err := txRepo.CreateBundleItem(ctx, nil, item); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return dto.BundleResponse{}, err
+	}
+
+	as.availabilityService.PublishBundle(ctx, bundle.ID.String())
+
+	var itemsResp []dto.BundleItemResponse
+	for _, item := range bundleItems {
+		merch, found, err := as.adminRepo.GetMerchByID(ctx, nil, item.MerchID.String())
+		if err == nil && found {
+			itemsResp = append(itemsResp, dto.BundleItemResponse{
+				ID:        item.ID,
+				MerchID:   item.MerchID,
+				MerchName: merch.Name,
+			})
+		}
+	}
+
+	return dto.BundleResponse{
+		ID:          bundle.ID,
+		Name:        bundle.Name,
+		Image:       bundle.Image,
+		Type:        bundle.Type,
+		Price:       bundle.Price,
+		Quota:       bundle.Quota,
+		Description: bundle.Description,
+		EventDate:   bundle.EventDate.Format("2006-01-02"),
+		EventID:     bundle.EventID,
+		BundleItems: itemsResp,
+	}, nil
+}
+func (as *AdminService) GetAllBundle(ctx context.Context) ([]dto.BundleResponse, error) {
+	bundles, err := as.adminRepo.GetAllBundle(ctx, nil)
+	if err != nil {
+		return nil, dto.ErrGetAllBundleNoPagination
+	}
+
+	var datas []dto.BundleResponse
+	for _, bundle := range bundles {
+		isAvailable := bundle.Quota > 0 && time.Now().Before(bundle.EventDate)
+
+		data := dto.BundleResponse{
+			ID:          bundle.ID,
+			Name:        bundle.Name,
+			Image:       bundle.Image,
+			Type:        bundle.Type,
+			Price:       bundle.Price,
+			Quota:       bundle.Quota,
+			Description: bundle.Description,
+			EventDate:   bundle.EventDate.Format("2006-01-02"),
+			EventID:     bundle.EventID,
+			IsAvailable: &isAvailable,
+		}
+
+		for _, bi := range bundle.BundleItems {
+			bundleItem := dto.BundleItemResponse{
+				ID:        bi.ID,
+				MerchID:   bi.MerchID,
+				MerchName: bi.Merch.Name,
+			}
+
+			data.BundleItems = append(data.BundleItems, bundleItem)
+		}
+
+		datas = append(datas, data)
+	}
+
+	return datas, nil
+}
+func (as *AdminService) GetAllBundleWithPagination(ctx context.Context, req dto.PaginationRequest) (dto.BundlePaginationResponse, error) {
+	dataWithPaginate, err := as.adminRepo.GetAllBundleWithPagination(ctx, nil, req)
+	if err != nil {
+		return dto.BundlePaginationResponse{}, dto.ErrGetAllBundleWithPagination
+	}
+
+	var datas []dto.BundleResponse
+	for _, bundle := range dataWithPaginate.Bundles {
+		isAvailable := bundle.Quota > 0 && time.Now().Before(bundle.EventDate)
+
+		data := dto.BundleResponse{
+			ID:          bundle.ID,
+			Name:        bundle.Name,
+			Image:       bundle.Image,
+			Type:        bundle.Type,
+			Price:       bundle.Price,
+			Quota:       bundle.Quota,
+			Description: bundle.Description,
+			EventDate:   bundle.EventDate.Format("2006-01-02"),
+			EventID:     bundle.EventID,
+			IsAvailable: &isAvailable,
+		}
+
+		for _, bi := range bundle.BundleItems {
+			bundleItem := dto.BundleItemResponse{
+				ID:        bi.ID,
+				MerchID:   bi.MerchID,
+				MerchName: bi.Merch.Name,
+			}
+
+			data.BundleItems = append(data.BundleItems, bundleItem)
+		}
+
+		datas = append(datas, data)
+	}
+
+	return dto.BundlePaginationResponse{
+		Data: datas,
+		PaginationResponse: dto.PaginationResponse{
+			Page:    dataWithPaginate.Page,
+			PerPage: dataWithPaginate.PerPage,
+			MaxPage: dataWithPaginate.MaxPage,
+			Count:   dataWithPaginate.Count,
+		},
+	}, nil
+}
+func (as *AdminService) GetDetailBundle(ctx context.Context, bundleID string) (dto.BundleResponse, error) {
+	bundle, _, err := as.adminRepo.GetBundleByID(ctx, nil, bundleID)
+	if err != nil {
+		return dto.BundleResponse{}, dto.ErrBundleNotFound
+	}
+
+	b := dto.BundleResponse{
+		ID:          bundle.ID,
+		Name:        bundle.Name,
+		Image:       bundle.Image,
+		Type:        bundle.Type,
+		Price:       bundle.Price,
+		Quota:       bundle.Quota,
+		Description: bundle.Description,
+		EventDate:   bundle.EventDate.Format("2006-01-02"),
+		EventID:     bundle.EventID,
+	}
+
+	for _, bi := range bundle.BundleItems {
+		bundleItem := dto.BundleItemResponse{
+			ID:        bi.ID,
+			MerchID:   bi.MerchID,
+			MerchName: bi.Merch.Name,
+		}
+
+		for _, mi := range bi.Merch.MerchImages {
+			bundleItem.MerchImages = append(bundleItem.MerchImages, dto.MerchImageResponse{
+				ID:   mi.ID,
+				Name: mi.Name,
+			})
+		}
+
+		b.BundleItems = append(b.BundleItems, bundleItem)
+	}
+
+	return b, nil
+}
+func (as *AdminService) UpdateBundle(ctx context.Context, req dto.UpdateBundleRequest) (dto.BundleResponse, error) {
+	bundle, flag, err := as.adminRepo.GetBundleByID(ctx, nil, req.ID)
+	if err != nil || !flag {
+		return dto.BundleResponse{}, dto.ErrBundleNotFound
+	}
+
+	if req.Name != "" {
+		if len(req.Name) < 3 {
+			return dto.BundleResponse{}, dto.ErrBundleNameTooShort
+		}
+
+		bundle.Name = req.Name
+	}
+
+	if req.Type != "" {
+		if !entity.IsValidBundleType(req.Type) {
+			return dto.BundleResponse{}, dto.ErrInvalidBundleType
+		}
+
+		bundle.Type = req.Type
+	}
+
+	if req.Price != nil {
+		if *req.Price < 0 {
+			return dto.BundleResponse{}, dto.ErrPriceOutOfBound
+		}
+
+		bundle.Price = *req.Price
+	}
+
+	if req.Quota != nil {
+		if *req.Quota < 0 {
+			return dto.BundleResponse{}, dto.ErrQuotaOutOfBound
+		}
+
+		bundle.Quota = *req.Quota
+	}
+
+	if req.FileHeader != nil && req.FileReader != nil {
+		ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(req.FileHeader.Filename), "."))
+		if ext != "jpg" && ext != "jpeg" && ext != "png" {
+			return dto.BundleResponse{}, dto.ErrInvalidExtensionPhoto
+		}
+
+		if bundle.Image != "" {
+			oldPath := filepath.Join("assets/bundle", bundle.Image)
+			if err := os.Remove(oldPath); err != nil && !os.IsNotExist(err) {
+				return dto.BundleResponse{}, dto.ErrDeleteOldImage
+			}
+		}
+
+		bundleName := strings.ToLower(bundle.Name)
+		bundleName = strings.ReplaceAll(bundleName, " ", "_")
+
+		fileName := fmt.Sprintf("bundle_%s_%s.%s", time.Now().Format("060102150405"), bundleName, ext)
+
+		saveDir := "assets/bundle"
+		if err := os.MkdirAll(saveDir, os.ModePerm); err != nil {
+			return dto.BundleResponse{}, dto.ErrCreateFile
+		}
+		savePath := filepath.Join(saveDir, fileName)
+
+		out, err := os.Create(savePath)
+		if err != nil {
+			return dto.BundleResponse{}, dto.ErrCreateFile
+		}
+		defer out.Close()
+
+		if _, err := io.Copy(out, req.FileReader); err != nil {
+			return dto.BundleResponse{}, dto.ErrSaveFile
+		}
+		bundle.Image = fileName
+	}
+
+	if req.Description != "" {
+		bundle.Description = req.Description
+	}
+
+	if req.EventDate != "" {
+		loc, err := time.LoadLocation("Asia/Jakarta")
+		if err != nil {
+			loc = time.FixedZone("UTC+7", 7*60*60)
+		}
+		eventDate, err := time.ParseInLocation("2006-01-02", req.EventDate, loc)
+		if err != nil {
+			return dto.BundleResponse{}, dto.ErrParseTime
+		}
+
+		bundle.EventDate = eventDate
+	}
+
+	if req.EventID != nil {
+		event, flag, err := as.adminRepo.GetEventByID(ctx, nil, req.EventID.String())
+		if err != nil || !flag {
+			return dto.BundleResponse{}, dto.ErrEventNotFound
+		}
+
+		bundle.EventID = &event.ID
+		bundle.Event = event
+	}
+
+	updateItems := req.BundleItems != nil
+
+	var newItems []dto.BundleItemResponse
+	if updateItems {
+		for _, biID := range req.BundleItems {
+			var item dto.BundleItemResponse
+			item.ID = uuid.New()
+
+			merch, flag, err := as.adminRepo.GetMerchByID(ctx, nil, biID.String())
+			if flag || err == nil {
+				item.MerchID = &merch.ID
+				item.MerchName = merch.Name
+			}
+
+			newItems = append(newItems, item)
+		}
+	}
+
+	err = as.adminRepo.RunInTransaction(ctx, func(txRepo repository.IAdminRepository) error {
+		if err := txRepo.UpdateBundle(ctx, nil, bundle); err != nil {
+			return dto.ErrUpdateBundle
+		}
+
+		if updateItems {
+			if err := txRepo.DeleteBundleItemsByBundleID(ctx, nil, bundle.ID.String()); err != nil {
+				return dto.ErrDeleteBundleItemsByBundleID
+			}
+
+			for _, bi := range newItems {
+				bundleItem := entity.BundleItem{
+					ID:       bi.ID,
+					BundleID: &bundle.ID,
+				}
+
+				if bi.MerchID != nil {
+					bundleItem.MerchID = bi.MerchID
+				}
+
+				if err := txRepo.CreateBundleItem(ctx, nil, bundleItem); err != nil {
+					return dto.ErrCreateBundleItem
+				}
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return dto.BundleResponse{}, err
+	}
+
+	as.availabilityService.PublishBundle(ctx, bundle.ID.String())
+
+	var respItems []dto.BundleItemResponse
+	if updateItems {
+		respItems = newItems
+	} else {
+		itemsEntity, err := as.adminRepo.GetBundleItemsByBundleID(ctx, nil, bundle.ID.String())
+		if err != nil || !flag {
+			return dto.BundleResponse{}, dto.ErrGetBundleItems
+		}
+		for _, e := range itemsEntity {
+			var biResp dto.BundleItemResponse
+			biResp.ID = e.ID
+			if e.MerchID != nil {
+				biResp.MerchID = e.MerchID
+				biResp.MerchName = e.Merch.Name
+			}
+
+			respItems = append(respItems, biResp)
+		}
+	}
+
+	return dto.BundleResponse{
+		ID:          bundle.ID,
+		Name:        bundle.Name,
+		Image:       bundle.Image,
+		Type:        bundle.Type,
+		Price:       bundle.Price,
+		Quota:       bundle.Quota,
+		Description: bundle.Description,
+		EventDate:   bundle.EventDate.Format("2006-01-02"),
+		EventID:     bundle.EventID,
+		BundleItems: respItems,
+	}, nil
+}
+func (as *AdminService) DeleteBundle(ctx context.Context, req dto.DeleteBundleRequest) (dto.BundleResponse, error) {
+	deletedBundle, _, err := as.adminRepo.GetBundleByID(ctx, nil, req.BundleID)
+	if err != nil {
+		return dto.BundleResponse{}, dto.ErrBundleNotFound
+	}
+
+	err = as.adminRepo.RunInTransaction(ctx, func(txRepo repository.IAdminRepository) error {
+		err = txRepo.DeleteBundleByID(ctx, nil, req.BundleID)
+		if err != nil {
+			return dto.ErrDeleteBundleByID
+		}
+
+		if err = txRepo.DeleteBundleItemsByBundleID(ctx, nil, req.BundleID); err != nil {
+			return dto.ErrDeleteBundleItemsByBundleID
+		}
+
+		return nil
+	})
+	if err == nil {
+		as.availabilityService.PublishRemoved(constants.ENUM_BUNDLE_ITEM_TYPE, deletedBundle.ID)
+	}
+
+	b := dto.BundleResponse{
+		ID:          deletedBundle.ID,
+		Name:        deletedBundle.Name,
+		Image:       deletedBundle.Image,
+		Type:        deletedBundle.Type,
+		Price:       deletedBundle.Price,
+		Quota:       deletedBundle.Quota,
+		Description: deletedBundle.Description,
+		EventDate:   deletedBundle.EventDate.Format("2006-01-02"),
+		EventID:     deletedBundle.EventID,
+	}
+
+	for _, bi := range deletedBundle.BundleItems {
+		bundleItem := dto.BundleItemResponse{
+			ID:        bi.ID,
+			MerchID:   bi.MerchID,
+			MerchName: bi.Merch.Name,
+		}
+
+		b.BundleItems = append(b.BundleItems, bundleItem)
+	}
+
+	return b, nil
+}
+
+// User
+func (as *AdminService) CreateStudentAmbassador(ctx context.Context, req dto.CreateStudentAmbassadorRequest) (dto.StudentAmbassadorResponse, error) {
+	if req.Name == "" || req.ReferalCode == "" {
+		return dto.StudentAmbassadorResponse{}, dto.ErrEmptyFields
+	}
+
+	_, flag, err := as.adminRepo.GetStudentAmbassadorByReferalCode(ctx, nil, req.ReferalCode)
+	if err == nil || flag {
+		return dto.StudentAmbassadorResponse{}, dto.ErrStudentAmbassadorAlreadyExists
+	}
+
+	if len(req.Name) < 3 {
+		return dto.StudentAmbassadorResponse{}, dto.ErrStudentAmbassadorNameTooShort
+	}
+
+	studentAmbassador := entity.StudentAmbassador{
+		ID:          uuid.New(),
+		Name:        req.Name,
+		ReferalCode: req.ReferalCode,
+		Discount:    req.Discount,
+		MaxReferal:  req.MaxReferal,
+	}
+
+	err = as.adminRepo.CreateStudentAmbassador(ctx, nil, studentAmbassador)
+	if err != nil {
+		return dto.StudentAmbassadorResponse{}, dto.ErrCreateStudentAmbassador
+	}
+
+	return dto.StudentAmbassadorResponse{
+		ID:          studentAmbassador.ID,
+		Name:        studentAmbassador.Name,
+		ReferalCode: studentAmbassador.ReferalCode,
+		Discount:    studentAmbassador.Discount,
+		MaxReferal:  studentAmbassador.MaxReferal,
+	}, nil
+}
+func (as *AdminService) GetAllStudentAmbassador(ctx context.Context) ([]dto.StudentAmbassadorResponse, error) {
+	studentAmbassadors, err := as.adminRepo.GetAllStudentAmbassador(ctx, nil)
+	if err != nil {
+		return nil, dto.ErrGetAllStudentAmbassadorNoPagination
+	}
+
+	var datas []dto.StudentAmbassadorResponse
+	for _, studentAmbassador := range studentAmbassadors {
+		data := dto.StudentAmbassadorResponse{
+			ID:          studentAmbassador.ID,
+			Name:        studentAmbassador.Name,
+			ReferalCode: studentAmbassador.ReferalCode,
+			Discount:    studentAmbassador.Discount,
+			MaxReferal:  studentAmbassador.MaxReferal,
+		}
+
+		datas = append(datas, data)
+	}
+
+	return datas, nil
+}
+func (as *AdminService) GetAllStudentAmbassadorWithPagination(ctx context.Context, req dto.PaginationRequest) (dto.StudentAmbassadorPaginationResponse, error) {
+	dataWithPaginate, err := as.adminRepo.GetAllStudentAmbassadorWithPagination(ctx, nil, req)
+	if err != nil {
+		return dto.StudentAmbassadorPaginationResponse{}, dto.ErrGetAllStudentAmbassadorWithPagination
+	}
+
+	var datas []dto.StudentAmbassadorResponse
+	for _, studentAmbassador := range dataWithPaginate.StudentAmbassadors {
+		data := dto.StudentAmbassadorResponse{
+			ID:          studentAmbassador.ID,
+			Name:        studentAmbassador.Name,
+			ReferalCode: studentAmbassador.ReferalCode,
+			Discount:    studentAmbassador.Discount,
+			MaxReferal:  studentAmbassador.MaxReferal,
+		}
+
+		datas = append(datas, data)
+	}
+
+	return dto.StudentAmbassadorPaginationResponse{
+		Data: datas,
+		PaginationResponse: dto.PaginationResponse{
+			Page:    dataWithPaginate.Page,
+			PerPage: dataWithPaginate.PerPage,
+			MaxPage: dataWithPaginate.MaxPage,
+			Count:   dataWithPaginate.Count,
+		},
+	}, nil
+}
+func (as *AdminService) GetDetailStudentAmbassador(ctx context.Context, studentAmbassadorID string) (dto.StudentAmbassadorResponse, error) {
+	studentAmbassador, _, err := as.adminRepo.GetStudentAmbassadorByID(ctx, nil, studentAmbassadorID)
+	if err != nil {
+		return dto.StudentAmbassadorResponse{}, dto.ErrStudentAmbassadorNotFound
+	}
+
+	return dto.StudentAmbassadorResponse{
+		ID:          studentAmbassador.ID,
+		Name:        studentAmbassador.Name,
+		ReferalCode: studentAmbassador.ReferalCode,
+		Discount:    studentAmbassador.Discount,
+		MaxReferal:  studentAmbassador.MaxReferal,
+	}, nil
+}
+func (as *AdminService) UpdateStudentAmbassador(ctx context.Context, req dto.UpdateStudentAmbassadorRequest) (dto.StudentAmbassadorResponse, error) {
+	studentAmbassador, flag, err := as.adminRepo.GetStudentAmbassadorByID(ctx, nil, req.ID)
+	if err != nil || !flag {
+		return dto.StudentAmbassadorResponse{}, dto.ErrStudentAmbassadorNotFound
+	}
+
+	if req.Name != "" {
+		if len(req.Name) < 3 {
+			return dto.StudentAmbassadorResponse{}, dto.ErrStudentAmbassadorNameTooShort
+		}
+
+		studentAmbassador.Name = req.Name
+	}
+
+	if req.ReferalCode != "" {
+		_, flag, err := as.adminRepo.GetStudentAmbassadorByReferalCode(ctx, nil, req.ReferalCode)
+		if err == nil || flag {
+			return dto.StudentAmbassadorResponse{}, dto.ErrReferalCodeAlreadyExists
+		}
+
+		studentAmbassador.ReferalCode = req.ReferalCode
+	}
+
+	if req.Discount != nil {
+		studentAmbassador.Discount = *req.Discount
+	}
+
+	if req.MaxReferal != nil {
+		studentAmbassador.MaxReferal = *req.MaxReferal
+	}
+
+	err = as.adminRepo.UpdateStudentAmbassador(ctx, nil, studentAmbassador)
+	if err != nil {
+		return dto.StudentAmbassadorResponse{}, dto.ErrUpdateStudentAmbassador
+	}
+
+	res := dto.StudentAmbassadorResponse{
+		ID:          studentAmbassador.ID,
+		Name:        studentAmbassador.Name,
+		ReferalCode: studentAmbassador.ReferalCode,
+		Discount:    studentAmbassador.Discount,
+		MaxReferal:  studentAmbassador.MaxReferal,
+	}
+
+	return res, nil
+}
+func (as *AdminService) DeleteStudentAmbassador(ctx context.Context, req dto.DeleteStudentAmbassadorRequest) (dto.StudentAmbassadorResponse, error) {
+	deletedStudentAmbassador, _, err := as.adminRepo.GetStudentAmbassadorByID(ctx, nil, req.StudentAmbassadorID)
+	if err != nil {
+		return dto.StudentAmbassadorResponse{}, dto.ErrStudentAmbassadorNotFound
+	}
+
+	err = as.adminRepo.DeleteStudentAmbassadorByID(ctx, nil, req.StudentAmbassadorID)
+	if err != nil {
+		return dto.StudentAmbassadorResponse{}, dto.ErrDeleteStudentAmbassadorByID
+	}
+
+	res := dto.StudentAmbassadorResponse{
+		ID:          deletedStudentAmbassador.ID,
+		Name:        deletedStudentAmbassador.Name,
+		ReferalCode: deletedStudentAmbassador.ReferalCode,
+		Discount:    deletedStudentAmbassador.Discount,
+		MaxReferal:  deletedStudentAmbassador.MaxReferal,
+	}
+
+	return res, nil
+}
+
+// Transaction & Ticket Form
+func (as *AdminService) CreateTransactionTicket(ctx context.Context, req dto.CreateTransactionTicketRequest) (dto.TransactionResponse, error) {
+	if len(req.TicketForms) == 0 {
+		return dto.TransactionResponse{}, dto.ErrEmptyTicketForms
+	}
+
+	token := ctx.Value("Authorization").(string)
+
+	userIDStr, err := as.jwtService.GetUserIDByToken(token)
+	if err != nil {
+		return dto.TransactionResponse{}, dto.ErrGetUserIDFromToken
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return dto.TransactionResponse{}, dto.ErrParseUUID
+	}
+
+	var transactionResponse dto.TransactionResponse
+	err = as.adminRepo.RunInTransaction(ctx, func(txRepo repository.IAdminRepository) error {
+		if !entity.IsValidItemType(req.ItemType) && req.ItemType != constants.ENUM_TICKET_ITEM_TYPE {
+			return dto.ErrItemTypeMustBeTicket
+		}
+
+		ticket, found, err := txRepo.GetTicketByID(ctx, nil, req.TicketID.String())
+		if err != nil || !found {
+			return dto.ErrTicketNotFound
+		}
+
+		if ticket.EventID == nil {
+			return dto.ErrTicketMustHaveEvent
+		}
+
+		// event dikunci sebelum ticket, sama dengan urutan UpdateTicket
+		if _, found, err := txRepo.GetEventByIDForUpdate(ctx, nil, ticket.EventID.String()); err != nil || !found {
+			return dto.ErrEventNotFound
+		}
+
+		ticket, found, err = txRepo.GetTicketByIDForUpdate(ctx, nil, req.TicketID.String())
+		if err != nil || !found {
+			return dto.ErrTicketNotFound
+		}
+
+		if ticket.Quota < len(req.TicketForms) {
+			return dto.ErrTicketSoldOut
+		}
+
+		if ticket.EventID == nil {
+			return dto.ErrTicketMustHaveEvent
+		}
+
+		if err := checkEventCapacity(ctx, txRepo, *ticket.EventID, ticket.ID.String(), ticket.Quota); err != nil {
+			return err
+		}
+
+		if err := txRepo.UpdateTicketQuota(ctx, nil, ticket.ID.String(), ticket.Quota-len(req.TicketForms)); err != nil {
+			return dto.ErrUpdateTicket
+		}
+
+		transactionID := uuid.New()
+		orderID := fmt.Sprintf("TEDX-%s", time.Now().Format("060102150405"))
+
+		now := time.Now()
+
+		transaction := entity.Transaction{
+			ID:                transactionID,
+			OrderID:           orderID,
+			ItemType:          req.ItemType,
+			TransactionStatus: "settlement",
+			PaymentType:       "invitation",
+			SettlementTime:    &now,
+			UserID:            &userID,
+			TicketID:          req.TicketID,
+		}
+
+		if err := txRepo.CreateTransaction(ctx, nil, transaction); err != nil {
+			return dto.ErrCreateTransaction
+		}
+		transaction.CreatedAt = now
+		transaction.Ticket = ticket
+
+		for _, form := range req.TicketForms {
+			if form.AudienceType != "" && (!entity.IsValidAudienceType(form.AudienceType) || form.AudienceType != "invited") {
+				return dto.ErrMustBeInvitedGuest
+			}
+
+			formattedPhone, answers, err := validateTicketFormRequest(form, ticket.FormFields)
+			if err != nil {
+				return err
+			}
+
+			ticketFormID := uuid.New()
+			ticketForm := entity.TicketForm{
+				ID:            ticketFormID,
+				AudienceType:  form.AudienceType,
+				Instansi:      form.Instansi,
+				Email:         form.Email,
+				FullName:      form.FullName,
+				PhoneNumber:   formattedPhone,
+				LineID:        form.LineID,
+				Answers:       answers,
+				WhatsAppOptIn: form.WhatsAppOptIn,
+				TransactionID: &transactionID,
+			}
+
+			if err := txRepo.CreateTicketForm(ctx, nil, ticketForm); err != nil {
+				return dto.ErrCreateTicketForm
+			}
+
+			outbox, err := newETicketEmail(ctx, as.emailTemplateService, transaction, ticketForm)
+			if err != nil {
+				return err
+			}
+			if err := txRepo.CreateEmailOutbox(ctx, nil, outbox); err != nil {
+				return dto.ErrCreateEmailOutbox
+			}
+
+			if notification, ok := newETicketNotification(transaction, ticketForm); ok {
+				if err := txRepo.CreateNotificationOutbox(ctx, nil, notification); err != nil {
+					return dto.ErrCreateNotificationOutbox
+				}
+			}
+
+			transactionResponse.TicketForms = append(transactionResponse.TicketForms, dto.TicketFormResponse{
+				ID:            ticketFormID,
+				AudienceType:  ticketForm.AudienceType,
+				Instansi:      ticketForm.Instansi,
+				Email:         ticketForm.Email,
+				FullName:      ticketForm.FullName,
+				PhoneNumber:   ticketForm.PhoneNumber,
+				LineID:        ticketForm.LineID,
+				Answers:       ticketForm.Answers,
+				WhatsAppOptIn: ticketForm.WhatsAppOptIn,
+			})
+		}
+		transactionResponse.ID = transactionID
+		transactionResponse.OrderID = transaction.OrderID
+		transactionResponse.ItemType = transaction.ItemType
+		transactionResponse.TicketType = ticket.Type
+		transactionResponse.TransactionStatus = transaction.TransactionStatus
+		transactionResponse.PaymentType = transaction.PaymentType
+		transactionResponse.SettlementTime = transaction.SettlementTime
+		transactionResponse.UserID = transaction.UserID
+		transactionResponse.TicketID = transaction.TicketID
+
+		return nil
+	})
+	if err != nil {
+		return dto.TransactionResponse{}, err
+	}
+
+	as.availabilityService.PublishTicket(ctx, req.TicketID.String())
+
+	return transactionResponse, nil
+}
+func (as *AdminService) GetAllTransactionTicket(ctx context.Context, transactionStatus, ticketCategory string) ([]dto.TransactionResponse, error) {
+	transactions, err := as.adminRepo.GetAllTransaction(ctx, nil, transactionStatus, ticketCategory)
+	if err != nil {
+		return nil, dto.ErrGetAllTransactionNoPagination
+	}
+
+	var datas []dto.TransactionResponse
+	for _, transaction := range transactions {
+		data := dto.TransactionResponse{
+			ID:                transaction.ID,
+			OrderID:           transaction.OrderID,
+			ItemType:          transaction.ItemType,
+			TicketType:        entity.TicketType(transaction.Ticket.Type),
+			ReferalCode:       transaction.ReferalCode,
+			TransactionStatus: transaction.TransactionStatus,
+			PaymentType:       transaction.PaymentType,
+			SignatureKey:      transaction.SignatureKey,
+			Acquire:           transaction.Acquire,
+			SettlementTime:    transaction.SettlementTime,
+			GrossAmount:       transaction.GrossAmount,
+			UserID:            transaction.UserID,
+			TicketID:          transaction.TicketID,
+			BundleID:          transaction.BundleID,
+		}
+
+		for _, ticketForm := range transaction.TicketForms {
+			data.TicketForms = append(data.TicketForms, dto.TicketFormResponse{
+				ID:              ticketForm.ID,
+				AudienceType:    ticketForm.AudienceType,
+				Instansi:        ticketForm.Instansi,
+				Email:           ticketForm.Email,
+				FullName:        ticketForm.FullName,
+				PhoneNumber:     ticketForm.PhoneNumber,
+				LineID:          ticketForm.LineID,
+				Answers:         ticketForm.Answers,
+				WhatsAppOptIn:   ticketForm.WhatsAppOptIn,
+				TransferredToID: ticketForm.TransferredToID,
+			})
+		}
+
+		datas = append(datas, data)
+	}
+
+	return datas, nil
+}
+func (as *AdminService) GetAllTransactionTicketWithPagination(ctx context.Context, req dto.PaginationRequest, transactionStatus, ticketCategory string) (dto.TransactionTicketPaginationResponse, error) {
+	dataWithPaginate, err := as.adminRepo.GetAllTransactionWithPagination(ctx, nil, req, transactionStatus, ticketCategory)
+	if err != nil {
+		return dto.TransactionTicketPaginationResponse{}, dto.ErrGetAllUserWithPagination
+	}
+
+	var datas []dto.TransactionResponse
+	for _, transaction := range dataWithPaginate.Transactions {
+		data := dto.TransactionResponse{
+			ID:                transaction.ID,
+			OrderID:           transaction.OrderID,
+			ItemType:          transaction.ItemType,
+			TicketType:        entity.TicketType(transaction.Ticket.Type),
+			ReferalCode:       transaction.ReferalCode,
+			TransactionStatus: transaction.TransactionStatus,
+			PaymentType:       transaction.PaymentType,
+			SignatureKey:      transaction.SignatureKey,
+			Acquire:           transaction.Acquire,
+			SettlementTime:    transaction.SettlementTime,
+			GrossAmount:       transaction.GrossAmount,
+			UserID:            transaction.UserID,
+			TicketID:          transaction.TicketID,
+			BundleID:          transaction.BundleID,
+		}
+
+		for _, ticketForm := range transaction.TicketForms {
+			data.TicketForms = append(data.TicketForms, dto.TicketFormResponse{
+				ID:              ticketForm.ID,
+				AudienceType:    ticketForm.AudienceType,
+				Instansi:        ticketForm.Instansi,
+				Email:           ticketForm.Email,
+				FullName:        ticketForm.FullName,
+				PhoneNumber:     ticketForm.PhoneNumber,
+				LineID:          ticketForm.LineID,
+				Answers:         ticketForm.Answers,
+				WhatsAppOptIn:   ticketForm.WhatsAppOptIn,
+				TransferredToID: ticketForm.TransferredToID,
+			})
+		}
+
+		datas = append(datas, data)
+	}
+
+	return dto.TransactionTicketPaginationResponse{
+		Data: datas,
+		PaginationResponse: dto.PaginationResponse{
+			Page:    dataWithPaginate.Page,
+			PerPage: dataWithPaginate.PerPage,
+			MaxPage: dataWithPaginate.MaxPage,
+			Count:   dataWithPaginate.Count,
+		},
+	}, nil
+}
+func (as *AdminService) GetDetailTransactionTicket(ctx context.Context, transactionTicketID string) (dto.TransactionResponse, error) {
+	transaction, _, err := as.adminRepo.GetTransactionByID(ctx, nil, transactionTicketID)
+	if err != nil {
+		return dto.TransactionResponse{}, dto.ErrTicketFormNotFound
+	}
+
+	res := dto.TransactionResponse{
+		ID:                transaction.ID,
+		OrderID:           transaction.OrderID,
+		ItemType:          transaction.ItemType,
+		TicketType:        entity.TicketType(transaction.Ticket.Type),
+		ReferalCode:       transaction.ReferalCode,
+		TransactionStatus: transaction.TransactionStatus,
+		PaymentType:       transaction.PaymentType,
+		SignatureKey:      transaction.SignatureKey,
+		Acquire:           transaction.Acquire,
+		SettlementTime:    transaction.SettlementTime,
+		GrossAmount:       transaction.GrossAmount,
+		UserID:            transaction.UserID,
+		TicketID:          transaction.TicketID,
+		BundleID:          transaction.BundleID,
+	}
+
+	for _, ticketForm := range transaction.TicketForms {
+		res.TicketForms = append(res.TicketForms, dto.TicketFormResponse{
+			ID:              ticketForm.ID,
+			AudienceType:    ticketForm.AudienceType,
+			Instansi:        ticketForm.Instansi,
+			Email:           ticketForm.Email,
+			FullName:        ticketForm.FullName,
+			PhoneNumber:     ticketForm.PhoneNumber,
+			LineID:          ticketForm.LineID,
+			Answers:         ticketForm.Answers,
+			WhatsAppOptIn:   ticketForm.WhatsAppOptIn,
+			TransferredToID: ticketForm.TransferredToID,
+		})
+	}
+
+	return res, nil
+}
+
+// Check-in
+
+// resolveTicketQR menolak QR yang dicabut atau versi lama.
+func (as *AdminService) resolveTicketQR(ctx context.Context, qrToken string) (entity.TicketForm, error) {
+	claims, err := helpers.VerifyTicketQR(qrToken)
+	if err != nil {
+		return entity.TicketForm{}, dto.ErrInvalidTicketQR
+	}
+
+	ticketForm, found, err := as.adminRepo.GetTicketFormByID(ctx, nil, claims.TicketFormID.String())
+	if err != nil || !found {
+		return entity.TicketForm{}, dto.ErrTicketFormNotFound
+	}
+
+	if err := checkTicketQR(claims, ticketForm); err != nil {
+		return entity.TicketForm{}, err
+	}
+
+	return ticketForm, nil
+}
+func checkTicketQR(claims helpers.TicketQRClaims, ticketForm entity.TicketForm) error {
+	if ticketForm.TransferredToID != nil {
+		return dto.ErrTicketTransferred
+	}
+
+	if ticketForm.QRRevokedAt != nil || claims.Version != ticketForm.QRVersion {
+		return dto.ErrTicketQRRevoked
+	}
+
+	if eventID := ticketForm.Transaction.Ticket.EventID; eventID != nil && claims.EventID != *eventID {
+		return dto.ErrTicketQREventMismatch
+	}
+
+	return nil
+}
+func (as *AdminService) GetDetailTicketCheckIn(ctx context.Context, qrToken string) (dto.TicketCheckInResponse, error) {
+	operator, err := as.checkInOperator(ctx)
+	if err != nil {
+		return dto.TicketCheckInResponse{}, err
+	}
+
+	ticketForm, err := as.resolveTicketQR(ctx, qrToken)
+	if err != nil {
+		return dto.TicketCheckInResponse{}, err
+	}
+
+	if err := checkCrewEvent(operator, ticketForm.Transaction.Ticket.EventID); err != nil {
+		return dto.TicketCheckInResponse{}, err
+	}
+
+	if ticketForm.TransactionID == nil || ticketForm.Transaction.TicketID == nil {
+		return dto.TicketCheckInResponse{}, dto.ErrTransactionNotFound
+	}
+
+	status := false
+	if len(ticketForm.GuestAttendances) != 0 {
+		status = true
+	}
+
+	var emailChecker string
+	if status {
+		emailChecker = ticketForm.GuestAttendances[0].CheckedByUser.Email
+	}
+
+	guestAttendances, err := as.adminRepo.GetAllGuestAttendanceByTicketFormID(ctx, nil, ticketForm.ID.String())
+	if err != nil {
+		return dto.TicketCheckInResponse{}, dto.ErrGetAllGuestAttendance
+	}
+
+	res := dto.TicketCheckInResponse{
+		TicketFormID:  ticketForm.ID,
+		TicketID:      *ticketForm.Transaction.TicketID,
+		TransactionID: *ticketForm.TransactionID,
+		TicketName:    ticketForm.Transaction.Ticket.Name,
+		TicketType:    ticketForm.Transaction.Ticket.Type,
+		EventID:       ticketForm.Transaction.Ticket.EventID,
+		EventName:     ticketForm.Transaction.Ticket.Event.Name,
+		AudienceType:  ticketForm.AudienceType,
+		Email:         ticketForm.Email,
+		FullName:      ticketForm.FullName,
+		PhoneNumber:   ticketForm.PhoneNumber,
+		LineID:        ticketForm.LineID,
+		Answers:       ticketForm.Answers,
+		Status:        status,
+		Inside:        isInside(ticketForm.GuestAttendances),
+		EmailChecker:  emailChecker,
+	}
+
+	for _, guestAttendance := range guestAttendances {
+		res.Attendances = append(res.Attendances, toGuestAttendanceResponse(guestAttendance))
+	}
+
+	return res, nil
+}
+func toGuestAttendanceResponse(guestAttendance entity.GuestAttendance) dto.GuestAttendanceResponse {
+	return dto.GuestAttendanceResponse{
+		ID:               guestAttendance.ID,
+		Direction:        guestAttendance.Direction,
+		Source:           guestAttendance.Source,
+		DeviceID:         guestAttendance.DeviceID,
+		GateID:           guestAttendance.GateID,
+		GateName:         guestAttendance.Gate.Name,
+		EventSessionID:   guestAttendance.EventSessionID,
+		EventSessionName: guestAttendance.EventSession.Name,
+		CheckedAt:        guestAttendance.CheckedAt,
+		CheckedBy:        guestAttendance.CheckedBy,
+		CheckedByEmail:   guestAttendance.CheckedByUser.Email,
+		VoidedAt:         guestAttendance.VoidedAt,
+		VoidedBy:         guestAttendance.VoidedBy,
+		VoidedByEmail:    guestAttendance.VoidedByUser.Email,
+		VoidReason:       guestAttendance.VoidReason,
+	}
+}
+func (as *AdminService) VoidCheckIn(ctx context.Context, req dto.VoidCheckInRequest) (dto.GuestAttendanceResponse, error) {
+	req.Reason = strings.TrimSpace(req.Reason)
+	if req.Reason == "" {
+		return dto.GuestAttendanceResponse{}, dto.ErrVoidReasonRequired
+	}
+
+	guestAttendance, found, err := as.adminRepo.GetGuestAttendanceByID(ctx, nil, req.ID)
+	if err != nil || !found {
+		return dto.GuestAttendanceResponse{}, dto.ErrGuestAttendanceNotFound
+	}
+
+	if guestAttendance.VoidedAt != nil {
+		return dto.GuestAttendanceResponse{}, dto.ErrCheckInAlreadyVoided
+	}
+
+	token := ctx.Value("Authorization").(string)
+
+	adminIDStr, err := as.jwtService.GetUserIDByToken(token)
+	if err != nil {
+		return dto.GuestAttendanceResponse{}, dto.ErrGetUserIDFromToken
+	}
+
+	admin, found, err := as.adminRepo.GetUserByID(ctx, nil, adminIDStr)
+	if err != nil || !found {
+		return dto.GuestAttendanceResponse{}, dto.ErrUserNotFound
+	}
+
+	// attendance tidak dihapus supaya tetap menjadi jejak audit
+	now := time.Now()
+	guestAttendance.VoidedAt = &now
+	guestAttendance.VoidedBy = &admin.ID
+	guestAttendance.VoidReason = req.Reason
+
+	err = as.adminRepo.UpdateGuestAttendance(ctx, nil, guestAttendance)
+	if err != nil {
+		return dto.GuestAttendanceResponse{}, dto.ErrUpdateGuestAttendance
+	}
+
+	guestAttendance.VoidedByUser = admin
+
+	return toGuestAttendanceResponse(guestAttendance), nil
+}
+
+// resolveCheckInLocation memastikan gate dan session milik event tiket yang discan.
+func (as *AdminService) resolveCheckInLocation(ctx context.Context, eventID *uuid.UUID, gateIDStr, eventSessionIDStr string) (*entity.Gate, *entity.EventSession, error) {
+	var (
+		gate         *entity.Gate
+		eventSession *entity.EventSession
+	)
+
+	if gateIDStr != "" {
+		g, found, err := as.adminRepo.GetGateByID(ctx, nil, gateIDStr)
+		if err != nil || !found {
+			return nil, nil, dto.ErrGateNotFound
+		}
+
+		if eventID == nil || g.EventID == nil || *g.EventID != *eventID {
+			return nil, nil, dto.ErrGateNotInEvent
+		}
+
+		gate = &g
+	}
+
+	if eventSessionIDStr != "" {
+		s, found, err := as.adminRepo.GetEventSessionByID(ctx, nil, eventSessionIDStr)
+		if err != nil || !found {
+			return nil, nil, dto.ErrEventSessionNotFound
+		}
+
+		if eventID == nil || s.EventID == nil || *s.EventID != *eventID {
+			return nil, nil, dto.ErrEventSessionNotInEvent
+		}
+
+		eventSession = &s
+	}
+
+	return gate, eventSession, nil
+}
+func checkInLocationIDs(gate *entity.Gate, eventSession *entity.EventSession) (*uuid.UUID, *uuid.UUID) {
+	var gateID, eventSessionID *uuid.UUID
+	if gate != nil {
+		gateID = &gate.ID
+	}
+	if eventSession != nil {
+		eventSessionID = &eventSession.ID
+	}
+
+	return gateID, eventSessionID
+}
+
+// latestAttendance: scan terakhir menentukan guest sedang di dalam atau tidak.
+func latestAttendance(attendances []entity.GuestAttendance) *entity.GuestAttendance {
+	var latest *entity.GuestAttendance
+	for i := range attendances {
+		if latest == nil || attendances[i].CheckedAt.After(latest.CheckedAt) {
+			latest = &attendances[i]
+		}
+	}
+
+	return latest
+}
+func isInside(attendances []entity.GuestAttendance) bool {
+	latest := latestAttendance(attendances)
+	return latest != nil && latest.Direction != entity.CheckInDirectionOut
+}
+
+// conflictingAttendances mengembalikan scan "in" sebelumnya yang melanggar re-entry policy.
+func conflictingAttendances(policy entity.ReentryPolicy, attendances []entity.GuestAttendance, eventSessionID *uuid.UUID) []entity.GuestAttendance {
+	var conflicts []entity.GuestAttendance
+	for _, attendance := range attendances {
+		if attendance.Direction == entity.CheckInDirectionOut {
+			continue
+		}
+
+		switch policy {
+		case entity.ReentryAllowed:
+			continue
+		case entity.ReentryPerSession:
+			if attendance.EventSessionID == nil || eventSessionID == nil || *attendance.EventSessionID != *eventSessionID {
+				continue
+			}
+		}
+
+		conflicts = append(conflicts, attendance)
+	}
+
+	return conflicts
+}
+func checkReentry(policy entity.ReentryPolicy, attendances []entity.GuestAttendance, direction entity.CheckInDirection, eventSessionID *uuid.UUID) error {
+	if direction == entity.CheckInDirectionOut {
+		if !isInside(attendances) {
+			return dto.ErrNotInside
+		}
+
+		return nil
+	}
+
+	switch policy {
+	case entity.ReentryAllowed:
+		if isInside(attendances) {
+			return dto.ErrAlreadyInside
+		}
+	case entity.ReentryPerSession:
+		if eventSessionID == nil {
+			return dto.ErrEventSessionRequired
+		}
+
+		if len(conflictingAttendances(policy, attendances, eventSessionID)) > 0 {
+			return dto.ErrAlreadyCheckedInSession
+		}
+	default:
+		if len(conflictingAttendances(policy, attendances, eventSessionID)) > 0 {
+			return dto.ErrAlreadyCheckedIn
+		}
+	}
+
+	return nil
+}
+func (as *AdminService) CheckIn(ctx context.Context, req dto.CheckInRequest) (dto.CheckInResponse, error) {
+	record, err := as.checkIn(ctx, req)
+	as.publishCheckIn(record, err)
+	if err != nil {
+		return dto.CheckInResponse{}, err
+	}
+
+	return dto.CheckInResponse{
+		TicketFormID: record.ticketForm.ID,
+		FullName:     record.ticketForm.FullName,
+		BadgeURL:     fmt.Sprintf("%s/api/v1/admin/print-badge/%s", os.Getenv("BASE_URL"), record.ticketForm.ID),
+	}, nil
+}
+func (as *AdminService) checkIn(ctx context.Context, req dto.CheckInRequest) (checkInRecord, error) {
+	record := checkInRecord{direction: req.Direction}
+
+	operator, err := as.checkInOperator(ctx)
+	if err != nil {
+		return record, err
+	}
+	record.operator = operator
+
+	record.ticketForm, err = as.resolveTicketQR(ctx, req.QRToken)
+	if err != nil {
+		return record, err
+	}
+
+	return record, as.recordCheckIn(ctx, &record, req, entity.CheckInOnline)
+}
+
+// recordCheckIn mengisi record supaya publishCheckIn tidak perlu query ulang.
+func (as *AdminService) recordCheckIn(ctx context.Context, record *checkInRecord, req dto.CheckInRequest, source entity.CheckInSource) error {
+	operator, ticketForm := record.operator, record.ticketForm
+
+	if err := checkCrewEvent(operator, ticketForm.Transaction.Ticket.EventID); err != nil {
+		return err
+	}
+
+	var err error
+	req.GateID, err = crewGateID(operator, req.GateID)
+	if err != nil {
+		return err
+	}
+
+	direction := entity.CheckInDirectionIn
+	if req.Direction != "" {
+		direction = req.Direction
+		if !entity.IsValidCheckInDirection(direction) {
+			return dto.ErrInvalidCheckInDirection
+		}
+	}
+	record.direction = direction
+
+	record.gate, record.eventSession, err = as.resolveCheckInLocation(ctx, ticketForm.Transaction.Ticket.EventID, req.GateID, req.EventSessionID)
+	if err != nil {
+		return err
+	}
+	gateID, eventSessionID := checkInLocationIDs(record.gate, record.eventSession)
+
+	// ticket form dikunci supaya scan bersamaan untuk tiket yang sama dicek satu per satu
+	return as.adminRepo.RunInTransaction(ctx, func(txRepo repository.IAdminRepository) error {
+		locked, found, err := txRepo.GetTicketFormByIDForUpdate(ctx, nil, ticketForm.ID.String())
+		if err != nil || !found {
+			return dto.ErrTicketFormNotFound
+		}
+
+		if locked.TransferredToID != nil {
+			return dto.ErrTicketTransferred
+		}
+
+		if locked.QRRevokedAt != nil {
+			return dto.ErrTicketQRRevoked
+		}
+
+		if err := checkReentry(locked.Transaction.Ticket.Event.ReentryPolicy, locked.GuestAttendances, direction, eventSessionID); err != nil {
+			return err
+		}
+
+		if direction == entity.CheckInDirectionIn && eventSessionID != nil {
+			if err := checkEventSessionCapacity(ctx, txRepo, eventSessionID.String(), locked.ID.String()); err != nil {
+				return err
+			}
+		}
+
+		guestAttendance := entity.GuestAttendance{
+			ID:             uuid.New(),
+			TicketFormID:   &locked.ID,
+			CheckedBy:      &operator.ID,
+			CheckedAt:      time.Now(),
+			Source:         source,
+			Direction:      direction,
+			GateID:         gateID,
+			EventSessionID: eventSessionID,
+		}
+
+		if err := txRepo.CreateGuestAttendance(ctx, nil, guestAttendance); err != nil {
+			return dto.ErrCreateGuestAttendance
+		}
+
+		return nil
+	})
+}
+
+// checkEventSessionCapacity: guest yang pernah masuk session tetap boleh masuk lagi, kapasitas 0 berarti tidak dibatasi.
+func checkEventSessionCapacity(ctx context.Context, txRepo repository.IAdminRepository, eventSessionID, ticketFormID string) error {
+	eventSession, found, err := txRepo.GetEventSessionByIDForUpdate(ctx, nil, eventSessionID)
+	if err != nil || !found {
+		return dto.ErrEventSessionNotFound
+	}
+
+	if eventSession.Capacity == 0 {
+		return nil
+	}
+
+	checkedIn, err := txRepo.CountEventSessionCheckIn(ctx, nil, eventSession.ID.String(), ticketFormID)
+	if err != nil {
+		return dto.ErrCountEventSessionCheckIn
+	}
+
+	if checkedIn >= int64(eventSession.Capacity) {
+		return dto.ErrEventSessionFull
+	}
+
+	return nil
+}
+
+// publishCheckIn dipanggil berurutan; total tamu dimuat ulang di background oleh feed service.
+func (as *AdminService) publishCheckIn(record checkInRecord, checkInErr error) {
+	if !as.checkInFeedService.HasSubscribers() {
+		return
+	}
+
+	ticketForm := record.ticketForm
+	event := dto.CheckInFeedEvent{
+		FullName:   ticketForm.FullName,
+		TicketName: ticketForm.Transaction.Ticket.Name,
+		EventName:  ticketForm.Transaction.Ticket.Event.Name,
+		Direction:  entity.CheckInDirectionIn,
+		Operator:   record.operator.Email,
+		Result:     entity.CheckInScanAccepted,
+		ScannedAt:  record.scannedAt,
+	}
+
+	if event.ScannedAt.IsZero() {
+		event.ScannedAt = time.Now()
+	}
+
+	if ticketForm.ID != uuid.Nil {
+		event.TicketFormID = &ticketForm.ID
+	}
+
+	if record.direction != "" {
+		event.Direction = record.direction
+	}
+
+	if record.gate != nil {
+		event.GateName = record.gate.Name
+	}
+
+	if record.eventSession != nil {
+		event.EventSessionName = record.eventSession.Name
+	}
+
+	if checkInErr != nil {
+		event.Result = entity.CheckInScanRejected
+		event.Reason = checkInErr.Error()
+	}
+
+	event.Totals = as.checkInFeedService.Totals(func() (*dto.GuestStatResponse, error) {
+		return as.adminRepo.GetAllGuestStats(context.Background(), nil)
+	})
+	as.checkInFeedService.Publish(event)
+}
+func (as *AdminService) GetCheckInFeedSnapshot(ctx context.Context) (*dto.GuestStatResponse, error) {
+	totals, err := as.adminRepo.GetAllGuestStats(ctx, nil)
+	if err != nil {
+		return nil, dto.ErrGetAllGuestStats
+	}
+
+	return totals, nil
+}
+func (as *AdminService) SubscribeCheckInFeed() (<-chan dto.CheckInFeedEvent, func()) {
+	return as.checkInFeedService.Subscribe()
+}
+
+// Help Desk
+func (as *AdminService) LookupAttendee(ctx context.Context, filter dto.AttendeeLookupQuery) ([]dto.AttendeeLookupCandidateResponse, error) {
+	filter.Search = strings.TrimSpace(filter.Search)
+	if len(filter.Search) < constants.ENUM_ATTENDEE_LOOKUP_MIN_SEARCH_LENGTH {
+		return nil, dto.ErrAttendeeLookupSearchTooShort
+	}
+
+	operator, err := as.checkInOperator(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	filter.EventID, err = crewEventID(operator, filter.EventID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter.Phone = lookupPhoneNumber(filter.Search)
+
+	ticketForms, err := as.adminRepo.GetAllTicketFormCandidate(ctx, nil, filter)
+	if err != nil {
+		return nil, dto.ErrLookupAttendee
+	}
+
+	candidates := []dto.AttendeeLookupCandidateResponse{}
+	for _, ticketForm := range ticketForms {
+		score, matchedOn := scoreAttendeeMatch(ticketForm, filter.Search, filter.Phone)
+		if score < constants.ENUM_ATTENDEE_LOOKUP_MIN_SCORE {
+			continue
+		}
+
+		candidates = append(candidates, dto.AttendeeLookupCandidateResponse{
+			TicketFormID: ticketForm.ID,
+			FullName:     ticketForm.FullName,
+			Email:        helpers.MaskEmail(ticketForm.Email),
+			PhoneNumber:  helpers.MaskPhoneNumber(ticketForm.PhoneNumber),
+			OrderID:      ticketForm.Transaction.OrderID,
+			TicketName:   ticketForm.Transaction.Ticket.Name,
+			TicketType:   ticketForm.Transaction.Ticket.Type,
+			EventID:      ticketForm.Transaction.Ticket.EventID,
+			EventName:    ticketForm.Transaction.Ticket.Event.Name,
+			Status:       len(ticketForm.GuestAttendances) != 0,
+			Inside:       isInside(ticketForm.GuestAttendances),
+			Score:        score,
+			MatchedOn:    matchedOn,
+		})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Score > candidates[j].Score
+	})
+
+	if len(candidates) > constants.ENUM_ATTENDEE_LOOKUP_MAX_RESULTS {
+		candidates = candidates[:constants.ENUM_ATTENDEE_LOOKUP_MAX_RESULTS]
+	}
+
+	return candidates, nil
+}
+
+// HelpDeskCheckIn: check-in tanpa QR setelah staf mencocokkan identitas guest.
+func (as *AdminService) HelpDeskCheckIn(ctx context.Context, req dto.HelpDeskCheckInRequest) (dto.TicketCheckInResponse, error) {
+	checkInReq := dto.CheckInRequest{
+		GateID:         req.GateID,
+		EventSessionID: req.EventSessionID,
+		Direction:      req.Direction,
+	}
+
+	record, err := as.helpDeskCheckIn(ctx, req, checkInReq)
+	as.publishCheckIn(record, err)
+	if err != nil {
+		return dto.TicketCheckInResponse{}, err
+	}
+
+	ticketForm, found, err := as.adminRepo.GetTicketFormByID(ctx, nil, record.ticketForm.ID.String())
+	if err != nil || !found {
+		return dto.TicketCheckInResponse{}, dto.ErrTicketFormNotFound
+	}
+
+	guestAttendances, err := as.adminRepo.GetAllGuestAttendanceByTicketFormID(ctx, nil, ticketForm.ID.String())
+	if err != nil {
+		return dto.TicketCheckInResponse{}, dto.ErrGetAllGuestAttendance
+	}
+
+	res := dto.TicketCheckInResponse{
+		TicketFormID:  ticketForm.ID,
+		TicketID:      *ticketForm.Transaction.TicketID,
+		TransactionID: *ticketForm.TransactionID,
+		TicketName:    ticketForm.Transaction.Ticket.Name,
+		TicketType:    ticketForm.Transaction.Ticket.Type,
+		EventID:       ticketForm.Transaction.Ticket.EventID,
+		EventName:     ticketForm.Transaction.Ticket.Event.Name,
+		AudienceType:  ticketForm.AudienceType,
+		Email:         ticketForm.Email,
+		FullName:      ticketForm.FullName,
+		PhoneNumber:   ticketForm.PhoneNumber,
+		LineID:        ticketForm.LineID,
+		Answers:       ticketForm.Answers,
+		Status:        true,
+		Inside:        isInside(ticketForm.GuestAttendances),
+		EmailChecker:  ticketForm.GuestAttendances[0].CheckedByUser.Email,
+	}
+
+	for _, guestAttendance := range guestAttendances {
+		res.Attendances = append(res.Attendances, toGuestAttendanceResponse(guestAttendance))
+	}
+
+	return res, nil
+}
+func (as *AdminService) helpDeskCheckIn(ctx context.Context, req dto.HelpDeskCheckInRequest, checkInReq dto.CheckInRequest) (checkInRecord, error) {
+	record := checkInRecord{direction: checkInReq.Direction}
+
+	operator, err := as.checkInOperator(ctx)
+	if err != nil {
+		return record, err
+	}
+	record.operator = operator
+
+	ticketForm, found, err := as.adminRepo.GetTicketFormByID(ctx, nil, req.TicketFormID)
+	if err != nil || !found {
+		return record, dto.ErrTicketFormNotFound
+	}
+	record.ticketForm = ticketForm
+
+	if ticketForm.TransactionID == nil || ticketForm.Transaction.TicketID == nil || ticketForm.Transaction.TransactionStatus != "settlement" {
+		return record, dto.ErrTicketNotPaid
+	}
+
+	if ticketForm.TransferredToID != nil {
+		return record, dto.ErrTicketTransferred
+	}
+
+	if ticketForm.QRRevokedAt != nil {
+		return record, dto.ErrTicketQRRevoked
+	}
+
+	if err := verifyAttendeeIdentity(ticketForm, req.Verification); err != nil {
+		return record, err
+	}
+
+	return record, as.recordCheckIn(ctx, &record, checkInReq, entity.CheckInHelpDesk)
+}
+func verifyAttendeeIdentity(ticketForm entity.TicketForm, verification string) error {
+	verification = strings.TrimSpace(verification)
+	if verification == "" {
+		return dto.ErrIdentityVerificationRequired
+	}
+
+	if strings.EqualFold(verification, ticketForm.Email) {
+		return nil
+	}
+
+	if phone, err := helpers.StandardizePhoneNumber(verification); err == nil && phone == ticketForm.PhoneNumber {
+		return nil
+	}
+
+	return dto.ErrIdentityVerificationFailed
+}
+
+// lookupPhoneNumber: string kosong kalau pencarian bukan nomor HP.
+func lookupPhoneNumber(search string) string {
+	digits := strings.Map(func(r rune) rune {
+		if r < '0' || r > '9' {
+			return -1
+		}
+		return r
+	}, search)
+	if len(digits) < 6 || len(digits) < len(strings.ReplaceAll(search, " ", ""))/2 {
+		return ""
+	}
+
+	if phone, err := helpers.StandardizePhoneNumber(digits); err == nil && len(digits) >= 10 {
+		return phone
+	}
+
+	return strings.TrimPrefix(digits, "0")
+}
+
+// scoreAttendeeMatch memberi skor 0-100 dari field yang paling cocok.
+func scoreAttendeeMatch(ticketForm entity.TicketForm, search, phone string) (int, []string) {
+	var (
+		best      int
+		matchedOn []string
+	)
+	match := func(field string, score int) {
+		if score == 0 {
+			return
+		}
+		matchedOn = append(matchedOn, field)
+		best = max(best, score)
+	}
+
+	lowerSearch := strings.ToLower(search)
+
+	orderID := strings.ToLower(ticketForm.Transaction.OrderID)
+	switch {
+	case orderID != "" && orderID == lowerSearch:
+		match("order_id", 100)
+	case orderID != "" && strings.Contains(orderID, lowerSearch):
+		match("order_id", 60)
+	}
+
+	email := strings.ToLower(ticketForm.Email)
+	switch {
+	case email == lowerSearch:
+		match("email", 100)
+	case strings.Contains(email, lowerSearch):
+		match("email", 60)
+	}
+
+	if phone != "" {
+		switch {
+		case ticketForm.PhoneNumber == phone:
+			match("phone_number", 100)
+		case strings.HasSuffix(ticketForm.PhoneNumber, phone):
+			match("phone_number", 80)
+		case strings.Contains(ticketForm.PhoneNumber, phone):
+			match("phone_number", 50)
+		}
+	}
+
+	match("full_name", scoreNameMatch(strings.ToLower(ticketForm.FullName), lowerSearch))
+
+	return best, matchedOn
+}
+func scoreNameMatch(fullName, search string) int {
+	search = strings.Join(strings.Fields(search), " ")
+	fullName = strings.Join(strings.Fields(fullName), " ")
+
+	switch {
+	case fullName == search:
+		return 95
+	case strings.HasPrefix(fullName, search):
+		return 85
+	case strings.Contains(fullName, search):
+		return 75
+	}
+
+	words := strings.Fields(search)
+	nameWords := strings.Fields(fullName)
+
+	matched := 0
+	for _, word := range words {
+		for _, nameWord := range nameWords {
+			if helpers.FuzzyWordMatch(word, nameWord) {
+				matched++
+				break
+			}
+		}
+	}
+
+	if len(words) == 0 || matched == 0 {
+		return 0
+	}
+
+	return 65 * matched / len(words)
+}
+func (as *AdminService) GetAllTicketCheckIn(ctx context.Context, filter dto.CheckInFilterQuery) ([]dto.TicketCheckInResponse, error) {
+	operator, err := as.checkInOperator(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	filter.EventID, err = crewEventID(operator, filter.EventID)
+	if err != nil {
+		return nil, err
+	}
+
+	ticketForms, err := as.adminRepo.GetAllTicketForm(ctx, nil, filter)
+	if err != nil {
+		return nil, dto.ErrGetAllTicketCheckInNoPagination
+	}
+
+	var datas []dto.TicketCheckInResponse
+	for _, ticketForm := range ticketForms {
+		var emailChecker string
+		if len(ticketForm.GuestAttendances) > 0 {
+			emailChecker = ticketForm.GuestAttendances[0].CheckedByUser.Email
+		}
+
+		data := dto.TicketCheckInResponse{
+			TicketFormID:  ticketForm.ID,
+			TicketID:      *ticketForm.Transaction.TicketID,
+			TransactionID: *ticketForm.TransactionID,
+			TicketName:    ticketForm.Transaction.Ticket.Name,
+			TicketType:    ticketForm.Transaction.Ticket.Type,
+			EventID:       ticketForm.Transaction.Ticket.EventID,
+			EventName:     ticketForm.Transaction.Ticket.Event.Name,
+			AudienceType:  ticketForm.AudienceType,
+			Email:         ticketForm.Email,
+			FullName:      ticketForm.FullName,
+			PhoneNumber:   ticketForm.PhoneNumber,
+			LineID:        ticketForm.LineID,
+			Answers:       ticketForm.Answers,
+			Status:        true,
+			EmailChecker:  emailChecker,
+		}
+
+		datas = append(datas, data)
+	}
+
+	return datas, nil
+}
+func (as *AdminService) GetAllTicketCheckInWithPagination(ctx context.Context, req dto.PaginationRequest, filter dto.CheckInFilterQuery) (dto.TicketFormPaginationResponse, error) {
+	operator, err := as.checkInOperator(ctx)
+	if err != nil {
+		return dto.TicketFormPaginationResponse{}, err
+	}
+
+	filter.EventID, err = crewEventID(operator, filter.EventID)
+	if err != nil {
+		return dto.TicketFormPaginationResponse{}, err
+	}
+
+	dataWithPaginate, err := as.adminRepo.GetAllTicketFormWithPagination(ctx, nil, req, filter)
+	if err != nil {
+		return dto.TicketFormPaginationResponse{}, dto.ErrGetAllTicketCheckInWithPagination
+	}
+
+	var datas []dto.TicketCheckInResponse
+	status := false
+	for _, ticketForm := range dataWithPaginate.TicketForms {
+		var emailChecker string
+		if len(ticketForm.GuestAttendances) > 0 {
+			emailChecker = ticketForm.GuestAttendances[0].CheckedByUser.Email
+		}
+
+		if emailChecker != "" {
+			status = true
+		}
+
+		data := dto.TicketCheckInResponse{
+			TicketFormID:  ticketForm.ID,
+			TicketID:      *ticketForm.Transaction.TicketID,
+			TransactionID: *ticketForm.TransactionID,
+			TicketName:    ticketForm.Transaction.Ticket.Name,
+			TicketType:    ticketForm.Transaction.Ticket.Type,
+			EventID:       ticketForm.Transaction.Ticket.EventID,
+			EventName:     ticketForm.Transaction.Ticket.Event.Name,
+			AudienceType:  ticketForm.AudienceType,
+			Email:         ticketForm.Email,
+			FullName:      ticketForm.FullName,
+			PhoneNumber:   ticketForm.PhoneNumber,
+			LineID:        ticketForm.LineID,
+			Answers:       ticketForm.Answers,
+			Status:        status,
+			EmailChecker:  emailChecker,
+		}
+
+		datas = append(datas, data)
+	}
+
+	return dto.TicketFormPaginationResponse{
+		Data: datas,
+		PaginationResponse: dto.PaginationResponse{
+			Page:    dataWithPaginate.Page,
+			PerPage: dataWithPaginate.PerPage,
+			MaxPage: dataWithPaginate.MaxPage,
+			Count:   dataWithPaginate.Count,
+		},
+	}, nil
+}
+func (as *AdminService) RevokeTicketQR(ctx context.Context, ticketFormIDStr string) error {
+	ticketForm, found, err := as.adminRepo.GetTicketFormByID(ctx, nil, ticketFormIDStr)
+	if err != nil || !found {
+		return dto.ErrTicketFormNotFound
+	}
+
+	if ticketForm.QRRevokedAt != nil {
+		return dto.ErrTicketQRAlreadyRevoked
+	}
+
+	now := time.Now()
+	if err := as.adminRepo.UpdateTicketFormQR(ctx, nil, ticketForm.ID.String(), ticketForm.QRVersion, &now); err != nil {
+		return dto.ErrUpdateTicketForm
+	}
+
+	if err := helpers.RemoveLegacyQRCodeFile(ticketForm.ID.String()); err != nil {
+		log.Printf("failed to remove old qr code %s: %v", ticketForm.ID, err)
+	}
+
+	return nil
+}
+func (as *AdminService) ReissueTicketQR(ctx context.Context, ticketFormIDStr string) error {
+	ticketForm, found, err := as.adminRepo.GetTicketFormByID(ctx, nil, ticketFormIDStr)
+	if err != nil || !found {
+		return dto.ErrTicketFormNotFound
+	}
+
+	if ticketForm.TransferredToID != nil {
+		return dto.ErrTicketTransferred
+	}
+
+	if ticketForm.TransactionID == nil || ticketForm.Transaction.TransactionStatus != "settlement" {
+		return dto.ErrTransactionNotSettled
+	}
+
+	// versi baru membatalkan semua QR dan link download sebelumnya
+	ticketForm.QRVersion++
+	ticketForm.QRRevokedAt = nil
+	err = as.adminRepo.RunInTransaction(ctx, func(txRepo repository.IAdminRepository) error {
+		if err := txRepo.UpdateTicketFormQR(ctx, nil, ticketForm.ID.String(), ticketForm.QRVersion, nil); err != nil {
+			return dto.ErrUpdateTicketForm
+		}
+
+		outbox, err := newETicketEmail(ctx, as.emailTemplateService, ticketForm.Transaction, ticketForm)
+		if err != nil {
+			return err
+		}
+		if err := txRepo.CreateEmailOutbox(ctx, nil, outbox); err != nil {
+			return dto.ErrCreateEmailOutbox
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := helpers.RemoveLegacyQRCodeFile(ticketForm.ID.String()); err != nil {
+		log.Printf("failed to remove old qr code %s: %v", ticketForm.ID, err)
+	}
+
+	return nil
+}
+func (as *AdminService) GetEventOccupancy(ctx context.Context, eventIDStr string) (*dto.EventOccupancyResponse, error) {
+	operator, err := as.checkInOperator(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	eventIDStr, err = crewEventID(operator, eventIDStr)
+	if err != nil {
+		return nil, err
+	}
+
+	event, found, err := as.adminRepo.GetEventByID(ctx, nil, eventIDStr)
+	if err != nil || !found {
+		return nil, dto.ErrEventNotFound
+	}
+
+	occupancy, err := as.adminRepo.GetEventOccupancy(ctx, nil, event)
+	if err != nil {
+		return nil, dto.ErrGetEventOccupancy
+	}
+
+	return occupancy, nil
+}
+
+// Offline Check-in
+
+// earliestAttendance: kalau beberapa scanner mencatat tiket yang sama, yang dipakai scan paling awal.
+func earliestAttendance(attendances []entity.GuestAttendance) *entity.GuestAttendance {
+	var earliest *entity.GuestAttendance
+	for i := range attendances {
+		if earliest == nil || attendances[i].CheckedAt.Before(earliest.CheckedAt) {
+			earliest = &attendances[i]
 		}
+	}
 
-		datas = append(datas, data)
+	return earliest
+}
+
+// checkInWindow sama dengan masa berlaku manifest.
+func checkInWindow(event entity.Event) (time.Time, time.Time) {
+	return event.StartAt.Add(-constants.ENUM_CHECK_IN_OPEN_BEFORE_HOURS * time.Hour), event.EndAt
+}
+
+// checkScanTime menolak scan di luar masa berlaku manifest atau setelah sync diterima.
+func checkScanTime(event entity.Event, scannedAt, receivedAt time.Time) error {
+	if scannedAt.After(receivedAt.Add(constants.ENUM_CHECK_IN_CLOCK_SKEW_SECONDS * time.Second)) {
+		return dto.ErrCheckInScanInFuture
 	}
 
-	return dto.MerchPaginationResponse{
-		Data: datas,
-		PaginationResponse: dto.PaginationResponse{
-			Page:    dataWithPaginate.Page,
-			PerPage: dataWithPaginate.PerPage,
-			MaxPage: dataWithPaginate.MaxPage,
-			Count:   dataWithPaginate.Count,
-		},
-	}, nil
+	validFrom, validUntil := checkInWindow(event)
+	if scannedAt.Before(validFrom) || scannedAt.After(validUntil) {
+		return dto.ErrCheckInScanOutsideWindow
+	}
+
+	return nil
 }
-func (as *AdminService) GetDetailMerch(ctx context.Context, merchID string) (dto.MerchResponse, error) {
-	merch, _, err := as.adminRepo.GetMerchByID(ctx, nil, merchID)
+func (as *AdminService) GetCheckInManifest(ctx context.Context, eventIDStr string) (dto.CheckInManifestResponse, error) {
+	operator, err := as.checkInOperator(ctx)
 	if err != nil {
-		return dto.MerchResponse{}, dto.ErrMerchNotFound
+		return dto.CheckInManifestResponse{}, err
 	}
 
-	var merchImages []dto.MerchImageResponse
-	for _, img := range merch.MerchImages {
-		merchImages = append(merchImages, dto.MerchImageResponse{
-			ID:   img.ID,
-			Name: img.Name,
-		})
+	eventIDStr, err = crewEventID(operator, eventIDStr)
+	if err != nil {
+		return dto.CheckInManifestResponse{}, err
 	}
 
-	return dto.MerchResponse{
-		ID:          merch.ID,
-		Name:        merch.Name,
-		Stock:       merch.Stock,
-		Price:       merch.Price,
-		Description: merch.Description,
-		Category:    merch.Category,
-		Images:      merchImages,
-	}, nil
-}
-func (as *AdminService) UpdateMerch(ctx context.Context, req dto.UpdateMerchRequest) (dto.MerchResponse, error) {
-	merch, found, err := as.adminRepo.GetMerchByID(ctx, nil, req.ID)
+	event, found, err := as.adminRepo.GetEventByID(ctx, nil, eventIDStr)
 	if err != nil || !found {
-		return dto.MerchResponse{}, dto.ErrMerchNotFound
+		return dto.CheckInManifestResponse{}, dto.ErrEventNotFound
 	}
 
-	if req.Name != "" {
-		if len(req.Name) < 3 {
-			return dto.MerchResponse{}, dto.ErrMerchNameTooShort
-		}
-		merch.Name = req.Name
+	ticketForms, err := as.adminRepo.GetAllAttendeeForExport(ctx, nil, dto.AttendeeExportFilterQuery{EventID: event.ID.String()})
+	if err != nil {
+		return dto.CheckInManifestResponse{}, dto.ErrGetCheckInManifest
 	}
 
-	if req.Description != "" {
-		if len(req.Description) < 5 {
-			return dto.MerchResponse{}, dto.ErrMerchDescriptionTooShort
-		}
-		merch.Description = req.Description
+	validFrom, validUntil := checkInWindow(event)
+	manifest := dto.CheckInManifest{
+		EventID:     event.ID,
+		EventName:   event.Name,
+		GeneratedAt: time.Now(),
+		ValidFrom:   validFrom,
+		ValidUntil:  validUntil,
+		Tickets:     make([]dto.CheckInManifestTicketResponse, 0, len(ticketForms)),
 	}
 
-	if req.Stock != nil {
-		if *req.Stock < 0 {
-			return dto.MerchResponse{}, dto.ErrStockOutOfBound
+	for _, ticketForm := range ticketForms {
+		if ticketForm.QRRevokedAt != nil {
+			continue
 		}
-		merch.Stock = *req.Stock
-	}
 
-	if req.Price != nil {
-		if *req.Price < 0 {
-			return dto.MerchResponse{}, dto.ErrPriceOutOfBound
+		item := dto.CheckInManifestTicketResponse{
+			TicketFormID: ticketForm.ID,
+			QRVersion:    ticketForm.QRVersion,
+			TicketName:   ticketForm.Transaction.Ticket.Name,
+			AudienceType: ticketForm.AudienceType,
+			FullName:     ticketForm.FullName,
 		}
-		merch.Price = *req.Price
-	}
 
-	if req.Category != "" {
-		category := entity.MerchCategory(req.Category)
-		if !entity.IsValidMerchCategory(category) {
-			return dto.MerchResponse{}, dto.ErrInvalidMerchCategory
+		if attendance := earliestAttendance(ticketForm.GuestAttendances); attendance != nil {
+			item.CheckedIn = true
+			item.CheckedAt = &attendance.CheckedAt
 		}
-		merch.Category = category
+
+		manifest.Tickets = append(manifest.Tickets, item)
 	}
 
-	err = as.adminRepo.RunInTransaction(ctx, func(txRepo repository.IAdminRepository) error {
-		if err := txRepo.UpdateMerch(ctx, nil, merch); err != nil {
-			return dto.ErrUpdateMerch
-		}
+	payload, err := json.Marshal(manifest)
+	if err != nil {
+		return dto.CheckInManifestResponse{}, dto.ErrSignCheckInManifest
+	}
 
-		if len(req.Images) > 0 {
-			oldImages, err := txRepo.GetMerchImagesByMerchID(ctx, nil, merch.ID.String())
+	return dto.CheckInManifestResponse{
+		Manifest:  payload,
+		Algorithm: "ed25519",
+		PublicKey: helpers.ManifestPublicKey(),
+		Signature: helpers.SignManifest(payload),
+	}, nil
+}
+func (as *AdminService) SyncCheckIn(ctx context.Context, req dto.SyncCheckInRequest) (dto.SyncCheckInResponse, error) {
+	receivedAt := time.Now()
+
+	req.DeviceID = strings.TrimSpace(req.DeviceID)
+	if req.DeviceID == "" {
+		return dto.SyncCheckInResponse{}, dto.ErrDeviceIDRequired
+	}
+
+	if len(req.Scans) == 0 {
+		return dto.SyncCheckInResponse{}, dto.ErrEmptyCheckInScans
+	}
+
+	if len(req.Scans) > constants.ENUM_CHECK_IN_SYNC_MAX_SCANS {
+		return dto.SyncCheckInResponse{}, dto.ErrTooManyCheckInScans
+	}
+
+	operator, err := as.checkInOperator(ctx)
+	if err != nil {
+		return dto.SyncCheckInResponse{}, err
+	}
+
+	req.EventID, err = crewEventID(operator, req.EventID)
+	if err != nil {
+		return dto.SyncCheckInResponse{}, err
+	}
+
+	event, found, err := as.adminRepo.GetEventByID(ctx, nil, req.EventID)
+	if err != nil || !found {
+		return dto.SyncCheckInResponse{}, dto.ErrEventNotFound
+	}
+
+	// scan diproses urut waktu scan, bukan urutan upload
+	order := make([]int, len(req.Scans))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return req.Scans[order[a]].ScannedAt.Before(req.Scans[order[b]].ScannedAt)
+	})
+
+	res := dto.SyncCheckInResponse{
+		Results: make([]dto.CheckInScanResultResponse, len(req.Scans)),
+	}
+
+	for _, i := range order {
+		var (
+			result dto.CheckInScanResultResponse
+			record *checkInRecord
+		)
+		err := as.adminRepo.RunInTransaction(ctx, func(txRepo repository.IAdminRepository) error {
+			var err error
+			result, record, err = as.applyCheckInScan(ctx, txRepo, event, operator, req.DeviceID, receivedAt, req.Scans[i])
 			if err != nil {
-				return dto.ErrGetMerchImages
+				return err
 			}
 
-			for _, img := range oldImages {
-				path := filepath.Join("assets/merch", img.Name)
-				if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
-					return dto.ErrDeleteOldImage
-				}
-				if err := txRepo.DeleteMerchImageByID(ctx, nil, img.ID.String()); err != nil {
-					return dto.ErrDeleteMerchImageByID
-				}
+			if err := txRepo.CreateCheckInScan(ctx, nil, newCheckInScan(event, operator, req.DeviceID, result)); err != nil {
+				return dto.ErrCreateCheckInScan
 			}
 
-			for _, img := range req.Images {
-				ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(img.FileHeader.Filename), "."))
-				if ext != "jpg" && ext != "jpeg" && ext != "png" {
-					return dto.ErrInvalidExtensionPhoto
-				}
+			return nil
+		})
+		if err != nil {
+			// attendance batal bersama transaksinya, scan tetap dicatat sebagai ditolak
+			result.Result = entity.CheckInScanRejected
+			result.Reason = err.Error()
+			if err := as.adminRepo.CreateCheckInScan(ctx, nil, newCheckInScan(event, operator, req.DeviceID, result)); err != nil {
+				log.Printf("failed to record check-in scan from device %s: %v", req.DeviceID, err)
+			}
+		} else if record != nil {
+			as.publishCheckIn(*record, nil)
+		}
 
-				newImageID := uuid.New()
-				newFileName := fmt.Sprintf("merch_%s.%s", newImageID, ext)
+		result.Index = i
+		res.Results[i] = result
 
-				saveDir := "assets/merch"
-				if err := os.MkdirAll(saveDir, os.ModePerm); err != nil {
-					return dto.ErrCreateFile
-				}
-				savePath := filepath.Join(saveDir, newFileName)
+		switch result.Result {
+		case entity.CheckInScanAccepted:
+			res.Accepted++
+		case entity.CheckInScanDuplicate:
+			res.Duplicate++
+		default:
+			res.Rejected++
+		}
+	}
 
-				out, err := os.Create(savePath)
-				if err != nil {
-					return dto.ErrCreateFile
-				}
-				defer out.Close()
+	return res, nil
+}
+func newCheckInScan(event entity.Event, operator entity.User, deviceID string, result dto.CheckInScanResultResponse) entity.CheckInScan {
+	return entity.CheckInScan{
+		ID:           uuid.New(),
+		DeviceID:     deviceID,
+		QRToken:      result.QRToken,
+		ScannedAt:    result.ScannedAt,
+		Result:       result.Result,
+		Reason:       result.Reason,
+		TicketFormID: result.TicketFormID,
+		EventID:      &event.ID,
+		SyncedBy:     &operator.ID,
+	}
+}
 
-				if _, err := io.Copy(out, img.FileReader); err != nil {
-					return dto.ErrSaveFile
-				}
+// applyCheckInScan dipanggil dengan ticket form terkunci; error hanya untuk kegagalan tulis.
+func (as *AdminService) applyCheckInScan(ctx context.Context, txRepo repository.IAdminRepository, event entity.Event, operator entity.User, deviceID string, receivedAt time.Time, scan dto.CheckInScanRequest) (dto.CheckInScanResultResponse, *checkInRecord, error) {
+	result := dto.CheckInScanResultResponse{
+		QRToken:   strings.TrimSpace(scan.QRToken),
+		ScannedAt: scan.ScannedAt,
+		Result:    entity.CheckInScanRejected,
+	}
 
-				newImage := entity.MerchImage{
-					ID:      newImageID,
-					MerchID: &merch.ID,
-					Name:    newFileName,
-				}
-				if err := txRepo.CreateMerchImage(ctx, nil, newImage); err != nil {
-					return dto.ErrCreateMerchImage
-				}
-			}
+	if scan.ScannedAt.IsZero() {
+		result.Reason = "missing scanned_at"
+		return result, nil, nil
+	}
+
+	if err := checkScanTime(event, scan.ScannedAt, receivedAt); err != nil {
+		result.Reason = err.Error()
+		return result, nil, nil
+	}
+
+	claims, err := helpers.VerifyTicketQR(result.QRToken)
+	if err != nil {
+		result.Reason = dto.ErrInvalidTicketQR.Error()
+		return result, nil, nil
+	}
+
+	ticketForm, found, err := txRepo.GetTicketFormByIDForUpdate(ctx, nil, claims.TicketFormID.String())
+	if err != nil || !found {
+		result.Reason = dto.ErrTicketFormNotFound.Error()
+		return result, nil, nil
+	}
+
+	result.TicketFormID = &ticketForm.ID
+	result.FullName = ticketForm.FullName
+
+	if err := checkTicketQR(claims, ticketForm); err != nil {
+		result.Reason = err.Error()
+		return result, nil, nil
+	}
+
+	if ticketForm.Transaction.Ticket.EventID == nil || *ticketForm.Transaction.Ticket.EventID != event.ID {
+		result.Reason = dto.ErrTicketQREventMismatch.Error()
+		return result, nil, nil
+	}
+
+	direction := entity.CheckInDirectionIn
+	if scan.Direction != "" {
+		direction = scan.Direction
+		if !entity.IsValidCheckInDirection(direction) {
+			result.Reason = dto.ErrInvalidCheckInDirection.Error()
+			return result, nil, nil
 		}
+	}
 
-		return nil
-	})
+	scan.GateID, err = crewGateID(operator, scan.GateID)
 	if err != nil {
-		return dto.MerchResponse{}, err
+		result.Reason = err.Error()
+		return result, nil, nil
 	}
 
-	images, err := as.adminRepo.GetMerchImagesByMerchID(ctx, nil, merch.ID.String())
+	gate, eventSession, err := as.resolveCheckInLocation(ctx, &event.ID, scan.GateID, scan.EventSessionID)
 	if err != nil {
-		return dto.MerchResponse{}, dto.ErrGetMerchImages
+		result.Reason = err.Error()
+		return result, nil, nil
 	}
+	gateID, eventSessionID := checkInLocationIDs(gate, eventSession)
 
-	var imageResponses []dto.MerchImageResponse
-	for _, img := range images {
-		imageResponses = append(imageResponses, dto.MerchImageResponse{
-			ID:   img.ID,
-			Name: img.Name,
-		})
+	policy := ticketForm.Transaction.Ticket.Event.ReentryPolicy
+	if policy == entity.ReentryPerSession && direction == entity.CheckInDirectionIn && eventSessionID == nil {
+		result.Reason = dto.ErrEventSessionRequired.Error()
+		return result, nil, nil
 	}
 
-	return dto.MerchResponse{
-		ID:          merch.ID,
-		Name:        merch.Name,
-		Stock:       merch.Stock,
-		Price:       merch.Price,
-		Description: merch.Description,
-		Category:    merch.Category,
-		Images:      imageResponses,
+	// scan yang diupload ulang setelah sync gagal tidak dianggap konflik
+	for _, attendance := range ticketForm.GuestAttendances {
+		if attendance.DeviceID == deviceID && attendance.Direction == direction && attendance.CheckedAt.Equal(scan.ScannedAt) {
+			result.Result = entity.CheckInScanAccepted
+			result.Reason = "already synced"
+			return result, nil, nil
+		}
+	}
+
+	// hanya scan "in" yang melanggar re-entry policy yang konflik, sisanya dicatat apa adanya
+	var existing *entity.GuestAttendance
+	if direction == entity.CheckInDirectionIn {
+		existing = earliestAttendance(conflictingAttendances(policy, ticketForm.GuestAttendances, eventSessionID))
+	}
+
+	if existing != nil && !scan.ScannedAt.Before(existing.CheckedAt) {
+		result.Result = entity.CheckInScanDuplicate
+		result.Reason = fmt.Sprintf("already checked in at %s", existing.CheckedAt.Format(time.RFC3339))
+		return result, nil, nil
+	}
+
+	// scan ini lebih awal dari check-in yang tercatat: check-in lama di-void, bukan ditimpa
+	if existing != nil {
+		now := time.Now()
+		existing.VoidedAt = &now
+		existing.VoidedBy = &operator.ID
+		existing.VoidReason = constants.ENUM_CHECK_IN_SUPERSEDED_REASON
+		if err := txRepo.UpdateGuestAttendance(ctx, nil, *existing); err != nil {
+			return result, nil, dto.ErrUpdateGuestAttendance
+		}
+
+		result.Reason = "replaced a later check-in"
+	}
+
+	// kapasitas session tidak dicek, scan offline mencatat guest yang sudah terlanjur masuk
+	guestAttendance := entity.GuestAttendance{
+		ID:             uuid.New(),
+		TicketFormID:   &ticketForm.ID,
+		CheckedBy:      &operator.ID,
+		CheckedAt:      scan.ScannedAt,
+		Source:         entity.CheckInOffline,
+		DeviceID:       deviceID,
+		Direction:      direction,
+		GateID:         gateID,
+		EventSessionID: eventSessionID,
+	}
+
+	if err := txRepo.CreateGuestAttendance(ctx, nil, guestAttendance); err != nil {
+		return result, nil, dto.ErrCreateGuestAttendance
+	}
+
+	result.Result = entity.CheckInScanAccepted
+	return result, &checkInRecord{
+		ticketForm:   ticketForm,
+		operator:     operator,
+		gate:         gate,
+		eventSession: eventSession,
+		direction:    direction,
+		scannedAt:    scan.ScannedAt,
 	}, nil
 }
-func (as *AdminService) DeleteMerch(ctx context.Context, req dto.DeleteMerchRequest) (dto.MerchResponse, error) {
-	deletedMerch, flag, err := as.adminRepo.GetMerchByID(ctx, nil, req.MerchID)
-	if err != nil || !flag {
-		return dto.MerchResponse{}, dto.ErrMerchNotFound
+
+// Attendee Export
+func formatFormAnswer(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		if v {
+			return "yes"
+		}
+		return "no"
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			values = append(values, formatFormAnswer(item))
+		}
+		return strings.Join(values, "; ")
+	case []string:
+		return strings.Join(v, "; ")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+func (as *AdminService) ExportAttendee(ctx context.Context, filter dto.AttendeeExportFilterQuery) ([]byte, error) {
+	ticketForms, err := as.adminRepo.GetAllAttendeeForExport(ctx, nil, filter)
+	if err != nil {
+		return nil, dto.ErrExportAttendee
 	}
 
-	var merchImages []dto.MerchImageResponse
-	for _, img := range deletedMerch.MerchImages {
-		merchImages = append(merchImages, dto.MerchImageResponse{
-			ID:   img.ID,
-			Name: img.Name,
-		})
+	// kolom custom digabung dari semua ticket, urut sesuai kemunculan
+	var (
+		fieldKeys   []string
+		fieldLabels = make(map[string]string)
+	)
+	for _, ticketForm := range ticketForms {
+		for _, field := range ticketForm.Transaction.Ticket.FormFields {
+			if _, ok := fieldLabels[field.Key]; ok {
+				continue
+			}
+
+			fieldKeys = append(fieldKeys, field.Key)
+			fieldLabels[field.Key] = field.Label
+		}
 	}
 
-	if err = as.adminRepo.DeleteMerchImagesByMerchID(ctx, nil, req.MerchID); err != nil {
-		return dto.MerchResponse{}, dto.ErrDeleteMerchImagesByMerchID
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+
+	header := []string{"ticket_form_id", "order_id", "event", "ticket", "audience_type", "instansi", "full_name", "email", "phone_number", "line_id", "checked_in"}
+	for _, key := range fieldKeys {
+		header = append(header, fieldLabels[key])
 	}
 
-	if err = as.adminRepo.DeleteMerchByID(ctx, nil, req.MerchID); err != nil {
-		return dto.MerchResponse{}, dto.ErrDeleteMerchByID
+	if err := writer.Write(header); err != nil {
+		return nil, dto.ErrExportAttendee
 	}
 
-	res := dto.MerchResponse{
-		ID:          deletedMerch.ID,
-		Name:        deletedMerch.Name,
-		Stock:       deletedMerch.Stock,
-		Price:       deletedMerch.Price,
-		Description: deletedMerch.Description,
-		Category:    deletedMerch.Category,
-		Images:      merchImages,
+	for _, ticketForm := range ticketForms {
+		checkedIn := "no"
+		if len(ticketForm.GuestAttendances) > 0 {
+			checkedIn = "yes"
+		}
+
+		row := []string{
+			ticketForm.ID.String(),
+			ticketForm.Transaction.OrderID,
+			ticketForm.Transaction.Ticket.Event.Name,
+			ticketForm.Transaction.Ticket.Name,
+			string(ticketForm.AudienceType),
+			string(ticketForm.Instansi),
+			ticketForm.FullName,
+			ticketForm.Email,
+			ticketForm.PhoneNumber,
+			ticketForm.LineID,
+			checkedIn,
+		}
+		for _, key := range fieldKeys {
+			row = append(row, formatFormAnswer(ticketForm.Answers[key]))
+		}
+
+		if err := writer.Write(row); err != nil {
+			return nil, dto.ErrExportAttendee
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, dto.ErrExportAttendee
 	}
 
-	return res, nil
+	return buf.Bytes(), nil
 }
 
-// Bundle
-func (as *AdminService) CreateBundle(ctx context.Context, req dto.CreateBundleRequest) (dto.BundleResponse, error) {
-	if req.Name == "" || req.FileHeader == nil || req.FileReader == nil || len(req.BundleItems) == 0 || req.Type == "" || req.EventDate == "" {
-		return dto.BundleResponse{}, dto.ErrEmptyFields
+// Badge
+func toBadge(ticketForm entity.TicketForm) badge.Badge {
+	instansi := "Umum"
+	if ticketForm.Instansi == entity.Unair {
+		instansi = "Universitas Airlangga"
 	}
 
-	if len(req.Name) < 3 {
-		return dto.BundleResponse{}, dto.ErrBundleNameTooShort
+	return badge.Badge{
+		EventName:  ticketForm.Transaction.Ticket.Event.Name,
+		Name:       ticketForm.FullName,
+		Instansi:   instansi,
+		TicketType: strings.ReplaceAll(string(ticketForm.Transaction.Ticket.Type), "-", " "),
+		QRContent:  signTicketQRToken(ticketForm, ticketForm.Transaction.Ticket.EventID),
 	}
-
-	if !entity.IsValidBundleType(req.Type) {
-		return dto.BundleResponse{}, dto.ErrInvalidBundleType
+}
+func (as *AdminService) PrintBadge(ctx context.Context, ticketFormID string, query dto.BadgeQuery) ([]byte, error) {
+	if query.Format == "" {
+		query.Format = constants.ENUM_BADGE_FORMAT_SINGLE
 	}
 
-	if req.Price < 0 {
-		return dto.BundleResponse{}, dto.ErrPriceOutOfBound
+	if query.Format != constants.ENUM_BADGE_FORMAT_SINGLE && query.Format != constants.ENUM_BADGE_FORMAT_SHEET {
+		return nil, dto.ErrInvalidBadgeFormat
 	}
 
-	if req.Quota < 0 {
-		return dto.BundleResponse{}, dto.ErrQuotaOutOfBound
+	operator, err := as.checkInOperator(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	var fileName string
-	if req.FileHeader != nil && req.FileReader != nil {
-		ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(req.FileHeader.Filename), "."))
-		if ext != "jpg" && ext != "jpeg" && ext != "png" {
-			return dto.BundleResponse{}, dto.ErrInvalidExtensionPhoto
-		}
-
-		bundleName := strings.ToLower(req.Name)
-		bundleName = strings.ReplaceAll(bundleName, " ", "_")
+	ticketForm, found, err := as.adminRepo.GetTicketFormByID(ctx, nil, ticketFormID)
+	if err != nil || !found {
+		return nil, dto.ErrTicketFormNotFound
+	}
 
-		fileName = fmt.Sprintf("bundle_%s_%s.%s", time.Now().Format("060102150405"), bundleName, ext)
+	if err := checkCrewEvent(operator, ticketForm.Transaction.Ticket.EventID); err != nil {
+		return nil, err
+	}
 
-		saveDir := "assets/bundle"
-		if err := os.MkdirAll(saveDir, os.ModePerm); err != nil {
-			return dto.BundleResponse{}, dto.ErrCreateFile
-		}
-		savePath := filepath.Join(saveDir, fileName)
+	if ticketForm.TransactionID == nil || ticketForm.Transaction.TransactionStatus != "settlement" {
+		return nil, dto.ErrTicketNotPaid
+	}
 
-		out, err := os.Create(savePath)
-		if err != nil {
-			return dto.BundleResponse{}, dto.ErrCreateFile
-		}
-		defer out.Close()
+	if ticketForm.TransferredToID != nil {
+		return nil, dto.ErrTicketTransferred
+	}
 
-		if _, err := io.Copy(out, req.FileReader); err != nil {
-			return dto.BundleResponse{}, dto.ErrSaveFile
-		}
+	if ticketForm.QRRevokedAt != nil {
+		return nil, dto.ErrTicketQRRevoked
 	}
 
-	loc, err := time.LoadLocation("Asia/Jakarta")
+	layout, err := badge.LoadLayout()
 	if err != nil {
-		loc = time.FixedZone("UTC+7", 7*60*60)
+		return nil, dto.ErrLoadBadgeLayout
+	}
+
+	var pdf []byte
+	if query.Format == constants.ENUM_BADGE_FORMAT_SHEET {
+		pdf, err = badge.RenderSheet(layout, []badge.Badge{toBadge(ticketForm)})
+	} else {
+		pdf, err = badge.RenderSingle(layout, toBadge(ticketForm))
 	}
-	eventDate, err := time.ParseInLocation("2006-01-02", req.EventDate, loc)
 	if err != nil {
-		return dto.BundleResponse{}, dto.ErrParseTime
+		return nil, dto.ErrRenderBadge
 	}
 
-	bundleID := uuid.New()
-	bundle := entity.Bundle{
-		ID:          bundleID,
-		Name:        req.Name,
-		Type:        req.Type,
-		Price:       req.Price,
-		Quota:       req.Quota,
-		Image:       fileName,
-		Description: req.Description,
-		EventDate:   eventDate,
+	return pdf, nil
+}
+
+// PrintEventBadges mencetak badge semua tiket valid event di kertas A4, urut nama.
+func (as *AdminService) PrintEventBadges(ctx context.Context, eventID string, filter dto.BadgeBulkFilterQuery) ([]byte, error) {
+	event, found, err := as.adminRepo.GetEventByID(ctx, nil, eventID)
+	if err != nil || !found {
+		return nil, dto.ErrEventNotFound
 	}
 
-	var bundleItems []entity.BundleItem
-	for _, merchID := range req.BundleItems {
-		bundleItems = append(bundleItems, entity.BundleItem{
-			ID:       uuid.New(),
-			BundleID: &bundleID,
-			MerchID:  merchID,
-		})
+	ticketForms, err := as.adminRepo.GetAllAttendeeForExport(ctx, nil, dto.AttendeeExportFilterQuery{
+		EventID:  event.ID.String(),
+		TicketID: filter.TicketID,
+	})
+	if err != nil {
+		return nil, dto.ErrRenderBadge
 	}
 
-	err = as.adminRepo.RunInTransaction(ctx, func(txRepo repository.IAdminRepository) error {
-		if err := txRepo.CreateBundle(ctx, nil, bundle); err != nil {
-			return err
+	var badges []badge.Badge
+	for _, ticketForm := range ticketForms {
+		if ticketForm.QRRevokedAt != nil {
+			continue
 		}
-		for _, item := range bundleItems {
-			_, _, err := txRepo.GetMerchByID(ctx, nil, item.MerchID.String())
-			if err != nil {
-				return dto.ErrMerchNotFound
-			}
 
-			if err := txRepo.CreateBundleItem(ctx, nil, item); err != nil {
-				return err
-			}
+		checkedIn := len(ticketForm.GuestAttendances) != 0
+		if (filter.CheckedIn == "true" && !checkedIn) || (filter.CheckedIn == "false" && checkedIn) {
+			continue
 		}
-		return nil
+
+		badges = append(badges, toBadge(ticketForm))
+	}
+
+	sort.SliceStable(badges, func(i, j int) bool {
+		return strings.ToLower(badges[i].Name) < strings.ToLower(badges[j].Name)
 	})
+
+	layout, err := badge.LoadLayout()
 	if err != nil {
-		return dto.BundleResponse{}, err
+		return nil, dto.ErrLoadBadgeLayout
 	}
 
-	var itemsResp []dto.BundleItemResponse
-	for _, item := range bundleItems {
-		merch, found, err := as.adminRepo.GetMerchByID(ctx, nil, item.MerchID.String())
-		if err == nil && found {
-			itemsResp = append(itemsResp, dto.BundleItemResponse{
-				ID:        item.ID,
-				MerchID:   item.MerchID,
-				MerchName: merch.Name,
-			})
+	pdf, err := badge.RenderSheet(layout, badges)
+	if err != nil {
+		return nil, dto.ErrRenderBadge
+	}
+
+	return pdf, nil
+}
+
+// Merch Pickup
+var merchSizeAnswerKeys = []string{"size", "shirt_size", "tshirt_size", "t_shirt_size", "ukuran", "ukuran_kaos"}
+
+func merchSizeFromAnswers(answers entity.FormAnswers) string {
+	for _, key := range merchSizeAnswerKeys {
+		if size, ok := answers[key].(string); ok && strings.TrimSpace(size) != "" {
+			return strings.ToUpper(strings.TrimSpace(size))
 		}
 	}
 
-	return dto.BundleResponse{
-		ID:          bundle.ID,
-		Name:        bundle.Name,
-		Image:       bundle.Image,
-		Type:        bundle.Type,
-		Price:       bundle.Price,
-		Quota:       bundle.Quota,
-		Description: bundle.Description,
-		EventDate:   bundle.EventDate.Format("2006-01-02"),
-		BundleItems: itemsResp,
-	}, nil
+	return ""
 }
-func (as *AdminService) GetAllBundle(ctx context.Context) ([]dto.BundleResponse, error) {
-	bundles, err := as.adminRepo.GetAllBundle(ctx, nil)
-	if err != nil {
-		return nil, dto.ErrGetAllBundleNoPagination
+
+// merchPickupLines: satu baris per item bundle untuk setiap ticket form.
+func merchPickupLines(ticketForm entity.TicketForm, bundle entity.Bundle) []dto.MerchPickupItemResponse {
+	pickups := make(map[uuid.UUID]entity.MerchPickup, len(ticketForm.MerchPickups))
+	for _, pickup := range ticketForm.MerchPickups {
+		if pickup.BundleItemID != nil {
+			pickups[*pickup.BundleItemID] = pickup
+		}
 	}
 
-	var datas []dto.BundleResponse
-	for _, bundle := range bundles {
-		isAvailable := bundle.Quota > 0 && time.Now().Before(bundle.EventDate)
+	var lines []dto.MerchPickupItemResponse
+	for _, item := range bundle.BundleItems {
+		line := dto.MerchPickupItemResponse{
+			TicketFormID:  ticketForm.ID,
+			FullName:      ticketForm.FullName,
+			BundleItemID:  item.ID,
+			MerchID:       item.MerchID,
+			MerchName:     item.Merch.Name,
+			MerchCategory: item.Merch.Category,
+		}
 
-		data := dto.BundleResponse{
-			ID:          bundle.ID,
-			Name:        bundle.Name,
-			Image:       bundle.Image,
-			Type:        bundle.Type,
-			Price:       bundle.Price,
-			Quota:       bundle.Quota,
-			Description: bundle.Description,
-			EventDate:   bundle.EventDate.Format("2006-01-02"),
-			IsAvailable: &isAvailable,
+		if item.Merch.Category == entity.TShirt {
+			line.Size = merchSizeFromAnswers(ticketForm.Answers)
 		}
 
-		for _, bi := range bundle.BundleItems {
-			bundleItem := dto.BundleItemResponse{
-				ID:        bi.ID,
-				MerchID:   bi.MerchID,
-				MerchName: bi.Merch.Name,
+		if pickup, ok := pickups[item.ID]; ok {
+			line.PickedUp = true
+			line.HandedOverAt = &pickup.HandedOverAt
+			line.HandedOverBy = pickup.HandedOverByUser.Email
+			if pickup.Size != "" {
+				line.Size = pickup.Size
 			}
-
-			data.BundleItems = append(data.BundleItems, bundleItem)
 		}
 
-		datas = append(datas, data)
+		lines = append(lines, line)
 	}
 
-	return datas, nil
+	return lines
 }
-func (as *AdminService) GetAllBundleWithPagination(ctx context.Context, req dto.PaginationRequest) (dto.BundlePaginationResponse, error) {
-	dataWithPaginate, err := as.adminRepo.GetAllBundleWithPagination(ctx, nil, req)
-	if err != nil {
-		return dto.BundlePaginationResponse{}, dto.ErrGetAllBundleWithPagination
+func toMerchPickupResponse(transaction entity.Transaction) dto.MerchPickupResponse {
+	res := dto.MerchPickupResponse{
+		TransactionID: transaction.ID,
+		OrderID:       transaction.OrderID,
+		BundleID:      transaction.BundleID,
+		BundleName:    transaction.Bundle.Name,
+		Items:         []dto.MerchPickupItemResponse{},
 	}
 
-	var datas []dto.BundleResponse
-	for _, bundle := range dataWithPaginate.Bundles {
-		isAvailable := bundle.Quota > 0 && time.Now().Before(bundle.EventDate)
-
-		data := dto.BundleResponse{
-			ID:          bundle.ID,
-			Name:        bundle.Name,
-			Image:       bundle.Image,
-			Type:        bundle.Type,
-			Price:       bundle.Price,
-			Quota:       bundle.Quota,
-			Description: bundle.Description,
-			EventDate:   bundle.EventDate.Format("2006-01-02"),
-			IsAvailable: &isAvailable,
-		}
-
-		for _, bi := range bundle.BundleItems {
-			bundleItem := dto.BundleItemResponse{
-				ID:        bi.ID,
-				MerchID:   bi.MerchID,
-				MerchName: bi.Merch.Name,
+	for _, ticketForm := range transaction.TicketForms {
+		for _, line := range merchPickupLines(ticketForm, transaction.Bundle) {
+			if !line.PickedUp {
+				res.Outstanding++
 			}
-
-			data.BundleItems = append(data.BundleItems, bundleItem)
+			res.Items = append(res.Items, line)
 		}
-
-		datas = append(datas, data)
 	}
 
-	return dto.BundlePaginationResponse{
-		Data: datas,
-		PaginationResponse: dto.PaginationResponse{
-			Page:    dataWithPaginate.Page,
-			PerPage: dataWithPaginate.PerPage,
-			MaxPage: dataWithPaginate.MaxPage,
-			Count:   dataWithPaginate.Count,
-		},
-	}, nil
+	return res
 }
-func (as *AdminService) GetDetailBundle(ctx context.Context, bundleID string) (dto.BundleResponse, error) {
-	bundle, _, err := as.adminRepo.GetBundleByID(ctx, nil, bundleID)
+func (as *AdminService) resolvePickupTransaction(ctx context.Context, qrToken string) (entity.Transaction, entity.User, error) {
+	operator, err := as.checkInOperator(ctx)
 	if err != nil {
-		return dto.BundleResponse{}, dto.ErrBundleNotFound
+		return entity.Transaction{}, entity.User{}, err
 	}
 
-	b := dto.BundleResponse{
-		ID:          bundle.ID,
-		Name:        bundle.Name,
-		Image:       bundle.Image,
-		Type:        bundle.Type,
-		Price:       bundle.Price,
-		Quota:       bundle.Quota,
-		Description: bundle.Description,
-		EventDate:   bundle.EventDate.Format("2006-01-02"),
+	ticketForm, err := as.resolveTicketQR(ctx, qrToken)
+	if err != nil {
+		return entity.Transaction{}, entity.User{}, err
 	}
 
-	for _, bi := range bundle.BundleItems {
-		bundleItem := dto.BundleItemResponse{
-			ID:        bi.ID,
-			MerchID:   bi.MerchID,
-			MerchName: bi.Merch.Name,
-		}
+	if ticketForm.TransactionID == nil {
+		return entity.Transaction{}, entity.User{}, dto.ErrTransactionNotFound
+	}
 
-		for _, mi := range bi.Merch.MerchImages {
-			bundleItem.MerchImages = append(bundleItem.MerchImages, dto.MerchImageResponse{
-				ID:   mi.ID,
-				Name: mi.Name,
-			})
-		}
+	transaction, found, err := as.adminRepo.GetTransactionForPickup(ctx, nil, ticketForm.TransactionID.String())
+	if err != nil || !found {
+		return entity.Transaction{}, entity.User{}, dto.ErrTransactionNotFound
+	}
 
-		b.BundleItems = append(b.BundleItems, bundleItem)
+	if transaction.TransactionStatus != "settlement" {
+		return entity.Transaction{}, entity.User{}, dto.ErrTicketNotPaid
 	}
 
-	return b, nil
+	if transaction.BundleID == nil || len(transaction.Bundle.BundleItems) == 0 {
+		return entity.Transaction{}, entity.User{}, dto.ErrNoMerchOwed
+	}
+
+	if err := checkCrewEvent(operator, transaction.Bundle.EventID); err != nil {
+		return entity.Transaction{}, entity.User{}, err
+	}
+
+	return transaction, operator, nil
 }
-func (as *AdminService) UpdateBundle(ctx context.Context, req dto.UpdateBundleRequest) (dto.BundleResponse, error) {
-	bundle, flag, err := as.adminRepo.GetBundleByID(ctx, nil, req.ID)
-	if err != nil || !flag {
-		return dto.BundleResponse{}, dto.ErrBundleNotFound
+func (as *AdminService) GetMerchPickup(ctx context.Context, qrToken string) (dto.MerchPickupResponse, error) {
+	transaction, _, err := as.resolvePickupTransaction(ctx, qrToken)
+	if err != nil {
+		return dto.MerchPickupResponse{}, err
 	}
 
-	if req.Name != "" {
-		if len(req.Name) < 3 {
-			return dto.BundleResponse{}, dto.ErrBundleNameTooShort
+	return toMerchPickupResponse(transaction), nil
+}
+
+// HandOverMerch menandai semua item yang belum diambil kalau tidak ada yang dipilih.
+func (as *AdminService) HandOverMerch(ctx context.Context, req dto.HandOverMerchRequest) (dto.MerchPickupResponse, error) {
+	transaction, operator, err := as.resolvePickupTransaction(ctx, req.QRToken)
+	if err != nil {
+		return dto.MerchPickupResponse{}, err
+	}
+
+	current := toMerchPickupResponse(transaction)
+
+	var handOver []dto.MerchPickupItemResponse
+	if len(req.Items) == 0 {
+		for _, line := range current.Items {
+			if !line.PickedUp {
+				handOver = append(handOver, line)
+			}
 		}
 
-		bundle.Name = req.Name
+		if len(handOver) == 0 {
+			return dto.MerchPickupResponse{}, dto.ErrNothingToHandOver
+		}
 	}
 
-	if req.Type != "" {
-		if !entity.IsValidBundleType(req.Type) {
-			return dto.BundleResponse{}, dto.ErrInvalidBundleType
+	for _, item := range req.Items {
+		var matched *dto.MerchPickupItemResponse
+		for i := range current.Items {
+			line := &current.Items[i]
+			if line.TicketFormID.String() == item.TicketFormID && line.BundleItemID.String() == item.BundleItemID {
+				matched = line
+				break
+			}
 		}
 
-		bundle.Type = req.Type
+		if matched == nil {
+			return dto.MerchPickupResponse{}, dto.ErrMerchPickupLineNotFound
+		}
+
+		if matched.PickedUp {
+			return dto.MerchPickupResponse{}, dto.ErrMerchAlreadyHandedOver
+		}
+
+		line := *matched
+		if size := strings.TrimSpace(item.Size); size != "" {
+			line.Size = strings.ToUpper(size)
+		}
+		handOver = append(handOver, line)
 	}
 
-	if req.Price != nil {
-		if *req.Price < 0 {
-			return dto.BundleResponse{}, dto.ErrPriceOutOfBound
+	now := time.Now()
+	err = as.adminRepo.RunInTransaction(ctx, func(txRepo repository.IAdminRepository) error {
+		for _, line := range handOver {
+			merchPickup := entity.MerchPickup{
+				ID:           uuid.New(),
+				Size:         line.Size,
+				TicketFormID: &line.TicketFormID,
+				BundleItemID: &line.BundleItemID,
+				MerchID:      line.MerchID,
+				HandedOverBy: &operator.ID,
+				HandedOverAt: now,
+			}
+
+			if err := txRepo.CreateMerchPickup(ctx, nil, merchPickup); err != nil {
+				return dto.ErrCreateMerchPickup
+			}
 		}
 
-		bundle.Price = *req.Price
+		return nil
+	})
+	if err != nil {
+		return dto.MerchPickupResponse{}, err
 	}
 
-	if req.Quota != nil {
-		if *req.Quota < 0 {
-			return dto.BundleResponse{}, dto.ErrQuotaOutOfBound
-		}
+	transaction, found, err := as.adminRepo.GetTransactionForPickup(ctx, nil, transaction.ID.String())
+	if err != nil || !found {
+		return dto.MerchPickupResponse{}, dto.ErrTransactionNotFound
+	}
 
-		bundle.Quota = *req.Quota
+	return toMerchPickupResponse(transaction), nil
+}
+func (as *AdminService) GetOutstandingMerchPickup(ctx context.Context, filter dto.MerchPickupFilterQuery) (dto.MerchPickupReportResponse, error) {
+	ticketForms, err := as.adminRepo.GetAllTicketFormForPickup(ctx, nil, filter)
+	if err != nil {
+		return dto.MerchPickupReportResponse{}, dto.ErrGetOutstandingMerchPickup
 	}
 
-	if req.FileHeader != nil && req.FileReader != nil {
-		ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(req.FileHeader.Filename), "."))
-		if ext != "jpg" && ext != "jpeg" && ext != "png" {
-			return dto.BundleResponse{}, dto.ErrInvalidExtensionPhoto
-		}
+	res := dto.MerchPickupReportResponse{
+		Summary: []dto.MerchPickupSummaryResponse{},
+		Items:   []dto.MerchPickupItemResponse{},
+	}
 
-		if bundle.Image != "" {
-			oldPath := filepath.Join("assets/bundle", bundle.Image)
-			if err := os.Remove(oldPath); err != nil && !os.IsNotExist(err) {
-				return dto.BundleResponse{}, dto.ErrDeleteOldImage
+	summaryIndex := make(map[string]int)
+	for _, ticketForm := range ticketForms {
+		for _, line := range merchPickupLines(ticketForm, ticketForm.Transaction.Bundle) {
+			key := line.MerchName + "|" + line.Size
+			if line.MerchID != nil {
+				key = line.MerchID.String() + "|" + line.Size
 			}
-		}
 
-		bundleName := strings.ToLower(bundle.Name)
-		bundleName = strings.ReplaceAll(bundleName, " ", "_")
+			i, ok := summaryIndex[key]
+			if !ok {
+				i = len(res.Summary)
+				summaryIndex[key] = i
+				res.Summary = append(res.Summary, dto.MerchPickupSummaryResponse{
+					MerchID:   line.MerchID,
+					MerchName: line.MerchName,
+					Size:      line.Size,
+				})
+			}
 
-		fileName := fmt.Sprintf("bundle_%s_%s.%s", time.Now().Format("060102150405"), bundleName, ext)
+			res.Owed++
+			res.Summary[i].Owed++
+			if line.PickedUp {
+				res.PickedUp++
+				res.Summary[i].PickedUp++
+				continue
+			}
 
-		saveDir := "assets/bundle"
-		if err := os.MkdirAll(saveDir, os.ModePerm); err != nil {
-			return dto.BundleResponse{}, dto.ErrCreateFile
+			res.Outstanding++
+			res.Summary[i].Outstanding++
+			res.Items = append(res.Items, line)
 		}
-		savePath := filepath.Join(saveDir, fileName)
+	}
 
-		out, err := os.Create(savePath)
-		if err != nil {
-			return dto.BundleResponse{}, dto.ErrCreateFile
+	sort.SliceStable(res.Summary, func(i, j int) bool {
+		if res.Summary[i].MerchName != res.Summary[j].MerchName {
+			return res.Summary[i].MerchName < res.Summary[j].MerchName
 		}
-		defer out.Close()
+		return res.Summary[i].Size < res.Summary[j].Size
+	})
 
-		if _, err := io.Copy(out, req.FileReader); err != nil {
-			return dto.BundleResponse{}, dto.ErrSaveFile
-		}
-		bundle.Image = fileName
-	}
+	return res, nil
+}
 
-	if req.Description != "" {
-		bundle.Description = req.Description
+// Dashboard Stats
+func (as *AdminService) GetAllStats(ctx context.Context) (dto.DashboardStatResponse, error) {
+	// Event Stats
+	events, err := as.adminRepo.GetAllEvent(ctx, nil)
+	if err != nil {
+		return dto.DashboardStatResponse{}, dto.ErrGetAllEventNoPagination
 	}
 
-	if req.EventDate != "" {
-		loc, err := time.LoadLocation("Asia/Jakarta")
-		if err != nil {
-			loc = time.FixedZone("UTC+7", 7*60*60)
-		}
-		eventDate, err := time.ParseInLocation("2006-01-02", req.EventDate, loc)
+	eventStats := make([]dto.EventStatResponse, 0, len(events))
+	for _, event := range events {
+		stat, err := as.adminRepo.GetEventStats(ctx, nil, event)
 		if err != nil {
-			return dto.BundleResponse{}, dto.ErrParseTime
+			return dto.DashboardStatResponse{}, dto.ErrGetAllEventStats
 		}
 
-		bundle.EventDate = eventDate
+		eventStats = append(eventStats, *stat)
 	}
 
-	updateItems := req.BundleItems != nil
+	// Total Bundle Type
+	totalBundleMerch, err := as.adminRepo.GetTotalBundle(ctx, nil, "bundle merch")
+	if err != nil {
+		return dto.DashboardStatResponse{}, dto.ErrGetTotalBundleMerch
+	}
+	totalBundleMerchTicket, err := as.adminRepo.GetTotalBundle(ctx, nil, "bundle merch ticket")
+	if err != nil {
+		return dto.DashboardStatResponse{}, dto.ErrGetTotalBundleMerchTicket
+	}
 
-	var newItems []dto.BundleItemResponse
-	if updateItems {
-		for _, biID := range req.BundleItems {
-			var item dto.BundleItemResponse
-			item.ID = uuid.New()
+	// Total Admin
+	totalAdmin, err := as.adminRepo.GetTotalAdmin(ctx, nil)
+	if err != nil {
+		return dto.DashboardStatResponse{}, dto.ErrGetTotalAdmin
+	}
 
-			merch, flag, err := as.adminRepo.GetMerchByID(ctx, nil, biID.String())
-			if flag || err == nil {
-				item.MerchID = &merch.ID
-				item.MerchName = merch.Name
-			}
+	// Guest Stats
+	guest, err := as.adminRepo.GetAllGuestStats(ctx, nil)
+	if err != nil {
+		return dto.DashboardStatResponse{}, dto.ErrGetAllGuestStats
+	}
 
-			newItems = append(newItems, item)
-		}
+	// Sponsor Stats
+	sponsor, err := as.adminRepo.GetTotalSponsor(ctx, nil, "sponsor")
+	if err != nil {
+		return dto.DashboardStatResponse{}, dto.ErrGetTotalSponsor
+	}
+	partner, err := as.adminRepo.GetTotalSponsor(ctx, nil, "partner")
+	if err != nil {
+		return dto.DashboardStatResponse{}, dto.ErrGetTotalPartner
+	}
+	mediaPartner, err := as.adminRepo.GetTotalSponsor(ctx, nil, "media partner")
+	if err != nil {
+		return dto.DashboardStatResponse{}, dto.ErrGetTotalMediaPartner
 	}
 
-	err = as.adminRepo.RunInTransaction(ctx, func(txRepo repository.IAdminRepository) error {
-		if err := txRepo.UpdateBundle(ctx, nil, bundle); err != nil {
-			return dto.ErrUpdateBundle
-		}
+	// Response
+	res := dto.DashboardStatResponse{
+		Events:                 eventStats,
+		TotalBundleMerch:       totalBundleMerch,
+		TotalBundleMerchTicket: totalBundleMerchTicket,
+		TotalAdmin:             totalAdmin,
+		Guest:                  *guest,
+		Sponsor:                sponsor,
+		Partner:                partner,
+		MediaPartner:           mediaPartner,
+	}
 
-		if updateItems {
-			if err := txRepo.DeleteBundleItemsByBundleID(ctx, nil, bundle.ID.String()); err != nil {
-				return dto.ErrDeleteBundleItemsByBundleID
-			}
+	return res, nil
+}
 
-			for _, bi := range newItems {
-				bundleItem := entity.BundleItem{
-					ID:       bi.ID,
-					BundleID: &bundle.ID,
-				}
+// Waitlist
+func (as *AdminService) GetAllWaitlist(ctx context.Context, filter dto.WaitlistFilterQuery) ([]dto.WaitlistResponse, error) {
+	if filter.Status != "" && !entity.IsValidWaitlistStatus(entity.WaitlistStatus(filter.Status)) {
+		return nil, dto.ErrInvalidWaitlistStatus
+	}
 
-				if bi.MerchID != nil {
-					bundleItem.MerchID = bi.MerchID
-				}
+	waitlists, err := as.adminRepo.GetAllWaitlist(ctx, nil, filter)
+	if err != nil {
+		return nil, dto.ErrGetAllWaitlistNoPagination
+	}
 
-				if err := txRepo.CreateBundleItem(ctx, nil, bundleItem); err != nil {
-					return dto.ErrCreateBundleItem
-				}
-			}
-		}
+	var datas []dto.WaitlistResponse
+	for _, waitlist := range waitlists {
+		datas = append(datas, toWaitlistResponse(waitlist))
+	}
 
-		return nil
-	})
-	if err != nil {
-		return dto.BundleResponse{}, err
+	return datas, nil
+}
+func (as *AdminService) GetAllWaitlistWithPagination(ctx context.Context, req dto.PaginationRequest, filter dto.WaitlistFilterQuery) (dto.WaitlistPaginationResponse, error) {
+	if filter.Status != "" && !entity.IsValidWaitlistStatus(entity.WaitlistStatus(filter.Status)) {
+		return dto.WaitlistPaginationResponse{}, dto.ErrInvalidWaitlistStatus
 	}
 
-	var respItems []dto.BundleItemResponse
-	if updateItems {
-		respItems = newItems
-	} else {
-		itemsEntity, err := as.adminRepo.GetBundleItemsByBundleID(ctx, nil, bundle.ID.String())
-		if err != nil || !flag {
-			return dto.BundleResponse{}, dto.ErrGetBundleItems
-		}
-		for _, e := range itemsEntity {
-			var biResp dto.BundleItemResponse
-			biResp.ID = e.ID
-			if e.MerchID != nil {
-				biResp.MerchID = e.MerchID
-				biResp.MerchName = e.Merch.Name
-			}
+	dataWithPaginate, err := as.adminRepo.GetAllWaitlistWithPagination(ctx, nil, req, filter)
+	if err != nil {
+		return dto.WaitlistPaginationResponse{}, dto.ErrGetAllWaitlistWithPagination
+	}
 
-			respItems = append(respItems, biResp)
-		}
+	var datas []dto.WaitlistResponse
+	for _, waitlist := range dataWithPaginate.Waitlists {
+		datas = append(datas, toWaitlistResponse(waitlist))
 	}
 
-	return dto.BundleResponse{
-		ID:          bundle.ID,
-		Name:        bundle.Name,
-		Image:       bundle.Image,
-		Type:        bundle.Type,
-		Price:       bundle.Price,
-		Quota:       bundle.Quota,
-		Description: bundle.Description,
-		EventDate:   bundle.EventDate.Format("2006-01-02"),
-		BundleItems: respItems,
+	return dto.WaitlistPaginationResponse{
+		Data: datas,
+		PaginationResponse: dto.PaginationResponse{
+			Page:    dataWithPaginate.Page,
+			PerPage: dataWithPaginate.PerPage,
+			MaxPage: dataWithPaginate.MaxPage,
+			Count:   dataWithPaginate.Count,
+		},
 	}, nil
 }
-func (as *AdminService) DeleteBundle(ctx context.Context, req dto.DeleteBundleRequest) (dto.BundleResponse, error) {
-	deletedBundle, _, err := as.adminRepo.GetBundleByID(ctx, nil, req.BundleID)
-	if err != nil {
-		return dto.BundleResponse{}, dto.ErrBundleNotFound
+func (as *AdminService) CancelWaitlist(ctx context.Context, waitlistID string) (dto.WaitlistResponse, error) {
+	waitlist, found, err := as.adminRepo.GetWaitlistByID(ctx, nil, waitlistID)
+	if err != nil || !found {
+		return dto.WaitlistResponse{}, dto.ErrWaitlistNotFound
 	}
 
+	if waitlist.Status != entity.WaitlistWaiting && waitlist.Status != entity.WaitlistOffered {
+		return dto.WaitlistResponse{}, dto.ErrWaitlistCannotBeCancelled
+	}
+
+	wasOffered := waitlist.Status == entity.WaitlistOffered
 	err = as.adminRepo.RunInTransaction(ctx, func(txRepo repository.IAdminRepository) error {
-		err = txRepo.DeleteBundleByID(ctx, nil, req.BundleID)
+		fromStatus := waitlist.Status
+		waitlist.Status = entity.WaitlistCancelled
+		updated, err := txRepo.UpdateWaitlist(ctx, nil, waitlist, fromStatus)
 		if err != nil {
-			return dto.ErrDeleteBundleByID
+			return dto.ErrUpdateWaitlist
+		}
+		if !updated {
+			// status sudah berubah (diklaim atau kedaluwarsa) sejak dibaca
+			return dto.ErrWaitlistCannotBeCancelled
 		}
 
-		if err = txRepo.DeleteBundleItemsByBundleID(ctx, nil, req.BundleID); err != nil {
-			return dto.ErrDeleteBundleItemsByBundleID
+		if wasOffered && waitlist.TicketID != nil {
+			if err := txRepo.RestoreTicketQuota(ctx, nil, waitlist.TicketID.String(), 1); err != nil {
+				return dto.ErrRestoreTicketQuota
+			}
 		}
 
 		return nil
 	})
-
-	b := dto.BundleResponse{
-		ID:          deletedBundle.ID,
-		Name:        deletedBundle.Name,
-		Image:       deletedBundle.Image,
-		Type:        deletedBundle.Type,
-		Price:       deletedBundle.Price,
-		Quota:       deletedBundle.Quota,
-		Description: deletedBundle.Description,
-		EventDate:   deletedBundle.EventDate.Format("2006-01-02"),
+	if err != nil {
+		return dto.WaitlistResponse{}, err
 	}
 
-	for _, bi := range deletedBundle.BundleItems {
-		bundleItem := dto.BundleItemResponse{
-			ID:        bi.ID,
-			MerchID:   bi.MerchID,
-			MerchName: bi.Merch.Name,
+	if wasOffered && waitlist.TicketID != nil {
+		if err := as.waitlistService.OfferAvailableSeats(ctx, waitlist.TicketID.String()); err != nil {
+			log.Printf("failed to offer waitlist seats for ticket %s: %v", waitlist.TicketID, err)
 		}
-
-		b.BundleItems = append(b.BundleItems, bundleItem)
 	}
 
-	return b, nil
+	return toWaitlistResponse(waitlist), nil
 }
-
-// User
-func (as *AdminService) CreateStudentAmbassador(ctx context.Context, req dto.CreateStudentAmbassadorRequest) (dto.StudentAmbassadorResponse, error) {
-	if req.Name == "" || req.ReferalCode == "" {
-		return dto.StudentAmbassadorResponse{}, dto.ErrEmptyFields
+func (as *AdminService) OfferWaitlist(ctx context.Context, ticketID string) error {
+	if _, found, err := as.adminRepo.GetTicketByID(ctx, nil, ticketID); err != nil || !found {
+		return dto.ErrTicketNotFound
 	}
 
-	_, flag, err := as.adminRepo.GetStudentAmbassadorByReferalCode(ctx, nil, req.ReferalCode)
-	if err == nil || flag {
-		return dto.StudentAmbassadorResponse{}, dto.ErrStudentAmbassadorAlreadyExists
+	if err := as.waitlistService.OfferAvailableSeats(ctx, ticketID); err != nil {
+		return dto.ErrOfferWaitlist
 	}
 
-	if len(req.Name) < 3 {
-		return dto.StudentAmbassadorResponse{}, dto.ErrStudentAmbassadorNameTooShort
-	}
+	return nil
+}
 
-	studentAmbassador := entity.StudentAmbassador{
-		ID:          uuid.New(),
-		Name:        req.Name,
-		ReferalCode: req.ReferalCode,
-		Discount:    req.Discount,
-		MaxReferal:  req.MaxReferal,
+// Ticket Transfer
+func (as *AdminService) GetTicketTransferHistory(ctx context.Context, ticketFormID string) ([]dto.TicketTransferResponse, error) {
+	ticketForm, found, err := as.adminRepo.GetTicketFormByID(ctx, nil, ticketFormID)
+	if err != nil || !found {
+		return nil, dto.ErrTicketFormNotFound
 	}
 
-	err = as.adminRepo.CreateStudentAmbassador(ctx, nil, studentAmbassador)
-	if err != nil {
-		return dto.StudentAmbassadorResponse{}, dto.ErrCreateStudentAmbassador
+	originID := ticketForm.ID.String()
+	if previous, found, _ := as.adminRepo.GetTicketTransferByToTicketFormID(ctx, nil, ticketFormID); found && previous.OriginTicketFormID != nil {
+		originID = previous.OriginTicketFormID.String()
 	}
 
-	return dto.StudentAmbassadorResponse{
-		ID:          studentAmbassador.ID,
-		Name:        studentAmbassador.Name,
-		ReferalCode: studentAmbassador.ReferalCode,
-		Discount:    studentAmbassador.Discount,
-		MaxReferal:  studentAmbassador.MaxReferal,
-	}, nil
-}
-func (as *AdminService) GetAllStudentAmbassador(ctx context.Context) ([]dto.StudentAmbassadorResponse, error) {
-	studentAmbassadors, err := as.adminRepo.GetAllStudentAmbassador(ctx, nil)
+	ticketTransfers, err := as.adminRepo.GetAllTicketTransferByOriginTicketFormID(ctx, nil, originID)
 	if err != nil {
-		return nil, dto.ErrGetAllStudentAmbassadorNoPagination
+		return nil, dto.ErrGetTicketTransferHistory
 	}
 
-	var datas []dto.StudentAmbassadorResponse
-	for _, studentAmbassador := range studentAmbassadors {
-		data := dto.StudentAmbassadorResponse{
-			ID:          studentAmbassador.ID,
-			Name:        studentAmbassador.Name,
-			ReferalCode: studentAmbassador.ReferalCode,
-			Discount:    studentAmbassador.Discount,
-			MaxReferal:  studentAmbassador.MaxReferal,
-		}
-
-		datas = append(datas, data)
+	var datas []dto.TicketTransferResponse
+	for _, ticketTransfer := range ticketTransfers {
+		datas = append(datas, toTicketTransferResponse(ticketTransfer))
 	}
 
 	return datas, nil
 }
-func (as *AdminService) GetAllStudentAmbassadorWithPagination(ctx context.Context, req dto.PaginationRequest) (dto.StudentAmbassadorPaginationResponse, error) {
-	dataWithPaginate, err := as.adminRepo.GetAllStudentAmbassadorWithPagination(ctx, nil, req)
-	if err != nil {
-		return dto.StudentAmbassadorPaginationResponse{}, dto.ErrGetAllStudentAmbassadorWithPagination
+
+// Email Outbox
+func toEmailOutboxResponse(outbox entity.EmailOutbox) dto.EmailOutboxResponse {
+	return dto.EmailOutboxResponse{
+		ID:            outbox.ID,
+		Kind:          outbox.Kind,
+		ToEmail:       outbox.ToEmail,
+		Subject:       outbox.Subject,
+		Status:        outbox.Status,
+		Attempts:      outbox.Attempts,
+		MaxAttempts:   outbox.MaxAttempts,
+		NextAttemptAt: outbox.NextAttemptAt,
+		LastError:     outbox.LastError,
+		SentAt:        outbox.SentAt,
+		TicketFormID:  outbox.TicketFormID,
+		RequestedBy:   outbox.RequestedBy,
+		BroadcastID:   outbox.BroadcastID,
+		CreatedAt:     outbox.CreatedAt,
+	}
+}
+func (as *AdminService) GetAllEmailOutboxWithPagination(ctx context.Context, req dto.PaginationRequest, filter dto.EmailOutboxFilterQuery) (dto.EmailOutboxPaginationResponse, error) {
+	if filter.Status != "" && !entity.IsValidEmailOutboxStatus(entity.EmailOutboxStatus(filter.Status)) {
+		return dto.EmailOutboxPaginationResponse{}, dto.ErrInvalidEmailOutboxStatus
 	}
 
-	var datas []dto.StudentAmbassadorResponse
-	for _, studentAmbassador := range dataWithPaginate.StudentAmbassadors {
-		data := dto.StudentAmbassadorResponse{
-			ID:          studentAmbassador.ID,
-			Name:        studentAmbassador.Name,
-			ReferalCode: studentAmbassador.ReferalCode,
-			Discount:    studentAmbassador.Discount,
-			MaxReferal:  studentAmbassador.MaxReferal,
-		}
+	dataWithPaginate, err := as.adminRepo.GetAllEmailOutboxWithPagination(ctx, nil, req, filter)
+	if err != nil {
+		return dto.EmailOutboxPaginationResponse{}, dto.ErrGetAllEmailOutboxWithPagination
+	}
 
-		datas = append(datas, data)
+	var datas []dto.EmailOutboxResponse
+	for _, outbox := range dataWithPaginate.EmailOutboxes {
+		datas = append(datas, toEmailOutboxResponse(outbox))
 	}
 
-	return dto.StudentAmbassadorPaginationResponse{
+	return dto.EmailOutboxPaginationResponse{
 		Data: datas,
 		PaginationResponse: dto.PaginationResponse{
 			Page:    dataWithPaginate.Page,
@@ -1993,541 +5617,585 @@ func (as *AdminService) GetAllStudentAmbassadorWithPagination(ctx context.Contex
 		},
 	}, nil
 }
-func (as *AdminService) GetDetailStudentAmbassador(ctx context.Context, studentAmbassadorID string) (dto.StudentAmbassadorResponse, error) {
-	studentAmbassador, _, err := as.adminRepo.GetStudentAmbassadorByID(ctx, nil, studentAmbassadorID)
-	if err != nil {
-		return dto.StudentAmbassadorResponse{}, dto.ErrStudentAmbassadorNotFound
+func (as *AdminService) RetryEmailOutbox(ctx context.Context, outboxID string) (dto.EmailOutboxResponse, error) {
+	outbox, found, err := as.adminRepo.GetEmailOutboxByID(ctx, nil, outboxID)
+	if err != nil || !found {
+		return dto.EmailOutboxResponse{}, dto.ErrEmailOutboxNotFound
 	}
 
-	return dto.StudentAmbassadorResponse{
-		ID:          studentAmbassador.ID,
-		Name:        studentAmbassador.Name,
-		ReferalCode: studentAmbassador.ReferalCode,
-		Discount:    studentAmbassador.Discount,
-		MaxReferal:  studentAmbassador.MaxReferal,
-	}, nil
-}
-func (as *AdminService) UpdateStudentAmbassador(ctx context.Context, req dto.UpdateStudentAmbassadorRequest) (dto.StudentAmbassadorResponse, error) {
-	studentAmbassador, flag, err := as.adminRepo.GetStudentAmbassadorByID(ctx, nil, req.ID)
-	if err != nil || !flag {
-		return dto.StudentAmbassadorResponse{}, dto.ErrStudentAmbassadorNotFound
+	if outbox.Status != entity.EmailOutboxFailed && outbox.Status != entity.EmailOutboxDead {
+		return dto.EmailOutboxResponse{}, dto.ErrEmailOutboxNotRetryable
 	}
 
-	if req.Name != "" {
-		if len(req.Name) < 3 {
-			return dto.StudentAmbassadorResponse{}, dto.ErrStudentAmbassadorNameTooShort
-		}
-
-		studentAmbassador.Name = req.Name
+	// Percobaan direset supaya email dead mendapat jatah retry penuh lagi.
+	now := time.Now()
+	if err := as.adminRepo.RetryEmailOutbox(ctx, nil, outboxID, now); err != nil {
+		return dto.EmailOutboxResponse{}, dto.ErrRetryEmailOutbox
 	}
 
-	if req.ReferalCode != "" {
-		_, flag, err := as.adminRepo.GetStudentAmbassadorByReferalCode(ctx, nil, req.ReferalCode)
-		if err == nil || flag {
-			return dto.StudentAmbassadorResponse{}, dto.ErrReferalCodeAlreadyExists
-		}
+	outbox.Status = entity.EmailOutboxPending
+	outbox.Attempts = 0
+	outbox.NextAttemptAt = now
+	outbox.LockedUntil = nil
 
-		studentAmbassador.ReferalCode = req.ReferalCode
-	}
+	return toEmailOutboxResponse(outbox), nil
+}
 
-	if req.Discount != nil {
-		studentAmbassador.Discount = *req.Discount
+// Notification Outbox
+func toNotificationOutboxResponse(outbox entity.NotificationOutbox) dto.NotificationOutboxResponse {
+	return dto.NotificationOutboxResponse{
+		ID:                outbox.ID,
+		Channel:           outbox.Channel,
+		Kind:              outbox.Kind,
+		ToPhone:           outbox.ToPhone,
+		Body:              outbox.Body,
+		Status:            outbox.Status,
+		Attempts:          outbox.Attempts,
+		MaxAttempts:       outbox.MaxAttempts,
+		NextAttemptAt:     outbox.NextAttemptAt,
+		LastError:         outbox.LastError,
+		ProviderMessageID: outbox.ProviderMessageID,
+		SentAt:            outbox.SentAt,
+		TicketFormID:      outbox.TicketFormID,
+		CreatedAt:         outbox.CreatedAt,
+	}
+}
+func (as *AdminService) GetAllNotificationOutboxWithPagination(ctx context.Context, req dto.PaginationRequest, filter dto.NotificationOutboxFilterQuery) (dto.NotificationOutboxPaginationResponse, error) {
+	if filter.Status != "" && !entity.IsValidNotificationOutboxStatus(entity.NotificationOutboxStatus(filter.Status)) {
+		return dto.NotificationOutboxPaginationResponse{}, dto.ErrInvalidNotificationOutboxStatus
 	}
 
-	if req.MaxReferal != nil {
-		studentAmbassador.MaxReferal = *req.MaxReferal
+	if filter.Kind != "" && !entity.IsValidNotificationKind(entity.NotificationKind(filter.Kind)) {
+		return dto.NotificationOutboxPaginationResponse{}, dto.ErrInvalidNotificationKind
 	}
 
-	err = as.adminRepo.UpdateStudentAmbassador(ctx, nil, studentAmbassador)
+	dataWithPaginate, err := as.adminRepo.GetAllNotificationOutboxWithPagination(ctx, nil, req, filter)
 	if err != nil {
-		return dto.StudentAmbassadorResponse{}, dto.ErrUpdateStudentAmbassador
+		return dto.NotificationOutboxPaginationResponse{}, dto.ErrGetAllNotificationOutboxWithPagination
 	}
 
-	res := dto.StudentAmbassadorResponse{
-		ID:          studentAmbassador.ID,
-		Name:        studentAmbassador.Name,
-		ReferalCode: studentAmbassador.ReferalCode,
-		Discount:    studentAmbassador.Discount,
-		MaxReferal:  studentAmbassador.MaxReferal,
+	var datas []dto.NotificationOutboxResponse
+	for _, outbox := range dataWithPaginate.NotificationOutboxes {
+		datas = append(datas, toNotificationOutboxResponse(outbox))
 	}
 
-	return res, nil
+	return dto.NotificationOutboxPaginationResponse{
+		Data: datas,
+		PaginationResponse: dto.PaginationResponse{
+			Page:    dataWithPaginate.Page,
+			PerPage: dataWithPaginate.PerPage,
+			MaxPage: dataWithPaginate.MaxPage,
+			Count:   dataWithPaginate.Count,
+		},
+	}, nil
 }
-func (as *AdminService) DeleteStudentAmbassador(ctx context.Context, req dto.DeleteStudentAmbassadorRequest) (dto.StudentAmbassadorResponse, error) {
-	deletedStudentAmbassador, _, err := as.adminRepo.GetStudentAmbassadorByID(ctx, nil, req.StudentAmbassadorID)
-	if err != nil {
-		return dto.StudentAmbassadorResponse{}, dto.ErrStudentAmbassadorNotFound
+func (as *AdminService) RetryNotificationOutbox(ctx context.Context, outboxID string) (dto.NotificationOutboxResponse, error) {
+	outbox, found, err := as.adminRepo.GetNotificationOutboxByID(ctx, nil, outboxID)
+	if err != nil || !found {
+		return dto.NotificationOutboxResponse{}, dto.ErrNotificationOutboxNotFound
 	}
 
-	err = as.adminRepo.DeleteStudentAmbassadorByID(ctx, nil, req.StudentAmbassadorID)
-	if err != nil {
-		return dto.StudentAmbassadorResponse{}, dto.ErrDeleteStudentAmbassadorByID
+	if outbox.Status != entity.NotificationOutboxFailed && outbox.Status != entity.NotificationOutboxDead {
+		return dto.NotificationOutboxResponse{}, dto.ErrNotificationOutboxNotRetryable
 	}
 
-	res := dto.StudentAmbassadorResponse{
-		ID:          deletedStudentAmbassador.ID,
-		Name:        deletedStudentAmbassador.Name,
-		ReferalCode: deletedStudentAmbassador.ReferalCode,
-		Discount:    deletedStudentAmbassador.Discount,
-		MaxReferal:  deletedStudentAmbassador.MaxReferal,
+	now := time.Now()
+	if err := as.adminRepo.RetryNotificationOutbox(ctx, nil, outboxID, now); err != nil {
+		return dto.NotificationOutboxResponse{}, dto.ErrRetryNotificationOutbox
 	}
 
-	return res, nil
+	outbox.Status = entity.NotificationOutboxPending
+	outbox.Attempts = 0
+	outbox.NextAttemptAt = now
+	outbox.LockedUntil = nil
+
+	return toNotificationOutboxResponse(outbox), nil
 }
 
-// Transaction & Ticket Form
-func (as *AdminService) CreateTransactionTicket(ctx context.Context, req dto.CreateTransactionTicketRequest) (dto.TransactionResponse, error) {
-	if len(req.TicketForms) == 0 {
-		return dto.TransactionResponse{}, dto.ErrEmptyTicketForms
+// Email Template
+func toEmailTemplateResponse(emailTemplate entity.EmailTemplate) dto.EmailTemplateResponse {
+	res := dto.EmailTemplateResponse{
+		Key:       emailTemplate.Key,
+		Subject:   emailTemplate.Subject,
+		HTML:      emailTemplate.HTML,
+		Text:      emailTemplate.Text,
+		Version:   emailTemplate.Version,
+		IsDefault: emailTemplate.ID == uuid.Nil,
+	}
+	if !res.IsDefault {
+		res.ID = &emailTemplate.ID
 	}
 
-	token := ctx.Value("Authorization").(string)
+	return res
+}
 
-	userIDStr, err := as.jwtService.GetUserIDByToken(token)
-	if err != nil {
-		return dto.TransactionResponse{}, dto.ErrGetUserIDFromToken
+// validateEmailTemplate merender template dengan data contoh supaya field yang salah ketahuan saat disimpan.
+func validateEmailTemplate(emailTemplate entity.EmailTemplate) error {
+	if _, err := renderEmailTemplate(emailTemplate, sampleEmailTemplateData(emailTemplate.Key)); err != nil {
+		return fmt.Errorf("%w: %v", dto.ErrInvalidEmailTemplate, err)
 	}
 
-	userID, err := uuid.Parse(userIDStr)
-	if err != nil {
-		return dto.TransactionResponse{}, dto.ErrParseUUID
+	return nil
+}
+func (as *AdminService) CreateEmailTemplate(ctx context.Context, req dto.CreateEmailTemplateRequest) (dto.EmailTemplateResponse, error) {
+	if req.Key == "" || req.Subject == "" || req.HTML == "" {
+		return dto.EmailTemplateResponse{}, dto.ErrEmptyFields
 	}
 
-	var transactionResponse dto.TransactionResponse
-	err = as.adminRepo.RunInTransaction(ctx, func(txRepo repository.IAdminRepository) error {
-		if !entity.IsValidItemType(req.ItemType) && req.ItemType != constants.ENUM_TICKET_ITEM_TYPE {
-			return dto.ErrItemTypeMustBeTicket
-		}
-
-		ticket, found, err := txRepo.GetTicketByID(ctx, nil, req.TicketID.String())
-		if err != nil || !found {
-			return dto.ErrTicketNotFound
-		}
+	if !entity.IsValidEmailTemplateKey(req.Key) {
+		return dto.EmailTemplateResponse{}, dto.ErrInvalidEmailTemplateKey
+	}
 
-		if ticket.Type != "main-event" {
-			return dto.ErrTicketTypeMustBeMainEvent
-		}
+	_, flag, err := as.adminRepo.GetEmailTemplateByKey(ctx, nil, string(req.Key))
+	if err == nil || flag {
+		return dto.EmailTemplateResponse{}, dto.ErrEmailTemplateAlreadyExists
+	}
 
-		if ticket.Quota <= 0 {
-			return dto.ErrTicketSoldOut
-		}
+	emailTemplate := entity.EmailTemplate{
+		ID:      uuid.New(),
+		Key:     req.Key,
+		Subject: req.Subject,
+		HTML:    req.HTML,
+		Text:    req.Text,
+		Version: 1,
+	}
 
-		transactionID := uuid.New()
-		orderID := fmt.Sprintf("TEDX-%s", time.Now().Format("060102150405"))
+	if err := validateEmailTemplate(emailTemplate); err != nil {
+		return dto.EmailTemplateResponse{}, err
+	}
 
-		now := time.Now()
+	err = as.adminRepo.CreateEmailTemplate(ctx, nil, emailTemplate)
+	if err != nil {
+		return dto.EmailTemplateResponse{}, dto.ErrCreateEmailTemplate
+	}
 
-		transaction := entity.Transaction{
-			ID:                transactionID,
-			OrderID:           orderID,
-			ItemType:          req.ItemType,
-			TransactionStatus: "settlement",
-			PaymentType:       "invitation",
-			SettlementTime:    &now,
-			UserID:            &userID,
-			TicketID:          req.TicketID,
-		}
+	return toEmailTemplateResponse(emailTemplate), nil
+}
 
-		if err := txRepo.CreateTransaction(ctx, nil, transaction); err != nil {
-			return dto.ErrCreateTransaction
-		}
+// GetAllEmailTemplate juga menampilkan template bawaan yang belum punya versi di database.
+func (as *AdminService) GetAllEmailTemplate(ctx context.Context) ([]dto.EmailTemplateResponse, error) {
+	emailTemplates, err := as.adminRepo.GetAllEmailTemplate(ctx, nil)
+	if err != nil {
+		return nil, dto.ErrGetAllEmailTemplate
+	}
 
-		for _, form := range req.TicketForms {
-			if form.AudienceType == "" || form.Instansi == "" || form.Email == "" || form.FullName == "" || form.PhoneNumber == "" {
-				return dto.ErrEmptyFields
-			}
+	stored := make(map[string]bool)
+	var datas []dto.EmailTemplateResponse
+	for _, emailTemplate := range emailTemplates {
+		stored[string(emailTemplate.Key)] = true
+		datas = append(datas, toEmailTemplateResponse(emailTemplate))
+	}
 
-			if !entity.IsValidAudienceType(form.AudienceType) || form.AudienceType != "invited" {
-				return dto.ErrMustBeInvitedGuest
-			}
+	keys := make([]string, 0, len(emailtemplate.Defaults))
+	for key := range emailtemplate.Defaults {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
 
-			if !entity.IsValidInstansi(form.Instansi) {
-				return dto.ErrInvalidInstansi
-			}
+	for _, key := range keys {
+		if stored[key] {
+			continue
+		}
 
-			if !helpers.IsValidEmail(form.Email) {
-				return dto.ErrInvalidEmail
-			}
+		fallback := emailtemplate.Defaults[key]
+		datas = append(datas, toEmailTemplateResponse(entity.EmailTemplate{
+			Key:     entity.EmailTemplateKey(key),
+			Subject: fallback.Subject,
+			HTML:    fallback.HTML,
+			Text:    fallback.Text,
+		}))
+	}
 
-			if len(form.FullName) < 5 {
-				return dto.ErrUserFullNameTooShort
-			}
+	return datas, nil
+}
+func (as *AdminService) GetDetailEmailTemplate(ctx context.Context, emailTemplateID string) (dto.EmailTemplateResponse, error) {
+	emailTemplate, flag, err := as.adminRepo.GetEmailTemplateByID(ctx, nil, emailTemplateID)
+	if err != nil || !flag {
+		return dto.EmailTemplateResponse{}, dto.ErrEmailTemplateNotFound
+	}
 
-			formattedPhone, err := helpers.StandardizePhoneNumber(form.PhoneNumber)
-			if err != nil {
-				return dto.ErrInvalidPhoneNumber
-			}
+	return toEmailTemplateResponse(emailTemplate), nil
+}
+func (as *AdminService) UpdateEmailTemplate(ctx context.Context, req dto.UpdateEmailTemplateRequest) (dto.EmailTemplateResponse, error) {
+	emailTemplate, flag, err := as.adminRepo.GetEmailTemplateByID(ctx, nil, req.ID)
+	if err != nil || !flag {
+		return dto.EmailTemplateResponse{}, dto.ErrEmailTemplateNotFound
+	}
 
-			ticketFormID := uuid.New()
-			ticketForm := entity.TicketForm{
-				ID:            ticketFormID,
-				AudienceType:  form.AudienceType,
-				Instansi:      form.Instansi,
-				Email:         form.Email,
-				FullName:      form.FullName,
-				PhoneNumber:   formattedPhone,
-				LineID:        form.LineID,
-				TransactionID: &transactionID,
-			}
+	if req.Subject != "" {
+		emailTemplate.Subject = req.Subject
+	}
 
-			if err := txRepo.UpdateTicketQuota(ctx, nil, ticket.ID.String(), ticket.Quota-len(req.TicketForms)); err != nil {
-				return dto.ErrUpdateTicket
-			}
-			if err := txRepo.CreateTicketForm(ctx, nil, ticketForm); err != nil {
-				return dto.ErrCreateTicketForm
-			}
+	if req.HTML != "" {
+		emailTemplate.HTML = req.HTML
+	}
 
-			qrURL, err := helpers.GenerateQRCodeFile(ticketForm.ID.String(), ticketForm.ID.String()+".png")
-			if err != nil {
-				return dto.ErrGenerateQRCode
-			}
+	if req.Text != nil {
+		emailTemplate.Text = *req.Text
+	}
 
-			headerImage := fmt.Sprintf("%s/assets_static/header-e-ticket-mail.png", os.Getenv("BASE_URL"))
-			emailData := struct {
-				HeaderImage  string
-				TicketID     string
-				Status       string
-				AttendeeName string
-				Email        string
-				AudienceType string
-				BookingDate  string
-				Price        string
-				QRCode       string
-			}{
-				HeaderImage:  headerImage,
-				TicketID:     transaction.ID.String(),
-				Status:       "settlement",
-				AttendeeName: ticketForm.FullName,
-				Email:        ticketForm.Email,
-				AudienceType: string(ticketForm.AudienceType),
-				BookingDate:  time.Now().Format("02 Jan 2006 15:04"),
-				Price:        "Rp 0",
-				QRCode:       qrURL,
-			}
+	if err := validateEmailTemplate(emailTemplate); err != nil {
+		return dto.EmailTemplateResponse{}, err
+	}
 
-			draftEmail, err := makeETicketEmail(emailData)
-			if err != nil {
-				return fmt.Errorf("error : %s", err)
-			}
+	emailTemplate.Version++
+	err = as.adminRepo.UpdateEmailTemplate(ctx, nil, emailTemplate)
+	if err != nil {
+		return dto.EmailTemplateResponse{}, dto.ErrUpdateEmailTemplate
+	}
 
-			err = utils.SendEmail(emailData.Email, draftEmail["subject"], draftEmail["body"])
-			if err != nil {
-				return fmt.Errorf("error : %s", err)
-			}
+	return toEmailTemplateResponse(emailTemplate), nil
+}
 
-			transactionResponse.TicketForms = append(transactionResponse.TicketForms, dto.TicketFormResponse{
-				ID:           ticketFormID,
-				AudienceType: ticketForm.AudienceType,
-				Instansi:     ticketForm.Instansi,
-				Email:        ticketForm.Email,
-				FullName:     ticketForm.FullName,
-				PhoneNumber:  ticketForm.PhoneNumber,
-				LineID:       ticketForm.LineID,
-			})
-		}
-		transactionResponse.ID = transactionID
-		transactionResponse.OrderID = transaction.OrderID
-		transactionResponse.ItemType = transaction.ItemType
-		transactionResponse.TicketType = ticket.Type
-		transactionResponse.TransactionStatus = transaction.TransactionStatus
-		transactionResponse.PaymentType = transaction.PaymentType
-		transactionResponse.SettlementTime = transaction.SettlementTime
-		transactionResponse.UserID = transaction.UserID
-		transactionResponse.TicketID = transaction.TicketID
+// DeleteEmailTemplate mengembalikan key tersebut ke template bawaan.
+func (as *AdminService) DeleteEmailTemplate(ctx context.Context, emailTemplateID string) (dto.EmailTemplateResponse, error) {
+	deletedTemplate, flag, err := as.adminRepo.GetEmailTemplateByID(ctx, nil, emailTemplateID)
+	if err != nil || !flag {
+		return dto.EmailTemplateResponse{}, dto.ErrEmailTemplateNotFound
+	}
 
-		return nil
-	})
+	err = as.adminRepo.DeleteEmailTemplateByID(ctx, nil, emailTemplateID)
 	if err != nil {
-		return dto.TransactionResponse{}, err
+		return dto.EmailTemplateResponse{}, dto.ErrDeleteEmailTemplateByID
 	}
 
-	return transactionResponse, nil
+	return toEmailTemplateResponse(deletedTemplate), nil
 }
-func (as *AdminService) GetAllTransactionTicket(ctx context.Context, transactionStatus, ticketCategory string) ([]dto.TransactionResponse, error) {
-	transactions, err := as.adminRepo.GetAllTransaction(ctx, nil, transactionStatus, ticketCategory)
+func (as *AdminService) PreviewEmailTemplate(ctx context.Context, req dto.PreviewEmailTemplateRequest) (dto.EmailTemplatePreviewResponse, error) {
+	if !entity.IsValidEmailTemplateKey(req.Key) {
+		return dto.EmailTemplatePreviewResponse{}, dto.ErrInvalidEmailTemplateKey
+	}
+
+	emailTemplate, err := as.emailTemplateService.Resolve(ctx, req.Key)
 	if err != nil {
-		return nil, dto.ErrGetAllTransactionNoPagination
+		return dto.EmailTemplatePreviewResponse{}, err
 	}
 
-	var datas []dto.TransactionResponse
-	for _, transaction := range transactions {
-		data := dto.TransactionResponse{
-			ID:                transaction.ID,
-			OrderID:           transaction.OrderID,
-			ItemType:          transaction.ItemType,
-			TicketType:        entity.TicketType(transaction.Ticket.Type),
-			ReferalCode:       transaction.ReferalCode,
-			TransactionStatus: transaction.TransactionStatus,
-			PaymentType:       transaction.PaymentType,
-			SignatureKey:      transaction.SignatureKey,
-			Acquire:           transaction.Acquire,
-			SettlementTime:    transaction.SettlementTime,
-			GrossAmount:       transaction.GrossAmount,
-			UserID:            transaction.UserID,
-			TicketID:          transaction.TicketID,
-			BundleID:          transaction.BundleID,
+	if req.Subject != "" {
+		emailTemplate.Subject = req.Subject
+	}
+
+	if req.HTML != "" {
+		emailTemplate.HTML = req.HTML
+	}
+
+	if req.Text != "" {
+		emailTemplate.Text = req.Text
+	}
+
+	data := sampleEmailTemplateData(req.Key)
+	if req.TicketFormID != "" && req.Key != entity.EmailTemplateWaitlistOffer && req.Key != entity.EmailTemplateBroadcast && req.Key != entity.EmailTemplateVerification && req.Key != entity.EmailTemplatePasswordReset {
+		ticketForm, found, err := as.adminRepo.GetTicketFormByID(ctx, nil, req.TicketFormID)
+		if err != nil || !found {
+			return dto.EmailTemplatePreviewResponse{}, dto.ErrTicketFormNotFound
 		}
 
-		for _, ticketForm := range transaction.TicketForms {
-			data.TicketForms = append(data.TicketForms, dto.TicketFormResponse{
-				ID:           ticketForm.ID,
-				AudienceType: ticketForm.AudienceType,
-				Instansi:     ticketForm.Instansi,
-				Email:        ticketForm.Email,
-				FullName:     ticketForm.FullName,
-				PhoneNumber:  ticketForm.PhoneNumber,
-				LineID:       ticketForm.LineID,
-			})
+		// Preview tidak membuat file QR baru; pakai URL QR yang sudah terkirim.
+		data = toETicketEmailData(ticketForm.Transaction, ticketForm, ticketQRLink(ticketForm))
+
+		if req.Key == entity.EmailTemplateReminder {
+			data = toReminderEmailData(ticketForm.Transaction, ticketForm, ticketQRLink(ticketForm))
+		}
+
+		if req.Key == entity.EmailTemplatePurchaseReceipt {
+			transaction, found, err := as.adminRepo.GetTransactionByID(ctx, nil, ticketForm.Transaction.ID.String())
+			if err != nil || !found {
+				return dto.EmailTemplatePreviewResponse{}, dto.ErrTransactionNotFound
+			}
+
+			var attendees []purchaseReceiptAttendee
+			for _, form := range transaction.TicketForms {
+				attendees = append(attendees, toPurchaseReceiptAttendee(form, ticketQRLink(form)))
+			}
+			data = toPurchaseReceiptEmailData(transaction, attendees)
 		}
+	}
+
+	draftEmail, err := renderEmailTemplate(emailTemplate, data)
+	if err != nil {
+		return dto.EmailTemplatePreviewResponse{}, fmt.Errorf("%w: %v", dto.ErrInvalidEmailTemplate, err)
+	}
+
+	return dto.EmailTemplatePreviewResponse{
+		Subject: draftEmail["subject"],
+		HTML:    draftEmail["body"],
+		Text:    draftEmail["text"],
+	}, nil
+}
 
-		datas = append(datas, data)
+// Resend E-Ticket
+func (as *AdminService) ResendETicket(ctx context.Context, req dto.ResendETicketRequest) (dto.ResendETicketResponse, error) {
+	token := ctx.Value("Authorization").(string)
+
+	userIDStr, err := as.jwtService.GetUserIDByToken(token)
+	if err != nil {
+		return dto.ResendETicketResponse{}, dto.ErrGetUserIDFromToken
 	}
 
-	return datas, nil
-}
-func (as *AdminService) GetAllTransactionTicketWithPagination(ctx context.Context, req dto.PaginationRequest, transactionStatus, ticketCategory string) (dto.TransactionTicketPaginationResponse, error) {
-	dataWithPaginate, err := as.adminRepo.GetAllTransactionWithPagination(ctx, nil, req, transactionStatus, ticketCategory)
+	userID, err := uuid.Parse(userIDStr)
 	if err != nil {
-		return dto.TransactionTicketPaginationResponse{}, dto.ErrGetAllUserWithPagination
+		return dto.ResendETicketResponse{}, dto.ErrParseUUID
 	}
 
-	var datas []dto.TransactionResponse
-	for _, transaction := range dataWithPaginate.Transactions {
-		data := dto.TransactionResponse{
-			ID:                transaction.ID,
-			OrderID:           transaction.OrderID,
-			ItemType:          transaction.ItemType,
-			TicketType:        entity.TicketType(transaction.Ticket.Type),
-			ReferalCode:       transaction.ReferalCode,
-			TransactionStatus: transaction.TransactionStatus,
-			PaymentType:       transaction.PaymentType,
-			SignatureKey:      transaction.SignatureKey,
-			Acquire:           transaction.Acquire,
-			SettlementTime:    transaction.SettlementTime,
-			GrossAmount:       transaction.GrossAmount,
-			UserID:            transaction.UserID,
-			TicketID:          transaction.TicketID,
-			BundleID:          transaction.BundleID,
+	if req.Email != "" {
+		if req.TicketFormID == "" {
+			return dto.ResendETicketResponse{}, dto.ErrResendEmailNeedsTicketForm
 		}
 
-		for _, ticketForm := range transaction.TicketForms {
-			data.TicketForms = append(data.TicketForms, dto.TicketFormResponse{
-				ID:           ticketForm.ID,
-				AudienceType: ticketForm.AudienceType,
-				Instansi:     ticketForm.Instansi,
-				Email:        ticketForm.Email,
-				FullName:     ticketForm.FullName,
-				PhoneNumber:  ticketForm.PhoneNumber,
-				LineID:       ticketForm.LineID,
-			})
+		if !helpers.IsValidEmail(req.Email) {
+			return dto.ResendETicketResponse{}, dto.ErrInvalidEmail
 		}
+	}
 
-		datas = append(datas, data)
+	transaction, found, err := as.adminRepo.GetTransactionByID(ctx, nil, req.TransactionID)
+	if err != nil || !found {
+		return dto.ResendETicketResponse{}, dto.ErrTransactionNotFound
 	}
 
-	return dto.TransactionTicketPaginationResponse{
-		Data: datas,
-		PaginationResponse: dto.PaginationResponse{
-			Page:    dataWithPaginate.Page,
-			PerPage: dataWithPaginate.PerPage,
-			MaxPage: dataWithPaginate.MaxPage,
-			Count:   dataWithPaginate.Count,
-		},
-	}, nil
-}
-func (as *AdminService) GetDetailTransactionTicket(ctx context.Context, transactionTicketID string) (dto.TransactionResponse, error) {
-	transaction, _, err := as.adminRepo.GetTransactionByID(ctx, nil, transactionTicketID)
+	forms, err := resendableTicketForms(transaction, req.TicketFormID)
 	if err != nil {
-		return dto.TransactionResponse{}, dto.ErrTicketFormNotFound
+		return dto.ResendETicketResponse{}, err
 	}
 
-	res := dto.TransactionResponse{
-		ID:                transaction.ID,
-		OrderID:           transaction.OrderID,
-		ItemType:          transaction.ItemType,
-		TicketType:        entity.TicketType(transaction.Ticket.Type),
-		ReferalCode:       transaction.ReferalCode,
-		TransactionStatus: transaction.TransactionStatus,
-		PaymentType:       transaction.PaymentType,
-		SignatureKey:      transaction.SignatureKey,
-		Acquire:           transaction.Acquire,
-		SettlementTime:    transaction.SettlementTime,
-		GrossAmount:       transaction.GrossAmount,
-		UserID:            transaction.UserID,
-		TicketID:          transaction.TicketID,
-		BundleID:          transaction.BundleID,
-	}
+	res := dto.ResendETicketResponse{TransactionID: transaction.ID}
+	err = as.adminRepo.RunInTransaction(ctx, func(txRepo repository.IAdminRepository) error {
+		for _, form := range forms {
+			// email yang dikoreksi juga disimpan supaya check-in dan email berikutnya memakai alamat yang benar
+			if req.Email != "" && req.Email != form.Email {
+				if err := txRepo.UpdateTicketFormEmail(ctx, nil, form.ID.String(), req.Email); err != nil {
+					return dto.ErrUpdateTicketForm
+				}
+				form.Email = req.Email
+			}
 
-	for _, ticketForm := range transaction.TicketForms {
-		res.TicketForms = append(res.TicketForms, dto.TicketFormResponse{
-			ID:           ticketForm.ID,
-			AudienceType: ticketForm.AudienceType,
-			Instansi:     ticketForm.Instansi,
-			Email:        ticketForm.Email,
-			FullName:     ticketForm.FullName,
-			PhoneNumber:  ticketForm.PhoneNumber,
-			LineID:       ticketForm.LineID,
-		})
+			outbox, err := newETicketEmail(ctx, as.emailTemplateService, transaction, form)
+			if err != nil {
+				return err
+			}
+
+			outbox.RequestedBy = &userID
+			if err := txRepo.CreateEmailOutbox(ctx, nil, outbox); err != nil {
+				return dto.ErrCreateEmailOutbox
+			}
+
+			res.Tickets = append(res.Tickets, toResendETicketItemResponse(form, outbox))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return dto.ResendETicketResponse{}, err
 	}
 
 	return res, nil
 }
 
-// Check-in
-func (as *AdminService) GetDetailTicketCheckIn(ctx context.Context, ticketFormIDStr string) (dto.TicketCheckInResponse, error) {
-	ticketForm, found, err := as.adminRepo.GetTicketFormByID(ctx, nil, ticketFormIDStr)
-	if err != nil || !found {
-		return dto.TicketCheckInResponse{}, dto.ErrTicketFormNotFound
+// Broadcast
+func validateBroadcastFilter(filter *dto.BroadcastFilterRequest) error {
+	if filter.EventID != "" {
+		if _, err := uuid.Parse(filter.EventID); err != nil {
+			return dto.ErrParseUUID
+		}
 	}
 
-	if ticketForm.TransactionID == nil || ticketForm.Transaction.TicketID == nil {
-		return dto.TicketCheckInResponse{}, dto.ErrTransactionNotFound
+	if filter.TicketType != "" && !entity.IsValidTicketType(filter.TicketType) {
+		return dto.ErrInvalidTicketType
 	}
 
-	status := false
-	if len(ticketForm.GuestAttendances) != 0 {
-		status = true
+	if filter.AudienceType != "" && !entity.IsValidAudienceType(filter.AudienceType) {
+		return dto.ErrInvalidAudienceType
 	}
 
-	var emailChecker string
-	if status {
-		token := ctx.Value("Authorization").(string)
+	if filter.Instansi != "" && !entity.IsValidInstansi(filter.Instansi) {
+		return dto.ErrInvalidInstansi
+	}
 
-		adminIDStr, err := as.jwtService.GetUserIDByToken(token)
-		if err != nil {
-			return dto.TicketCheckInResponse{}, dto.ErrGetUserIDFromToken
-		}
+	if filter.CheckedIn != "" && filter.CheckedIn != "true" && filter.CheckedIn != "false" {
+		return dto.ErrInvalidCheckedInFilter
+	}
 
-		admin, found, err := as.adminRepo.GetUserByID(ctx, nil, adminIDStr)
-		if err != nil || !found {
-			return dto.TicketCheckInResponse{}, dto.ErrUserNotFound
+	switch filter.TransactionStatus {
+	case "":
+		filter.TransactionStatus = "settlement"
+	case "settlement", "pending", "failed", "cancelled", "expired", "refunded":
+	default:
+		return dto.ErrInvalidTransactionStatus
+	}
+
+	return nil
+}
+
+// uniqueBroadcastRecipients: satu email cukup menerima satu pengumuman.
+func uniqueBroadcastRecipients(ticketForms []entity.TicketForm) []entity.TicketForm {
+	seen := make(map[string]bool)
+
+	var recipients []entity.TicketForm
+	for _, ticketForm := range ticketForms {
+		email := strings.ToLower(strings.TrimSpace(ticketForm.Email))
+		if email == "" || seen[email] {
+			continue
 		}
+		seen[email] = true
 
-		emailChecker = admin.Email
+		recipients = append(recipients, ticketForm)
 	}
 
-	res := dto.TicketCheckInResponse{
-		TicketFormID:  ticketForm.ID,
-		TicketID:      *ticketForm.Transaction.TicketID,
-		TransactionID: *ticketForm.TransactionID,
-		TicketName:    ticketForm.Transaction.Ticket.Name,
-		TicketType:    ticketForm.Transaction.Ticket.Type,
-		AudienceType:  ticketForm.AudienceType,
-		Email:         ticketForm.Email,
-		FullName:      ticketForm.FullName,
-		PhoneNumber:   ticketForm.PhoneNumber,
-		LineID:        ticketForm.LineID,
-		Status:        status,
-		EmailChecker:  emailChecker,
+	return recipients
+}
+func toBroadcastFilter(broadcast entity.Broadcast) dto.BroadcastFilterRequest {
+	filter := dto.BroadcastFilterRequest{
+		TicketType:        broadcast.TicketType,
+		AudienceType:      broadcast.AudienceType,
+		Instansi:          broadcast.Instansi,
+		CheckedIn:         broadcast.CheckedIn,
+		TransactionStatus: broadcast.TransactionStatus,
+	}
+	if broadcast.EventID != nil {
+		filter.EventID = broadcast.EventID.String()
 	}
 
-	return res, nil
+	return filter
 }
-func (as *AdminService) CheckIn(ctx context.Context, ticketFormIDStr string) error {
-	ticketForm, found, err := as.adminRepo.GetTicketFormByID(ctx, nil, ticketFormIDStr)
-	if err != nil || !found {
-		return dto.ErrTicketFormNotFound
+func toBroadcastResponse(broadcast entity.Broadcast) dto.BroadcastResponse {
+	return dto.BroadcastResponse{
+		ID:             broadcast.ID,
+		Subject:        broadcast.Subject,
+		Message:        broadcast.Message,
+		Filter:         toBroadcastFilter(broadcast),
+		RecipientCount: broadcast.RecipientCount,
+		CreatedBy:      broadcast.CreatedBy,
+		CreatedAt:      broadcast.CreatedAt,
+	}
+}
+func (as *AdminService) CountBroadcastRecipient(ctx context.Context, filter dto.BroadcastFilterRequest) (dto.BroadcastRecipientCountResponse, error) {
+	if err := validateBroadcastFilter(&filter); err != nil {
+		return dto.BroadcastRecipientCountResponse{}, err
 	}
 
-	if len(ticketForm.GuestAttendances) > 0 {
-		return dto.ErrAlreadyCheckedIn
+	ticketForms, err := as.adminRepo.GetAllBroadcastRecipient(ctx, nil, filter)
+	if err != nil {
+		return dto.BroadcastRecipientCountResponse{}, dto.ErrGetBroadcastRecipient
 	}
 
+	return dto.BroadcastRecipientCountResponse{
+		Filter:         filter,
+		TicketCount:    len(ticketForms),
+		RecipientCount: len(uniqueBroadcastRecipients(ticketForms)),
+	}, nil
+}
+func (as *AdminService) CreateBroadcast(ctx context.Context, req dto.CreateBroadcastRequest) (dto.BroadcastResponse, error) {
 	token := ctx.Value("Authorization").(string)
 
-	adminIDStr, err := as.jwtService.GetUserIDByToken(token)
+	userIDStr, err := as.jwtService.GetUserIDByToken(token)
 	if err != nil {
-		return dto.ErrGetUserIDFromToken
+		return dto.BroadcastResponse{}, dto.ErrGetUserIDFromToken
 	}
 
-	adminID, err := uuid.Parse(adminIDStr)
+	userID, err := uuid.Parse(userIDStr)
 	if err != nil {
-		return dto.ErrParseUUID
+		return dto.BroadcastResponse{}, dto.ErrParseUUID
 	}
 
-	guestAttendance := entity.GuestAttendance{
-		ID:           uuid.New(),
-		TicketFormID: &ticketForm.ID,
-		CheckedBy:    &adminID,
+	req.Subject = strings.TrimSpace(req.Subject)
+	req.Message = strings.TrimSpace(req.Message)
+	if req.Subject == "" || req.Message == "" {
+		return dto.BroadcastResponse{}, dto.ErrEmptyFields
 	}
 
-	err = as.adminRepo.CreateGuestAttendance(ctx, nil, guestAttendance)
-	if err != nil {
-		return dto.ErrCreateGuestAttendance
+	if err := validateBroadcastFilter(&req.Filter); err != nil {
+		return dto.BroadcastResponse{}, err
 	}
 
-	return nil
-}
-func (as *AdminService) GetAllTicketCheckIn(ctx context.Context, filter dto.CheckInFilterQuery) ([]dto.TicketCheckInResponse, error) {
-	ticketForms, err := as.adminRepo.GetAllTicketForm(ctx, nil, filter)
+	ticketForms, err := as.adminRepo.GetAllBroadcastRecipient(ctx, nil, req.Filter)
 	if err != nil {
-		return nil, dto.ErrGetAllTicketCheckInNoPagination
+		return dto.BroadcastResponse{}, dto.ErrGetBroadcastRecipient
+	}
+
+	recipients := uniqueBroadcastRecipients(ticketForms)
+	if len(recipients) == 0 {
+		return dto.BroadcastResponse{}, dto.ErrNoBroadcastRecipient
+	}
+
+	broadcast := entity.Broadcast{
+		ID:                uuid.New(),
+		Subject:           req.Subject,
+		Message:           req.Message,
+		TicketType:        req.Filter.TicketType,
+		AudienceType:      req.Filter.AudienceType,
+		Instansi:          req.Filter.Instansi,
+		CheckedIn:         req.Filter.CheckedIn,
+		TransactionStatus: req.Filter.TransactionStatus,
+		RecipientCount:    len(recipients),
+		CreatedBy:         &userID,
+	}
+	broadcast.CreatedAt = time.Now()
+	if req.Filter.EventID != "" {
+		eventID, _ := uuid.Parse(req.Filter.EventID)
+		broadcast.EventID = &eventID
+	}
+
+	header := emailHeaderAttachment()
+	outboxes := make([]entity.EmailOutbox, 0, len(recipients))
+	for _, recipient := range recipients {
+		eventName := recipient.Transaction.Ticket.Event.Name
+		if eventName == "" {
+			eventName = recipient.Transaction.Bundle.Event.Name
+		}
+
+		draftEmail, err := as.emailTemplateService.Render(ctx, entity.EmailTemplateBroadcast, broadcastEmailData{
+			HeaderImage:  emailInlineImage(header),
+			Subject:      broadcast.Subject,
+			Message:      broadcast.Message,
+			AttendeeName: recipient.FullName,
+			Email:        recipient.Email,
+			EventName:    eventName,
+		})
+		if err != nil {
+			return dto.BroadcastResponse{}, dto.ErrMakeBroadcastEmail
+		}
+
+		outbox := newEmailOutbox(string(entity.EmailTemplateBroadcast), recipient.Email, draftEmail)
+		outbox.TicketFormID = &recipient.ID
+		outbox.RequestedBy = &userID
+		outbox.BroadcastID = &broadcast.ID
+		outbox.Attachments = entity.EmailAttachments{header}
+		outboxes = append(outboxes, outbox)
 	}
 
-	var datas []dto.TicketCheckInResponse
-	for _, ticketForm := range ticketForms {
-		var emailChecker string
-		if len(ticketForm.GuestAttendances) > 0 {
-			emailChecker = ticketForm.GuestAttendances[0].CheckedByUser.Email
+	err = as.adminRepo.RunInTransaction(ctx, func(txRepo repository.IAdminRepository) error {
+		if err := txRepo.CreateBroadcast(ctx, nil, broadcast); err != nil {
+			return dto.ErrCreateBroadcast
 		}
 
-		data := dto.TicketCheckInResponse{
-			TicketFormID:  ticketForm.ID,
-			TicketID:      *ticketForm.Transaction.TicketID,
-			TransactionID: *ticketForm.TransactionID,
-			TicketName:    ticketForm.Transaction.Ticket.Name,
-			TicketType:    ticketForm.Transaction.Ticket.Type,
-			AudienceType:  ticketForm.AudienceType,
-			Email:         ticketForm.Email,
-			FullName:      ticketForm.FullName,
-			PhoneNumber:   ticketForm.PhoneNumber,
-			LineID:        ticketForm.LineID,
-			Status:        true,
-			EmailChecker:  emailChecker,
+		for _, outbox := range outboxes {
+			if err := txRepo.CreateEmailOutbox(ctx, nil, outbox); err != nil {
+				return dto.ErrCreateEmailOutbox
+			}
 		}
 
-		datas = append(datas, data)
+		return nil
+	})
+	if err != nil {
+		return dto.BroadcastResponse{}, err
 	}
 
-	return datas, nil
+	res := toBroadcastResponse(broadcast)
+	res.Delivery = &dto.BroadcastDeliveryResponse{Pending: int64(len(outboxes))}
+
+	return res, nil
 }
-func (as *AdminService) GetAllTicketCheckInWithPagination(ctx context.Context, req dto.PaginationRequest, filter dto.CheckInFilterQuery) (dto.TicketFormPaginationResponse, error) {
-	dataWithPaginate, err := as.adminRepo.GetAllTicketFormWithPagination(ctx, nil, req, filter)
+func (as *AdminService) GetAllBroadcastWithPagination(ctx context.Context, req dto.PaginationRequest) (dto.BroadcastPaginationResponse, error) {
+	dataWithPaginate, err := as.adminRepo.GetAllBroadcastWithPagination(ctx, nil, req)
 	if err != nil {
-		return dto.TicketFormPaginationResponse{}, dto.ErrGetAllTicketCheckInWithPagination
+		return dto.BroadcastPaginationResponse{}, dto.ErrGetAllBroadcastWithPagination
 	}
 
-	var datas []dto.TicketCheckInResponse
-	status := false
-	for _, ticketForm := range dataWithPaginate.TicketForms {
-		var emailChecker string
-		if len(ticketForm.GuestAttendances) > 0 {
-			emailChecker = ticketForm.GuestAttendances[0].CheckedByUser.Email
-		}
-
-		if emailChecker != "" {
-			status = true
-		}
-
-		data := dto.TicketCheckInResponse{
-			TicketFormID:  ticketForm.ID,
-			TicketID:      *ticketForm.Transaction.TicketID,
-			TransactionID: *ticketForm.TransactionID,
-			TicketName:    ticketForm.Transaction.Ticket.Name,
-			TicketType:    ticketForm.Transaction.Ticket.Type,
-			AudienceType:  ticketForm.AudienceType,
-			Email:         ticketForm.Email,
-			FullName:      ticketForm.FullName,
-			PhoneNumber:   ticketForm.PhoneNumber,
-			LineID:        ticketForm.LineID,
-			Status:        status,
-			EmailChecker:  emailChecker,
-		}
-
-		datas = append(datas, data)
+	var datas []dto.BroadcastResponse
+	for _, broadcast := range dataWithPaginate.Broadcasts {
+		datas = append(datas, toBroadcastResponse(broadcast))
 	}
 
-	return dto.TicketFormPaginationResponse{
+	return dto.BroadcastPaginationResponse{
 		Data: datas,
 		PaginationResponse: dto.PaginationResponse{
 			Page:    dataWithPaginate.Page,
@@ -2538,65 +6206,25 @@ func (as *AdminService) GetAllTicketCheckInWithPagination(ctx context.Context, r
 	}, nil
 }
 
-// Dashboard Stats
-func (as *AdminService) GetAllStats(ctx context.Context) (dto.DashboardStatResponse, error) {
-	// Ticket Type Stats
-	preEvent3, err := as.adminRepo.GetAllTicketStats(ctx, nil, "pre-event-3")
-	if err != nil {
-		return dto.DashboardStatResponse{}, dto.ErrGetAllPreEvent3Stats
-	}
-	mainEvent, err := as.adminRepo.GetAllTicketStats(ctx, nil, "main-event")
-	if err != nil {
-		return dto.DashboardStatResponse{}, dto.ErrGetAllMainEventStats
-	}
-
-	// Total Bundle Type
-	totalBundleMerch, err := as.adminRepo.GetTotalBundle(ctx, nil, "bundle merch")
-	if err != nil {
-		return dto.DashboardStatResponse{}, dto.ErrGetTotalBundleMerch
-	}
-	totalBundleMerchTicket, err := as.adminRepo.GetTotalBundle(ctx, nil, "bundle merch ticket")
-	if err != nil {
-		return dto.DashboardStatResponse{}, dto.ErrGetTotalBundleMerchTicket
-	}
-
-	// Total Admin
-	totalAdmin, err := as.adminRepo.GetTotalAdmin(ctx, nil)
-	if err != nil {
-		return dto.DashboardStatResponse{}, dto.ErrGetTotalAdmin
-	}
-
-	// Guest Stats
-	guest, err := as.adminRepo.GetAllGuestStats(ctx, nil)
-	if err != nil {
-		return dto.DashboardStatResponse{}, dto.ErrGetAllGuestStats
+// GetDetailBroadcast menyertakan rekap status pengiriman.
+func (as *AdminService) GetDetailBroadcast(ctx context.Context, broadcastID string) (dto.BroadcastResponse, error) {
+	broadcast, found, err := as.adminRepo.GetBroadcastByID(ctx, nil, broadcastID)
+	if err != nil || !found {
+		return dto.BroadcastResponse{}, dto.ErrBroadcastNotFound
 	}
 
-	// Sponsor Stats
-	sponsor, err := as.adminRepo.GetTotalSponsor(ctx, nil, "sponsor")
-	if err != nil {
-		return dto.DashboardStatResponse{}, dto.ErrGetTotalSponsor
-	}
-	partner, err := as.adminRepo.GetTotalSponsor(ctx, nil, "partner")
-	if err != nil {
-		return dto.DashboardStatResponse{}, dto.ErrGetTotalPartner
-	}
-	mediaPartner, err := as.adminRepo.GetTotalSponsor(ctx, nil, "media partner")
+	counts, err := as.adminRepo.CountEmailOutboxByBroadcastID(ctx, nil, broadcastID)
 	if err != nil {
-		return dto.DashboardStatResponse{}, dto.ErrGetTotalMediaPartner
+		return dto.BroadcastResponse{}, dto.ErrCountBroadcastDelivery
 	}
 
-	// Response
-	res := dto.DashboardStatResponse{
-		PreEvent3:              *preEvent3,
-		MainEvent:              *mainEvent,
-		TotalBundleMerch:       totalBundleMerch,
-		TotalBundleMerchTicket: totalBundleMerchTicket,
-		TotalAdmin:             totalAdmin,
-		Guest:                  *guest,
-		Sponsor:                sponsor,
-		Partner:                partner,
-		MediaPartner:           mediaPartner,
+	res := toBroadcastResponse(broadcast)
+	res.Delivery = &dto.BroadcastDeliveryResponse{
+		Pending: counts[entity.EmailOutboxPending],
+		Sending: counts[entity.EmailOutboxSending],
+		Sent:    counts[entity.EmailOutboxSent],
+		Failed:  counts[entity.EmailOutboxFailed],
+		Dead:    counts[entity.EmailOutboxDead],
 	}
 
 	return res, nil
@@ -0,0 +1,250 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Amierza/TedXBackend/dto"
+	"github.com/Amierza/TedXBackend/entity"
+	"github.com/Amierza/TedXBackend/helpers"
+	"github.com/Amierza/TedXBackend/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// fakeAdminRepo hanya mengisi method yang dipakai sync offline.
+type fakeAdminRepo struct {
+	repository.IAdminRepository
+
+	ticketForm entity.TicketForm
+	created    []entity.GuestAttendance
+	updated    []entity.GuestAttendance
+}
+
+func (fr *fakeAdminRepo) GetTicketFormByIDForUpdate(ctx context.Context, tx *gorm.DB, ticketFormID string) (entity.TicketForm, bool, error) {
+	if ticketFormID != fr.ticketForm.ID.String() {
+		return entity.TicketForm{}, false, nil
+	}
+
+	return fr.ticketForm, true, nil
+}
+
+func (fr *fakeAdminRepo) CreateGuestAttendance(ctx context.Context, tx *gorm.DB, guestAttendance entity.GuestAttendance) error {
+	fr.created = append(fr.created, guestAttendance)
+	return nil
+}
+
+func (fr *fakeAdminRepo) UpdateGuestAttendance(ctx context.Context, tx *gorm.DB, guestAttendance entity.GuestAttendance) error {
+	fr.updated = append(fr.updated, guestAttendance)
+	return nil
+}
+
+func TestEarliestAttendance(t *testing.T) {
+	base := testEvent().StartAt
+
+	tests := []struct {
+		name        string
+		attendances []entity.GuestAttendance
+		want        *time.Time
+	}{
+		{"no attendance", nil, nil},
+		{"single", []entity.GuestAttendance{{CheckedAt: base}}, &base},
+		{"earliest is not first", []entity.GuestAttendance{{CheckedAt: base.Add(time.Minute)}, {CheckedAt: base}, {CheckedAt: base.Add(time.Hour)}}, &base},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := earliestAttendance(tt.attendances)
+			if (got == nil) != (tt.want == nil) || (got != nil && !got.CheckedAt.Equal(*tt.want)) {
+				t.Fatalf("earliestAttendance() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConflictingAttendances(t *testing.T) {
+	morning, afternoon := uuid.New(), uuid.New()
+	attendances := []entity.GuestAttendance{
+		{Direction: entity.CheckInDirectionIn, EventSessionID: &morning},
+		{Direction: entity.CheckInDirectionOut, EventSessionID: &morning},
+	}
+
+	tests := []struct {
+		name           string
+		policy         entity.ReentryPolicy
+		eventSessionID *uuid.UUID
+		want           int
+	}{
+		{"single entry conflicts with any check-in", entity.ReentrySingleEntry, &afternoon, 1},
+		{"re-entry never conflicts", entity.ReentryAllowed, &morning, 0},
+		{"per session conflicts in the same session", entity.ReentryPerSession, &morning, 1},
+		{"per session allows another session", entity.ReentryPerSession, &afternoon, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := conflictingAttendances(tt.policy, attendances, tt.eventSessionID); len(got) != tt.want {
+				t.Fatalf("conflictingAttendances() = %d conflicts, want %d", len(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyCheckInScan(t *testing.T) {
+	event := testEvent()
+	receivedAt := event.StartAt.Add(2 * time.Hour)
+	operator := entity.User{ID: uuid.New(), Role: entity.Admin}
+
+	checkedIn := entity.GuestAttendance{
+		ID:        uuid.New(),
+		CheckedAt: event.StartAt.Add(time.Hour),
+		Direction: entity.CheckInDirectionIn,
+		Source:    entity.CheckInOnline,
+	}
+	synced := entity.GuestAttendance{
+		ID:        uuid.New(),
+		CheckedAt: event.StartAt.Add(time.Hour),
+		Direction: entity.CheckInDirectionIn,
+		Source:    entity.CheckInOffline,
+		DeviceID:  "scanner-1",
+	}
+
+	tests := []struct {
+		name        string
+		ticketForm  entity.TicketForm
+		qrVersion   int
+		scannedAt   time.Time
+		direction   entity.CheckInDirection
+		wantResult  entity.CheckInScanResult
+		wantReason  string
+		wantCreated int
+		wantVoided  int
+	}{
+		{
+			name:        "first scan is recorded",
+			ticketForm:  testTicketForm(event),
+			scannedAt:   event.StartAt.Add(30 * time.Minute),
+			wantResult:  entity.CheckInScanAccepted,
+			wantCreated: 1,
+		},
+		{
+			name:       "re-uploaded scan is not a conflict",
+			ticketForm: testTicketForm(event, synced),
+			scannedAt:  synced.CheckedAt,
+			wantResult: entity.CheckInScanAccepted,
+			wantReason: "already synced",
+		},
+		{
+			name:       "later scan is a duplicate",
+			ticketForm: testTicketForm(event, checkedIn),
+			scannedAt:  checkedIn.CheckedAt.Add(time.Minute),
+			wantResult: entity.CheckInScanDuplicate,
+		},
+		{
+			name:        "earlier scan voids the recorded check-in",
+			ticketForm:  testTicketForm(event, checkedIn),
+			scannedAt:   checkedIn.CheckedAt.Add(-time.Minute),
+			wantResult:  entity.CheckInScanAccepted,
+			wantReason:  "replaced a later check-in",
+			wantCreated: 1,
+			wantVoided:  1,
+		},
+		{
+			name:        "out scan never conflicts",
+			ticketForm:  testTicketForm(event, checkedIn),
+			scannedAt:   checkedIn.CheckedAt.Add(time.Minute),
+			direction:   entity.CheckInDirectionOut,
+			wantResult:  entity.CheckInScanAccepted,
+			wantCreated: 1,
+		},
+		{
+			name:       "scan after the sync was received is rejected",
+			ticketForm: testTicketForm(event, checkedIn),
+			scannedAt:  receivedAt.Add(time.Hour),
+			wantResult: entity.CheckInScanRejected,
+			wantReason: dto.ErrCheckInScanInFuture.Error(),
+		},
+		{
+			name:       "scan before the manifest window is rejected",
+			ticketForm: testTicketForm(event, checkedIn),
+			scannedAt:  event.StartAt.Add(-24 * time.Hour),
+			wantResult: entity.CheckInScanRejected,
+			wantReason: dto.ErrCheckInScanOutsideWindow.Error(),
+		},
+		{
+			name:       "old qr version is rejected",
+			ticketForm: testTicketForm(event),
+			qrVersion:  2,
+			scannedAt:  event.StartAt.Add(30 * time.Minute),
+			wantResult: entity.CheckInScanRejected,
+			wantReason: dto.ErrTicketQRRevoked.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeAdminRepo{ticketForm: tt.ticketForm}
+			as := &AdminService{adminRepo: repo}
+
+			version := tt.ticketForm.QRVersion
+			if tt.qrVersion != 0 {
+				// tiket sudah diterbitkan ulang, QR yang discan versi lama
+				repo.ticketForm.QRVersion = tt.qrVersion
+			}
+
+			scan := dto.CheckInScanRequest{
+				QRToken:   helpers.SignTicketQR(helpers.TicketQRClaims{TicketFormID: tt.ticketForm.ID, EventID: event.ID, Version: version}),
+				ScannedAt: tt.scannedAt,
+				Direction: tt.direction,
+			}
+
+			got, record, err := as.applyCheckInScan(context.Background(), repo, event, operator, "scanner-1", receivedAt, scan)
+			if err != nil {
+				t.Fatalf("applyCheckInScan() error = %v", err)
+			}
+			if got.Result != tt.wantResult || (tt.wantReason != "" && got.Reason != tt.wantReason) {
+				t.Fatalf("applyCheckInScan() = %s (%s), want %s (%s)", got.Result, got.Reason, tt.wantResult, tt.wantReason)
+			}
+			if len(repo.created) != tt.wantCreated || len(repo.updated) != tt.wantVoided {
+				t.Fatalf("created %d voided %d attendances, want %d and %d", len(repo.created), len(repo.updated), tt.wantCreated, tt.wantVoided)
+			}
+			if (record != nil) != (tt.wantCreated > 0) {
+				t.Fatalf("applyCheckInScan() record = %v, want a feed record only for new check-ins", record)
+			}
+			if tt.wantVoided > 0 && (repo.updated[0].VoidedAt == nil || !repo.created[0].CheckedAt.Equal(tt.scannedAt)) {
+				t.Fatalf("later check-in voided at %v, new check-in at %v, want voided and %v", repo.updated[0].VoidedAt, repo.created[0].CheckedAt, tt.scannedAt)
+			}
+		})
+	}
+}
+
+func TestCheckScanTime(t *testing.T) {
+	event := testEvent()
+	validFrom, validUntil := checkInWindow(event)
+	receivedAt := event.EndAt.Add(time.Hour)
+
+	tests := []struct {
+		name       string
+		scannedAt  time.Time
+		receivedAt time.Time
+		want       error
+	}{
+		{"inside the window", event.StartAt, receivedAt, nil},
+		{"window start", validFrom, receivedAt, nil},
+		{"window end", validUntil, receivedAt, nil},
+		{"before the window", validFrom.Add(-time.Second), receivedAt, dto.ErrCheckInScanOutsideWindow},
+		{"after the window", validUntil.Add(time.Second), receivedAt, dto.ErrCheckInScanOutsideWindow},
+		{"small clock skew is tolerated", event.StartAt.Add(time.Minute), event.StartAt, nil},
+		{"after the sync was received", event.StartAt.Add(time.Hour), event.StartAt, dto.ErrCheckInScanInFuture},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := checkScanTime(event, tt.scannedAt, tt.receivedAt); !errors.Is(err, tt.want) {
+				t.Fatalf("checkScanTime() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/Amierza/TedXBackend/constants"
+	"github.com/Amierza/TedXBackend/dto"
+	"github.com/Amierza/TedXBackend/entity"
+	"github.com/Amierza/TedXBackend/repository"
+	"github.com/google/uuid"
+)
+
+type (
+	IAvailabilityService interface {
+		GetAllAvailability(ctx context.Context) ([]dto.AvailabilityResponse, error)
+		Subscribe() (<-chan dto.AvailabilityResponse, func())
+		PublishTicket(ctx context.Context, ticketID string)
+		PublishBundle(ctx context.Context, bundleID string)
+		PublishRemoved(itemType entity.ItemType, itemID uuid.UUID)
+	}
+
+	AvailabilityService struct {
+		availabilityRepo repository.IAvailabilityRepository
+
+		mu          sync.RWMutex
+		subscribers map[chan dto.AvailabilityResponse]struct{}
+	}
+)
+
+func NewAvailabilityService(availabilityRepo repository.IAvailabilityRepository) *AvailabilityService {
+	return &AvailabilityService{
+		availabilityRepo: availabilityRepo,
+		subscribers:      make(map[chan dto.AvailabilityResponse]struct{}),
+	}
+}
+
+func ticketAvailability(ticket entity.Ticket) dto.AvailabilityResponse {
+	return dto.AvailabilityResponse{
+		ItemType:    constants.ENUM_TICKET_ITEM_TYPE,
+		ID:          ticket.ID,
+		Name:        ticket.Name,
+		Quota:       ticket.Quota,
+		IsAvailable: ticket.Quota > 0 && time.Now().Before(ticket.EventDate),
+	}
+}
+func bundleAvailability(bundle entity.Bundle) dto.AvailabilityResponse {
+	return dto.AvailabilityResponse{
+		ItemType:    constants.ENUM_BUNDLE_ITEM_TYPE,
+		ID:          bundle.ID,
+		Name:        bundle.Name,
+		Quota:       bundle.Quota,
+		IsAvailable: bundle.Quota > 0 && time.Now().Before(bundle.EventDate),
+	}
+}
+
+func (as *AvailabilityService) GetAllAvailability(ctx context.Context) ([]dto.AvailabilityResponse, error) {
+	tickets, err := as.availabilityRepo.GetAllTicket(ctx, nil)
+	if err != nil {
+		return nil, dto.ErrGetAllTicketNoPagination
+	}
+
+	bundles, err := as.availabilityRepo.GetAllBundle(ctx, nil)
+	if err != nil {
+		return nil, dto.ErrGetAllBundleNoPagination
+	}
+
+	datas := make([]dto.AvailabilityResponse, 0, len(tickets)+len(bundles))
+	for _, ticket := range tickets {
+		datas = append(datas, ticketAvailability(ticket))
+	}
+	for _, bundle := range bundles {
+		datas = append(datas, bundleAvailability(bundle))
+	}
+
+	return datas, nil
+}
+
+// Subscribe: fungsi yang dikembalikan wajib dipanggil saat listener berhenti.
+func (as *AvailabilityService) Subscribe() (<-chan dto.AvailabilityResponse, func()) {
+	ch := make(chan dto.AvailabilityResponse, constants.ENUM_AVAILABILITY_SUBSCRIBER_BUFFER)
+
+	as.mu.Lock()
+	as.subscribers[ch] = struct{}{}
+	as.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			as.mu.Lock()
+			delete(as.subscribers, ch)
+			as.mu.Unlock()
+			close(ch)
+		})
+	}
+
+	return ch, unsubscribe
+}
+
+// broadcast tidak memblokir; listener yang tertinggal menyusul di update berikutnya.
+func (as *AvailabilityService) broadcast(update dto.AvailabilityResponse) {
+	as.mu.RLock()
+	defer as.mu.RUnlock()
+
+	for ch := range as.subscribers {
+		select {
+		case ch <- update:
+		default:
+		}
+	}
+}
+
+func (as *AvailabilityService) PublishTicket(ctx context.Context, ticketID string) {
+	ticket, found, err := as.availabilityRepo.GetTicketByID(ctx, nil, ticketID)
+	if err != nil || !found {
+		log.Printf("failed to publish availability for ticket %s: %v", ticketID, err)
+		return
+	}
+
+	as.broadcast(ticketAvailability(ticket))
+}
+func (as *AvailabilityService) PublishBundle(ctx context.Context, bundleID string) {
+	bundle, found, err := as.availabilityRepo.GetBundleByID(ctx, nil, bundleID)
+	if err != nil || !found {
+		log.Printf("failed to publish availability for bundle %s: %v", bundleID, err)
+		return
+	}
+
+	as.broadcast(bundleAvailability(bundle))
+}
+func (as *AvailabilityService) PublishRemoved(itemType entity.ItemType, itemID uuid.UUID) {
+	as.broadcast(dto.AvailabilityResponse{
+		ItemType:  itemType,
+		ID:        itemID,
+		IsRemoved: true,
+	})
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"sync"
+	"time"
+
+	"github.com/Amierza/TedXBackend/constants"
+	"github.com/Amierza/TedXBackend/dto"
+)
+
+type (
+	ICheckInFeedService interface {
+		Subscribe() (<-chan dto.CheckInFeedEvent, func())
+		HasSubscribers() bool
+		Publish(event dto.CheckInFeedEvent)
+		Totals(load func() (*dto.GuestStatResponse, error)) *dto.GuestStatResponse
+	}
+
+	CheckInFeedService struct {
+		mu          sync.RWMutex
+		subscribers map[chan dto.CheckInFeedEvent]struct{}
+
+		totalsMu         sync.Mutex
+		totals           *dto.GuestStatResponse
+		totalsLoadedAt   time.Time
+		totalsRefreshing bool
+	}
+)
+
+func NewCheckInFeedService() *CheckInFeedService {
+	return &CheckInFeedService{
+		subscribers: make(map[chan dto.CheckInFeedEvent]struct{}),
+	}
+}
+
+// Subscribe: fungsi yang dikembalikan wajib dipanggil saat listener berhenti.
+func (cs *CheckInFeedService) Subscribe() (<-chan dto.CheckInFeedEvent, func()) {
+	ch := make(chan dto.CheckInFeedEvent, constants.ENUM_CHECK_IN_FEED_SUBSCRIBER_BUFFER)
+
+	cs.mu.Lock()
+	cs.subscribers[ch] = struct{}{}
+	cs.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			cs.mu.Lock()
+			delete(cs.subscribers, ch)
+			cs.mu.Unlock()
+			close(ch)
+		})
+	}
+
+	return ch, unsubscribe
+}
+func (cs *CheckInFeedService) HasSubscribers() bool {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+
+	return len(cs.subscribers) > 0
+}
+
+// Publish tidak memblokir scanner; listener yang tertinggal melewatkan event ini.
+func (cs *CheckInFeedService) Publish(event dto.CheckInFeedEvent) {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+
+	for ch := range cs.subscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// Totals langsung mengembalikan total terakhir dan memuat ulang di background paling sering sekali per interval.
+func (cs *CheckInFeedService) Totals(load func() (*dto.GuestStatResponse, error)) *dto.GuestStatResponse {
+	cs.totalsMu.Lock()
+	defer cs.totalsMu.Unlock()
+
+	if !cs.totalsRefreshing && time.Since(cs.totalsLoadedAt) >= constants.ENUM_CHECK_IN_FEED_TOTALS_INTERVAL_SECONDS*time.Second {
+		cs.totalsRefreshing = true
+		go cs.refreshTotals(load)
+	}
+
+	return cs.totals
+}
+func (cs *CheckInFeedService) refreshTotals(load func() (*dto.GuestStatResponse, error)) {
+	totals, err := load()
+
+	cs.totalsMu.Lock()
+	defer cs.totalsMu.Unlock()
+
+	cs.totalsRefreshing = false
+	cs.totalsLoadedAt = time.Now()
+	if err == nil {
+		cs.totals = totals
+	}
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Amierza/TedXBackend/entity"
+	"github.com/Amierza/TedXBackend/helpers"
+	"github.com/google/uuid"
+)
+
+func TestMain(m *testing.M) {
+	if err := helpers.SetQRSigningKey(strings.Repeat("k", 32)); err != nil {
+		panic(err)
+	}
+
+	os.Exit(m.Run())
+}
+
+// ticketForms: form yang sudah ditransfer dulu, lalu form yang masih dipegang.
+func ticketForms(transferred, kept int) []entity.TicketForm {
+	var forms []entity.TicketForm
+	for i := 0; i < transferred; i++ {
+		to := uuid.New()
+		forms = append(forms, entity.TicketForm{ID: uuid.New(), TransferredToID: &to})
+	}
+	for i := 0; i < kept; i++ {
+		forms = append(forms, entity.TicketForm{ID: uuid.New()})
+	}
+
+	return forms
+}
+
+// testEvent: event satu hari 09.00-17.00 UTC dengan aturan single entry.
+func testEvent() entity.Event {
+	return entity.Event{
+		ID:            uuid.New(),
+		StartAt:       time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC),
+		EndAt:         time.Date(2026, 5, 1, 17, 0, 0, 0, time.UTC),
+		ReentryPolicy: entity.ReentrySingleEntry,
+	}
+}
+
+func testTicketForm(event entity.Event, attendances ...entity.GuestAttendance) entity.TicketForm {
+	return entity.TicketForm{
+		ID:               uuid.New(),
+		QRVersion:        1,
+		GuestAttendances: attendances,
+		Transaction: entity.Transaction{
+			Ticket: entity.Ticket{EventID: &event.ID, Event: event},
+		},
+	}
+}
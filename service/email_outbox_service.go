@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/Amierza/TedXBackend/constants"
+	"github.com/Amierza/TedXBackend/entity"
+	"github.com/Amierza/TedXBackend/repository"
+	"github.com/Amierza/TedXBackend/utils/mailer"
+	"github.com/google/uuid"
+)
+
+type (
+	IEmailOutboxService interface {
+		DeliverDue(ctx context.Context) (int, error)
+		StartWorker(workers int, interval time.Duration)
+	}
+
+	EmailOutboxService struct {
+		emailOutboxRepo repository.IEmailOutboxRepository
+		mailer          mailer.Mailer
+	}
+)
+
+func NewEmailOutboxService(emailOutboxRepo repository.IEmailOutboxRepository, mailTransport mailer.Mailer) *EmailOutboxService {
+	return &EmailOutboxService{
+		emailOutboxRepo: emailOutboxRepo,
+		mailer:          mailTransport,
+	}
+}
+
+// newEmailOutbox menyiapkan email untuk disimpan bersama perubahan datanya.
+func newEmailOutbox(kind, toEmail string, draftEmail map[string]string) entity.EmailOutbox {
+	return entity.EmailOutbox{
+		ID:            uuid.New(),
+		Kind:          kind,
+		ToEmail:       toEmail,
+		Subject:       draftEmail["subject"],
+		Body:          draftEmail["body"],
+		TextBody:      draftEmail["text"],
+		Status:        entity.EmailOutboxPending,
+		MaxAttempts:   constants.ENUM_EMAIL_OUTBOX_MAX_ATTEMPTS,
+		NextAttemptAt: time.Now(),
+	}
+}
+
+// emailOutboxBackoff: 30s, 1m, 2m, 4m, ... dibatasi 1 jam.
+func emailOutboxBackoff(attempts int) time.Duration {
+	backoff := constants.ENUM_EMAIL_OUTBOX_BACKOFF_BASE_SECONDS * time.Second
+	limit := constants.ENUM_EMAIL_OUTBOX_BACKOFF_LIMIT_SECONDS * time.Second
+	for i := 1; i < attempts && backoff < limit; i++ {
+		backoff *= 2
+	}
+
+	if backoff > limit {
+		return limit
+	}
+
+	return backoff
+}
+
+// toMailerAttachments: file yang hilang membuat pengiriman gagal dan dicoba ulang.
+func toMailerAttachments(attachments entity.EmailAttachments) ([]mailer.Attachment, error) {
+	result := make([]mailer.Attachment, 0, len(attachments))
+	for _, attachment := range attachments {
+		data := attachment.Data
+		if attachment.Path != "" {
+			b, err := os.ReadFile(attachment.Path)
+			if err != nil {
+				return nil, err
+			}
+			data = b
+		}
+
+		result = append(result, mailer.Attachment{
+			Filename:    attachment.Filename,
+			ContentType: attachment.ContentType,
+			ContentID:   attachment.ContentID,
+			Data:        data,
+		})
+	}
+
+	return result, nil
+}
+
+// emailOutboxLease dibulatkan ke mikrodetik supaya sama dengan locked_until di Postgres.
+func emailOutboxLease(now time.Time) time.Time {
+	return now.Add(constants.ENUM_EMAIL_OUTBOX_LEASE_SECONDS * time.Second).Truncate(time.Microsecond)
+}
+
+// deliver memperpanjang lease tepat sebelum mengirim.
+func (es *EmailOutboxService) deliver(ctx context.Context, outbox entity.EmailOutbox) {
+	if outbox.LockedUntil == nil {
+		return
+	}
+
+	leaseUntil := emailOutboxLease(time.Now())
+	renewed, err := es.emailOutboxRepo.RenewEmailOutboxLease(ctx, nil, outbox.ID.String(), *outbox.LockedUntil, leaseUntil)
+	if err != nil {
+		log.Printf("failed to renew email outbox %s lease: %v", outbox.ID, err)
+		return
+	}
+	if !renewed {
+		return
+	}
+
+	now := time.Now()
+	outbox.Attempts++
+	outbox.LockedUntil = nil
+
+	attachments, err := toMailerAttachments(outbox.Attachments)
+	if err == nil {
+		err = es.mailer.Send(ctx, mailer.Message{
+			To:          outbox.ToEmail,
+			Subject:     outbox.Subject,
+			HTMLBody:    outbox.Body,
+			TextBody:    outbox.TextBody,
+			Attachments: attachments,
+		})
+	}
+
+	if err != nil {
+		outbox.LastError = err.Error()
+		if outbox.Attempts >= outbox.MaxAttempts {
+			outbox.Status = entity.EmailOutboxDead
+			log.Printf("email outbox %s to %s is dead after %d attempts: %v", outbox.ID, outbox.ToEmail, outbox.Attempts, err)
+		} else {
+			outbox.Status = entity.EmailOutboxFailed
+			outbox.NextAttemptAt = now.Add(emailOutboxBackoff(outbox.Attempts))
+		}
+	} else {
+		outbox.Status = entity.EmailOutboxSent
+		outbox.LastError = ""
+		outbox.SentAt = &now
+	}
+
+	updated, err := es.emailOutboxRepo.UpdateEmailOutboxDelivery(ctx, nil, outbox, leaseUntil)
+	if err != nil {
+		log.Printf("failed to update email outbox %s: %v", outbox.ID, err)
+		return
+	}
+	if !updated {
+		log.Printf("email outbox %s lease was taken over before its delivery was recorded", outbox.ID)
+	}
+}
+func (es *EmailOutboxService) DeliverDue(ctx context.Context) (int, error) {
+	now := time.Now()
+	lockedUntil := emailOutboxLease(now)
+
+	outboxes, err := es.emailOutboxRepo.ClaimDueEmailOutbox(ctx, nil, now, lockedUntil, constants.ENUM_EMAIL_OUTBOX_BATCH_SIZE)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, outbox := range outboxes {
+		es.deliver(ctx, outbox)
+	}
+
+	return len(outboxes), nil
+}
+
+// StartWorker: batch penuh langsung diikuti batch berikutnya.
+func (es *EmailOutboxService) StartWorker(workers int, interval time.Duration) {
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+
+			for range ticker.C {
+				for {
+					delivered, err := es.DeliverDue(context.Background())
+					if err != nil {
+						log.Printf("failed to deliver email outbox: %v", err)
+						break
+					}
+					if delivered < constants.ENUM_EMAIL_OUTBOX_BATCH_SIZE {
+						break
+					}
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+}
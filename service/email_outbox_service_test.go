@@ -0,0 +1,235 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Amierza/TedXBackend/entity"
+	"github.com/Amierza/TedXBackend/utils/mailer"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// fakeEmailOutboxRepo meniru aturan claim dan lease EmailOutboxRepository di memori.
+type fakeEmailOutboxRepo struct {
+	mu       sync.Mutex
+	outboxes map[uuid.UUID]entity.EmailOutbox
+}
+
+func newFakeEmailOutboxRepo(outboxes ...entity.EmailOutbox) *fakeEmailOutboxRepo {
+	repo := &fakeEmailOutboxRepo{outboxes: make(map[uuid.UUID]entity.EmailOutbox)}
+	for _, outbox := range outboxes {
+		repo.outboxes[outbox.ID] = outbox
+	}
+
+	return repo
+}
+
+func (fr *fakeEmailOutboxRepo) get(id uuid.UUID) entity.EmailOutbox {
+	fr.mu.Lock()
+	defer fr.mu.Unlock()
+
+	return fr.outboxes[id]
+}
+
+func (fr *fakeEmailOutboxRepo) ClaimDueEmailOutbox(ctx context.Context, tx *gorm.DB, now, lockedUntil time.Time, limit int) ([]entity.EmailOutbox, error) {
+	fr.mu.Lock()
+	defer fr.mu.Unlock()
+
+	var claimed []entity.EmailOutbox
+	for id, outbox := range fr.outboxes {
+		due := (outbox.Status == entity.EmailOutboxPending || outbox.Status == entity.EmailOutboxFailed) && !outbox.NextAttemptAt.After(now)
+		stale := outbox.Status == entity.EmailOutboxSending && outbox.LockedUntil != nil && outbox.LockedUntil.Before(now)
+		if !due && !stale {
+			continue
+		}
+
+		outbox.Status = entity.EmailOutboxSending
+		outbox.LockedUntil = &lockedUntil
+		fr.outboxes[id] = outbox
+		claimed = append(claimed, outbox)
+	}
+
+	sort.Slice(claimed, func(i, j int) bool { return claimed[i].NextAttemptAt.Before(claimed[j].NextAttemptAt) })
+	if len(claimed) > limit {
+		claimed = claimed[:limit]
+	}
+
+	return claimed, nil
+}
+
+func (fr *fakeEmailOutboxRepo) RenewEmailOutboxLease(ctx context.Context, tx *gorm.DB, outboxID string, claimedUntil, lockedUntil time.Time) (bool, error) {
+	fr.mu.Lock()
+	defer fr.mu.Unlock()
+
+	outbox, ok := fr.outboxes[uuid.MustParse(outboxID)]
+	if !ok || outbox.Status != entity.EmailOutboxSending || outbox.LockedUntil == nil || !outbox.LockedUntil.Equal(claimedUntil) {
+		return false, nil
+	}
+
+	outbox.LockedUntil = &lockedUntil
+	fr.outboxes[outbox.ID] = outbox
+	return true, nil
+}
+
+func (fr *fakeEmailOutboxRepo) UpdateEmailOutboxDelivery(ctx context.Context, tx *gorm.DB, outbox entity.EmailOutbox, claimedUntil time.Time) (bool, error) {
+	fr.mu.Lock()
+	defer fr.mu.Unlock()
+
+	current, ok := fr.outboxes[outbox.ID]
+	if !ok || current.Status != entity.EmailOutboxSending || current.LockedUntil == nil || !current.LockedUntil.Equal(claimedUntil) {
+		return false, nil
+	}
+
+	fr.outboxes[outbox.ID] = outbox
+	return true, nil
+}
+
+type failingMailer struct{}
+
+func (failingMailer) Send(ctx context.Context, msg mailer.Message) error {
+	return errors.New("smtp down")
+}
+
+func TestEmailOutboxBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{0, 30 * time.Second},
+		{1, 30 * time.Second},
+		{2, time.Minute},
+		{3, 2 * time.Minute},
+		{7, 32 * time.Minute},
+		{8, time.Hour},
+		{100, time.Hour},
+	}
+
+	for _, tt := range tests {
+		if got := emailOutboxBackoff(tt.attempts); got != tt.want {
+			t.Errorf("emailOutboxBackoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestEmailOutboxDeliverDue(t *testing.T) {
+	past := time.Now().Add(-time.Minute)
+	future := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name         string
+		outbox       entity.EmailOutbox
+		mailer       mailer.Mailer
+		wantClaimed  int
+		wantStatus   entity.EmailOutboxStatus
+		wantAttempts int
+		wantSent     int
+	}{
+		{
+			name:         "pending email is sent",
+			outbox:       entity.EmailOutbox{Status: entity.EmailOutboxPending, MaxAttempts: 8, NextAttemptAt: past},
+			mailer:       mailer.NewMemoryMailer(),
+			wantClaimed:  1,
+			wantStatus:   entity.EmailOutboxSent,
+			wantAttempts: 1,
+			wantSent:     1,
+		},
+		{
+			name:         "email not yet due is left alone",
+			outbox:       entity.EmailOutbox{Status: entity.EmailOutboxFailed, Attempts: 1, MaxAttempts: 8, NextAttemptAt: future},
+			mailer:       mailer.NewMemoryMailer(),
+			wantStatus:   entity.EmailOutboxFailed,
+			wantAttempts: 1,
+		},
+		{
+			name:         "failed send is retried later",
+			outbox:       entity.EmailOutbox{Status: entity.EmailOutboxPending, MaxAttempts: 8, NextAttemptAt: past},
+			mailer:       failingMailer{},
+			wantClaimed:  1,
+			wantStatus:   entity.EmailOutboxFailed,
+			wantAttempts: 1,
+		},
+		{
+			name:         "last attempt marks the email dead",
+			outbox:       entity.EmailOutbox{Status: entity.EmailOutboxFailed, Attempts: 7, MaxAttempts: 8, NextAttemptAt: past},
+			mailer:       failingMailer{},
+			wantClaimed:  1,
+			wantStatus:   entity.EmailOutboxDead,
+			wantAttempts: 8,
+		},
+		{
+			name:         "stuck sending email is reclaimed",
+			outbox:       entity.EmailOutbox{Status: entity.EmailOutboxSending, Attempts: 1, MaxAttempts: 8, NextAttemptAt: past, LockedUntil: &past},
+			mailer:       mailer.NewMemoryMailer(),
+			wantClaimed:  1,
+			wantStatus:   entity.EmailOutboxSent,
+			wantAttempts: 2,
+			wantSent:     1,
+		},
+		{
+			name:         "email leased by another worker is skipped",
+			outbox:       entity.EmailOutbox{Status: entity.EmailOutboxSending, MaxAttempts: 8, NextAttemptAt: past, LockedUntil: &future},
+			mailer:       mailer.NewMemoryMailer(),
+			wantStatus:   entity.EmailOutboxSending,
+			wantAttempts: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.outbox.ID = uuid.New()
+			tt.outbox.ToEmail = "guest@example.com"
+			repo := newFakeEmailOutboxRepo(tt.outbox)
+			es := NewEmailOutboxService(repo, tt.mailer)
+
+			claimed, err := es.DeliverDue(context.Background())
+			if err != nil {
+				t.Fatalf("DeliverDue() error = %v", err)
+			}
+			if claimed != tt.wantClaimed {
+				t.Fatalf("DeliverDue() claimed %d, want %d", claimed, tt.wantClaimed)
+			}
+
+			got := repo.get(tt.outbox.ID)
+			if got.Status != tt.wantStatus || got.Attempts != tt.wantAttempts {
+				t.Fatalf("outbox status = %s attempts = %d, want %s attempts = %d", got.Status, got.Attempts, tt.wantStatus, tt.wantAttempts)
+			}
+
+			if tt.wantClaimed > 0 && got.LockedUntil != nil {
+				t.Errorf("outbox still leased after delivery")
+			}
+			if got.Status == entity.EmailOutboxFailed && tt.wantClaimed > 0 && !got.NextAttemptAt.After(time.Now()) {
+				t.Errorf("failed outbox next attempt %v is not in the future", got.NextAttemptAt)
+			}
+
+			if memory, ok := tt.mailer.(*mailer.MemoryMailer); ok && len(memory.Messages()) != tt.wantSent {
+				t.Errorf("sent %d emails, want %d", len(memory.Messages()), tt.wantSent)
+			}
+		})
+	}
+}
+
+func TestEmailOutboxDeliverLosesLease(t *testing.T) {
+	ourLease := emailOutboxLease(time.Now())
+	theirLease := ourLease.Add(time.Minute)
+
+	outbox := entity.EmailOutbox{ID: uuid.New(), ToEmail: "guest@example.com", Status: entity.EmailOutboxSending, MaxAttempts: 8, LockedUntil: &theirLease}
+	repo := newFakeEmailOutboxRepo(outbox)
+	memory := mailer.NewMemoryMailer()
+	es := NewEmailOutboxService(repo, memory)
+
+	// worker ini masih memegang lease lama, padahal email sudah diambil worker lain
+	outbox.LockedUntil = &ourLease
+	es.deliver(context.Background(), outbox)
+
+	if len(memory.Messages()) != 0 {
+		t.Fatalf("sent %d emails without holding the lease", len(memory.Messages()))
+	}
+	if got := repo.get(outbox.ID); got.Status != entity.EmailOutboxSending || !got.LockedUntil.Equal(theirLease) {
+		t.Fatalf("outbox = %s locked until %v, want untouched", got.Status, got.LockedUntil)
+	}
+}
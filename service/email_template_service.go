@@ -0,0 +1,274 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	htmltemplate "html/template"
+	"os"
+	"path/filepath"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/Amierza/TedXBackend/constants"
+	"github.com/Amierza/TedXBackend/dto"
+	"github.com/Amierza/TedXBackend/entity"
+	"github.com/Amierza/TedXBackend/repository"
+	emailtemplate "github.com/Amierza/TedXBackend/utils/email_template"
+)
+
+type (
+	IEmailTemplateService interface {
+		Resolve(ctx context.Context, key entity.EmailTemplateKey) (entity.EmailTemplate, error)
+		Render(ctx context.Context, key entity.EmailTemplateKey, data any) (map[string]string, error)
+	}
+
+	EmailTemplateService struct {
+		emailTemplateRepo repository.IEmailTemplateRepository
+	}
+
+	eTicketEmailData struct {
+		HeaderImage  htmltemplate.URL
+		TicketID     string
+		Status       string
+		AttendeeName string
+		Email        string
+		AudienceType string
+		BookingDate  string
+		Price        string
+		QRCode       htmltemplate.URL
+		QRCodeURL    string
+	}
+	waitlistOfferEmailData struct {
+		HeaderImage  htmltemplate.URL
+		AttendeeName string
+		TicketName   string
+		ExpiresAt    string
+		ClaimURL     string
+	}
+	purchaseReceiptEmailData struct {
+		HeaderImage   htmltemplate.URL
+		OrderID       string
+		Status        string
+		PurchaserName string
+		BookingDate   string
+		Price         string
+		Attendees     []purchaseReceiptAttendee
+	}
+	broadcastEmailData struct {
+		HeaderImage  htmltemplate.URL
+		Subject      string
+		Message      string
+		AttendeeName string
+		Email        string
+		EventName    string
+	}
+	reminderEmailData struct {
+		HeaderImage  htmltemplate.URL
+		AttendeeName string
+		EventName    string
+		EventDate    string
+		Venue        string
+		TimeLeft     string
+		QRCode       htmltemplate.URL
+		QRCodeURL    string
+	}
+	emailVerificationEmailData struct {
+		HeaderImage htmltemplate.URL
+		Name        string
+		Email       string
+		EmailChange bool
+		ExpiresAt   string
+		VerifyURL   string
+	}
+	passwordResetEmailData struct {
+		HeaderImage htmltemplate.URL
+		Name        string
+		Email       string
+		ExpiresAt   string
+		ResetURL    string
+	}
+	purchaseReceiptAttendee struct {
+		FullName     string
+		Email        string
+		AudienceType string
+		QRCode       htmltemplate.URL
+		QRCodeURL    string
+	}
+)
+
+func NewEmailTemplateService(emailTemplateRepo repository.IEmailTemplateRepository) *EmailTemplateService {
+	return &EmailTemplateService{
+		emailTemplateRepo: emailTemplateRepo,
+	}
+}
+
+const emailHeaderImageFile = "header-e-ticket-mail.png"
+
+// htmltemplate.URL karena html/template menolak skema cid:
+func emailHeaderImage() htmltemplate.URL {
+	return htmltemplate.URL(fmt.Sprintf("%s/assets_static/%s", os.Getenv("BASE_URL"), emailHeaderImageFile))
+}
+func emailInlineImage(attachment entity.EmailAttachment) htmltemplate.URL {
+	return htmltemplate.URL("cid:" + attachment.ContentID)
+}
+
+// emailHeaderAttachment hanya menyimpan path; file header dibaca worker saat email dikirim.
+func emailHeaderAttachment() entity.EmailAttachment {
+	return entity.EmailAttachment{
+		Filename:    emailHeaderImageFile,
+		ContentType: "image/png",
+		ContentID:   emailHeaderImageFile,
+		Path:        filepath.Join("assets_static", emailHeaderImageFile),
+	}
+}
+
+// renderEmailTemplate: subject dan teks memakai text/template, HTML memakai html/template.
+func renderEmailTemplate(tmpl entity.EmailTemplate, data any) (map[string]string, error) {
+	subjectTmpl, err := texttemplate.New("subject").Parse(tmpl.Subject)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse subject template: %w", err)
+	}
+
+	var subject bytes.Buffer
+	if err := subjectTmpl.Execute(&subject, data); err != nil {
+		return nil, fmt.Errorf("failed to execute subject template: %w", err)
+	}
+
+	htmlTmpl, err := htmltemplate.New(string(tmpl.Key)).Parse(tmpl.HTML)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse HTML template: %w", err)
+	}
+
+	var html bytes.Buffer
+	if err := htmlTmpl.Execute(&html, data); err != nil {
+		return nil, fmt.Errorf("failed to execute HTML template: %w", err)
+	}
+
+	var text bytes.Buffer
+	if tmpl.Text != "" {
+		textTmpl, err := texttemplate.New("text").Parse(tmpl.Text)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse text template: %w", err)
+		}
+
+		if err := textTmpl.Execute(&text, data); err != nil {
+			return nil, fmt.Errorf("failed to execute text template: %w", err)
+		}
+	}
+
+	return map[string]string{
+		"subject": strings.TrimSpace(subject.String()),
+		"body":    html.String(),
+		"text":    text.String(),
+	}, nil
+}
+
+// sampleEmailTemplateData dipakai untuk validasi dan preview template.
+func sampleEmailTemplateData(key entity.EmailTemplateKey) any {
+	now := time.Now()
+
+	switch key {
+	case entity.EmailTemplateWaitlistOffer:
+		return waitlistOfferEmailData{
+			HeaderImage:  emailHeaderImage(),
+			AttendeeName: "Airlangga Putra",
+			TicketName:   "Main Event",
+			ExpiresAt:    now.Add(30 * time.Minute).Format("02 Jan 2006 15:04"),
+			ClaimURL:     getFrontendURL() + "/waitlist/claim?ticket_id=sample&token=sample",
+		}
+	case entity.EmailTemplateVerification:
+		return emailVerificationEmailData{
+			HeaderImage: emailHeaderImage(),
+			Name:        "Airlangga Putra",
+			Email:       "guest@example.com",
+			ExpiresAt:   now.Add(constants.ENUM_EMAIL_VERIFICATION_EXPIRY_HOURS * time.Hour).Format("02 Jan 2006 15:04"),
+			VerifyURL:   getFrontendURL() + "/verify-email?token=sample",
+		}
+	case entity.EmailTemplatePasswordReset:
+		return passwordResetEmailData{
+			HeaderImage: emailHeaderImage(),
+			Name:        "Airlangga Putra",
+			Email:       "guest@example.com",
+			ExpiresAt:   now.Add(constants.ENUM_PASSWORD_RESET_EXPIRY_MINUTES * time.Minute).Format("02 Jan 2006 15:04"),
+			ResetURL:    getFrontendURL() + "/reset-password?token=sample",
+		}
+	case entity.EmailTemplateBroadcast:
+		return broadcastEmailData{
+			HeaderImage:  emailHeaderImage(),
+			Subject:      "Venue update",
+			Message:      "The main event has moved to Airlangga Convention Center.\nDoors open at 08:00.",
+			AttendeeName: "Airlangga Putra",
+			Email:        "attendee@example.com",
+			EventName:    "TEDxUniversitasAirlangga",
+		}
+	case entity.EmailTemplateReminder:
+		return reminderEmailData{
+			HeaderImage:  emailHeaderImage(),
+			AttendeeName: "Airlangga Putra",
+			EventName:    "TEDxUniversitasAirlangga",
+			EventDate:    now.Add(24 * time.Hour).Format("02 Jan 2006 15:04"),
+			Venue:        "Airlangga Convention Center",
+			TimeLeft:     "1 day",
+			QRCode:       htmltemplate.URL(ticketQRLink(entity.TicketForm{})),
+			QRCodeURL:    ticketQRLink(entity.TicketForm{}),
+		}
+	case entity.EmailTemplatePurchaseReceipt:
+		return purchaseReceiptEmailData{
+			HeaderImage:   emailHeaderImage(),
+			OrderID:       "TEDX-SAMPLE",
+			Status:        "settlement",
+			PurchaserName: "Airlangga Putra",
+			BookingDate:   now.Format("02 Jan 2006 15:04"),
+			Price:         "Rp 300000",
+			Attendees: []purchaseReceiptAttendee{
+				{FullName: "Airlangga Putra", Email: "attendee@example.com", AudienceType: string(entity.Regular), QRCode: htmltemplate.URL(ticketQRLink(entity.TicketForm{})), QRCodeURL: ticketQRLink(entity.TicketForm{})},
+				{FullName: "Kirana Dewi", Email: "attendee@example.com", AudienceType: string(entity.Regular), QRCode: htmltemplate.URL(ticketQRLink(entity.TicketForm{})), QRCodeURL: ticketQRLink(entity.TicketForm{})},
+			},
+		}
+	default:
+		return eTicketEmailData{
+			HeaderImage:  emailHeaderImage(),
+			TicketID:     "00000000-0000-0000-0000-000000000000",
+			Status:       "settlement",
+			AttendeeName: "Airlangga Putra",
+			Email:        "attendee@example.com",
+			AudienceType: string(entity.Regular),
+			BookingDate:  now.Format("02 Jan 2006 15:04"),
+			Price:        "Rp 150000",
+			QRCode:       htmltemplate.URL(ticketQRLink(entity.TicketForm{})),
+			QRCodeURL:    ticketQRLink(entity.TicketForm{}),
+		}
+	}
+}
+
+func (ets *EmailTemplateService) Resolve(ctx context.Context, key entity.EmailTemplateKey) (entity.EmailTemplate, error) {
+	emailTemplate, found, err := ets.emailTemplateRepo.GetEmailTemplateByKey(ctx, nil, string(key))
+	if err != nil {
+		return entity.EmailTemplate{}, dto.ErrGetEmailTemplate
+	}
+	if found {
+		return emailTemplate, nil
+	}
+
+	fallback, ok := emailtemplate.Defaults[string(key)]
+	if !ok {
+		return entity.EmailTemplate{}, dto.ErrEmailTemplateNotFound
+	}
+
+	return entity.EmailTemplate{
+		Key:     key,
+		Subject: fallback.Subject,
+		HTML:    fallback.HTML,
+		Text:    fallback.Text,
+	}, nil
+}
+func (ets *EmailTemplateService) Render(ctx context.Context, key entity.EmailTemplateKey, data any) (map[string]string, error) {
+	emailTemplate, err := ets.Resolve(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return renderEmailTemplate(emailTemplate, data)
+}
@@ -0,0 +1,276 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Amierza/TedXBackend/constants"
+	"github.com/Amierza/TedXBackend/dto"
+	"github.com/Amierza/TedXBackend/entity"
+	"github.com/Amierza/TedXBackend/repository"
+	"github.com/Amierza/TedXBackend/utils/calendar"
+	"github.com/google/uuid"
+)
+
+type (
+	IEventReminderService interface {
+		SendDueReminders(ctx context.Context) (int, error)
+		StartWorker(interval time.Duration)
+	}
+
+	EventReminderService struct {
+		eventReminderRepo    repository.IEventReminderRepository
+		emailTemplateService IEmailTemplateService
+		offsets              []time.Duration
+	}
+)
+
+// NewEventReminderService mengurutkan offset dari yang terbesar.
+func NewEventReminderService(eventReminderRepo repository.IEventReminderRepository, emailTemplateService IEmailTemplateService, offsets []time.Duration) *EventReminderService {
+	sorted := append([]time.Duration(nil), offsets...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] > sorted[j] })
+
+	return &EventReminderService{
+		eventReminderRepo:    eventReminderRepo,
+		emailTemplateService: emailTemplateService,
+		offsets:              sorted,
+	}
+}
+
+// ParseEventReminderOffsets membaca offset seperti "7d,1d,3h"; satuan d berarti hari.
+func ParseEventReminderOffsets(value string) ([]time.Duration, error) {
+	if strings.TrimSpace(value) == "" {
+		value = constants.ENUM_EVENT_REMINDER_OFFSETS
+	}
+
+	var offsets []time.Duration
+	seen := make(map[time.Duration]bool)
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		var offset time.Duration
+		if days, ok := strings.CutSuffix(part, "d"); ok {
+			n, err := strconv.Atoi(days)
+			if err != nil {
+				return nil, fmt.Errorf("invalid reminder offset %q", part)
+			}
+			offset = time.Duration(n) * 24 * time.Hour
+		} else {
+			d, err := time.ParseDuration(part)
+			if err != nil {
+				return nil, fmt.Errorf("invalid reminder offset %q", part)
+			}
+			offset = d
+		}
+
+		if offset < time.Minute {
+			return nil, fmt.Errorf("reminder offset %q must be at least one minute", part)
+		}
+
+		if !seen[offset] {
+			seen[offset] = true
+			offsets = append(offsets, offset)
+		}
+	}
+
+	if len(offsets) == 0 {
+		return nil, errors.New("no reminder offset configured")
+	}
+
+	return offsets, nil
+}
+
+// reminderTimeLeft membulatkan sisa waktu: 167h -> "7 days", 3h10m -> "3 hours".
+func reminderTimeLeft(d time.Duration) string {
+	unit, n := "minute", int(d/time.Minute)
+	switch {
+	case d >= 24*time.Hour:
+		unit, n = "day", int((d+12*time.Hour)/(24*time.Hour))
+	case d >= time.Hour:
+		unit, n = "hour", int((d+30*time.Minute)/time.Hour)
+	case n < 1:
+		n = 1
+	}
+
+	if n != 1 {
+		unit += "s"
+	}
+
+	return fmt.Sprintf("%d %s", n, unit)
+}
+
+// reminderEvent: transaksi bundle mengambil event dan tanggalnya dari Bundle.
+func reminderEvent(transaction entity.Transaction) (entity.Event, string, time.Time) {
+	if transaction.TicketID == nil {
+		return transaction.Bundle.Event, transaction.Bundle.Name, transaction.Bundle.EventDate
+	}
+
+	return transaction.Ticket.Event, transaction.Ticket.Name, transaction.Ticket.EventDate
+}
+func toReminderEmailData(transaction entity.Transaction, form entity.TicketForm, qrURL string) reminderEmailData {
+	event, itemName, eventDate := reminderEvent(transaction)
+
+	eventName := event.Name
+	if eventName == "" {
+		eventName = itemName
+	}
+
+	venue := event.Venue
+	if venue == "" {
+		venue = "TBA"
+	}
+
+	return reminderEmailData{
+		HeaderImage:  emailHeaderImage(),
+		AttendeeName: form.FullName,
+		EventName:    eventName,
+		EventDate:    eventDate.Format("02 Jan 2006 15:04"),
+		Venue:        venue,
+		TimeLeft:     reminderTimeLeft(time.Until(eventDate)),
+		QRCode:       htmltemplate.URL(qrURL),
+		QRCodeURL:    qrURL,
+	}
+}
+
+// reminderCalendarAttachment memakai UID per ticket form supaya reminder H-1 memperbarui entri H-7.
+func reminderCalendarAttachment(transaction entity.Transaction, form entity.TicketForm, data reminderEmailData) entity.EmailAttachment {
+	event, _, eventDate := reminderEvent(transaction)
+
+	end := eventDate
+	if event.EndAt.After(eventDate) {
+		end = event.EndAt
+	}
+
+	ics := calendar.Render(calendar.Event{
+		UID:         form.ID.String() + "@tedxuniversitasairlangga",
+		Summary:     data.EventName,
+		Description: fmt.Sprintf("Ticket holder: %s\nShow your QR code at the gate: %s", form.FullName, data.QRCodeURL),
+		Location:    event.Venue,
+		Start:       eventDate,
+		End:         end,
+	})
+
+	return entity.EmailAttachment{
+		Filename:    "event.ics",
+		ContentType: calendar.ContentType,
+		Data:        ics,
+	}
+}
+func (ers *EventReminderService) newReminderEmail(ctx context.Context, form entity.TicketForm) (entity.EmailOutbox, error) {
+	transaction := form.Transaction
+
+	qr, err := generateTicketQRCode(form, transactionEventID(transaction))
+	if err != nil {
+		return entity.EmailOutbox{}, dto.ErrGenerateQRCode
+	}
+
+	header := emailHeaderAttachment()
+	qrImage := ticketQRAttachment(form, qr)
+
+	data := toReminderEmailData(transaction, form, qr.URL)
+	ics := reminderCalendarAttachment(transaction, form, data)
+	data.HeaderImage = emailInlineImage(header)
+	data.QRCode = emailInlineImage(qrImage)
+
+	draftEmail, err := ers.emailTemplateService.Render(ctx, entity.EmailTemplateReminder, data)
+	if err != nil {
+		return entity.EmailOutbox{}, dto.ErrMakeReminderEmail
+	}
+
+	outbox := newEmailOutbox(string(entity.EmailTemplateReminder), form.Email, draftEmail)
+	outbox.TicketFormID = &form.ID
+	outbox.Attachments = entity.EmailAttachments{header, qrImage, ics}
+
+	return outbox, nil
+}
+
+// sendReminder mencatat reminder dan email-nya dalam satu transaksi.
+func (ers *EventReminderService) sendReminder(ctx context.Context, form entity.TicketForm, offset time.Duration) (bool, error) {
+	outbox, err := ers.newReminderEmail(ctx, form)
+	if err != nil {
+		return false, err
+	}
+
+	created := false
+	err = ers.eventReminderRepo.RunInTransaction(ctx, func(txRepo repository.IEventReminderRepository) error {
+		created, err = txRepo.CreateEventReminder(ctx, nil, entity.EventReminder{
+			ID:            uuid.New(),
+			OffsetMinutes: int(offset / time.Minute),
+			TicketFormID:  form.ID,
+			EmailOutboxID: &outbox.ID,
+		})
+		if err != nil {
+			return dto.ErrCreateEventReminder
+		}
+		if !created {
+			return nil
+		}
+
+		if err := txRepo.CreateEmailOutbox(ctx, nil, outbox); err != nil {
+			return dto.ErrCreateEmailOutbox
+		}
+
+		return nil
+	})
+
+	return created, err
+}
+
+// SendDueReminders: setiap offset hanya berlaku sampai offset berikutnya.
+func (ers *EventReminderService) SendDueReminders(ctx context.Context) (int, error) {
+	now := time.Now()
+
+	sent := 0
+	for i, offset := range ers.offsets {
+		var next time.Duration
+		if i+1 < len(ers.offsets) {
+			next = ers.offsets[i+1]
+		}
+
+		for {
+			ticketForms, err := ers.eventReminderRepo.GetAllDueReminderTicketForm(ctx, nil, int(offset/time.Minute), now.Add(next), now.Add(offset), constants.ENUM_EVENT_REMINDER_BATCH_SIZE)
+			if err != nil {
+				return sent, err
+			}
+
+			created := 0
+			for _, form := range ticketForms {
+				ok, err := ers.sendReminder(ctx, form, offset)
+				if err != nil {
+					log.Printf("failed to queue %s reminder for ticket form %s: %v", reminderTimeLeft(offset), form.ID, err)
+					continue
+				}
+				if ok {
+					created++
+				}
+			}
+			sent += created
+
+			if len(ticketForms) < constants.ENUM_EVENT_REMINDER_BATCH_SIZE || created == 0 {
+				break
+			}
+		}
+	}
+
+	return sent, nil
+}
+func (ers *EventReminderService) StartWorker(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if _, err := ers.SendDueReminders(context.Background()); err != nil {
+			log.Printf("failed to send event reminders: %v", err)
+		}
+	}
+}
@@ -1,12 +1,16 @@
 package service
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"time"
 
 	"github.com/Amierza/TedXBackend/dto"
+	"github.com/Amierza/TedXBackend/entity"
 	"github.com/golang-jwt/jwt/v5"
+	"gorm.io/gorm"
 )
 
 type (
@@ -15,6 +19,12 @@ type (
 		ValidateToken(token string) (*jwt.Token, error)
 		GetUserIDByToken(tokenString string) (string, error)
 		GetRoleIDByToken(tokenString string) (string, error)
+		IsTokenRevoked(ctx context.Context, tokenString string) (bool, error)
+	}
+
+	// IUserLookup cukup untuk membaca PasswordChangedAt saat mengecek token yang dicabut
+	IUserLookup interface {
+		GetUserByID(ctx context.Context, tx *gorm.DB, userID string) (entity.User, bool, error)
 	}
 
 	jwtCustomClaim struct {
@@ -24,15 +34,17 @@ type (
 	}
 
 	JWTService struct {
-		secretKey string
-		issuer    string
+		secretKey  string
+		issuer     string
+		userLookup IUserLookup
 	}
 )
 
-func NewJWTService() *JWTService {
+func NewJWTService(userLookup IUserLookup) *JWTService {
 	return &JWTService{
-		secretKey: getSecretKey(),
-		issuer:    "Template",
+		secretKey:  getSecretKey(),
+		issuer:     "Template",
+		userLookup: userLookup,
 	}
 }
 
@@ -113,3 +125,35 @@ func (j *JWTService) GetRoleIDByToken(tokenString string) (string, error) {
 
 	return roleID, nil
 }
+
+// IsTokenRevoked membaca user di setiap request; user yang sudah dihapus dianggap dicabut.
+func (j *JWTService) IsTokenRevoked(ctx context.Context, tokenString string) (bool, error) {
+	token, err := j.ValidateToken(tokenString)
+	if err != nil {
+		return false, dto.ErrValidateToken
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return false, dto.ErrTokenInvalid
+	}
+
+	user, found, err := j.userLookup.GetUserByID(ctx, nil, fmt.Sprintf("%v", claims["user_id"]))
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && !found) {
+		return true, nil
+	}
+	if err != nil {
+		return false, dto.ErrCheckTokenRevoked
+	}
+	if user.PasswordChangedAt == nil {
+		return false, nil
+	}
+
+	issuedAt, err := claims.GetIssuedAt()
+	if err != nil || issuedAt == nil {
+		return true, nil
+	}
+
+	// iat hanya presisi detik
+	return !issuedAt.After(user.PasswordChangedAt.Truncate(time.Second)), nil
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Amierza/TedXBackend/dto"
+	"github.com/Amierza/TedXBackend/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type fakeUserLookup struct {
+	user entity.User
+	err  error
+}
+
+func (fl fakeUserLookup) GetUserByID(ctx context.Context, tx *gorm.DB, userID string) (entity.User, bool, error) {
+	if fl.err != nil {
+		return entity.User{}, false, fl.err
+	}
+
+	return fl.user, true, nil
+}
+
+func TestIsTokenRevoked(t *testing.T) {
+	userID := uuid.New()
+	hourAgo := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name            string
+		lookup          fakeUserLookup
+		changedOnIssued bool
+		want            bool
+		wantErr         error
+	}{
+		{"password never changed", fakeUserLookup{user: entity.User{ID: userID}}, false, false, nil},
+		{"password changed before the token", fakeUserLookup{user: entity.User{ID: userID, PasswordChangedAt: &hourAgo}}, false, false, nil},
+		{"password changed right after the token", fakeUserLookup{user: entity.User{ID: userID}}, true, true, nil},
+		{"deleted user", fakeUserLookup{err: gorm.ErrRecordNotFound}, false, true, nil},
+		{"database error", fakeUserLookup{err: errors.New("connection refused")}, false, false, dto.ErrCheckTokenRevoked},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := NewJWTService(nil).GenerateToken(userID.String(), "user")
+			if err != nil {
+				t.Fatalf("GenerateToken() error = %v", err)
+			}
+
+			if tt.changedOnIssued {
+				// iat dibulatkan ke detik, jadi perubahan di detik yang sama harus tetap mencabut token
+				changedAt := time.Now()
+				tt.lookup.user.PasswordChangedAt = &changedAt
+			}
+
+			got, err := NewJWTService(tt.lookup).IsTokenRevoked(context.Background(), token)
+			if !errors.Is(err, tt.wantErr) || got != tt.want {
+				t.Fatalf("IsTokenRevoked() = %v, %v, want %v, %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
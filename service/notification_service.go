@@ -0,0 +1,281 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Amierza/TedXBackend/constants"
+	"github.com/Amierza/TedXBackend/entity"
+	"github.com/Amierza/TedXBackend/repository"
+	"github.com/Amierza/TedXBackend/utils/notifier"
+	"github.com/google/uuid"
+)
+
+type (
+	INotificationService interface {
+		DeliverDue(ctx context.Context) (int, error)
+		QueueScheduledNotifications(ctx context.Context) (int, error)
+		StartWorker(interval time.Duration)
+		StartScheduler(interval time.Duration)
+	}
+
+	NotificationService struct {
+		notificationOutboxRepo repository.INotificationOutboxRepository
+		notifiers              map[string]notifier.Notifier
+	}
+)
+
+func NewNotificationService(notificationOutboxRepo repository.INotificationOutboxRepository, notifiers ...notifier.Notifier) *NotificationService {
+	byChannel := make(map[string]notifier.Notifier, len(notifiers))
+	for _, n := range notifiers {
+		byChannel[n.Channel()] = n
+	}
+
+	return &NotificationService{
+		notificationOutboxRepo: notificationOutboxRepo,
+		notifiers:              byChannel,
+	}
+}
+
+// newWhatsAppNotification: false berarti pemegang tiket tidak opt-in.
+func newWhatsAppNotification(kind entity.NotificationKind, form entity.TicketForm, body string, params []string) (entity.NotificationOutbox, bool) {
+	if !form.WhatsAppOptIn || form.PhoneNumber == "" {
+		return entity.NotificationOutbox{}, false
+	}
+
+	return entity.NotificationOutbox{
+		ID:            uuid.New(),
+		Channel:       constants.ENUM_NOTIFICATION_CHANNEL_WHATSAPP,
+		Kind:          kind,
+		ToPhone:       form.PhoneNumber,
+		Body:          body,
+		Params:        params,
+		DedupeKey:     fmt.Sprintf("%s:%s:%s", constants.ENUM_NOTIFICATION_CHANNEL_WHATSAPP, kind, form.ID),
+		Status:        entity.NotificationOutboxPending,
+		MaxAttempts:   constants.ENUM_NOTIFICATION_OUTBOX_MAX_ATTEMPTS,
+		NextAttemptAt: time.Now(),
+		TicketFormID:  &form.ID,
+	}, true
+}
+
+// notificationLocation: jam di pesan dan batas hari-H mengikuti WIB.
+func notificationLocation() *time.Location {
+	loc, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		loc = time.FixedZone("UTC+7", 7*60*60)
+	}
+
+	return loc
+}
+func notificationEventName(transaction entity.Transaction) string {
+	event, itemName, _ := reminderEvent(transaction)
+	if event.Name != "" {
+		return event.Name
+	}
+
+	return itemName
+}
+
+// notificationQRLink: link download QR di WhatsApp dibuat berumur pendek.
+func notificationQRLink(form entity.TicketForm) string {
+	return newTicketQRLink(form, constants.ENUM_NOTIFICATION_QR_LINK_EXPIRY_HOURS*time.Hour)
+}
+func newETicketNotification(transaction entity.Transaction, form entity.TicketForm) (entity.NotificationOutbox, bool) {
+	eventName := notificationEventName(transaction)
+	qrURL := notificationQRLink(form)
+
+	body := fmt.Sprintf("Hi %s, your e-ticket for %s is ready. Show this QR code at the gate: %s", form.FullName, eventName, qrURL)
+
+	return newWhatsAppNotification(entity.NotificationETicket, form, body, []string{form.FullName, eventName, qrURL})
+}
+func newPaymentReminderNotification(form entity.TicketForm) (entity.NotificationOutbox, bool) {
+	transaction := form.Transaction
+
+	itemName := transaction.Ticket.Name
+	if transaction.TicketID == nil {
+		itemName = transaction.Bundle.Name
+	}
+
+	paymentURL := transaction.PaymentURL
+	if paymentURL == "" {
+		paymentURL = getFrontendURL()
+	}
+
+	body := fmt.Sprintf("Hi %s, your order %s for %s is still waiting for payment. Complete it before it expires: %s", form.FullName, transaction.OrderID, itemName, paymentURL)
+
+	return newWhatsAppNotification(entity.NotificationPaymentReminder, form, body, []string{form.FullName, transaction.OrderID, itemName, paymentURL})
+}
+func newEventDayNotification(form entity.TicketForm) (entity.NotificationOutbox, bool) {
+	transaction := form.Transaction
+	event, _, eventDate := reminderEvent(transaction)
+	eventName := notificationEventName(transaction)
+
+	venue := event.Venue
+	if venue == "" {
+		venue = "TBA"
+	}
+
+	startAt := eventDate.In(notificationLocation()).Format("15:04")
+	qrURL := notificationQRLink(form)
+
+	body := fmt.Sprintf("Hi %s, today is %s! It starts at %s at %s. Show this QR code at the gate: %s", form.FullName, eventName, startAt, venue, qrURL)
+
+	return newWhatsAppNotification(entity.NotificationEventDay, form, body, []string{form.FullName, eventName, startAt, venue, qrURL})
+}
+
+func (ns *NotificationService) deliver(ctx context.Context, outbox entity.NotificationOutbox) {
+	now := time.Now()
+	outbox.Attempts++
+	outbox.LockedUntil = nil
+
+	var (
+		messageID string
+		err       error
+	)
+	n, ok := ns.notifiers[outbox.Channel]
+	if !ok {
+		err = fmt.Errorf("no notifier configured for channel %q", outbox.Channel)
+	} else {
+		messageID, err = n.Send(ctx, notifier.Message{
+			Kind:   string(outbox.Kind),
+			To:     outbox.ToPhone,
+			Body:   outbox.Body,
+			Params: outbox.Params,
+		})
+	}
+
+	if err != nil {
+		outbox.LastError = err.Error()
+		if outbox.Attempts >= outbox.MaxAttempts {
+			outbox.Status = entity.NotificationOutboxDead
+			log.Printf("notification outbox %s to %s is dead after %d attempts: %v", outbox.ID, outbox.ToPhone, outbox.Attempts, err)
+		} else {
+			outbox.Status = entity.NotificationOutboxFailed
+			outbox.NextAttemptAt = now.Add(emailOutboxBackoff(outbox.Attempts))
+		}
+	} else {
+		outbox.Status = entity.NotificationOutboxSent
+		outbox.LastError = ""
+		outbox.ProviderMessageID = messageID
+		outbox.SentAt = &now
+	}
+
+	if err := ns.notificationOutboxRepo.UpdateNotificationOutboxDelivery(ctx, nil, outbox); err != nil {
+		log.Printf("failed to update notification outbox %s: %v", outbox.ID, err)
+	}
+}
+func (ns *NotificationService) DeliverDue(ctx context.Context) (int, error) {
+	now := time.Now()
+	lockedUntil := now.Add(constants.ENUM_NOTIFICATION_OUTBOX_LEASE_SECONDS * time.Second)
+
+	outboxes, err := ns.notificationOutboxRepo.ClaimDueNotificationOutbox(ctx, nil, now, lockedUntil, constants.ENUM_NOTIFICATION_OUTBOX_BATCH_SIZE)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, outbox := range outboxes {
+		ns.deliver(ctx, outbox)
+	}
+
+	return len(outboxes), nil
+}
+
+// queue: pesan dengan DedupeKey yang sudah ada tidak dihitung.
+func (ns *NotificationService) queue(ctx context.Context, ticketForms []entity.TicketForm, build func(entity.TicketForm) (entity.NotificationOutbox, bool)) (int, error) {
+	queued := 0
+	for _, form := range ticketForms {
+		outbox, ok := build(form)
+		if !ok {
+			continue
+		}
+
+		created, err := ns.notificationOutboxRepo.CreateNotificationOutbox(ctx, nil, outbox)
+		if err != nil {
+			return queued, err
+		}
+		if created {
+			queued++
+		}
+	}
+
+	return queued, nil
+}
+
+// QueueScheduledNotifications mengantrikan payment reminder dan pemberitahuan hari-H.
+func (ns *NotificationService) QueueScheduledNotifications(ctx context.Context) (int, error) {
+	now := time.Now()
+	queued := 0
+
+	// Snap Midtrans kedaluwarsa setelah 24 jam, transaksi yang lebih lama tidak diingatkan lagi
+	for {
+		ticketForms, err := ns.notificationOutboxRepo.GetAllPaymentReminderTicketForm(ctx, nil, now.Add(-constants.ENUM_PAYMENT_REMINDER_EXPIRY_HOURS*time.Hour), now.Add(-constants.ENUM_PAYMENT_REMINDER_AFTER_MINUTES*time.Minute), constants.ENUM_NOTIFICATION_SCHEDULE_BATCH_SIZE)
+		if err != nil {
+			return queued, err
+		}
+
+		n, err := ns.queue(ctx, ticketForms, newPaymentReminderNotification)
+		queued += n
+		if err != nil {
+			return queued, err
+		}
+
+		if len(ticketForms) < constants.ENUM_NOTIFICATION_SCHEDULE_BATCH_SIZE || n == 0 {
+			break
+		}
+	}
+
+	loc := notificationLocation()
+	local := now.In(loc)
+	if local.Hour() < constants.ENUM_EVENT_DAY_NOTICE_HOUR {
+		return queued, nil
+	}
+
+	endOfDay := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc).AddDate(0, 0, 1)
+	for {
+		ticketForms, err := ns.notificationOutboxRepo.GetAllEventDayTicketForm(ctx, nil, now, endOfDay, constants.ENUM_NOTIFICATION_SCHEDULE_BATCH_SIZE)
+		if err != nil {
+			return queued, err
+		}
+
+		n, err := ns.queue(ctx, ticketForms, newEventDayNotification)
+		queued += n
+		if err != nil {
+			return queued, err
+		}
+
+		if len(ticketForms) < constants.ENUM_NOTIFICATION_SCHEDULE_BATCH_SIZE || n == 0 {
+			break
+		}
+	}
+
+	return queued, nil
+}
+func (ns *NotificationService) StartWorker(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		for {
+			delivered, err := ns.DeliverDue(context.Background())
+			if err != nil {
+				log.Printf("failed to deliver notification outbox: %v", err)
+				break
+			}
+			if delivered < constants.ENUM_NOTIFICATION_OUTBOX_BATCH_SIZE {
+				break
+			}
+		}
+	}
+}
+func (ns *NotificationService) StartScheduler(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if _, err := ns.QueueScheduledNotifications(context.Background()); err != nil {
+			log.Printf("failed to queue scheduled notifications: %v", err)
+		}
+	}
+}
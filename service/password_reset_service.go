@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Amierza/TedXBackend/constants"
+	"github.com/Amierza/TedXBackend/dto"
+	"github.com/Amierza/TedXBackend/entity"
+	"github.com/Amierza/TedXBackend/helpers"
+	"github.com/Amierza/TedXBackend/repository"
+	"github.com/google/uuid"
+)
+
+type (
+	IPasswordResetService interface {
+		ForgotPassword(ctx context.Context, req dto.ForgotPasswordRequest, scope passwordResetScope) error
+		ResetPassword(ctx context.Context, req dto.ResetPasswordRequest, scope passwordResetScope) (dto.ResetPasswordResponse, error)
+	}
+
+	PasswordResetService struct {
+		passwordResetRepo    repository.IPasswordResetRepository
+		emailTemplateService IEmailTemplateService
+	}
+
+	// passwordResetScope: API user hanya untuk guest, API admin untuk admin dan crew
+	passwordResetScope struct {
+		roles     []entity.Role
+		resetPath string
+	}
+)
+
+var (
+	guestPasswordReset = passwordResetScope{roles: []entity.Role{entity.Guest}, resetPath: "/reset-password"}
+	adminPasswordReset = passwordResetScope{roles: []entity.Role{entity.Admin, entity.Crew}, resetPath: "/admin/reset-password"}
+)
+
+func NewPasswordResetService(passwordResetRepo repository.IPasswordResetRepository, emailTemplateService IEmailTemplateService) *PasswordResetService {
+	return &PasswordResetService{
+		passwordResetRepo:    passwordResetRepo,
+		emailTemplateService: emailTemplateService,
+	}
+}
+
+func (s passwordResetScope) allows(user entity.User) bool {
+	return slices.Contains(s.roles, user.Role)
+}
+func (prs *PasswordResetService) newPasswordResetEmail(ctx context.Context, user entity.User, token string, expiresAt time.Time, scope passwordResetScope) (entity.EmailOutbox, error) {
+	data := passwordResetEmailData{
+		HeaderImage: emailHeaderImage(),
+		Name:        user.Name,
+		Email:       user.Email,
+		ExpiresAt:   expiresAt.Format("02 Jan 2006 15:04"),
+		ResetURL:    fmt.Sprintf("%s%s?token=%s", getFrontendURL(), scope.resetPath, token),
+	}
+
+	draftEmail, err := prs.emailTemplateService.Render(ctx, entity.EmailTemplatePasswordReset, data)
+	if err != nil {
+		return entity.EmailOutbox{}, dto.ErrMakePasswordResetEmail
+	}
+
+	return newEmailOutbox(string(entity.EmailTemplatePasswordReset), user.Email, draftEmail), nil
+}
+
+// ForgotPassword selalu berhasil untuk email yang valid supaya email terdaftar tidak bisa ditebak.
+func (prs *PasswordResetService) ForgotPassword(ctx context.Context, req dto.ForgotPasswordRequest, scope passwordResetScope) error {
+	email := strings.TrimSpace(req.Email)
+	if !helpers.IsValidEmail(email) {
+		return dto.ErrInvalidEmail
+	}
+
+	user, found, err := prs.passwordResetRepo.GetUserByEmail(ctx, nil, email)
+	if err != nil {
+		return dto.ErrForgotPassword
+	}
+	if !found || !scope.allows(user) || isCrewExpired(user) {
+		return nil
+	}
+
+	since := time.Now().Add(-constants.ENUM_PASSWORD_RESET_WINDOW_MINUTES * time.Minute)
+	requested, err := prs.passwordResetRepo.CountPasswordResetByEmail(ctx, nil, user.Email, since)
+	if err != nil {
+		return dto.ErrCountPasswordReset
+	}
+	if requested >= constants.ENUM_PASSWORD_RESET_REQUEST_LIMIT {
+		return nil
+	}
+
+	token, err := helpers.GenerateRandomToken(32)
+	if err != nil {
+		return dto.ErrGenerateToken
+	}
+
+	expiresAt := time.Now().Add(constants.ENUM_PASSWORD_RESET_EXPIRY_MINUTES * time.Minute)
+	outbox, err := prs.newPasswordResetEmail(ctx, user, token, expiresAt, scope)
+	if err != nil {
+		return err
+	}
+
+	return prs.passwordResetRepo.RunInTransaction(ctx, func(txRepo repository.IPasswordResetRepository) error {
+		if err := txRepo.CreatePasswordReset(ctx, nil, entity.PasswordReset{
+			ID:        uuid.New(),
+			Email:     user.Email,
+			TokenHash: helpers.HashToken(token),
+			ExpiresAt: expiresAt,
+			UserID:    user.ID,
+		}); err != nil {
+			return dto.ErrCreatePasswordReset
+		}
+
+		if err := txRepo.CreateEmailOutbox(ctx, nil, outbox); err != nil {
+			return dto.ErrCreateEmailOutbox
+		}
+
+		return nil
+	})
+}
+
+// ResetPassword mencatat PasswordChangedAt supaya JWT yang terbit sebelumnya ditolak.
+func (prs *PasswordResetService) ResetPassword(ctx context.Context, req dto.ResetPasswordRequest, scope passwordResetScope) (dto.ResetPasswordResponse, error) {
+	if len(req.Password) < 8 {
+		return dto.ResetPasswordResponse{}, dto.ErrPasswordTooShort
+	}
+
+	var res dto.ResetPasswordResponse
+	err := prs.passwordResetRepo.RunInTransaction(ctx, func(txRepo repository.IPasswordResetRepository) error {
+		passwordReset, found, err := txRepo.GetPasswordResetByTokenHash(ctx, nil, helpers.HashToken(req.Token))
+		if err != nil {
+			return dto.ErrResetPassword
+		}
+		if !found {
+			return dto.ErrInvalidPasswordResetToken
+		}
+
+		if passwordReset.UsedAt != nil {
+			return dto.ErrPasswordResetUsed
+		}
+
+		now := time.Now()
+		if !now.Before(passwordReset.ExpiresAt) {
+			return dto.ErrPasswordResetExpired
+		}
+
+		user, found, err := txRepo.GetUserByID(ctx, nil, passwordReset.UserID.String())
+		if err != nil {
+			return dto.ErrResetPassword
+		}
+
+		// link tidak berlaku lagi kalau email akun sudah berganti sejak request
+		if !found || !scope.allows(user) || !strings.EqualFold(user.Email, passwordReset.Email) {
+			return dto.ErrInvalidPasswordResetToken
+		}
+
+		hashP, err := helpers.HashPassword(req.Password)
+		if err != nil {
+			return dto.ErrHashPassword
+		}
+
+		if err := txRepo.UpdateUserPassword(ctx, nil, user.ID.String(), hashP, now); err != nil {
+			return dto.ErrResetPassword
+		}
+
+		if err := txRepo.UsePasswordResetByUserID(ctx, nil, user.ID.String(), now); err != nil {
+			return dto.ErrResetPassword
+		}
+
+		if err := txRepo.DeleteSessionByUserID(ctx, nil, user.ID.String()); err != nil {
+			return dto.ErrRevokeSession
+		}
+
+		res = dto.ResetPasswordResponse{
+			UserID:            user.ID,
+			Email:             user.Email,
+			PasswordChangedAt: now,
+		}
+
+		return nil
+	})
+	if err != nil {
+		return dto.ResetPasswordResponse{}, err
+	}
+
+	return res, nil
+}
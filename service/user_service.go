@@ -1,13 +1,15 @@
 package service
 
 import (
-	"bytes"
 	"context"
 	_ "embed"
+	"errors"
 	"fmt"
-	"html/template"
+	htmltemplate "html/template"
+	"log"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	m "github.com/Amierza/TedXBackend/config/midtrans"
@@ -16,8 +18,7 @@ import (
 	"github.com/Amierza/TedXBackend/entity"
 	"github.com/Amierza/TedXBackend/helpers"
 	"github.com/Amierza/TedXBackend/repository"
-	"github.com/Amierza/TedXBackend/utils"
-	emailtemplate "github.com/Amierza/TedXBackend/utils/email_template"
+	"github.com/Amierza/TedXBackend/utils/badge"
 	"github.com/google/uuid"
 	"github.com/midtrans/midtrans-go"
 	"github.com/midtrans/midtrans-go/snap"
@@ -28,14 +29,30 @@ type (
 		// Authentication
 		Login(ctx context.Context, req dto.LoginRequest) (dto.LoginResponse, error)
 
+		// Email Verification
+		VerifyEmail(ctx context.Context, req dto.VerifyEmailRequest) (dto.EmailVerificationResponse, error)
+		ResendEmailVerification(ctx context.Context) (dto.EmailVerificationResponse, error)
+
+		// Password Reset
+		ForgotPassword(ctx context.Context, req dto.ForgotPasswordRequest) error
+		ResetPassword(ctx context.Context, req dto.ResetPasswordRequest) (dto.ResetPasswordResponse, error)
+
 		// User
 		GetDetailUser(ctx context.Context) (dto.UserResponse, error)
 		UpdateUser(ctx context.Context, req dto.UpdateUserRequest) (dto.UserResponse, error)
 
+		// Event
+		GetAllEvent(ctx context.Context) ([]dto.EventResponse, error)
+		GetDetailEvent(ctx context.Context, eventID string) (dto.EventResponse, error)
+
 		// Ticket
-		GetAllTicket(ctx context.Context) ([]dto.TicketResponse, error)
+		GetAllTicket(ctx context.Context, eventID string) ([]dto.TicketResponse, error)
 		GetDetailTicket(ctx context.Context, ticketID string) (dto.TicketResponse, error)
 
+		// Availability
+		GetAllAvailability(ctx context.Context) ([]dto.AvailabilityResponse, error)
+		SubscribeAvailability() (<-chan dto.AvailabilityResponse, func())
+
 		// Sponsorship
 		GetAllSponsorship(ctx context.Context) ([]dto.SponsorshipResponse, error)
 
@@ -56,18 +73,44 @@ type (
 
 		// Webhook for Midtrans
 		UpdateTransactionTicket(ctx context.Context, req dto.UpdateMidtransTransactionTicketRequest) error
+
+		// Waitlist
+		JoinWaitlist(ctx context.Context, ticketID string) (dto.WaitlistResponse, error)
+		GetMyWaitlist(ctx context.Context) ([]dto.WaitlistResponse, error)
+		LeaveWaitlist(ctx context.Context, waitlistID string) (dto.WaitlistResponse, error)
+
+		// Ticket Transfer
+		TransferTicket(ctx context.Context, ticketFormID string, req dto.TransferTicketRequest) (dto.TicketTransferResponse, error)
+		GetTicketTransferHistory(ctx context.Context, ticketFormID string) ([]dto.TicketTransferResponse, error)
+
+		// Ticket QR
+		GetTicketQRCode(ctx context.Context, token string) ([]byte, error)
+
+		// Resend E-Ticket
+		ResendETicket(ctx context.Context, req dto.ResendETicketRequest) (dto.ResendETicketResponse, error)
+
+		// Notification
+		UpdateWhatsAppOptIn(ctx context.Context, ticketFormID string, req dto.UpdateWhatsAppOptInRequest) (dto.WhatsAppOptInResponse, error)
 	}
 
 	UserService struct {
-		userRepo   repository.IUserRepository
-		jwtService IJWTService
+		userRepo             repository.IUserRepository
+		jwtService           IJWTService
+		waitlistService      IWaitlistService
+		availabilityService  IAvailabilityService
+		emailTemplateService IEmailTemplateService
+		passwordResetService IPasswordResetService
 	}
 )
 
-func NewUserService(userRepo repository.IUserRepository, jwtService IJWTService) *UserService {
+func NewUserService(userRepo repository.IUserRepository, jwtService IJWTService, waitlistService IWaitlistService, availabilityService IAvailabilityService, emailTemplateService IEmailTemplateService, passwordResetService IPasswordResetService) *UserService {
 	return &UserService{
-		userRepo:   userRepo,
-		jwtService: jwtService,
+		userRepo:             userRepo,
+		jwtService:           jwtService,
+		waitlistService:      waitlistService,
+		availabilityService:  availabilityService,
+		emailTemplateService: emailTemplateService,
+		passwordResetService: passwordResetService,
 	}
 }
 
@@ -105,6 +148,149 @@ func (us *UserService) Login(ctx context.Context, req dto.LoginRequest) (dto.Log
 	}, nil
 }
 
+// Email Verification
+// newEmailVerificationEmail: untuk ganti email, link dikirim ke alamat baru.
+func newEmailVerificationEmail(ctx context.Context, emailTemplateService IEmailTemplateService, user entity.User, email string) (entity.EmailOutbox, error) {
+	expiresAt := time.Now().Add(constants.ENUM_EMAIL_VERIFICATION_EXPIRY_HOURS * time.Hour)
+	token := helpers.SignEmailVerification(helpers.EmailVerificationClaims{
+		UserID:    user.ID,
+		Email:     email,
+		ExpiresAt: expiresAt,
+	})
+
+	data := emailVerificationEmailData{
+		HeaderImage: emailHeaderImage(),
+		Name:        user.Name,
+		Email:       email,
+		EmailChange: !strings.EqualFold(email, user.Email),
+		ExpiresAt:   expiresAt.Format("02 Jan 2006 15:04"),
+		VerifyURL:   fmt.Sprintf("%s/verify-email?token=%s", getFrontendURL(), token),
+	}
+
+	draftEmail, err := emailTemplateService.Render(ctx, entity.EmailTemplateVerification, data)
+	if err != nil {
+		return entity.EmailOutbox{}, dto.ErrMakeEmailVerificationEmail
+	}
+
+	return newEmailOutbox(string(entity.EmailTemplateVerification), email, draftEmail), nil
+}
+
+// checkoutRequiresVerifiedEmail dikontrol CHECKOUT_REQUIRE_VERIFIED_EMAIL.
+func checkoutRequiresVerifiedEmail() bool {
+	return os.Getenv("CHECKOUT_REQUIRE_VERIFIED_EMAIL") == "true"
+}
+func toEmailVerificationResponse(user entity.User) dto.EmailVerificationResponse {
+	return dto.EmailVerificationResponse{
+		UserID:        user.ID,
+		Email:         user.Email,
+		PendingEmail:  user.PendingEmail,
+		EmailVerified: user.EmailVerified,
+	}
+}
+func (us *UserService) checkEmailVerificationLimit(ctx context.Context, email string) error {
+	since := time.Now().Add(-constants.ENUM_EMAIL_VERIFICATION_WINDOW_MINUTES * time.Minute)
+	sent, err := us.userRepo.CountEmailOutboxByToEmailAndKind(ctx, nil, email, string(entity.EmailTemplateVerification), since)
+	if err != nil {
+		return dto.ErrCountEmailVerification
+	}
+
+	if sent >= constants.ENUM_EMAIL_VERIFICATION_RESEND_LIMIT {
+		return dto.ErrEmailVerificationRateLimited
+	}
+
+	return nil
+}
+
+// VerifyEmail: token untuk PendingEmail juga mengganti email akun.
+func (us *UserService) VerifyEmail(ctx context.Context, req dto.VerifyEmailRequest) (dto.EmailVerificationResponse, error) {
+	claims, err := helpers.VerifyEmailVerification(req.Token, time.Now())
+	if errors.Is(err, helpers.ErrExpiredEmailVerification) {
+		return dto.EmailVerificationResponse{}, dto.ErrEmailVerificationExpired
+	}
+	if err != nil {
+		return dto.EmailVerificationResponse{}, dto.ErrInvalidEmailVerificationToken
+	}
+
+	user, found, err := us.userRepo.GetUserByID(ctx, nil, claims.UserID.String())
+	if err != nil || !found {
+		return dto.EmailVerificationResponse{}, dto.ErrUserNotFound
+	}
+
+	now := time.Now()
+	switch {
+	case user.PendingEmail != "" && strings.EqualFold(claims.Email, user.PendingEmail):
+		if other, found, _ := us.userRepo.GetUserByEmail(ctx, nil, user.PendingEmail); found && other.ID != user.ID {
+			return dto.EmailVerificationResponse{}, dto.ErrEmailAlreadyExists
+		}
+
+		user.Email = user.PendingEmail
+		user.PendingEmail = ""
+		user.EmailVerified = &now
+
+	case strings.EqualFold(claims.Email, user.Email):
+		if user.EmailVerified != nil {
+			return toEmailVerificationResponse(user), nil
+		}
+
+		user.EmailVerified = &now
+
+	default:
+		return dto.EmailVerificationResponse{}, dto.ErrEmailVerificationStale
+	}
+
+	if err := us.userRepo.UpdateUserEmailVerification(ctx, nil, user); err != nil {
+		return dto.EmailVerificationResponse{}, dto.ErrVerifyEmail
+	}
+
+	return toEmailVerificationResponse(user), nil
+}
+
+// ResendEmailVerification mengirim ke PendingEmail kalau ada, selain itu ke email akun.
+func (us *UserService) ResendEmailVerification(ctx context.Context) (dto.EmailVerificationResponse, error) {
+	token := ctx.Value("Authorization").(string)
+
+	userIDStr, err := us.jwtService.GetUserIDByToken(token)
+	if err != nil {
+		return dto.EmailVerificationResponse{}, dto.ErrGetUserIDFromToken
+	}
+
+	user, found, err := us.userRepo.GetUserByID(ctx, nil, userIDStr)
+	if err != nil || !found {
+		return dto.EmailVerificationResponse{}, dto.ErrUserNotFound
+	}
+
+	email := user.PendingEmail
+	if email == "" {
+		if user.EmailVerified != nil {
+			return dto.EmailVerificationResponse{}, dto.ErrEmailAlreadyVerified
+		}
+		email = user.Email
+	}
+
+	if err := us.checkEmailVerificationLimit(ctx, email); err != nil {
+		return dto.EmailVerificationResponse{}, err
+	}
+
+	outbox, err := newEmailVerificationEmail(ctx, us.emailTemplateService, user, email)
+	if err != nil {
+		return dto.EmailVerificationResponse{}, err
+	}
+
+	if err := us.userRepo.CreateEmailOutbox(ctx, nil, outbox); err != nil {
+		return dto.EmailVerificationResponse{}, dto.ErrCreateEmailOutbox
+	}
+
+	return toEmailVerificationResponse(user), nil
+}
+
+// Password Reset
+func (us *UserService) ForgotPassword(ctx context.Context, req dto.ForgotPasswordRequest) error {
+	return us.passwordResetService.ForgotPassword(ctx, req, guestPasswordReset)
+}
+func (us *UserService) ResetPassword(ctx context.Context, req dto.ResetPasswordRequest) (dto.ResetPasswordResponse, error) {
+	return us.passwordResetService.ResetPassword(ctx, req, guestPasswordReset)
+}
+
 // User
 func (us *UserService) GetDetailUser(ctx context.Context) (dto.UserResponse, error) {
 	token := ctx.Value("Authorization").(string)
@@ -124,6 +310,7 @@ func (us *UserService) GetDetailUser(ctx context.Context) (dto.UserResponse, err
 		Name:          user.Name,
 		Email:         user.Email,
 		EmailVerified: user.EmailVerified,
+		PendingEmail:  user.PendingEmail,
 		Password:      user.Password,
 		Role:          user.Role,
 	}, nil
@@ -143,7 +330,9 @@ func (us *UserService) UpdateUser(ctx context.Context, req dto.UpdateUserRequest
 		return dto.UserResponse{}, dto.ErrUserNotFound
 	}
 
-	if req.Email != "" {
+	// email baru disimpan sebagai PendingEmail sampai link di alamat baru dibuka
+	var verificationEmail *entity.EmailOutbox
+	if req.Email != "" && !strings.EqualFold(req.Email, user.Email) {
 		_, flag, err := us.userRepo.GetUserByEmail(ctx, nil, req.Email)
 		if err == nil || flag {
 			return dto.UserResponse{}, dto.ErrEmailAlreadyExists
@@ -153,7 +342,17 @@ func (us *UserService) UpdateUser(ctx context.Context, req dto.UpdateUserRequest
 			return dto.UserResponse{}, dto.ErrInvalidEmail
 		}
 
-		user.Email = req.Email
+		if err := us.checkEmailVerificationLimit(ctx, req.Email); err != nil {
+			return dto.UserResponse{}, err
+		}
+
+		outbox, err := newEmailVerificationEmail(ctx, us.emailTemplateService, user, req.Email)
+		if err != nil {
+			return dto.UserResponse{}, err
+		}
+
+		user.PendingEmail = req.Email
+		verificationEmail = &outbox
 	}
 
 	if req.Name != "" {
@@ -177,9 +376,21 @@ func (us *UserService) UpdateUser(ctx context.Context, req dto.UpdateUserRequest
 		user.Password = hashP
 	}
 
-	err = us.userRepo.UpdateUser(ctx, nil, user)
+	err = us.userRepo.RunInTransaction(ctx, func(txRepo repository.IUserRepository) error {
+		if err := txRepo.UpdateUser(ctx, nil, user); err != nil {
+			return dto.ErrUpdateUser
+		}
+
+		if verificationEmail != nil {
+			if err := txRepo.CreateEmailOutbox(ctx, nil, *verificationEmail); err != nil {
+				return dto.ErrCreateEmailOutbox
+			}
+		}
+
+		return nil
+	})
 	if err != nil {
-		return dto.UserResponse{}, dto.ErrUpdateUser
+		return dto.UserResponse{}, err
 	}
 
 	res := dto.UserResponse{
@@ -187,6 +398,7 @@ func (us *UserService) UpdateUser(ctx context.Context, req dto.UpdateUserRequest
 		Name:          user.Name,
 		Email:         user.Email,
 		EmailVerified: user.EmailVerified,
+		PendingEmail:  user.PendingEmail,
 		Password:      user.Password,
 		Role:          user.Role,
 	}
@@ -194,9 +406,32 @@ func (us *UserService) UpdateUser(ctx context.Context, req dto.UpdateUserRequest
 	return res, nil
 }
 
+// Event
+func (us *UserService) GetAllEvent(ctx context.Context) ([]dto.EventResponse, error) {
+	events, err := us.userRepo.GetAllEvent(ctx, nil)
+	if err != nil {
+		return nil, dto.ErrGetAllEventNoPagination
+	}
+
+	var datas []dto.EventResponse
+	for _, event := range events {
+		datas = append(datas, toEventResponse(event))
+	}
+
+	return datas, nil
+}
+func (us *UserService) GetDetailEvent(ctx context.Context, eventID string) (dto.EventResponse, error) {
+	event, _, err := us.userRepo.GetEventByID(ctx, nil, eventID)
+	if err != nil {
+		return dto.EventResponse{}, dto.ErrEventNotFound
+	}
+
+	return toEventResponse(event), nil
+}
+
 // Ticket
-func (us *UserService) GetAllTicket(ctx context.Context) ([]dto.TicketResponse, error) {
-	tickets, err := us.userRepo.GetAllTicket(ctx, nil)
+func (us *UserService) GetAllTicket(ctx context.Context, eventID string) ([]dto.TicketResponse, error) {
+	tickets, err := us.userRepo.GetAllTicket(ctx, nil, eventID)
 	if err != nil {
 		return nil, dto.ErrGetAllTicketNoPagination
 	}
@@ -215,6 +450,8 @@ func (us *UserService) GetAllTicket(ctx context.Context) ([]dto.TicketResponse,
 			Description: ticket.Description,
 			EventDate:   ticket.EventDate.Format("2006-01-02"),
 			IsAvailable: &isAvailable,
+			EventID:     ticket.EventID,
+			EventName:   ticket.Event.Name,
 		}
 
 		datas = append(datas, data)
@@ -228,6 +465,11 @@ func (us *UserService) GetDetailTicket(ctx context.Context, ticketID string) (dt
 		return dto.TicketResponse{}, dto.ErrTicketNotFound
 	}
 
+	var formFields []dto.TicketFormFieldResponse
+	for _, field := range ticket.FormFields {
+		formFields = append(formFields, toTicketFormFieldResponse(field))
+	}
+
 	return dto.TicketResponse{
 		ID:          ticket.ID.String(),
 		Name:        ticket.Name,
@@ -237,9 +479,19 @@ func (us *UserService) GetDetailTicket(ctx context.Context, ticketID string) (dt
 		Image:       ticket.Image,
 		Description: ticket.Description,
 		EventDate:   ticket.EventDate.Format("2006-01-02"),
+		EventID:     ticket.EventID,
+		FormFields:  formFields,
 	}, nil
 }
 
+// Availability
+func (us *UserService) GetAllAvailability(ctx context.Context) ([]dto.AvailabilityResponse, error) {
+	return us.availabilityService.GetAllAvailability(ctx)
+}
+func (us *UserService) SubscribeAvailability() (<-chan dto.AvailabilityResponse, func()) {
+	return us.availabilityService.Subscribe()
+}
+
 // Sponsorship
 func (us *UserService) GetAllSponsorship(ctx context.Context) ([]dto.SponsorshipResponse, error) {
 	sponsorships, err := us.userRepo.GetAllSponsorship(ctx, nil)
@@ -337,6 +589,7 @@ func (us *UserService) GetAllBundle(ctx context.Context) ([]dto.BundleResponse,
 			Quota:       bundle.Quota,
 			Description: bundle.Description,
 			EventDate:   bundle.EventDate.Format("2006-01-02"),
+			EventID:     bundle.EventID,
 		}
 
 		for _, bi := range bundle.BundleItems {
@@ -396,6 +649,10 @@ func (us *UserService) CreateTransactionTicket(ctx context.Context, req dto.Crea
 		return dto.TransactionResponse{}, dto.ErrUserNotFound
 	}
 
+	if checkoutRequiresVerifiedEmail() && user.EmailVerified == nil {
+		return dto.TransactionResponse{}, dto.ErrEmailNotVerified
+	}
+
 	var transactionResponse dto.TransactionResponse
 	err = us.userRepo.RunInTransaction(ctx, func(txRepo repository.IUserRepository) error {
 		if req.ReferalCode != "" {
@@ -427,13 +684,36 @@ func (us *UserService) CreateTransactionTicket(ctx context.Context, req dto.Crea
 			bundle entity.Bundle
 		)
 
+		// offer waitlist dikunci sebelum ticket, sama dengan ExpireOffers, supaya tidak deadlock
+		var waitlist entity.Waitlist
+		if req.WaitlistToken != "" {
+			w, found, err := txRepo.GetWaitlistByOfferTokenHashForUpdate(ctx, nil, helpers.HashToken(req.WaitlistToken))
+			if err != nil || !found {
+				return dto.ErrInvalidWaitlistToken
+			}
+
+			if w.Status != entity.WaitlistOffered || w.UserID == nil || *w.UserID != userID || w.TicketID == nil || req.TicketID == nil || *req.TicketID != *w.TicketID {
+				return dto.ErrInvalidWaitlistToken
+			}
+
+			if w.OfferExpiresAt == nil || time.Now().After(*w.OfferExpiresAt) {
+				return dto.ErrWaitlistOfferExpired
+			}
+
+			if len(req.TicketForms) != 1 {
+				return dto.ErrWaitlistOfferSeatLimit
+			}
+
+			waitlist = w
+		}
+
 		if req.TicketID != nil && *req.TicketID != uuid.Nil {
-			t, found, err := txRepo.GetTicketByID(ctx, nil, req.TicketID.String())
+			t, found, err := txRepo.GetTicketByIDForUpdate(ctx, nil, req.TicketID.String())
 			if err != nil || !found {
 				return dto.ErrTicketNotFound
 			}
 
-			if t.Quota <= 0 {
+			if t.Quota < len(req.TicketForms) && req.WaitlistToken == "" {
 				return dto.ErrTicketSoldOut
 			}
 
@@ -441,12 +721,12 @@ func (us *UserService) CreateTransactionTicket(ctx context.Context, req dto.Crea
 		}
 
 		if req.BundleID != nil && *req.BundleID != uuid.Nil {
-			b, found, err := txRepo.GetBundleByID(ctx, nil, req.BundleID.String())
+			b, found, err := txRepo.GetBundleByIDForUpdate(ctx, nil, req.BundleID.String())
 			if err != nil || !found {
 				return dto.ErrTicketNotFound
 			}
 
-			if b.Quota <= 0 {
+			if b.Quota < len(req.TicketForms) {
 				return dto.ErrBundleSoldOut
 			}
 
@@ -470,30 +750,28 @@ func (us *UserService) CreateTransactionTicket(ctx context.Context, req dto.Crea
 			return dto.ErrCreateTransaction
 		}
 
-		for _, form := range req.TicketForms {
-			if form.AudienceType == "" || form.Instansi == "" || form.Email == "" || form.FullName == "" || form.PhoneNumber == "" {
-				return dto.ErrEmptyFields
-			}
-
-			if !entity.IsValidAudienceType(form.AudienceType) || form.AudienceType != "regular" {
-				return dto.ErrMustBeInvitedGuest
-			}
-
-			if !entity.IsValidInstansi(form.Instansi) {
-				return dto.ErrInvalidInstansi
+		if req.WaitlistToken != "" {
+			now := time.Now()
+			waitlist.Status = entity.WaitlistClaimed
+			waitlist.ClaimedAt = &now
+			waitlist.TransactionID = &transactionID
+			updated, err := txRepo.UpdateWaitlist(ctx, nil, waitlist, entity.WaitlistOffered)
+			if err != nil {
+				return dto.ErrUpdateWaitlist
 			}
-
-			if !helpers.IsValidEmail(form.Email) {
-				return dto.ErrInvalidEmail
+			if !updated {
+				return dto.ErrWaitlistOfferExpired
 			}
+		}
 
-			if len(form.FullName) < 5 {
-				return dto.ErrUserFullNameTooShort
+		for _, form := range req.TicketForms {
+			if form.AudienceType != "" && (!entity.IsValidAudienceType(form.AudienceType) || form.AudienceType != "regular") {
+				return dto.ErrMustBeInvitedGuest
 			}
 
-			formattedPhone, err := helpers.StandardizePhoneNumber(form.PhoneNumber)
+			formattedPhone, answers, err := validateTicketFormRequest(form, ticket.FormFields)
 			if err != nil {
-				return dto.ErrInvalidPhoneNumber
+				return err
 			}
 
 			ticketFormID := uuid.New()
@@ -505,6 +783,8 @@ func (us *UserService) CreateTransactionTicket(ctx context.Context, req dto.Crea
 				FullName:      form.FullName,
 				PhoneNumber:   formattedPhone,
 				LineID:        form.LineID,
+				Answers:       answers,
+				WhatsAppOptIn: form.WhatsAppOptIn,
 				TransactionID: &transactionID,
 			}
 
@@ -514,7 +794,7 @@ func (us *UserService) CreateTransactionTicket(ctx context.Context, req dto.Crea
 				}
 			}
 
-			if req.TicketID != nil && *req.TicketID != uuid.Nil {
+			if req.TicketID != nil && *req.TicketID != uuid.Nil && req.WaitlistToken == "" {
 				if err := txRepo.UpdateTicketQuota(ctx, nil, ticket.ID.String(), ticket.Quota-len(req.TicketForms)); err != nil {
 					return dto.ErrUpdateTicketQuota
 				}
@@ -525,13 +805,15 @@ func (us *UserService) CreateTransactionTicket(ctx context.Context, req dto.Crea
 			}
 
 			transactionResponse.TicketForms = append(transactionResponse.TicketForms, dto.TicketFormResponse{
-				ID:           ticketFormID,
-				AudienceType: ticketForm.AudienceType,
-				Instansi:     ticketForm.Instansi,
-				Email:        ticketForm.Email,
-				FullName:     ticketForm.FullName,
-				PhoneNumber:  ticketForm.PhoneNumber,
-				LineID:       ticketForm.LineID,
+				ID:            ticketFormID,
+				AudienceType:  ticketForm.AudienceType,
+				Instansi:      ticketForm.Instansi,
+				Email:         ticketForm.Email,
+				FullName:      ticketForm.FullName,
+				PhoneNumber:   ticketForm.PhoneNumber,
+				LineID:        ticketForm.LineID,
+				Answers:       ticketForm.Answers,
+				WhatsAppOptIn: ticketForm.WhatsAppOptIn,
 			})
 
 			r := &snap.Request{
@@ -563,42 +845,255 @@ func (us *UserService) CreateTransactionTicket(ctx context.Context, req dto.Crea
 			transactionResponse.RedirectURL = snapResp.RedirectURL
 		}
 
+		// Link pembayaran disimpan untuk payment reminder WhatsApp.
+		transaction.PaymentURL = transactionResponse.RedirectURL
+		if err := txRepo.UpdateTransactionTicket(ctx, nil, transaction); err != nil {
+			return dto.ErrUpdateTransactionTicket
+		}
+
 		return nil
 	})
 	if err != nil {
 		return dto.TransactionResponse{}, err
 	}
 
+	if req.TicketID != nil && *req.TicketID != uuid.Nil {
+		us.availabilityService.PublishTicket(ctx, req.TicketID.String())
+	}
+
+	if req.BundleID != nil && *req.BundleID != uuid.Nil {
+		us.availabilityService.PublishBundle(ctx, req.BundleID.String())
+	}
+
 	return transactionResponse, nil
 }
 
 // Webhook for Midtrans
-func makeETicketEmail(data struct {
-	HeaderImage  string
-	TicketID     string
-	Status       string
-	AttendeeName string
-	Email        string
-	AudienceType string
-	BookingDate  string
-	Price        string
-	QRCode       string
-}) (map[string]string, error) {
-	tmpl, err := template.New("eticket").Parse(emailtemplate.EticketHTML)
+type ticketQRCode struct {
+	Token string
+	URL   string
+	PNG   []byte
+}
+
+// generateTicketQRCode merender token check-in yang ditandatangani, bukan ID form-nya.
+func generateTicketQRCode(form entity.TicketForm, eventID *uuid.UUID) (ticketQRCode, error) {
+	token := signTicketQRToken(form, eventID)
+	png, err := helpers.GenerateQRCode(token)
+	if err != nil {
+		return ticketQRCode{}, err
+	}
+
+	return ticketQRCode{Token: token, URL: ticketQRLink(form), PNG: png}, nil
+}
+
+// ticketQRLink terikat ke QRVersion form dan kedaluwarsa sendiri.
+func ticketQRLink(form entity.TicketForm) string {
+	return newTicketQRLink(form, constants.ENUM_TICKET_QR_LINK_EXPIRY_HOURS*time.Hour)
+}
+func newTicketQRLink(form entity.TicketForm, ttl time.Duration) string {
+	token := helpers.SignTicketQRLink(helpers.TicketQRLinkClaims{
+		TicketFormID: form.ID,
+		Version:      form.QRVersion,
+		ExpiresAt:    time.Now().Add(ttl),
+	})
+
+	return fmt.Sprintf("%s/api/v1/user/ticket-qr?token=%s", os.Getenv("BASE_URL"), token)
+}
+func signTicketQRToken(form entity.TicketForm, eventID *uuid.UUID) string {
+	claims := helpers.TicketQRClaims{
+		TicketFormID: form.ID,
+		Version:      form.QRVersion,
+		IssuedAt:     time.Now(),
+	}
+	if eventID != nil {
+		claims.EventID = *eventID
+	}
+
+	return helpers.SignTicketQR(claims)
+}
+func transactionEventID(transaction entity.Transaction) *uuid.UUID {
+	if transaction.Ticket.EventID != nil {
+		return transaction.Ticket.EventID
+	}
+
+	return transaction.Bundle.EventID
+}
+
+// eTicketTemplateKey: tiket undangan, termasuk hasil transfer dan reissue-nya, memakai template invitation.
+func eTicketTemplateKey(transaction entity.Transaction) entity.EmailTemplateKey {
+	if transaction.PaymentType == "invitation" {
+		return entity.EmailTemplateInvitation
+	}
+
+	return entity.EmailTemplateETicket
+}
+func toETicketEmailData(transaction entity.Transaction, form entity.TicketForm, qrURL string) eTicketEmailData {
+	bookingDate := transaction.CreatedAt
+	if bookingDate.IsZero() {
+		bookingDate = time.Now()
+	}
+
+	return eTicketEmailData{
+		HeaderImage:  emailHeaderImage(),
+		TicketID:     transaction.ID.String(),
+		Status:       transaction.TransactionStatus,
+		AttendeeName: form.FullName,
+		Email:        form.Email,
+		AudienceType: string(form.AudienceType),
+		BookingDate:  bookingDate.Format("02 Jan 2006 15:04"),
+		Price:        fmt.Sprintf("Rp %.0f", transaction.GrossAmount),
+		QRCode:       htmltemplate.URL(qrURL),
+		QRCodeURL:    qrURL,
+	}
+}
+func ticketQRAttachment(form entity.TicketForm, qr ticketQRCode) entity.EmailAttachment {
+	filename := "qr-" + form.ID.String() + ".png"
+
+	return entity.EmailAttachment{
+		Filename:    filename,
+		ContentType: "image/png",
+		ContentID:   filename,
+		Data:        qr.PNG,
+	}
+}
+
+// ticketPDFAttachment memakai layout badge sebagai tiket PDF dengan token QR yang sama.
+func ticketPDFAttachment(transaction entity.Transaction, form entity.TicketForm, qr ticketQRCode) (entity.EmailAttachment, error) {
+	layout, err := badge.LoadLayout()
+	if err != nil {
+		return entity.EmailAttachment{}, dto.ErrLoadBadgeLayout
+	}
+
+	form.Transaction = transaction
+	ticketBadge := toBadge(form)
+	ticketBadge.QRContent = qr.Token
+	if ticketBadge.EventName == "" {
+		ticketBadge.EventName = transaction.Bundle.Event.Name
+	}
+	if transaction.BundleID != nil {
+		ticketBadge.TicketType = transaction.Bundle.Name
+	}
+
+	pdf, err := badge.RenderSingle(layout, ticketBadge)
+	if err != nil {
+		return entity.EmailAttachment{}, dto.ErrRenderBadge
+	}
+
+	return entity.EmailAttachment{
+		Filename:    "e-ticket-" + form.ID.String() + ".pdf",
+		ContentType: "application/pdf",
+		Data:        pdf,
+	}, nil
+}
+
+// newETicketEmail merender e-ticket satu ticket form menjadi email outbox.
+func newETicketEmail(ctx context.Context, emailTemplateService IEmailTemplateService, transaction entity.Transaction, form entity.TicketForm) (entity.EmailOutbox, error) {
+	qr, err := generateTicketQRCode(form, transactionEventID(transaction))
+	if err != nil {
+		return entity.EmailOutbox{}, dto.ErrGenerateQRCode
+	}
+
+	return renderETicketEmail(ctx, emailTemplateService, transaction, form, qr)
+}
+
+// renderETicketEmail menyematkan header dan QR secara inline lalu melampirkan tiket PDF.
+func renderETicketEmail(ctx context.Context, emailTemplateService IEmailTemplateService, transaction entity.Transaction, form entity.TicketForm, qr ticketQRCode) (entity.EmailOutbox, error) {
+	header := emailHeaderAttachment()
+	qrImage := ticketQRAttachment(form, qr)
+	pdf, err := ticketPDFAttachment(transaction, form, qr)
+	if err != nil {
+		return entity.EmailOutbox{}, err
+	}
+
+	data := toETicketEmailData(transaction, form, qr.URL)
+	data.HeaderImage = emailInlineImage(header)
+	data.QRCode = emailInlineImage(qrImage)
+
+	key := eTicketTemplateKey(transaction)
+	draftEmail, err := emailTemplateService.Render(ctx, key, data)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse HTML template: %w", err)
+		return entity.EmailOutbox{}, dto.ErrMakeETicketEmail
+	}
+
+	outbox := newEmailOutbox(string(key), form.Email, draftEmail)
+	outbox.TicketFormID = &form.ID
+	outbox.Attachments = entity.EmailAttachments{header, qrImage, pdf}
+
+	return outbox, nil
+}
+func toPurchaseReceiptEmailData(transaction entity.Transaction, attendees []purchaseReceiptAttendee) purchaseReceiptEmailData {
+	bookingDate := transaction.CreatedAt
+	if bookingDate.IsZero() {
+		bookingDate = time.Now()
+	}
+
+	purchaserName := transaction.User.Name
+	if purchaserName == "" {
+		purchaserName = transaction.User.Email
+	}
+
+	return purchaseReceiptEmailData{
+		HeaderImage:   emailHeaderImage(),
+		OrderID:       transaction.OrderID,
+		Status:        transaction.TransactionStatus,
+		PurchaserName: purchaserName,
+		BookingDate:   bookingDate.Format("02 Jan 2006 15:04"),
+		Price:         fmt.Sprintf("Rp %.0f", transaction.GrossAmount),
+		Attendees:     attendees,
+	}
+}
+func toPurchaseReceiptAttendee(form entity.TicketForm, qrURL string) purchaseReceiptAttendee {
+	return purchaseReceiptAttendee{
+		FullName:     form.FullName,
+		Email:        form.Email,
+		AudienceType: string(form.AudienceType),
+		QRCode:       htmltemplate.URL(qrURL),
+		QRCodeURL:    qrURL,
+	}
+}
+
+// newETicketEmails membuat satu e-ticket per ticket form ditambah satu ringkasan pesanan untuk pembeli.
+func newETicketEmails(ctx context.Context, emailTemplateService IEmailTemplateService, transaction entity.Transaction) ([]entity.EmailOutbox, error) {
+	eventID := transactionEventID(transaction)
+
+	var outboxes []entity.EmailOutbox
+	var attendees []purchaseReceiptAttendee
+	receiptAttachments := entity.EmailAttachments{emailHeaderAttachment()}
+	for _, form := range activeTicketForms(transaction.TicketForms) {
+		qr, err := generateTicketQRCode(form, eventID)
+		if err != nil {
+			return nil, dto.ErrGenerateQRCode
+		}
+
+		outbox, err := renderETicketEmail(ctx, emailTemplateService, transaction, form, qr)
+		if err != nil {
+			return nil, err
+		}
+		outboxes = append(outboxes, outbox)
+
+		qrImage := ticketQRAttachment(form, qr)
+		attendee := toPurchaseReceiptAttendee(form, qr.URL)
+		attendee.QRCode = emailInlineImage(qrImage)
+		attendees = append(attendees, attendee)
+		receiptAttachments = append(receiptAttachments, qrImage)
 	}
 
-	var strMail bytes.Buffer
-	if err := tmpl.Execute(&strMail, data); err != nil {
-		return nil, fmt.Errorf("failed to execute HTML template: %w", err)
+	if transaction.User.Email == "" || len(attendees) == 0 {
+		return outboxes, nil
 	}
 
-	draftEmail := map[string]string{
-		"subject": "tedxuniversitasairlangga",
-		"body":    strMail.String(),
+	data := toPurchaseReceiptEmailData(transaction, attendees)
+	data.HeaderImage = emailInlineImage(receiptAttachments[0])
+
+	draftEmail, err := emailTemplateService.Render(ctx, entity.EmailTemplatePurchaseReceipt, data)
+	if err != nil {
+		return nil, dto.ErrMakePurchaseReceiptEmail
 	}
-	return draftEmail, nil
+
+	receipt := newEmailOutbox(string(entity.EmailTemplatePurchaseReceipt), transaction.User.Email, draftEmail)
+	receipt.Attachments = receiptAttachments
+
+	return append(outboxes, receipt), nil
 }
 func (us *UserService) UpdateTransactionTicket(ctx context.Context, req dto.UpdateMidtransTransactionTicketRequest) error {
 	transaction, found, err := us.userRepo.GetTransactionByOrderID(ctx, nil, req.OrderID)
@@ -627,55 +1122,74 @@ func (us *UserService) UpdateTransactionTicket(ctx context.Context, req dto.Upda
 		}
 		transaction.GrossAmount = grossAmount
 
-		err = us.userRepo.UpdateTransactionTicket(ctx, nil, transaction)
-		if err != nil {
-			return dto.ErrUpdateTransactionTicket
-		}
+		// status settlement dan e-ticket di-commit bersama, jadi email tidak hilang walaupun SMTP down
+		var retaken, refundRequired bool
+		err = us.userRepo.RunInTransaction(ctx, func(txRepo repository.IUserRepository) error {
+			current, found, err := txRepo.GetTransactionByIDForUpdate(ctx, nil, transaction.ID.String())
+			if err != nil || !found {
+				return dto.ErrTransactionNotFound
+			}
 
-		sentEmails := make(map[string]bool)
-		for _, form := range transaction.TicketForms {
-			if sentEmails[form.Email] {
-				continue
+			// notifikasi settlement yang dikirim ulang Midtrans tidak mengantrikan e-ticket lagi
+			if current.TransactionStatus == "settlement" || current.TransactionStatus == "refund_required" {
+				return nil
 			}
-			sentEmails[form.Email] = true
 
-			qrURL, err := helpers.GenerateQRCodeFile(form.ID.String(), form.ID.String()+".png")
-			if err != nil {
-				return dto.ErrGenerateQRCode
-			}
-
-			headerImage := fmt.Sprintf("%s/assets_static/header-e-ticket-mail.png", os.Getenv("BASE_URL"))
-			emailData := struct {
-				HeaderImage  string
-				TicketID     string
-				Status       string
-				AttendeeName string
-				Email        string
-				AudienceType string
-				BookingDate  string
-				Price        string
-				QRCode       string
-			}{
-				HeaderImage:  headerImage,
-				TicketID:     transaction.ID.String(),
-				Status:       transaction.TransactionStatus,
-				AttendeeName: form.FullName,
-				Email:        form.Email,
-				AudienceType: string(form.AudienceType),
-				BookingDate:  transaction.CreatedAt.Format("02 Jan 2006 15:04"),
-				Price:        fmt.Sprintf("Rp %.0f", transaction.GrossAmount),
-				QRCode:       qrURL,
-			}
-
-			draftEmail, err := makeETicketEmail(emailData)
-			if err != nil {
-				return dto.ErrMakeETicketEmail
+			// kursi transaksi yang sudah expired/cancelled sudah dilepas, jadi diambil lagi
+			if !isSeatHoldingStatus(current.TransactionStatus) {
+				taken, err := takeTransactionSeats(ctx, txRepo, transaction)
+				if err != nil {
+					return err
+				}
+
+				if !taken {
+					refundRequired = true
+					transaction.TransactionStatus = "refund_required"
+					if err := txRepo.UpdateTransactionTicket(ctx, nil, transaction); err != nil {
+						return dto.ErrUpdateTransactionTicket
+					}
+
+					return nil
+				}
+
+				retaken = true
+			}
+
+			if err := txRepo.UpdateTransactionTicket(ctx, nil, transaction); err != nil {
+				return dto.ErrUpdateTransactionTicket
 			}
 
-			err = utils.SendEmail(emailData.Email, draftEmail["subject"], draftEmail["body"])
+			outboxes, err := newETicketEmails(ctx, us.emailTemplateService, transaction)
 			if err != nil {
-				return dto.ErrSendEmail
+				return err
+			}
+
+			for _, outbox := range outboxes {
+				if err := txRepo.CreateEmailOutbox(ctx, nil, outbox); err != nil {
+					return dto.ErrCreateEmailOutbox
+				}
+			}
+
+			for _, form := range activeTicketForms(transaction.TicketForms) {
+				if notification, ok := newETicketNotification(transaction, form); ok {
+					if err := txRepo.CreateNotificationOutbox(ctx, nil, notification); err != nil {
+						return dto.ErrCreateNotificationOutbox
+					}
+				}
 			}
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		if refundRequired {
+			log.Printf("transaction %s settled after its seats were released, marked refund_required", transaction.OrderID)
+		}
+
+		if retaken {
+			us.publishTransactionAvailability(ctx, transaction)
 		}
 
 		return nil
@@ -692,9 +1206,671 @@ func (us *UserService) UpdateTransactionTicket(ctx context.Context, req dto.Upda
 	case "expire":
 		transaction.TransactionStatus = "expired"
 
+	case "refund", "partial_refund":
+		transaction.TransactionStatus = "refunded"
+
 	default:
 		return dto.ErrUnknownTransactionStatus
 	}
 
-	return us.userRepo.UpdateTransactionTicket(ctx, nil, transaction)
+	var released bool
+	err = us.userRepo.RunInTransaction(ctx, func(txRepo repository.IUserRepository) error {
+		current, found, err := txRepo.GetTransactionByIDForUpdate(ctx, nil, transaction.ID.String())
+		if err != nil || !found {
+			return dto.ErrTransactionNotFound
+		}
+
+		if err := txRepo.UpdateTransactionTicket(ctx, nil, transaction); err != nil {
+			return dto.ErrUpdateTransactionTicket
+		}
+
+		if !isSeatHoldingStatus(current.TransactionStatus) || isSeatHoldingStatus(transaction.TransactionStatus) {
+			return nil
+		}
+		released = true
+
+		if transaction.TransactionStatus == "refunded" {
+			if err := txRepo.RevokeTicketFormQRByTransactionID(ctx, nil, transaction.ID.String()); err != nil {
+				return dto.ErrUpdateTicketForm
+			}
+		}
+
+		seats := transactionSeats(transaction)
+		if seats == 0 {
+			return nil
+		}
+
+		if transaction.TicketID != nil && *transaction.TicketID != uuid.Nil {
+			if err := txRepo.RestoreTicketQuota(ctx, nil, transaction.TicketID.String(), seats); err != nil {
+				return dto.ErrRestoreTicketQuota
+			}
+		}
+
+		if transaction.BundleID != nil && *transaction.BundleID != uuid.Nil {
+			if err := txRepo.RestoreBundleQuota(ctx, nil, transaction.BundleID.String(), seats); err != nil {
+				return dto.ErrRestoreTicketQuota
+			}
+		}
+
+		return nil
+	})
+	if err != nil || !released {
+		return err
+	}
+
+	if transaction.TicketID != nil && *transaction.TicketID != uuid.Nil {
+		if err := us.waitlistService.OfferAvailableSeats(ctx, transaction.TicketID.String()); err != nil {
+			log.Printf("failed to offer waitlist seats for ticket %s: %v", transaction.TicketID, err)
+		}
+	}
+
+	if transaction.BundleID != nil && *transaction.BundleID != uuid.Nil {
+		us.availabilityService.PublishBundle(ctx, transaction.BundleID.String())
+	}
+
+	return nil
+}
+
+// takeTransactionSeats mengambil ulang kursi transaksi dari quota; false kalau quota sudah habis.
+func takeTransactionSeats(ctx context.Context, txRepo repository.IUserRepository, transaction entity.Transaction) (bool, error) {
+	seats := transactionSeats(transaction)
+	if seats == 0 {
+		return true, nil
+	}
+
+	if transaction.TicketID != nil && *transaction.TicketID != uuid.Nil {
+		ticket, found, err := txRepo.GetTicketByIDForUpdate(ctx, nil, transaction.TicketID.String())
+		if err != nil || !found {
+			return false, dto.ErrTicketNotFound
+		}
+
+		if ticket.Quota < seats {
+			return false, nil
+		}
+
+		if err := txRepo.UpdateTicketQuota(ctx, nil, ticket.ID.String(), ticket.Quota-seats); err != nil {
+			return false, dto.ErrUpdateTicketQuota
+		}
+	}
+
+	if transaction.BundleID != nil && *transaction.BundleID != uuid.Nil {
+		bundle, found, err := txRepo.GetBundleByIDForUpdate(ctx, nil, transaction.BundleID.String())
+		if err != nil || !found {
+			return false, dto.ErrBundleNotFound
+		}
+
+		if bundle.Quota < seats {
+			return false, nil
+		}
+
+		if err := txRepo.UpdateBundleQuota(ctx, nil, bundle.ID.String(), bundle.Quota-seats); err != nil {
+			return false, dto.ErrUpdateBundleQuota
+		}
+	}
+
+	return true, nil
+}
+func (us *UserService) publishTransactionAvailability(ctx context.Context, transaction entity.Transaction) {
+	if transaction.TicketID != nil && *transaction.TicketID != uuid.Nil {
+		us.availabilityService.PublishTicket(ctx, transaction.TicketID.String())
+	}
+
+	if transaction.BundleID != nil && *transaction.BundleID != uuid.Nil {
+		us.availabilityService.PublishBundle(ctx, transaction.BundleID.String())
+	}
+}
+
+// transactionSeats hanya menghitung form yang belum ditransfer.
+func transactionSeats(transaction entity.Transaction) int {
+	seats := 0
+	for _, form := range transaction.TicketForms {
+		if form.TransferredToID == nil {
+			seats++
+		}
+	}
+
+	return seats
+}
+
+// isSeatHoldingStatus: transaksi dengan status ini masih memegang kursi di quota ticket atau bundle.
+func isSeatHoldingStatus(status string) bool {
+	return status == "" || status == "pending" || status == "settlement"
+}
+
+// Waitlist
+func (us *UserService) JoinWaitlist(ctx context.Context, ticketID string) (dto.WaitlistResponse, error) {
+	token := ctx.Value("Authorization").(string)
+
+	userIDStr, err := us.jwtService.GetUserIDByToken(token)
+	if err != nil {
+		return dto.WaitlistResponse{}, dto.ErrGetUserIDFromToken
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return dto.WaitlistResponse{}, dto.ErrParseUUID
+	}
+
+	user, found, err := us.userRepo.GetUserByID(ctx, nil, userIDStr)
+	if err != nil || !found {
+		return dto.WaitlistResponse{}, dto.ErrUserNotFound
+	}
+
+	ticket, found, err := us.userRepo.GetTicketByID(ctx, nil, ticketID)
+	if err != nil || !found {
+		return dto.WaitlistResponse{}, dto.ErrTicketNotFound
+	}
+
+	if ticket.Quota > 0 {
+		return dto.WaitlistResponse{}, dto.ErrTicketStillAvailable
+	}
+
+	if _, found, _ := us.userRepo.GetActiveWaitlistByUserIDAndTicketID(ctx, nil, userIDStr, ticketID); found {
+		return dto.WaitlistResponse{}, dto.ErrAlreadyInWaitlist
+	}
+
+	waitlist := entity.Waitlist{
+		ID:       uuid.New(),
+		Status:   entity.WaitlistWaiting,
+		UserID:   &userID,
+		TicketID: &ticket.ID,
+	}
+
+	if err := us.userRepo.CreateWaitlist(ctx, nil, waitlist); err != nil {
+		return dto.WaitlistResponse{}, dto.ErrCreateWaitlist
+	}
+
+	waitlist.User = user
+	waitlist.Ticket = ticket
+	waitlist.CreatedAt = time.Now()
+
+	return toWaitlistResponse(waitlist), nil
+}
+func (us *UserService) GetMyWaitlist(ctx context.Context) ([]dto.WaitlistResponse, error) {
+	token := ctx.Value("Authorization").(string)
+
+	userID, err := us.jwtService.GetUserIDByToken(token)
+	if err != nil {
+		return nil, dto.ErrGetUserIDFromToken
+	}
+
+	waitlists, err := us.userRepo.GetAllWaitlistByUserID(ctx, nil, userID)
+	if err != nil {
+		return nil, dto.ErrGetAllWaitlistNoPagination
+	}
+
+	var datas []dto.WaitlistResponse
+	for _, waitlist := range waitlists {
+		datas = append(datas, toWaitlistResponse(waitlist))
+	}
+
+	return datas, nil
+}
+func (us *UserService) LeaveWaitlist(ctx context.Context, waitlistID string) (dto.WaitlistResponse, error) {
+	token := ctx.Value("Authorization").(string)
+
+	userID, err := us.jwtService.GetUserIDByToken(token)
+	if err != nil {
+		return dto.WaitlistResponse{}, dto.ErrGetUserIDFromToken
+	}
+
+	waitlist, found, err := us.userRepo.GetWaitlistByID(ctx, nil, waitlistID)
+	if err != nil || !found || waitlist.UserID == nil || waitlist.UserID.String() != userID {
+		return dto.WaitlistResponse{}, dto.ErrWaitlistNotFound
+	}
+
+	if waitlist.Status != entity.WaitlistWaiting && waitlist.Status != entity.WaitlistOffered {
+		return dto.WaitlistResponse{}, dto.ErrWaitlistCannotBeCancelled
+	}
+
+	wasOffered := waitlist.Status == entity.WaitlistOffered
+	err = us.userRepo.RunInTransaction(ctx, func(txRepo repository.IUserRepository) error {
+		fromStatus := waitlist.Status
+		waitlist.Status = entity.WaitlistCancelled
+		updated, err := txRepo.UpdateWaitlist(ctx, nil, waitlist, fromStatus)
+		if err != nil {
+			return dto.ErrUpdateWaitlist
+		}
+		if !updated {
+			// status sudah berubah (diklaim atau kedaluwarsa) sejak dibaca
+			return dto.ErrWaitlistCannotBeCancelled
+		}
+
+		if wasOffered && waitlist.TicketID != nil {
+			if err := txRepo.RestoreTicketQuota(ctx, nil, waitlist.TicketID.String(), 1); err != nil {
+				return dto.ErrRestoreTicketQuota
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return dto.WaitlistResponse{}, err
+	}
+
+	if wasOffered && waitlist.TicketID != nil {
+		if err := us.waitlistService.OfferAvailableSeats(ctx, waitlist.TicketID.String()); err != nil {
+			log.Printf("failed to offer waitlist seats for ticket %s: %v", waitlist.TicketID, err)
+		}
+	}
+
+	return toWaitlistResponse(waitlist), nil
+}
+
+// Ticket Transfer
+func toTicketTransferResponse(ticketTransfer entity.TicketTransfer) dto.TicketTransferResponse {
+	return dto.TicketTransferResponse{
+		ID:                 ticketTransfer.ID,
+		OriginTicketFormID: ticketTransfer.OriginTicketFormID,
+		FromTicketFormID:   ticketTransfer.FromTicketFormID,
+		ToTicketFormID:     ticketTransfer.ToTicketFormID,
+		FromFullName:       ticketTransfer.FromFullName,
+		FromEmail:          ticketTransfer.FromEmail,
+		FromPhoneNumber:    ticketTransfer.FromPhoneNumber,
+		ToFullName:         ticketTransfer.ToFullName,
+		ToEmail:            ticketTransfer.ToEmail,
+		ToPhoneNumber:      ticketTransfer.ToPhoneNumber,
+		TransferredBy:      ticketTransfer.TransferredBy,
+		TransferredAt:      ticketTransfer.CreatedAt,
+	}
+}
+func (us *UserService) TransferTicket(ctx context.Context, ticketFormID string, req dto.TransferTicketRequest) (dto.TicketTransferResponse, error) {
+	token := ctx.Value("Authorization").(string)
+
+	userIDStr, err := us.jwtService.GetUserIDByToken(token)
+	if err != nil {
+		return dto.TicketTransferResponse{}, dto.ErrGetUserIDFromToken
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return dto.TicketTransferResponse{}, dto.ErrParseUUID
+	}
+
+	if req.Email == "" || req.FullName == "" || req.PhoneNumber == "" {
+		return dto.TicketTransferResponse{}, dto.ErrEmptyFields
+	}
+
+	if !helpers.IsValidEmail(req.Email) {
+		return dto.TicketTransferResponse{}, dto.ErrInvalidEmail
+	}
+
+	if len(req.FullName) < 5 {
+		return dto.TicketTransferResponse{}, dto.ErrUserFullNameTooShort
+	}
+
+	formattedPhone, err := helpers.StandardizePhoneNumber(req.PhoneNumber)
+	if err != nil {
+		return dto.TicketTransferResponse{}, dto.ErrInvalidPhoneNumber
+	}
+
+	var (
+		transaction   entity.Transaction
+		newTicketForm entity.TicketForm
+		transfer      entity.TicketTransfer
+	)
+	err = us.userRepo.RunInTransaction(ctx, func(txRepo repository.IUserRepository) error {
+		ticketForm, found, err := txRepo.GetTicketFormByIDForUpdate(ctx, nil, ticketFormID)
+		if err != nil || !found {
+			return dto.ErrTicketFormNotFound
+		}
+
+		transaction = ticketForm.Transaction
+		if ticketForm.TransactionID == nil || transaction.UserID == nil || *transaction.UserID != userID {
+			return dto.ErrNotTicketOwner
+		}
+
+		if transaction.TransactionStatus != "settlement" {
+			return dto.ErrTransactionNotSettled
+		}
+
+		if ticketForm.TransferredToID != nil {
+			return dto.ErrTicketTransferred
+		}
+
+		if ticketForm.QRRevokedAt != nil {
+			return dto.ErrTicketQRRevoked
+		}
+
+		if len(ticketForm.GuestAttendances) > 0 {
+			return dto.ErrAlreadyCheckedIn
+		}
+
+		eventDate := transaction.Ticket.EventDate
+		if transaction.TicketID == nil {
+			eventDate = transaction.Bundle.EventDate
+		}
+
+		cutoff := eventDate.Add(-constants.ENUM_TICKET_TRANSFER_CUTOFF_HOURS * time.Hour)
+		if time.Now().After(cutoff) {
+			return dto.ErrTransferCutoffPassed
+		}
+
+		if ticketForm.Email == req.Email && ticketForm.FullName == req.FullName && ticketForm.PhoneNumber == formattedPhone {
+			return dto.ErrTransferToSameHolder
+		}
+
+		newTicketForm = entity.TicketForm{
+			ID:            uuid.New(),
+			AudienceType:  ticketForm.AudienceType,
+			Instansi:      ticketForm.Instansi,
+			Email:         req.Email,
+			FullName:      req.FullName,
+			PhoneNumber:   formattedPhone,
+			LineID:        req.LineID,
+			Answers:       ticketForm.Answers,
+			WhatsAppOptIn: req.WhatsAppOptIn,
+			TransactionID: ticketForm.TransactionID,
+		}
+
+		if err := txRepo.CreateTicketForm(ctx, nil, newTicketForm); err != nil {
+			return dto.ErrCreateTicketForm
+		}
+
+		now := time.Now()
+		ticketForm.TransferredToID = &newTicketForm.ID
+		ticketForm.TransferredAt = &now
+		ticketForm.QRRevokedAt = &now
+		if err := txRepo.UpdateTicketForm(ctx, nil, ticketForm); err != nil {
+			return dto.ErrUpdateTicketForm
+		}
+
+		originID := ticketForm.ID
+		if previous, found, _ := txRepo.GetTicketTransferByToTicketFormID(ctx, nil, ticketForm.ID.String()); found && previous.OriginTicketFormID != nil {
+			originID = *previous.OriginTicketFormID
+		}
+
+		transfer = entity.TicketTransfer{
+			ID:                 uuid.New(),
+			FromFullName:       ticketForm.FullName,
+			FromEmail:          ticketForm.Email,
+			FromPhoneNumber:    ticketForm.PhoneNumber,
+			ToFullName:         newTicketForm.FullName,
+			ToEmail:            newTicketForm.Email,
+			ToPhoneNumber:      newTicketForm.PhoneNumber,
+			OriginTicketFormID: &originID,
+			FromTicketFormID:   &ticketForm.ID,
+			ToTicketFormID:     &newTicketForm.ID,
+			TransferredBy:      &userID,
+		}
+
+		if err := txRepo.CreateTicketTransfer(ctx, nil, transfer); err != nil {
+			return dto.ErrCreateTicketTransfer
+		}
+
+		outbox, err := newETicketEmail(ctx, us.emailTemplateService, transaction, newTicketForm)
+		if err != nil {
+			return err
+		}
+		if err := txRepo.CreateEmailOutbox(ctx, nil, outbox); err != nil {
+			return dto.ErrCreateEmailOutbox
+		}
+
+		if notification, ok := newETicketNotification(transaction, newTicketForm); ok {
+			if err := txRepo.CreateNotificationOutbox(ctx, nil, notification); err != nil {
+				return dto.ErrCreateNotificationOutbox
+			}
+		}
+
+		transfer.CreatedAt = now
+
+		return nil
+	})
+	if err != nil {
+		return dto.TicketTransferResponse{}, err
+	}
+
+	if err := helpers.RemoveLegacyQRCodeFile(ticketFormID); err != nil {
+		log.Printf("failed to remove old qr code %s: %v", ticketFormID, err)
+	}
+
+	return toTicketTransferResponse(transfer), nil
+}
+func (us *UserService) GetTicketTransferHistory(ctx context.Context, ticketFormID string) ([]dto.TicketTransferResponse, error) {
+	token := ctx.Value("Authorization").(string)
+
+	userIDStr, err := us.jwtService.GetUserIDByToken(token)
+	if err != nil {
+		return nil, dto.ErrGetUserIDFromToken
+	}
+
+	ticketForm, found, err := us.userRepo.GetTicketFormByID(ctx, nil, ticketFormID)
+	if err != nil || !found {
+		return nil, dto.ErrTicketFormNotFound
+	}
+
+	if ticketForm.Transaction.UserID == nil || ticketForm.Transaction.UserID.String() != userIDStr {
+		return nil, dto.ErrNotTicketOwner
+	}
+
+	originID := ticketForm.ID.String()
+	if previous, found, _ := us.userRepo.GetTicketTransferByToTicketFormID(ctx, nil, ticketFormID); found && previous.OriginTicketFormID != nil {
+		originID = previous.OriginTicketFormID.String()
+	}
+
+	ticketTransfers, err := us.userRepo.GetAllTicketTransferByOriginTicketFormID(ctx, nil, originID)
+	if err != nil {
+		return nil, dto.ErrGetTicketTransferHistory
+	}
+
+	var datas []dto.TicketTransferResponse
+	for _, ticketTransfer := range ticketTransfers {
+		datas = append(datas, toTicketTransferResponse(ticketTransfer))
+	}
+
+	return datas, nil
+}
+
+// Ticket QR
+// GetTicketQRCode menolak link untuk form yang sudah ditransfer, dicabut, atau di-reissue.
+func (us *UserService) GetTicketQRCode(ctx context.Context, token string) ([]byte, error) {
+	claims, err := helpers.VerifyTicketQRLink(token, time.Now())
+	if errors.Is(err, helpers.ErrExpiredTicketQRLink) {
+		return nil, dto.ErrTicketQRLinkExpired
+	}
+	if err != nil {
+		return nil, dto.ErrInvalidTicketQRLink
+	}
+
+	ticketForm, found, err := us.userRepo.GetTicketFormByID(ctx, nil, claims.TicketFormID.String())
+	if err != nil || !found {
+		return nil, dto.ErrTicketFormNotFound
+	}
+
+	if ticketForm.TransferredToID != nil {
+		return nil, dto.ErrTicketTransferred
+	}
+
+	if ticketForm.QRRevokedAt != nil {
+		return nil, dto.ErrTicketQRRevoked
+	}
+
+	if ticketForm.QRVersion != claims.Version {
+		return nil, dto.ErrInvalidTicketQRLink
+	}
+
+	if ticketForm.TransactionID == nil || ticketForm.Transaction.TransactionStatus != "settlement" {
+		return nil, dto.ErrTransactionNotSettled
+	}
+
+	qr, err := generateTicketQRCode(ticketForm, transactionEventID(ticketForm.Transaction))
+	if err != nil {
+		return nil, dto.ErrGenerateQRCode
+	}
+
+	return qr.PNG, nil
+}
+
+// Resend E-Ticket
+// resendableTicketForms: tiket yang sudah ditransfer atau QR-nya dicabut tidak dikirim ulang.
+func resendableTicketForms(transaction entity.Transaction, ticketFormID string) ([]entity.TicketForm, error) {
+	if transaction.TransactionStatus != "settlement" {
+		return nil, dto.ErrTransactionNotSettled
+	}
+
+	if ticketFormID != "" {
+		for _, form := range transaction.TicketForms {
+			if form.ID.String() != ticketFormID {
+				continue
+			}
+
+			if form.TransferredToID != nil {
+				return nil, dto.ErrTicketTransferred
+			}
+			if form.QRRevokedAt != nil {
+				return nil, dto.ErrTicketQRRevoked
+			}
+
+			return []entity.TicketForm{form}, nil
+		}
+
+		return nil, dto.ErrTicketFormNotInTransaction
+	}
+
+	forms := activeTicketForms(transaction.TicketForms)
+	if len(forms) == 0 {
+		return nil, dto.ErrNoTicketToResend
+	}
+
+	return forms, nil
+}
+
+// activeTicketForms membuang form yang sudah ditransfer atau QR-nya dicabut.
+func activeTicketForms(ticketForms []entity.TicketForm) []entity.TicketForm {
+	var forms []entity.TicketForm
+	for _, form := range ticketForms {
+		if form.TransferredToID == nil && form.QRRevokedAt == nil {
+			forms = append(forms, form)
+		}
+	}
+
+	return forms
+}
+func toResendETicketItemResponse(form entity.TicketForm, outbox entity.EmailOutbox) dto.ResendETicketItemResponse {
+	return dto.ResendETicketItemResponse{
+		TicketFormID:  form.ID,
+		FullName:      form.FullName,
+		Email:         outbox.ToEmail,
+		EmailOutboxID: outbox.ID,
+	}
+}
+func (us *UserService) ResendETicket(ctx context.Context, req dto.ResendETicketRequest) (dto.ResendETicketResponse, error) {
+	token := ctx.Value("Authorization").(string)
+
+	userIDStr, err := us.jwtService.GetUserIDByToken(token)
+	if err != nil {
+		return dto.ResendETicketResponse{}, dto.ErrGetUserIDFromToken
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return dto.ResendETicketResponse{}, dto.ErrParseUUID
+	}
+
+	var res dto.ResendETicketResponse
+	err = us.userRepo.RunInTransaction(ctx, func(txRepo repository.IUserRepository) error {
+		// transaksi dikunci supaya resend bersamaan tidak sama-sama lolos dari batas di bawah
+		transaction, found, err := txRepo.GetTransactionByIDForUpdate(ctx, nil, req.TransactionID)
+		if err != nil || !found {
+			return dto.ErrTransactionNotFound
+		}
+
+		if transaction.UserID == nil || *transaction.UserID != userID {
+			return dto.ErrNotTicketOwner
+		}
+
+		forms, err := resendableTicketForms(transaction, req.TicketFormID)
+		if err != nil {
+			return err
+		}
+
+		// dibatasi per tiket, jadi transaksi berisi banyak tiket tetap bisa dikirim ulang sekaligus
+		ticketFormIDs := make([]string, 0, len(forms))
+		for _, form := range forms {
+			ticketFormIDs = append(ticketFormIDs, form.ID.String())
+		}
+
+		since := time.Now().Add(-constants.ENUM_E_TICKET_RESEND_WINDOW_MINUTES * time.Minute)
+		sent, err := txRepo.CountMaxEmailOutboxPerTicketForm(ctx, nil, userIDStr, ticketFormIDs, since)
+		if err != nil {
+			return dto.ErrCountResendETicket
+		}
+
+		if sent >= constants.ENUM_E_TICKET_RESEND_LIMIT {
+			return dto.ErrResendETicketRateLimited
+		}
+
+		res = dto.ResendETicketResponse{TransactionID: transaction.ID}
+		for _, form := range forms {
+			outbox, err := newETicketEmail(ctx, us.emailTemplateService, transaction, form)
+			if err != nil {
+				return err
+			}
+
+			outbox.RequestedBy = &userID
+			if err := txRepo.CreateEmailOutbox(ctx, nil, outbox); err != nil {
+				return dto.ErrCreateEmailOutbox
+			}
+
+			res.Tickets = append(res.Tickets, toResendETicketItemResponse(form, outbox))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return dto.ResendETicketResponse{}, err
+	}
+
+	return res, nil
+}
+
+// Notification
+// UpdateWhatsAppOptIn langsung mengirim link e-ticket ke pemegang tiket yang baru opt-in.
+func (us *UserService) UpdateWhatsAppOptIn(ctx context.Context, ticketFormID string, req dto.UpdateWhatsAppOptInRequest) (dto.WhatsAppOptInResponse, error) {
+	token := ctx.Value("Authorization").(string)
+
+	userIDStr, err := us.jwtService.GetUserIDByToken(token)
+	if err != nil {
+		return dto.WhatsAppOptInResponse{}, dto.ErrGetUserIDFromToken
+	}
+
+	ticketForm, found, err := us.userRepo.GetTicketFormByID(ctx, nil, ticketFormID)
+	if err != nil || !found {
+		return dto.WhatsAppOptInResponse{}, dto.ErrTicketFormNotFound
+	}
+
+	if ticketForm.Transaction.UserID == nil || ticketForm.Transaction.UserID.String() != userIDStr {
+		return dto.WhatsAppOptInResponse{}, dto.ErrNotTicketOwner
+	}
+
+	if ticketForm.TransferredToID != nil {
+		return dto.WhatsAppOptInResponse{}, dto.ErrTicketTransferred
+	}
+
+	ticketForm.WhatsAppOptIn = *req.WhatsAppOptIn
+	err = us.userRepo.RunInTransaction(ctx, func(txRepo repository.IUserRepository) error {
+		if err := txRepo.UpdateTicketFormWhatsAppOptIn(ctx, nil, ticketFormID, ticketForm.WhatsAppOptIn); err != nil {
+			return dto.ErrUpdateWhatsAppOptIn
+		}
+
+		if ticketForm.Transaction.TransactionStatus != "settlement" || ticketForm.QRRevokedAt != nil {
+			return nil
+		}
+
+		if notification, ok := newETicketNotification(ticketForm.Transaction, ticketForm); ok {
+			if err := txRepo.CreateNotificationOutbox(ctx, nil, notification); err != nil {
+				return dto.ErrCreateNotificationOutbox
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return dto.WhatsAppOptInResponse{}, err
+	}
+
+	return dto.WhatsAppOptInResponse{
+		TicketFormID:  ticketForm.ID,
+		WhatsAppOptIn: ticketForm.WhatsAppOptIn,
+	}, nil
 }
@@ -0,0 +1,436 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Amierza/TedXBackend/dto"
+	"github.com/Amierza/TedXBackend/entity"
+	"github.com/Amierza/TedXBackend/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// fakeUserRepo menyimpan satu transaksi, ticket, bundle dan waitlist di memori.
+type fakeUserRepo struct {
+	repository.IUserRepository
+
+	transaction entity.Transaction
+	ticket      entity.Ticket
+	bundle      entity.Bundle
+	waitlist    entity.Waitlist
+	ticketForm  entity.TicketForm
+	// concurrentStatus meniru status waitlist yang sudah diubah proses lain
+	concurrentStatus entity.WaitlistStatus
+
+	status         string
+	revoked        bool
+	restoredTicket int
+	restoredBundle int
+}
+
+func (fr *fakeUserRepo) RunInTransaction(ctx context.Context, fn func(txRepo repository.IUserRepository) error) error {
+	return fn(fr)
+}
+
+func (fr *fakeUserRepo) GetTransactionByOrderID(ctx context.Context, tx *gorm.DB, orderID string) (entity.Transaction, bool, error) {
+	return fr.transaction, fr.transaction.OrderID == orderID, nil
+}
+
+func (fr *fakeUserRepo) GetTransactionByIDForUpdate(ctx context.Context, tx *gorm.DB, transactionID string) (entity.Transaction, bool, error) {
+	return fr.transaction, fr.transaction.ID.String() == transactionID, nil
+}
+
+func (fr *fakeUserRepo) UpdateTransactionTicket(ctx context.Context, tx *gorm.DB, transaction entity.Transaction) error {
+	fr.status = transaction.TransactionStatus
+	return nil
+}
+
+func (fr *fakeUserRepo) RevokeTicketFormQRByTransactionID(ctx context.Context, tx *gorm.DB, transactionID string) error {
+	fr.revoked = true
+	return nil
+}
+
+func (fr *fakeUserRepo) GetTicketByIDForUpdate(ctx context.Context, tx *gorm.DB, ticketID string) (entity.Ticket, bool, error) {
+	return fr.ticket, fr.ticket.ID.String() == ticketID, nil
+}
+
+func (fr *fakeUserRepo) UpdateTicketQuota(ctx context.Context, tx *gorm.DB, ticketID string, newQuota int) error {
+	fr.ticket.Quota = newQuota
+	return nil
+}
+
+func (fr *fakeUserRepo) GetBundleByIDForUpdate(ctx context.Context, tx *gorm.DB, bundleID string) (entity.Bundle, bool, error) {
+	return fr.bundle, fr.bundle.ID.String() == bundleID, nil
+}
+
+func (fr *fakeUserRepo) UpdateBundleQuota(ctx context.Context, tx *gorm.DB, bundleID string, newQuota int) error {
+	fr.bundle.Quota = newQuota
+	return nil
+}
+
+func (fr *fakeUserRepo) RestoreTicketQuota(ctx context.Context, tx *gorm.DB, ticketID string, amount int) error {
+	fr.restoredTicket += amount
+	return nil
+}
+
+func (fr *fakeUserRepo) RestoreBundleQuota(ctx context.Context, tx *gorm.DB, bundleID string, amount int) error {
+	fr.restoredBundle += amount
+	return nil
+}
+
+func (fr *fakeUserRepo) GetTicketFormByIDForUpdate(ctx context.Context, tx *gorm.DB, ticketFormID string) (entity.TicketForm, bool, error) {
+	return fr.ticketForm, fr.ticketForm.ID.String() == ticketFormID, nil
+}
+
+func (fr *fakeUserRepo) GetWaitlistByID(ctx context.Context, tx *gorm.DB, waitlistID string) (entity.Waitlist, bool, error) {
+	return fr.waitlist, fr.waitlist.ID.String() == waitlistID, nil
+}
+
+func (fr *fakeUserRepo) UpdateWaitlist(ctx context.Context, tx *gorm.DB, waitlist entity.Waitlist, fromStatus entity.WaitlistStatus) (bool, error) {
+	current := fr.waitlist.Status
+	if fr.concurrentStatus != "" {
+		current = fr.concurrentStatus
+	}
+	if current != fromStatus {
+		return false, nil
+	}
+
+	fr.waitlist = waitlist
+	return true, nil
+}
+
+type fakeWaitlistService struct {
+	IWaitlistService
+
+	offered []string
+}
+
+func (fs *fakeWaitlistService) OfferAvailableSeats(ctx context.Context, ticketID string) error {
+	fs.offered = append(fs.offered, ticketID)
+	return nil
+}
+
+type fakeAvailabilityService struct {
+	IAvailabilityService
+
+	published []string
+}
+
+func (fs *fakeAvailabilityService) PublishTicket(ctx context.Context, ticketID string) {
+	fs.published = append(fs.published, ticketID)
+}
+
+func (fs *fakeAvailabilityService) PublishBundle(ctx context.Context, bundleID string) {
+	fs.published = append(fs.published, bundleID)
+}
+
+func newTestUserService(repo *fakeUserRepo) (*UserService, *fakeWaitlistService) {
+	waitlistService := &fakeWaitlistService{}
+	return NewUserService(repo, NewJWTService(nil), waitlistService, &fakeAvailabilityService{}, nil, nil), waitlistService
+}
+
+// userContext membawa token login user seperti yang diisi middleware.
+func userContext(t *testing.T, us *UserService, userID uuid.UUID) context.Context {
+	t.Helper()
+
+	token, err := us.jwtService.GenerateToken(userID.String(), "user")
+	if err != nil {
+		t.Fatalf("GenerateToken() error = %v", err)
+	}
+
+	return context.WithValue(context.Background(), "Authorization", token)
+}
+
+func TestUpdateTransactionTicketRestoresQuota(t *testing.T) {
+	ticketID, bundleID := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name           string
+		previous       string
+		next           string
+		ticketID       *uuid.UUID
+		bundleID       *uuid.UUID
+		forms          []entity.TicketForm
+		wantStatus     string
+		wantTicket     int
+		wantBundle     int
+		wantRevoked    bool
+		wantWaitlisted bool
+	}{
+		{
+			name:           "expired order returns its seats",
+			previous:       "pending",
+			next:           "expire",
+			ticketID:       &ticketID,
+			forms:          ticketForms(0, 2),
+			wantStatus:     "expired",
+			wantTicket:     2,
+			wantWaitlisted: true,
+		},
+		{
+			name:           "refund revokes the qr codes",
+			previous:       "settlement",
+			next:           "refund",
+			ticketID:       &ticketID,
+			forms:          ticketForms(0, 3),
+			wantStatus:     "refunded",
+			wantTicket:     3,
+			wantRevoked:    true,
+			wantWaitlisted: true,
+		},
+		{
+			name:       "bundle order returns bundle seats",
+			previous:   "pending",
+			next:       "cancel",
+			bundleID:   &bundleID,
+			forms:      ticketForms(0, 4),
+			wantStatus: "cancelled",
+			wantBundle: 4,
+		},
+		{
+			name:       "order that no longer holds seats returns nothing",
+			previous:   "expired",
+			next:       "cancel",
+			ticketID:   &ticketID,
+			forms:      ticketForms(0, 2),
+			wantStatus: "cancelled",
+		},
+		{
+			name:       "pending order keeps its seats",
+			previous:   "",
+			next:       "pending",
+			ticketID:   &ticketID,
+			forms:      ticketForms(0, 2),
+			wantStatus: "pending",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeUserRepo{transaction: entity.Transaction{
+				ID:                uuid.New(),
+				OrderID:           "ORDER-1",
+				TransactionStatus: tt.previous,
+				TicketID:          tt.ticketID,
+				BundleID:          tt.bundleID,
+				TicketForms:       tt.forms,
+			}}
+			us, waitlistService := newTestUserService(repo)
+
+			err := us.UpdateTransactionTicket(context.Background(), dto.UpdateMidtransTransactionTicketRequest{OrderID: "ORDER-1", TransactionStatus: tt.next})
+			if err != nil {
+				t.Fatalf("UpdateTransactionTicket() error = %v", err)
+			}
+
+			if repo.status != tt.wantStatus {
+				t.Errorf("status = %q, want %q", repo.status, tt.wantStatus)
+			}
+			if repo.restoredTicket != tt.wantTicket || repo.restoredBundle != tt.wantBundle {
+				t.Errorf("restored ticket %d bundle %d, want %d and %d", repo.restoredTicket, repo.restoredBundle, tt.wantTicket, tt.wantBundle)
+			}
+			if repo.revoked != tt.wantRevoked {
+				t.Errorf("qr revoked = %v, want %v", repo.revoked, tt.wantRevoked)
+			}
+			if (len(waitlistService.offered) > 0) != tt.wantWaitlisted {
+				t.Errorf("waitlist offered = %v, want %v", waitlistService.offered, tt.wantWaitlisted)
+			}
+		})
+	}
+}
+
+func TestUpdateTransactionTicketLateSettlement(t *testing.T) {
+	ticket := entity.Ticket{ID: uuid.New()}
+	repo := &fakeUserRepo{
+		ticket: ticket,
+		transaction: entity.Transaction{
+			ID:                uuid.New(),
+			OrderID:           "ORDER-1",
+			TransactionStatus: "expired",
+			TicketID:          &ticket.ID,
+			TicketForms:       ticketForms(0, 2),
+		},
+	}
+	us, _ := newTestUserService(repo)
+
+	err := us.UpdateTransactionTicket(context.Background(), dto.UpdateMidtransTransactionTicketRequest{
+		OrderID:           "ORDER-1",
+		TransactionStatus: "settlement",
+		SettlementTime:    "2026-05-01 10:00:00",
+		GrossAmount:       "150000.00",
+	})
+	if err != nil {
+		t.Fatalf("UpdateTransactionTicket() error = %v", err)
+	}
+
+	if repo.status != "refund_required" || repo.ticket.Quota != 0 {
+		t.Fatalf("status = %q quota = %d, want refund_required and untouched quota", repo.status, repo.ticket.Quota)
+	}
+}
+
+func TestUpdateTransactionTicketRepeatedSettlement(t *testing.T) {
+	for _, status := range []string{"settlement", "refund_required"} {
+		t.Run(status, func(t *testing.T) {
+			ticket := entity.Ticket{ID: uuid.New(), Quota: 3}
+			repo := &fakeUserRepo{
+				ticket: ticket,
+				transaction: entity.Transaction{
+					ID:                uuid.New(),
+					OrderID:           "ORDER-1",
+					TransactionStatus: status,
+					TicketID:          &ticket.ID,
+					TicketForms:       ticketForms(0, 2),
+				},
+			}
+			us, _ := newTestUserService(repo)
+
+			err := us.UpdateTransactionTicket(context.Background(), dto.UpdateMidtransTransactionTicketRequest{
+				OrderID:           "ORDER-1",
+				TransactionStatus: "settlement",
+				SettlementTime:    "2026-05-01 10:00:00",
+				GrossAmount:       "150000.00",
+			})
+			if err != nil {
+				t.Fatalf("UpdateTransactionTicket() error = %v", err)
+			}
+
+			if repo.status != "" || repo.ticket.Quota != 3 {
+				t.Fatalf("status = %q quota = %d, want the repeated notification ignored", repo.status, repo.ticket.Quota)
+			}
+		})
+	}
+}
+
+func TestTakeTransactionSeats(t *testing.T) {
+	tests := []struct {
+		name        string
+		ticketQuota int
+		bundleQuota int
+		bundle      bool
+		forms       []entity.TicketForm
+		wantTaken   bool
+		wantQuota   int
+	}{
+		{"ticket seats are taken back", 5, 0, false, ticketForms(0, 2), true, 3},
+		{"transferred forms are not counted twice", 2, 0, false, ticketForms(2, 2), true, 0},
+		{"sold out ticket", 1, 0, false, ticketForms(0, 2), false, 1},
+		{"bundle seats are taken back", 0, 4, true, ticketForms(0, 3), true, 1},
+		{"sold out bundle", 0, 2, true, ticketForms(0, 3), false, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeUserRepo{
+				ticket: entity.Ticket{ID: uuid.New(), Quota: tt.ticketQuota},
+				bundle: entity.Bundle{ID: uuid.New(), Quota: tt.bundleQuota},
+			}
+			transaction := entity.Transaction{TicketID: &repo.ticket.ID, TicketForms: tt.forms}
+			quota := func() int { return repo.ticket.Quota }
+			if tt.bundle {
+				transaction = entity.Transaction{BundleID: &repo.bundle.ID, TicketForms: tt.forms}
+				quota = func() int { return repo.bundle.Quota }
+			}
+
+			taken, err := takeTransactionSeats(context.Background(), repo, transaction)
+			if err != nil {
+				t.Fatalf("takeTransactionSeats() error = %v", err)
+			}
+			if taken != tt.wantTaken || quota() != tt.wantQuota {
+				t.Fatalf("takeTransactionSeats() = %v quota %d, want %v quota %d", taken, quota(), tt.wantTaken, tt.wantQuota)
+			}
+		})
+	}
+}
+
+func TestLeaveWaitlist(t *testing.T) {
+	userID, ticketID := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name             string
+		status           entity.WaitlistStatus
+		concurrentStatus entity.WaitlistStatus
+		wantErr          error
+		wantRestored     int
+	}{
+		{"waiting entry", entity.WaitlistWaiting, "", nil, 0},
+		{"offered entry returns its seat", entity.WaitlistOffered, "", nil, 1},
+		{"offer claimed by a checkout meanwhile", entity.WaitlistOffered, entity.WaitlistClaimed, dto.ErrWaitlistCannotBeCancelled, 0},
+		{"offer expired meanwhile", entity.WaitlistOffered, entity.WaitlistExpired, dto.ErrWaitlistCannotBeCancelled, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeUserRepo{
+				waitlist:         entity.Waitlist{ID: uuid.New(), UserID: &userID, TicketID: &ticketID, Status: tt.status},
+				concurrentStatus: tt.concurrentStatus,
+			}
+			us, _ := newTestUserService(repo)
+
+			if _, err := us.LeaveWaitlist(userContext(t, us, userID), repo.waitlist.ID.String()); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("LeaveWaitlist() error = %v, want %v", err, tt.wantErr)
+			}
+			if repo.restoredTicket != tt.wantRestored {
+				t.Fatalf("restored %d seats, want %d", repo.restoredTicket, tt.wantRestored)
+			}
+		})
+	}
+}
+
+func TestTransactionSeats(t *testing.T) {
+	tests := []struct {
+		name  string
+		forms []entity.TicketForm
+		want  int
+	}{
+		{"no forms", nil, 0},
+		{"nothing transferred", ticketForms(0, 3), 3},
+		{"transferred forms are replaced, not added", ticketForms(2, 3), 3},
+		{"transfer chain keeps one seat", ticketForms(3, 1), 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := transactionSeats(entity.Transaction{TicketForms: tt.forms}); got != tt.want {
+				t.Fatalf("transactionSeats() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTransferTicketRejectsUnusableForms(t *testing.T) {
+	userID := uuid.New()
+	revokedAt := time.Now()
+	transferredTo := uuid.New()
+
+	tests := []struct {
+		name       string
+		ticketForm entity.TicketForm
+		want       error
+	}{
+		{"revoked qr", entity.TicketForm{QRRevokedAt: &revokedAt}, dto.ErrTicketQRRevoked},
+		{"already transferred", entity.TicketForm{TransferredToID: &transferredTo}, dto.ErrTicketTransferred},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transactionID := uuid.New()
+			tt.ticketForm.ID = uuid.New()
+			tt.ticketForm.TransactionID = &transactionID
+			tt.ticketForm.Transaction = entity.Transaction{ID: transactionID, UserID: &userID, TransactionStatus: "settlement"}
+
+			repo := &fakeUserRepo{ticketForm: tt.ticketForm}
+			us, _ := newTestUserService(repo)
+
+			_, err := us.TransferTicket(userContext(t, us, userID), tt.ticketForm.ID.String(), dto.TransferTicketRequest{
+				Email:       "friend@example.com",
+				FullName:    "New Holder",
+				PhoneNumber: "081234567890",
+			})
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("TransferTicket() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/Amierza/TedXBackend/constants"
+	"github.com/Amierza/TedXBackend/dto"
+	"github.com/Amierza/TedXBackend/entity"
+	"github.com/Amierza/TedXBackend/helpers"
+	"github.com/Amierza/TedXBackend/repository"
+)
+
+type (
+	IWaitlistService interface {
+		OfferAvailableSeats(ctx context.Context, ticketID string) error
+		ExpireOffers(ctx context.Context) error
+		StartOfferExpiryWorker(interval time.Duration)
+	}
+
+	WaitlistService struct {
+		waitlistRepo         repository.IWaitlistRepository
+		availabilityService  IAvailabilityService
+		emailTemplateService IEmailTemplateService
+	}
+
+	waitlistOffer struct {
+		waitlist entity.Waitlist
+		token    string
+	}
+)
+
+func NewWaitlistService(waitlistRepo repository.IWaitlistRepository, availabilityService IAvailabilityService, emailTemplateService IEmailTemplateService) *WaitlistService {
+	return &WaitlistService{
+		waitlistRepo:         waitlistRepo,
+		availabilityService:  availabilityService,
+		emailTemplateService: emailTemplateService,
+	}
+}
+
+func toWaitlistResponse(waitlist entity.Waitlist) dto.WaitlistResponse {
+	return dto.WaitlistResponse{
+		ID:             waitlist.ID,
+		Status:         waitlist.Status,
+		TicketID:       waitlist.TicketID,
+		TicketName:     waitlist.Ticket.Name,
+		UserID:         waitlist.UserID,
+		UserName:       waitlist.User.Name,
+		UserEmail:      waitlist.User.Email,
+		TransactionID:  waitlist.TransactionID,
+		OfferedAt:      waitlist.OfferedAt,
+		OfferExpiresAt: waitlist.OfferExpiresAt,
+		ClaimedAt:      waitlist.ClaimedAt,
+		JoinedAt:       waitlist.CreatedAt,
+	}
+}
+
+func getFrontendURL() string {
+	frontendURL := os.Getenv("FRONTEND_URL")
+	if frontendURL == "" {
+		frontendURL = os.Getenv("BASE_URL")
+	}
+
+	return frontendURL
+}
+
+// OfferAvailableSeats menawarkan setiap kursi kosong ke antrean waitlist terdepan.
+func (ws *WaitlistService) OfferAvailableSeats(ctx context.Context, ticketID string) error {
+	err := ws.waitlistRepo.RunInTransaction(ctx, func(txRepo repository.IWaitlistRepository) error {
+		ticket, found, err := txRepo.GetTicketByIDForUpdate(ctx, nil, ticketID)
+		if err != nil || !found {
+			return dto.ErrTicketNotFound
+		}
+
+		quota := ticket.Quota
+		for quota > 0 {
+			waitlist, found, err := txRepo.GetNextWaitingWaitlistByTicketID(ctx, nil, ticketID)
+			if err != nil {
+				return dto.ErrWaitlistNotFound
+			}
+			if !found {
+				break
+			}
+
+			token, err := helpers.GenerateRandomToken(32)
+			if err != nil {
+				return dto.ErrGenerateToken
+			}
+
+			now := time.Now()
+			expiresAt := now.Add(constants.ENUM_WAITLIST_OFFER_DURATION_MINUTES * time.Minute)
+
+			waitlist.Status = entity.WaitlistOffered
+			waitlist.OfferTokenHash = helpers.HashToken(token)
+			waitlist.OfferedAt = &now
+			waitlist.OfferExpiresAt = &expiresAt
+			updated, err := txRepo.UpdateWaitlist(ctx, nil, waitlist, entity.WaitlistWaiting)
+			if err != nil {
+				return dto.ErrUpdateWaitlist
+			}
+			if !updated {
+				continue
+			}
+
+			outbox, err := ws.newWaitlistOfferEmail(ctx, waitlistOffer{waitlist: waitlist, token: token})
+			if err != nil {
+				return err
+			}
+			if err := txRepo.CreateEmailOutbox(ctx, nil, outbox); err != nil {
+				return dto.ErrCreateEmailOutbox
+			}
+
+			quota--
+		}
+
+		if quota != ticket.Quota {
+			if err := txRepo.UpdateTicketQuota(ctx, nil, ticketID, quota); err != nil {
+				return dto.ErrUpdateTicketQuota
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	ws.availabilityService.PublishTicket(ctx, ticketID)
+
+	return nil
+}
+
+func (ws *WaitlistService) newWaitlistOfferEmail(ctx context.Context, offer waitlistOffer) (entity.EmailOutbox, error) {
+	emailData := waitlistOfferEmailData{
+		HeaderImage:  emailHeaderImage(),
+		AttendeeName: offer.waitlist.User.Name,
+		TicketName:   offer.waitlist.Ticket.Name,
+		ExpiresAt:    offer.waitlist.OfferExpiresAt.Format("02 Jan 2006 15:04"),
+		ClaimURL:     fmt.Sprintf("%s/waitlist/claim?ticket_id=%s&token=%s", getFrontendURL(), offer.waitlist.TicketID, offer.token),
+	}
+
+	draftEmail, err := ws.emailTemplateService.Render(ctx, entity.EmailTemplateWaitlistOffer, emailData)
+	if err != nil {
+		return entity.EmailOutbox{}, dto.ErrMakeWaitlistOfferEmail
+	}
+
+	return newEmailOutbox(constants.ENUM_EMAIL_TEMPLATE_WAITLIST_OFFER, offer.waitlist.User.Email, draftEmail), nil
+}
+
+// ExpireOffers mengembalikan kursi offer yang tidak diklaim lalu menawarkannya ke antrean berikutnya.
+func (ws *WaitlistService) ExpireOffers(ctx context.Context) error {
+	ticketIDs := make(map[string]bool)
+	err := ws.waitlistRepo.RunInTransaction(ctx, func(txRepo repository.IWaitlistRepository) error {
+		waitlists, err := txRepo.GetExpiredWaitlistOffers(ctx, nil, time.Now())
+		if err != nil {
+			return dto.ErrGetAllWaitlistNoPagination
+		}
+
+		for _, waitlist := range waitlists {
+			waitlist.Status = entity.WaitlistExpired
+			updated, err := txRepo.UpdateWaitlist(ctx, nil, waitlist, entity.WaitlistOffered)
+			if err != nil {
+				return dto.ErrUpdateWaitlist
+			}
+
+			if !updated || waitlist.TicketID == nil {
+				continue
+			}
+
+			if err := txRepo.RestoreTicketQuota(ctx, nil, waitlist.TicketID.String(), 1); err != nil {
+				return dto.ErrRestoreTicketQuota
+			}
+
+			ticketIDs[waitlist.TicketID.String()] = true
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for ticketID := range ticketIDs {
+		if err := ws.OfferAvailableSeats(ctx, ticketID); err != nil {
+			log.Printf("failed to offer waitlist seats for ticket %s: %v", ticketID, err)
+		}
+	}
+
+	return nil
+}
+
+func (ws *WaitlistService) StartOfferExpiryWorker(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if err := ws.ExpireOffers(context.Background()); err != nil {
+			log.Printf("failed to expire waitlist offers: %v", err)
+		}
+	}
+}
@@ -0,0 +1,251 @@
+package badge
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/skip2/go-qrcode"
+)
+
+//go:embed default-layout.json
+var defaultLayoutJSON []byte
+
+var ErrInvalidLayout = errors.New("invalid badge layout")
+
+type (
+	// TextElement: Y diukur dari atas badge dalam satuan point.
+	TextElement struct {
+		Text      string  `json:"text,omitempty"`
+		Y         float64 `json:"y"`
+		Size      float64 `json:"size"`
+		MinSize   float64 `json:"min_size"`
+		Bold      bool    `json:"bold"`
+		Color     string  `json:"color"`
+		Uppercase bool    `json:"uppercase"`
+	}
+	QRElement struct {
+		Y    float64 `json:"y"`
+		Size float64 `json:"size"`
+	}
+	Band struct {
+		Height float64 `json:"height"`
+		Color  string  `json:"color"`
+	}
+	Sheet struct {
+		PageWidth  float64 `json:"page_width"`
+		PageHeight float64 `json:"page_height"`
+		Margin     float64 `json:"margin"`
+		Gap        float64 `json:"gap"`
+	}
+	Layout struct {
+		BadgeWidth  float64     `json:"badge_width"`
+		BadgeHeight float64     `json:"badge_height"`
+		Padding     float64     `json:"padding"`
+		Background  string      `json:"background"`
+		Border      string      `json:"border"`
+		HeaderBand  Band        `json:"header_band"`
+		Header      TextElement `json:"header"`
+		Name        TextElement `json:"name"`
+		Instansi    TextElement `json:"instansi"`
+		TicketType  TextElement `json:"ticket_type"`
+		QR          QRElement   `json:"qr"`
+		Sheet       Sheet       `json:"sheet"`
+	}
+	Badge struct {
+		EventName  string
+		Name       string
+		Instansi   string
+		TicketType string
+		QRContent  string
+	}
+)
+
+// LoadLayout membaca BADGE_LAYOUT_PATH kalau diisi, field yang kosong memakai default.
+func LoadLayout() (Layout, error) {
+	var layout Layout
+	if err := json.Unmarshal(defaultLayoutJSON, &layout); err != nil {
+		return Layout{}, err
+	}
+
+	path := os.Getenv("BADGE_LAYOUT_PATH")
+	if path == "" {
+		return layout, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Layout{}, err
+	}
+
+	if err := json.Unmarshal(data, &layout); err != nil {
+		return Layout{}, err
+	}
+
+	if layout.BadgeWidth <= 0 || layout.BadgeHeight <= 0 {
+		return Layout{}, ErrInvalidLayout
+	}
+
+	return layout, nil
+}
+
+// RenderSingle membuat PDF satu halaman seukuran badge.
+func RenderSingle(layout Layout, badge Badge) ([]byte, error) {
+	var doc pdfDocument
+	page := doc.addPage(layout.BadgeWidth, layout.BadgeHeight)
+	if err := drawBadge(page, layout, badge, 0, 0); err != nil {
+		return nil, err
+	}
+
+	return doc.bytes(), nil
+}
+
+// RenderSheet menyusun semua badge ke halaman sheet sebanyak yang dibutuhkan.
+func RenderSheet(layout Layout, badges []Badge) ([]byte, error) {
+	sheet := layout.Sheet
+	columns := int((sheet.PageWidth - 2*sheet.Margin + sheet.Gap) / (layout.BadgeWidth + sheet.Gap))
+	rows := int((sheet.PageHeight - 2*sheet.Margin + sheet.Gap) / (layout.BadgeHeight + sheet.Gap))
+	if columns < 1 || rows < 1 {
+		return nil, ErrInvalidLayout
+	}
+
+	// grid ditaruh di tengah halaman supaya margin potong kiri-kanan sama
+	gridWidth := float64(columns)*layout.BadgeWidth + float64(columns-1)*sheet.Gap
+	gridHeight := float64(rows)*layout.BadgeHeight + float64(rows-1)*sheet.Gap
+	offsetX := (sheet.PageWidth - gridWidth) / 2
+	offsetY := (sheet.PageHeight - gridHeight) / 2
+
+	var (
+		doc  pdfDocument
+		page *pdfPage
+	)
+	perPage := columns * rows
+	for i, badge := range badges {
+		slot := i % perPage
+		if slot == 0 {
+			page = doc.addPage(sheet.PageWidth, sheet.PageHeight)
+		}
+
+		x := offsetX + float64(slot%columns)*(layout.BadgeWidth+sheet.Gap)
+		y := offsetY + float64(slot/columns)*(layout.BadgeHeight+sheet.Gap)
+		if err := drawBadge(page, layout, badge, x, y); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(badges) == 0 {
+		doc.addPage(sheet.PageWidth, sheet.PageHeight)
+	}
+
+	return doc.bytes(), nil
+}
+
+func drawBadge(page *pdfPage, layout Layout, badge Badge, x, y float64) error {
+	if layout.Background != "" {
+		page.fillRect(x, y, layout.BadgeWidth, layout.BadgeHeight, parseColor(layout.Background))
+	}
+
+	if layout.HeaderBand.Height > 0 {
+		page.fillRect(x, y, layout.BadgeWidth, layout.HeaderBand.Height, parseColor(layout.HeaderBand.Color))
+	}
+
+	if layout.Border != "" {
+		page.strokeRect(x, y, layout.BadgeWidth, layout.BadgeHeight, parseColor(layout.Border))
+	}
+
+	header := layout.Header.Text
+	if header == "" {
+		header = badge.EventName
+	}
+
+	drawText(page, layout, layout.Header, header, x, y)
+	drawText(page, layout, layout.Name, badge.Name, x, y)
+	drawText(page, layout, layout.Instansi, badge.Instansi, x, y)
+	drawText(page, layout, layout.TicketType, badge.TicketType, x, y)
+
+	if badge.QRContent == "" || layout.QR.Size <= 0 {
+		return nil
+	}
+
+	return drawQR(page, badge.QRContent, x+(layout.BadgeWidth-layout.QR.Size)/2, y+layout.QR.Y, layout.QR.Size)
+}
+
+// drawText mengecilkan teks sampai MinSize supaya nama panjang tetap muat.
+func drawText(page *pdfPage, layout Layout, element TextElement, text string, x, y float64) {
+	text = strings.TrimSpace(text)
+	if text == "" || element.Size <= 0 {
+		return
+	}
+
+	if element.Uppercase {
+		text = strings.ToUpper(text)
+	}
+
+	font := fontRegular
+	if element.Bold {
+		font = fontBold
+	}
+
+	maxWidth := layout.BadgeWidth - 2*layout.Padding
+	size := element.Size
+	if width := textWidth(text, font, size); width > maxWidth {
+		size = max(size*maxWidth/width, element.MinSize)
+	}
+
+	for textWidth(text, font, size) > maxWidth && len([]rune(text)) > 1 {
+		runes := []rune(strings.TrimSuffix(text, "…"))
+		text = strings.TrimSpace(string(runes[:len(runes)-1])) + "…"
+	}
+
+	width := textWidth(text, font, size)
+	page.text(x+(layout.BadgeWidth-width)/2, y+element.Y, font, size, parseColor(element.Color), text)
+}
+func drawQR(page *pdfPage, content string, x, y, size float64) error {
+	code, err := qrcode.New(content, qrcode.Medium)
+	if err != nil {
+		return err
+	}
+	code.DisableBorder = true
+
+	bitmap := code.Bitmap()
+	module := size / float64(len(bitmap))
+	black := [3]float64{0, 0, 0}
+
+	// satu rect per deretan modul hitam biar ukuran PDF tetap kecil
+	for row, line := range bitmap {
+		for col := 0; col < len(line); col++ {
+			if !line[col] {
+				continue
+			}
+
+			start := col
+			for col < len(line) && line[col] {
+				col++
+			}
+
+			page.fillRect(x+float64(start)*module, y+float64(row)*module, float64(col-start)*module, module, black)
+		}
+	}
+
+	return nil
+}
+func parseColor(hex string) [3]float64 {
+	hex = strings.TrimPrefix(hex, "#")
+	if len(hex) != 6 {
+		return [3]float64{0, 0, 0}
+	}
+
+	var color [3]float64
+	for i := range color {
+		v, err := strconv.ParseUint(hex[i*2:i*2+2], 16, 8)
+		if err != nil {
+			return [3]float64{0, 0, 0}
+		}
+		color[i] = float64(v) / 255
+	}
+
+	return color
+}
@@ -0,0 +1,144 @@
+package badge
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// pdfDocument adalah penulis PDF 1.4 minimal, hanya untuk kebutuhan badge.
+type pdfDocument struct {
+	pages []pdfPage
+}
+
+type pdfPage struct {
+	width, height float64
+	content       bytes.Buffer
+}
+
+const (
+	fontRegular = "F1"
+	fontBold    = "F2"
+)
+
+func (d *pdfDocument) addPage(width, height float64) *pdfPage {
+	d.pages = append(d.pages, pdfPage{width: width, height: height})
+	return &d.pages[len(d.pages)-1]
+}
+
+// koordinat dihitung dari pojok kiri atas, PDF menghitung dari bawah
+func (p *pdfPage) fillRect(x, y, w, h float64, color [3]float64) {
+	fmt.Fprintf(&p.content, "%.3f %.3f %.3f rg %.2f %.2f %.2f %.2f re f\n",
+		color[0], color[1], color[2], x, p.height-y-h, w, h)
+}
+func (p *pdfPage) strokeRect(x, y, w, h float64, color [3]float64) {
+	fmt.Fprintf(&p.content, "%.3f %.3f %.3f RG 0.5 w %.2f %.2f %.2f %.2f re S\n",
+		color[0], color[1], color[2], x, p.height-y-h, w, h)
+}
+func (p *pdfPage) text(x, y float64, font string, size float64, color [3]float64, s string) {
+	fmt.Fprintf(&p.content, "BT %.3f %.3f %.3f rg /%s %.2f Tf %.2f %.2f Td (%s) Tj ET\n",
+		color[0], color[1], color[2], font, size, x, p.height-y-size, escapePDFText(s))
+}
+
+func (d *pdfDocument) bytes() []byte {
+	var (
+		buf     bytes.Buffer
+		offsets []int
+	)
+
+	writeObject := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// object 1 catalog, 2 pages, 3-4 font, lalu page dan content per halaman
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+i*2)
+	}
+
+	writeObject("<< /Type /Catalog /Pages 2 0 R >>")
+	writeObject(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	writeObject("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	writeObject("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+
+	for i := range d.pages {
+		page := &d.pages[i]
+		writeObject(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] /Resources << /Font << /%s 3 0 R /%s 4 0 R >> >> /Contents %d 0 R >>",
+			page.width, page.height, fontRegular, fontBold, 6+i*2))
+		writeObject(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.content.Len(), page.content.String()))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return buf.Bytes()
+}
+
+// escapePDFText: karakter di luar Latin-1 menjadi "?".
+func escapePDFText(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '…':
+			b.WriteString("\\205")
+		case r < 32:
+			b.WriteByte(' ')
+		case r < 128:
+			b.WriteRune(r)
+		case r >= 0xA0 && r <= 0xFF:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteByte('?')
+		}
+	}
+
+	return b.String()
+}
+
+var (
+	helveticaWidths = []int{
+		278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+		1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+		333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+		556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+	}
+	helveticaBoldWidths = []int{
+		278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+		975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+		333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+		611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+	}
+)
+
+// textWidth mengukur lebar s dalam point memakai metrik Helvetica standar.
+func textWidth(s string, font string, size float64) float64 {
+	widths := helveticaWidths
+	if font == fontBold {
+		widths = helveticaBoldWidths
+	}
+
+	total := 0
+	for _, r := range s {
+		if r >= 32 && int(r-32) < len(widths) {
+			total += widths[r-32]
+		} else {
+			total += 556
+		}
+	}
+
+	return float64(total) * size / 1000
+}
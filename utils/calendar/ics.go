@@ -0,0 +1,96 @@
+package calendar
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	ContentType = "text/calendar; charset=utf-8; method=PUBLISH"
+
+	productID  = "-//TEDxUniversitasAirlangga//Event Reminder//EN"
+	timeLayout = "20060102T150405Z"
+	lineLimit  = 75
+)
+
+// Event: UID harus stabil supaya kalender penerima tidak membuat duplikat.
+type Event struct {
+	UID         string
+	Summary     string
+	Description string
+	Location    string
+	URL         string
+	Start       time.Time
+	End         time.Time
+}
+
+// Render menghasilkan file .ics (RFC 5545) dengan satu event.
+func Render(event Event) []byte {
+	end := event.End
+	if !end.After(event.Start) {
+		end = event.Start.Add(2 * time.Hour)
+	}
+
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:" + productID,
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		"BEGIN:VEVENT",
+		"UID:" + escapeText(event.UID),
+		"DTSTAMP:" + time.Now().UTC().Format(timeLayout),
+		"DTSTART:" + event.Start.UTC().Format(timeLayout),
+		"DTEND:" + end.UTC().Format(timeLayout),
+		"SUMMARY:" + escapeText(event.Summary),
+	}
+	if event.Description != "" {
+		lines = append(lines, "DESCRIPTION:"+escapeText(event.Description))
+	}
+	if event.Location != "" {
+		lines = append(lines, "LOCATION:"+escapeText(event.Location))
+	}
+	if event.URL != "" {
+		lines = append(lines, "URL:"+event.URL)
+	}
+	lines = append(lines, "END:VEVENT", "END:VCALENDAR")
+
+	var b strings.Builder
+	for _, line := range lines {
+		b.WriteString(foldLine(line))
+		b.WriteString("\r\n")
+	}
+
+	return []byte(b.String())
+}
+
+func escapeText(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(s)
+}
+
+// foldLine memecah baris lebih dari 75 oktet tanpa memotong karakter UTF-8.
+func foldLine(line string) string {
+	if len(line) <= lineLimit {
+		return line
+	}
+
+	var b strings.Builder
+	width := 0
+	for _, r := range line {
+		size := len(string(r))
+		if width+size > lineLimit {
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += size
+	}
+
+	return b.String()
+}
@@ -1,6 +1,148 @@
 package emailtemplate
 
-import _ "embed"
+import (
+	_ "embed"
+
+	"github.com/Amierza/TedXBackend/constants"
+)
 
 //go:embed e-ticket-mail.html
 var EticketHTML string
+
+//go:embed waitlist-offer-mail.html
+var WaitlistOfferHTML string
+
+//go:embed purchase-receipt-mail.html
+var PurchaseReceiptHTML string
+
+//go:embed broadcast-mail.html
+var BroadcastHTML string
+
+//go:embed reminder-mail.html
+var ReminderHTML string
+
+//go:embed email-verification-mail.html
+var EmailVerificationHTML string
+
+//go:embed password-reset-mail.html
+var PasswordResetHTML string
+
+// Default dipakai kalau belum ada EmailTemplate di database untuk key tersebut.
+type Default struct {
+	Subject string
+	HTML    string
+	Text    string
+}
+
+var Defaults = map[string]Default{
+	constants.ENUM_EMAIL_TEMPLATE_E_TICKET: {
+		Subject: "tedxuniversitasairlangga",
+		HTML:    EticketHTML,
+		Text:    eTicketText,
+	},
+	constants.ENUM_EMAIL_TEMPLATE_INVITATION: {
+		Subject: "tedxuniversitasairlangga - your invitation",
+		HTML:    EticketHTML,
+		Text:    eTicketText,
+	},
+	constants.ENUM_EMAIL_TEMPLATE_WAITLIST_OFFER: {
+		Subject: "tedxuniversitasairlangga - your seat is waiting",
+		HTML:    WaitlistOfferHTML,
+		Text:    waitlistOfferText,
+	},
+	constants.ENUM_EMAIL_TEMPLATE_PURCHASE_RECEIPT: {
+		Subject: "tedxuniversitasairlangga - order {{.OrderID}}",
+		HTML:    PurchaseReceiptHTML,
+		Text:    purchaseReceiptText,
+	},
+	constants.ENUM_EMAIL_TEMPLATE_BROADCAST: {
+		Subject: "{{.Subject}}",
+		HTML:    BroadcastHTML,
+		Text:    broadcastText,
+	},
+	constants.ENUM_EMAIL_TEMPLATE_REMINDER: {
+		Subject: "tedxuniversitasairlangga - {{.EventName}} is {{.TimeLeft}} away",
+		HTML:    ReminderHTML,
+		Text:    reminderText,
+	},
+	constants.ENUM_EMAIL_TEMPLATE_VERIFICATION: {
+		Subject: "tedxuniversitasairlangga - confirm your email",
+		HTML:    EmailVerificationHTML,
+		Text:    emailVerificationText,
+	},
+	constants.ENUM_EMAIL_TEMPLATE_PASSWORD_RESET: {
+		Subject: "tedxuniversitasairlangga - reset your password",
+		HTML:    PasswordResetHTML,
+		Text:    passwordResetText,
+	},
+}
+
+const eTicketText = `Hi {{.AttendeeName}},
+
+Ticket ID: {{.TicketID}}
+Status: {{.Status}}
+Email: {{.Email}}
+Audience Type: {{.AudienceType}}
+Booking Date: {{.BookingDate}}
+Price: {{.Price}}
+
+Your QR code is attached as a PDF ticket, or open it at {{.QRCodeURL}}
+
+Please show this e-ticket when entering the event.
+`
+
+const waitlistOfferText = `Hi {{.AttendeeName}}, a seat has opened up and we are holding it for you.
+
+Ticket: {{.TicketName}}
+Offer expires at: {{.ExpiresAt}}
+
+Claim your seat: {{.ClaimURL}}
+`
+
+const purchaseReceiptText = `Hi {{.PurchaserName}}, thank you for your order.
+
+Order ID: {{.OrderID}}
+Status: {{.Status}}
+Booking Date: {{.BookingDate}}
+Total: {{.Price}}
+
+Attendees:
+{{range .Attendees}}- {{.FullName}} ({{.Email}}, {{.AudienceType}}): {{.QRCodeURL}}
+{{end}}
+Each attendee has also received their own e-ticket by email.
+`
+
+const broadcastText = `Hi {{.AttendeeName}},
+
+{{.Message}}
+
+{{if .EventName}}You are receiving this because you hold a ticket for {{.EventName}}.
+{{end}}`
+
+const reminderText = `Hi {{.AttendeeName}}, {{.EventName}} is {{.TimeLeft}} away.
+
+Date: {{.EventDate}}
+Venue: {{.Venue}}
+
+Show your QR code at the gate: {{.QRCodeURL}}
+
+The attached calendar invite (.ics) adds the event to your calendar.
+`
+
+const emailVerificationText = `Hi {{.Name}},
+
+{{if .EmailChange}}Please confirm that you want to use {{.Email}} for your account.{{else}}Please confirm your email address ({{.Email}}) to finish setting up your account.{{end}}
+
+Confirm your email: {{.VerifyURL}}
+
+This link expires at {{.ExpiresAt}}. If you did not request this, you can ignore this email.
+`
+
+const passwordResetText = `Hi {{.Name}},
+
+We received a request to reset the password for {{.Email}}.
+
+Reset your password: {{.ResetURL}}
+
+This link can only be used once and expires at {{.ExpiresAt}}. If you did not request this, you can ignore this email and your password will stay the same.
+`
@@ -0,0 +1,50 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync/atomic"
+	"time"
+)
+
+var unsafeFileChars = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// FileMailer menulis setiap email sebagai file .eml untuk development.
+type FileMailer struct {
+	dir  string
+	from string
+	seq  atomic.Uint64
+}
+
+func NewFileMailer(dir, from string) (*FileMailer, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	return &FileMailer{
+		dir:  dir,
+		from: from,
+	}, nil
+}
+
+func (fm *FileMailer) Send(ctx context.Context, msg Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("%s-%04d-%s.eml", time.Now().Format("20060102-150405"), fm.seq.Add(1), unsafeFileChars.ReplaceAllString(msg.To, "_"))
+	file, err := os.Create(filepath.Join(fm.dir, name))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if _, err := buildMessage(fm.from, msg).WriteTo(file); err != nil {
+		return err
+	}
+
+	return file.Close()
+}
@@ -0,0 +1,114 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/mail"
+	"strings"
+
+	"github.com/Amierza/TedXBackend/config"
+	"gopkg.in/gomail.v2"
+)
+
+const (
+	TransportSMTP   = "smtp"
+	TransportFile   = "file"
+	TransportMemory = "memory"
+
+	defaultFileDir = "tmp/mails"
+)
+
+type (
+	// Mailer adalah transport email: SMTP, file, atau hanya direkam.
+	Mailer interface {
+		Send(ctx context.Context, msg Message) error
+	}
+
+	Message struct {
+		To          string
+		Subject     string
+		HTMLBody    string
+		TextBody    string
+		Attachments []Attachment
+	}
+
+	// Attachment dengan ContentID di-embed inline sebagai "cid:<ContentID>".
+	Attachment struct {
+		Filename    string
+		ContentType string
+		ContentID   string
+		Data        []byte
+	}
+)
+
+// NewMailer memilih transport dari MAIL_TRANSPORT (default smtp).
+func NewMailer(cfg *config.EmailConfig) (Mailer, error) {
+	from := senderAddress(cfg)
+
+	switch cfg.Transport {
+	case "", TransportSMTP:
+		return NewSMTPMailer(cfg, from), nil
+	case TransportFile:
+		dir := cfg.FileDir
+		if dir == "" {
+			dir = defaultFileDir
+		}
+		return NewFileMailer(dir, from)
+	case TransportMemory:
+		return NewMemoryMailer(), nil
+	default:
+		return nil, fmt.Errorf("unknown mail transport %q", cfg.Transport)
+	}
+}
+
+// senderAddress menerima SMTP_SENDER_NAME berupa nama saja atau alamat lengkap.
+func senderAddress(cfg *config.EmailConfig) string {
+	name := strings.TrimSpace(cfg.SenderName)
+	if name == "" {
+		return cfg.AuthEmail
+	}
+
+	if strings.Contains(name, "<") {
+		if address, err := mail.ParseAddress(name); err == nil {
+			return address.String()
+		}
+	}
+
+	return (&mail.Address{Name: name, Address: cfg.AuthEmail}).String()
+}
+
+func buildMessage(from string, msg Message) *gomail.Message {
+	m := gomail.NewMessage()
+	m.SetHeader("From", from)
+	m.SetHeader("To", msg.To)
+	m.SetHeader("Subject", msg.Subject)
+	if msg.TextBody != "" {
+		m.SetBody("text/plain", msg.TextBody)
+		m.AddAlternative("text/html", msg.HTMLBody)
+	} else {
+		m.SetBody("text/html", msg.HTMLBody)
+	}
+
+	for _, attachment := range msg.Attachments {
+		data := attachment.Data
+		settings := []gomail.FileSetting{
+			gomail.SetCopyFunc(func(w io.Writer) error {
+				_, err := w.Write(data)
+				return err
+			}),
+		}
+		if attachment.ContentType != "" {
+			settings = append(settings, gomail.SetHeader(map[string][]string{"Content-Type": {attachment.ContentType}}))
+		}
+
+		if attachment.ContentID != "" {
+			settings = append(settings, gomail.SetHeader(map[string][]string{"Content-ID": {"<" + attachment.ContentID + ">"}}))
+			m.Embed(attachment.Filename, settings...)
+		} else {
+			m.Attach(attachment.Filename, settings...)
+		}
+	}
+
+	return m
+}
@@ -0,0 +1,91 @@
+package mailer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMailers(t *testing.T) {
+	msg := Message{
+		To:       "guest+1@example.com",
+		Subject:  "Your e-ticket",
+		HTMLBody: "<p>See you there</p>",
+		TextBody: "See you there",
+		Attachments: []Attachment{
+			{Filename: "qr.png", ContentType: "image/png", ContentID: "qr", Data: []byte("png")},
+		},
+	}
+
+	fileMailer, err := NewFileMailer(t.TempDir(), "TEDx <noreply@example.com>")
+	if err != nil {
+		t.Fatalf("NewFileMailer() error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		mailer Mailer
+		check  func(t *testing.T)
+	}{
+		{
+			name:   "memory mailer records the message",
+			mailer: NewMemoryMailer(),
+		},
+		{
+			name:   "file mailer writes an eml file",
+			mailer: fileMailer,
+			check: func(t *testing.T) {
+				files, err := filepath.Glob(filepath.Join(fileMailer.dir, "*.eml"))
+				if err != nil || len(files) != 1 {
+					t.Fatalf("found %d eml files, want 1 (%v)", len(files), err)
+				}
+				if strings.Contains(filepath.Base(files[0]), "+") {
+					t.Errorf("file name %q keeps unsafe characters", filepath.Base(files[0]))
+				}
+
+				content, err := os.ReadFile(files[0])
+				if err != nil {
+					t.Fatalf("read eml: %v", err)
+				}
+				for _, want := range []string{"To: guest+1@example.com", "Subject: Your e-ticket", "noreply@example.com", "Content-ID: <qr>"} {
+					if !strings.Contains(string(content), want) {
+						t.Errorf("eml does not contain %q", want)
+					}
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.mailer.Send(context.Background(), msg); err != nil {
+				t.Fatalf("Send() error = %v", err)
+			}
+
+			if memory, ok := tt.mailer.(*MemoryMailer); ok {
+				if got := memory.Messages(); len(got) != 1 || got[0].To != msg.To {
+					t.Fatalf("Messages() = %+v, want the sent message", got)
+				}
+			}
+
+			if tt.check != nil {
+				tt.check(t)
+			}
+		})
+	}
+}
+
+func TestMailerCancelledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	memory := NewMemoryMailer()
+	if err := memory.Send(ctx, Message{To: "guest@example.com"}); err == nil {
+		t.Fatal("Send() with a cancelled context returned nil")
+	}
+	if len(memory.Messages()) != 0 {
+		t.Fatal("cancelled message was recorded")
+	}
+}
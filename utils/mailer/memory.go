@@ -0,0 +1,40 @@
+package mailer
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryMailer hanya merekam email yang dikirim, untuk test.
+type MemoryMailer struct {
+	mu       sync.Mutex
+	messages []Message
+}
+
+func NewMemoryMailer() *MemoryMailer {
+	return &MemoryMailer{}
+}
+
+func (mm *MemoryMailer) Send(ctx context.Context, msg Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+
+	mm.messages = append(mm.messages, msg)
+	return nil
+}
+func (mm *MemoryMailer) Messages() []Message {
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+
+	return append([]Message(nil), mm.messages...)
+}
+func (mm *MemoryMailer) Reset() {
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+
+	mm.messages = nil
+}
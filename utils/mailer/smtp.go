@@ -0,0 +1,92 @@
+package mailer
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/Amierza/TedXBackend/config"
+	"gopkg.in/gomail.v2"
+)
+
+// koneksi SMTP ditutup kalau idle supaya tidak diputus server di tengah batch
+const smtpIdleTimeout = 30 * time.Second
+
+type SMTPMailer struct {
+	dialer *gomail.Dialer
+	from   string
+
+	mu     sync.Mutex
+	sender gomail.SendCloser
+	idle   *time.Timer
+}
+
+func NewSMTPMailer(cfg *config.EmailConfig, from string) *SMTPMailer {
+	return &SMTPMailer{
+		dialer: gomail.NewDialer(cfg.Host, cfg.Port, cfg.AuthEmail, cfg.AuthPassword),
+		from:   from,
+	}
+}
+
+func (sm *SMTPMailer) Send(ctx context.Context, msg Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	m := buildMessage(sm.from, msg)
+
+	reused := sm.sender != nil
+	if err := sm.send(m); err != nil {
+		if !reused {
+			return err
+		}
+
+		// koneksi lama bisa sudah diputus server, coba sekali lagi dengan koneksi baru
+		if err := sm.send(m); err != nil {
+			return err
+		}
+	}
+
+	if sm.idle != nil {
+		sm.idle.Stop()
+	}
+	sm.idle = time.AfterFunc(smtpIdleTimeout, sm.closeIdle)
+
+	return nil
+}
+func (sm *SMTPMailer) send(m *gomail.Message) error {
+	if sm.sender == nil {
+		sender, err := sm.dialer.Dial()
+		if err != nil {
+			return err
+		}
+		sm.sender = sender
+	}
+
+	if err := gomail.Send(sm.sender, m); err != nil {
+		sm.close()
+		return err
+	}
+
+	return nil
+}
+func (sm *SMTPMailer) close() {
+	if sm.sender == nil {
+		return
+	}
+
+	if err := sm.sender.Close(); err != nil {
+		log.Printf("failed to close smtp connection: %v", err)
+	}
+	sm.sender = nil
+}
+func (sm *SMTPMailer) closeIdle() {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	sm.close()
+}
@@ -0,0 +1,34 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync/atomic"
+)
+
+// LogNotifier hanya menulis pesan ke log, untuk development.
+type LogNotifier struct {
+	channel string
+	seq     atomic.Uint64
+}
+
+func NewLogNotifier(channel string) *LogNotifier {
+	return &LogNotifier{
+		channel: channel,
+	}
+}
+
+func (ln *LogNotifier) Channel() string {
+	return ln.channel
+}
+func (ln *LogNotifier) Send(ctx context.Context, msg Message) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	id := fmt.Sprintf("log-%d", ln.seq.Add(1))
+	log.Printf("[%s] %s to %s (%s): %s", ln.channel, msg.Kind, msg.To, id, msg.Body)
+
+	return id, nil
+}
@@ -0,0 +1,42 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Amierza/TedXBackend/config"
+	"github.com/Amierza/TedXBackend/constants"
+)
+
+const (
+	ProviderLog      = "log"
+	ProviderWhatsApp = "whatsapp"
+)
+
+type (
+	// Notifier adalah channel notifikasi selain email, service tidak tahu provider-nya
+	Notifier interface {
+		Channel() string
+		Send(ctx context.Context, msg Message) (string, error)
+	}
+
+	// Message: provider yang memakai template cukup mengirim Params
+	Message struct {
+		Kind   string
+		To     string
+		Body   string
+		Params []string
+	}
+)
+
+// NewWhatsAppNotifier memilih provider dari WHATSAPP_PROVIDER, default log.
+func NewWhatsAppNotifier(cfg *config.WhatsAppConfig) (Notifier, error) {
+	switch cfg.Provider {
+	case "", ProviderLog:
+		return NewLogNotifier(constants.ENUM_NOTIFICATION_CHANNEL_WHATSAPP), nil
+	case ProviderWhatsApp:
+		return NewWhatsAppCloudNotifier(cfg)
+	default:
+		return nil, fmt.Errorf("unknown whatsapp provider %q", cfg.Provider)
+	}
+}
@@ -0,0 +1,132 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Amierza/TedXBackend/config"
+	"github.com/Amierza/TedXBackend/constants"
+)
+
+const (
+	defaultWhatsAppAPIURL           = "https://graph.facebook.com/v19.0"
+	defaultWhatsAppTemplateLanguage = "id"
+)
+
+// WhatsAppCloudNotifier mengirim jenis pesan yang punya template sebagai template.
+type WhatsAppCloudNotifier struct {
+	client        *http.Client
+	endpoint      string
+	accessToken   string
+	language      string
+	templateNames map[string]string
+}
+
+func NewWhatsAppCloudNotifier(cfg *config.WhatsAppConfig) (*WhatsAppCloudNotifier, error) {
+	if cfg.PhoneNumberID == "" || cfg.AccessToken == "" {
+		return nil, errors.New("WHATSAPP_PHONE_NUMBER_ID and WHATSAPP_ACCESS_TOKEN are required")
+	}
+
+	apiURL := strings.TrimRight(cfg.APIURL, "/")
+	if apiURL == "" {
+		apiURL = defaultWhatsAppAPIURL
+	}
+
+	language := cfg.TemplateLanguage
+	if language == "" {
+		language = defaultWhatsAppTemplateLanguage
+	}
+
+	return &WhatsAppCloudNotifier{
+		client:      &http.Client{Timeout: 15 * time.Second},
+		endpoint:    fmt.Sprintf("%s/%s/messages", apiURL, cfg.PhoneNumberID),
+		accessToken: cfg.AccessToken,
+		language:    language,
+		templateNames: map[string]string{
+			constants.ENUM_NOTIFICATION_KIND_E_TICKET:         cfg.TemplateETicket,
+			constants.ENUM_NOTIFICATION_KIND_PAYMENT_REMINDER: cfg.TemplatePaymentReminder,
+			constants.ENUM_NOTIFICATION_KIND_EVENT_DAY:        cfg.TemplateEventDay,
+		},
+	}, nil
+}
+
+func (wn *WhatsAppCloudNotifier) Channel() string {
+	return constants.ENUM_NOTIFICATION_CHANNEL_WHATSAPP
+}
+func (wn *WhatsAppCloudNotifier) payload(msg Message) map[string]any {
+	payload := map[string]any{
+		"messaging_product": "whatsapp",
+		"to":                msg.To,
+	}
+
+	templateName := wn.templateNames[msg.Kind]
+	if templateName == "" {
+		payload["type"] = "text"
+		payload["text"] = map[string]any{"body": msg.Body, "preview_url": true}
+		return payload
+	}
+
+	parameters := make([]map[string]string, 0, len(msg.Params))
+	for _, param := range msg.Params {
+		parameters = append(parameters, map[string]string{"type": "text", "text": param})
+	}
+
+	payload["type"] = "template"
+	payload["template"] = map[string]any{
+		"name":       templateName,
+		"language":   map[string]string{"code": wn.language},
+		"components": []map[string]any{{"type": "body", "parameters": parameters}},
+	}
+
+	return payload
+}
+func (wn *WhatsAppCloudNotifier) Send(ctx context.Context, msg Message) (string, error) {
+	body, err := json.Marshal(wn.payload(msg))
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, wn.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+wn.accessToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := wn.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if err != nil {
+		return "", err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("whatsapp api returned %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	var result struct {
+		Messages []struct {
+			ID string `json:"id"`
+		} `json:"messages"`
+	}
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return "", err
+	}
+
+	if len(result.Messages) == 0 {
+		return "", errors.New("whatsapp api returned no message id")
+	}
+
+	return result.Messages[0].ID, nil
+}